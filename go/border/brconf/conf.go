@@ -25,6 +25,7 @@ import (
 	"github.com/scionproto/scion/go/lib/common"
 	"github.com/scionproto/scion/go/lib/keyconf"
 	"github.com/scionproto/scion/go/lib/topology"
+	"github.com/scionproto/scion/go/lib/topology/overlay"
 )
 
 // BRConf is the main config structure. It contains the dynamic
@@ -43,6 +44,9 @@ type BRConf struct {
 	ExternalQosConfig conf.ExternalConfig
 	// Dir is the configuration directory.
 	Dir string
+	// DirectPorts is the range of ports of the applications that do not use
+	// the dispatcher.
+	DirectPorts overlay.PortRange
 }
 
 // Load sets up the configuration, loading it from the supplied config directory.
@@ -17,11 +17,24 @@ package brconf
 import (
 	"io"
 	"strings"
+	"time"
 
 	"github.com/scionproto/scion/go/lib/common"
 	"github.com/scionproto/scion/go/lib/config"
 	"github.com/scionproto/scion/go/lib/env"
 	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/topology/overlay"
+	"github.com/scionproto/scion/go/lib/util"
+)
+
+const (
+	// DefaultFlowMaxFlows is the default maximum number of flow records.
+	DefaultFlowMaxFlows = 65536
+	// DefaultFlowActiveTimeout is the default active timeout of flow records.
+	DefaultFlowActiveTimeout = time.Minute
+	// DefaultFlowIdleTimeout is the default idle timeout of flow records.
+	DefaultFlowIdleTimeout = 15 * time.Second
 )
 
 var _ config.Config = (*Config)(nil)
@@ -33,6 +46,7 @@ type Config struct {
 	Logging  log.Config   `toml:"log,omitempty"`
 	Metrics  env.Metrics  `toml:"metrics,omitempty"`
 	BR       BR           `toml:"br,omitempty"`
+	FlowAcct FlowAcct     `toml:"flow_accounting,omitempty"`
 }
 
 func (cfg *Config) InitDefaults() {
@@ -42,6 +56,7 @@ func (cfg *Config) InitDefaults() {
 		&cfg.Logging,
 		&cfg.Metrics,
 		&cfg.BR,
+		&cfg.FlowAcct,
 	)
 }
 
@@ -52,6 +67,7 @@ func (cfg *Config) Validate() error {
 		&cfg.Logging,
 		&cfg.Metrics,
 		&cfg.BR,
+		&cfg.FlowAcct,
 	)
 }
 
@@ -62,6 +78,7 @@ func (cfg *Config) Sample(dst io.Writer, path config.Path, _ config.CtxMap) {
 		&cfg.Logging,
 		&cfg.Metrics,
 		&cfg.BR,
+		&cfg.FlowAcct,
 	)
 }
 
@@ -76,6 +93,11 @@ type BR struct {
 	// RollbackFailAction indicates the action that should be taken
 	// if the rollback fails.
 	RollbackFailAction FailAction `toml:"rollback_fail_action,omitempty"`
+	// DirectPorts is the range of ports of the applications that do not use
+	// the dispatcher. SCION/UDP packets to a port in the range are delivered
+	// to the overlay port of the same number. If empty, all packets are
+	// delivered to the dispatcher.
+	DirectPorts overlay.PortRange `toml:"direct_ports,omitempty"`
 }
 
 func (cfg *BR) InitDefaults() {
@@ -96,6 +118,99 @@ func (cfg *BR) ConfigName() string {
 	return "br"
 }
 
+var _ config.Config = (*FlowAcct)(nil)
+
+// FlowExport is the format flow records are exported in.
+type FlowExport string
+
+const (
+	// FlowExportJSON exports flow records as JSON lines to a file.
+	FlowExportJSON FlowExport = "json"
+	// FlowExportIPFIX exports flow records as IPFIX messages over UDP.
+	FlowExportIPFIX FlowExport = "ipfix"
+)
+
+// FlowAcct contains the configuration of the flow accounting.
+type FlowAcct struct {
+	// Enabled enables the flow accounting.
+	Enabled bool `toml:"enabled,omitempty"`
+	// Export is the export format, json or ipfix.
+	Export FlowExport `toml:"export,omitempty"`
+	// File is the file the JSON lines are appended to. If empty, the records
+	// are written to stdout.
+	File string `toml:"file,omitempty"`
+	// Collector is the UDP address of the IPFIX collector.
+	Collector string `toml:"collector,omitempty"`
+	// EnterpriseNumber is the private enterprise number of the SCION specific
+	// IPFIX information elements.
+	EnterpriseNumber uint32 `toml:"enterprise_number,omitempty"`
+	// ObservationDomain is the IPFIX observation domain ID.
+	ObservationDomain uint32 `toml:"observation_domain,omitempty"`
+	// SamplingInterval accounts every n-th packet. 1 accounts all packets.
+	SamplingInterval int `toml:"sampling_interval,omitempty"`
+	// MaxFlows is the maximum number of flow records kept in memory. Packets
+	// of new flows are not accounted while the table is full.
+	MaxFlows int `toml:"max_flows,omitempty"`
+	// ActiveTimeout is the time after which a record of an active flow is
+	// exported and restarted.
+	ActiveTimeout util.DurWrap `toml:"active_timeout,omitempty"`
+	// IdleTimeout is the time without packets after which a flow record is
+	// exported and removed.
+	IdleTimeout util.DurWrap `toml:"idle_timeout,omitempty"`
+}
+
+func (cfg *FlowAcct) InitDefaults() {
+	if cfg.Export == "" {
+		cfg.Export = FlowExportJSON
+	}
+	if cfg.SamplingInterval == 0 {
+		cfg.SamplingInterval = 1
+	}
+	if cfg.MaxFlows == 0 {
+		cfg.MaxFlows = DefaultFlowMaxFlows
+	}
+	if cfg.ActiveTimeout.Duration == 0 {
+		cfg.ActiveTimeout.Duration = DefaultFlowActiveTimeout
+	}
+	if cfg.IdleTimeout.Duration == 0 {
+		cfg.IdleTimeout.Duration = DefaultFlowIdleTimeout
+	}
+}
+
+func (cfg *FlowAcct) Validate() error {
+	if !cfg.Enabled {
+		return nil
+	}
+	switch cfg.Export {
+	case FlowExportJSON:
+	case FlowExportIPFIX:
+		if cfg.Collector == "" {
+			return serrors.New("collector must be set for ipfix export")
+		}
+		if cfg.EnterpriseNumber == 0 {
+			return serrors.New("enterprise_number must be set for ipfix export")
+		}
+	default:
+		return serrors.New("Unknown flow export", "export", cfg.Export)
+	}
+	if cfg.SamplingInterval < 1 {
+		return serrors.New("sampling_interval must be positive",
+			"sampling_interval", cfg.SamplingInterval)
+	}
+	if cfg.MaxFlows < 1 {
+		return serrors.New("max_flows must be positive", "max_flows", cfg.MaxFlows)
+	}
+	return nil
+}
+
+func (cfg *FlowAcct) Sample(dst io.Writer, path config.Path, _ config.CtxMap) {
+	config.WriteString(dst, flowAcctSample)
+}
+
+func (cfg *FlowAcct) ConfigName() string {
+	return "flow_accounting"
+}
+
 type FailAction string
 
 const (
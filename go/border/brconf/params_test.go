@@ -23,6 +23,7 @@ import (
 
 	"github.com/scionproto/scion/go/lib/env/envtest"
 	"github.com/scionproto/scion/go/lib/log/logtest"
+	"github.com/scionproto/scion/go/lib/topology/overlay"
 )
 
 func TestConfigSample(t *testing.T) {
@@ -41,17 +42,34 @@ func InitTestConfig(cfg *Config) {
 	envtest.InitTest(&cfg.General, &cfg.Metrics, nil, nil)
 	logtest.InitTestLogging(&cfg.Logging)
 	InitTestBRConfig(&cfg.BR)
+	InitTestFlowAcctConfig(&cfg.FlowAcct)
 }
 
 func InitTestBRConfig(cfg *BR) {
+	cfg.DirectPorts = overlay.DefaultDirectPorts
+}
+
+func InitTestFlowAcctConfig(cfg *FlowAcct) {
+	cfg.Enabled = true
 }
 
 func CheckTestConfig(t *testing.T, cfg *Config, id string) {
 	envtest.CheckTest(t, &cfg.General, &cfg.Metrics, nil, nil, id)
 	logtest.CheckTestLogging(t, &cfg.Logging, id)
 	CheckTestBRConfig(t, &cfg.BR)
+	CheckTestFlowAcctConfig(t, &cfg.FlowAcct)
 }
 
 func CheckTestBRConfig(t *testing.T, cfg *BR) {
 	assert.Equal(t, FailActionFatal, cfg.RollbackFailAction)
+	assert.True(t, cfg.DirectPorts.Empty())
+}
+
+func CheckTestFlowAcctConfig(t *testing.T, cfg *FlowAcct) {
+	assert.False(t, cfg.Enabled)
+	assert.Equal(t, FlowExportJSON, cfg.Export)
+	assert.Equal(t, 1, cfg.SamplingInterval)
+	assert.Equal(t, DefaultFlowMaxFlows, cfg.MaxFlows)
+	assert.Equal(t, DefaultFlowActiveTimeout, cfg.ActiveTimeout.Duration)
+	assert.Equal(t, DefaultFlowIdleTimeout, cfg.IdleTimeout.Duration)
 }
@@ -20,4 +20,51 @@ const brSample = `
 # Action that should be taken when an error occurs during a context rollback.
 # (fatal | continue) (default fatal)
 rollback_fail_action = "fatal"
+
+# The range of ports of the applications that do not use the dispatcher, of
+# the form min-max. SCION/UDP packets to a port in the range are delivered
+# directly to the application, all others to the dispatcher. Applications
+# registered with the dispatcher must not use ports in the range. (default "")
+direct_ports = ""
+`
+
+const flowAcctSample = `
+# Enable the accounting of flows, keyed by source and destination IA and host,
+# L4 protocol and ports, ingress and egress interface and QoS queue.
+# (default false)
+enabled = false
+
+# The export format of the flow records. (json | ipfix) (default json)
+export = "json"
+
+# The file the JSON lines are appended to. If empty, the records are written to
+# stdout. (default "")
+file = ""
+
+# The UDP address of the IPFIX collector. Required for ipfix export.
+# (default "")
+collector = ""
+
+# The private enterprise number of the SCION specific IPFIX information
+# elements (IAs, hosts and QoS queue). Required for ipfix export. (default 0)
+enterprise_number = 0
+
+# The IPFIX observation domain ID. (default 0)
+observation_domain = 0
+
+# Account every n-th packet. The exported counters are not scaled.
+# (default 1)
+sampling_interval = 1
+
+# The maximum number of flow records kept in memory. Packets of new flows are
+# not accounted while the table is full. (default 65536)
+max_flows = 65536
+
+# The time after which the record of an active flow is exported and
+# restarted. (default 1m)
+active_timeout = "1m"
+
+# The time without packets after which a flow record is exported and removed.
+# (default 15s)
+idle_timeout = "15s"
 `
@@ -0,0 +1,127 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package capture implements selective packet capture in the router. A
+// capture is started with a request to the HTTP endpoint, which streams the
+// matching packets in pcap format until the packet count or the duration is
+// reached, or the client disconnects:
+//
+//	curl -sN 'http://<br>/capture?if=1&action=drop&count=100' | wireshark -k -i -
+//
+// The SCION packets are captured with their headers intact. They are wrapped
+// in the IP/UDP overlay header they were received with, such that Wireshark
+// can dissect them.
+package capture
+
+import (
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/scionproto/scion/go/border/qos/conf"
+	"github.com/scionproto/scion/go/border/rpkt"
+	"github.com/scionproto/scion/go/lib/common"
+)
+
+// sessionBufLen is the number of packets buffered per capture. Packets that
+// do not fit are dropped from the capture, but not from the router.
+const sessionBufLen = 1024
+
+// Packet is a captured packet.
+type Packet struct {
+	Time time.Time
+	Meta Meta
+	// Src and Dst are the overlay addresses the packet was received with.
+	Src *net.UDPAddr
+	Dst *net.UDPAddr
+	// Raw is a copy of the SCION packet.
+	Raw common.RawBytes
+}
+
+type session struct {
+	filter  Filter
+	pkts    chan *Packet
+	dropped uint64
+}
+
+var (
+	// active is the number of running captures. It is checked before any
+	// other work is done for a packet.
+	active   int32
+	mtx      sync.RWMutex
+	sessions = make(map[*session]struct{})
+)
+
+func register(f Filter) *session {
+	s := &session{filter: f, pkts: make(chan *Packet, sessionBufLen)}
+	mtx.Lock()
+	defer mtx.Unlock()
+	sessions[s] = struct{}{}
+	atomic.AddInt32(&active, 1)
+	return s
+}
+
+func unregister(s *session) {
+	mtx.Lock()
+	defer mtx.Unlock()
+	delete(sessions, s)
+	atomic.AddInt32(&active, -1)
+}
+
+// Capture hands the packet to all running captures whose filter it matches.
+// queueNo is the QoS queue the packet is put on, negative if it bypasses the
+// queues. act is the action the QoS subsystem takes on the packet. Capture
+// must be called before the packet is routed or released.
+func Capture(rp *rpkt.RtrPkt, queueNo int, act conf.PoliceAction) {
+	if atomic.LoadInt32(&active) == 0 {
+		return
+	}
+	m := meta(rp, queueNo, act)
+	var pkt *Packet
+	mtx.RLock()
+	defer mtx.RUnlock()
+	for s := range sessions {
+		if !s.filter.Match(&m) {
+			continue
+		}
+		if pkt == nil {
+			pkt = &Packet{
+				Time: rp.TimeIn,
+				Meta: m,
+				Src:  rp.Ingress.Src,
+				Dst:  rp.Ingress.Dst,
+				Raw:  append(common.RawBytes(nil), rp.Raw...),
+			}
+		}
+		select {
+		case s.pkts <- pkt:
+		default:
+			atomic.AddUint64(&s.dropped, 1)
+		}
+	}
+}
+
+func meta(rp *rpkt.RtrPkt, queueNo int, act conf.PoliceAction) Meta {
+	m := Meta{Ingress: rp.Ingress.IfID, Queue: queueNo, Action: act}
+	m.SrcIA, _ = rp.SrcIA()
+	m.DstIA, _ = rp.DstIA()
+	if ifNext, err := rp.IFNext(); err == nil && ifNext != nil {
+		m.Egress = *ifNext
+	}
+	if l4h, err := rp.L4Hdr(false); err == nil && l4h != nil {
+		m.L4 = rp.L4Type
+	}
+	return m
+}
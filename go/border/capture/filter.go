@@ -0,0 +1,169 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package capture
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/scionproto/scion/go/border/qos/conf"
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/util"
+)
+
+const (
+	// DefaultDuration is the duration of a capture without an explicit
+	// duration.
+	DefaultDuration = 10 * time.Second
+	// MaxDuration is the maximum duration of a capture.
+	MaxDuration = time.Hour
+)
+
+var actions = map[string]conf.PoliceAction{
+	"pass":       conf.PASS,
+	"notify":     conf.NOTIFY,
+	"drop":       conf.DROP,
+	"dropnotify": conf.DROPNOTIFY,
+}
+
+var l4Types = map[string]common.L4ProtocolType{
+	"scmp": common.L4SCMP,
+	"tcp":  common.L4TCP,
+	"udp":  common.L4UDP,
+}
+
+// Meta is the metadata of a packet that filters are matched against.
+type Meta struct {
+	SrcIA   addr.IA
+	DstIA   addr.IA
+	L4      common.L4ProtocolType
+	Ingress common.IFIDType
+	Egress  common.IFIDType
+	// Queue is the QoS queue, negative if the packet bypasses the queues.
+	Queue  int
+	Action conf.PoliceAction
+}
+
+// Filter selects the packets of a capture. Unset fields match any packet.
+type Filter struct {
+	// IfID matches packets that enter or leave on the interface.
+	IfID *common.IFIDType
+	// SrcIA and DstIA match the source and destination IA. Wildcard ISD or
+	// AS numbers match any ISD or AS.
+	SrcIA addr.IA
+	DstIA addr.IA
+	L4    common.L4ProtocolType
+	Queue *int
+	// Action matches the action the QoS subsystem took on the packet.
+	Action *conf.PoliceAction
+}
+
+// Match returns whether the packet matches the filter.
+func (f *Filter) Match(m *Meta) bool {
+	if f.IfID != nil && *f.IfID != m.Ingress && *f.IfID != m.Egress {
+		return false
+	}
+	if !matchIA(f.SrcIA, m.SrcIA) || !matchIA(f.DstIA, m.DstIA) {
+		return false
+	}
+	if f.L4 != common.L4None && f.L4 != m.L4 {
+		return false
+	}
+	if f.Queue != nil && *f.Queue != m.Queue {
+		return false
+	}
+	if f.Action != nil && *f.Action != m.Action {
+		return false
+	}
+	return true
+}
+
+func matchIA(f, ia addr.IA) bool {
+	return (f.I == 0 || f.I == ia.I) && (f.A == 0 || f.A == ia.A)
+}
+
+// Params are the parameters of a capture.
+type Params struct {
+	Filter Filter
+	// Count is the maximum number of packets, 0 is unlimited.
+	Count int
+	// Duration is the maximum duration of the capture.
+	Duration time.Duration
+}
+
+// ParseParams parses the parameters from the query of a capture request. The
+// recognized keys are if, src, dst, l4, queue, action, count and duration.
+func ParseParams(q url.Values) (Params, error) {
+	p := Params{Duration: DefaultDuration}
+	var err error
+	if v := q.Get("if"); v != "" {
+		ifid, err := strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			return p, serrors.WrapStr("invalid interface", err, "if", v)
+		}
+		i := common.IFIDType(ifid)
+		p.Filter.IfID = &i
+	}
+	if v := q.Get("src"); v != "" {
+		if p.Filter.SrcIA, err = addr.IAFromString(v); err != nil {
+			return p, serrors.WrapStr("invalid source IA", err, "src", v)
+		}
+	}
+	if v := q.Get("dst"); v != "" {
+		if p.Filter.DstIA, err = addr.IAFromString(v); err != nil {
+			return p, serrors.WrapStr("invalid destination IA", err, "dst", v)
+		}
+	}
+	if v := q.Get("l4"); v != "" {
+		l4, ok := l4Types[strings.ToLower(v)]
+		if !ok {
+			return p, serrors.New("unknown L4 type", "l4", v)
+		}
+		p.Filter.L4 = l4
+	}
+	if v := q.Get("queue"); v != "" {
+		queue, err := strconv.Atoi(v)
+		if err != nil {
+			return p, serrors.WrapStr("invalid queue", err, "queue", v)
+		}
+		p.Filter.Queue = &queue
+	}
+	if v := q.Get("action"); v != "" {
+		act, ok := actions[strings.ToLower(v)]
+		if !ok {
+			return p, serrors.New("unknown action", "action", v)
+		}
+		p.Filter.Action = &act
+	}
+	if v := q.Get("count"); v != "" {
+		if p.Count, err = strconv.Atoi(v); err != nil || p.Count < 0 {
+			return p, serrors.New("invalid count", "count", v)
+		}
+	}
+	if v := q.Get("duration"); v != "" {
+		if p.Duration, err = util.ParseDuration(v); err != nil {
+			return p, serrors.WrapStr("invalid duration", err, "duration", v)
+		}
+		if p.Duration <= 0 || p.Duration > MaxDuration {
+			return p, serrors.New("duration out of range", "duration", v,
+				"max", MaxDuration)
+		}
+	}
+	return p, nil
+}
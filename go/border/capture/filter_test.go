@@ -0,0 +1,104 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package capture
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/border/qos/conf"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/xtest"
+)
+
+func TestParseParams(t *testing.T) {
+	tests := map[string]struct {
+		Query    string
+		Meta     Meta
+		Match    bool
+		Count    int
+		Duration time.Duration
+		Err      bool
+	}{
+		"empty matches all": {
+			Query:    "",
+			Meta:     Meta{Queue: -1},
+			Match:    true,
+			Duration: DefaultDuration,
+		},
+		"egress interface": {
+			Query:    "if=2&count=10&duration=1m",
+			Meta:     Meta{Ingress: 1, Egress: 2},
+			Match:    true,
+			Count:    10,
+			Duration: time.Minute,
+		},
+		"other interface": {
+			Query:    "if=3",
+			Meta:     Meta{Ingress: 1, Egress: 2},
+			Duration: DefaultDuration,
+		},
+		"wildcard AS": {
+			Query: "src=1-0&dst=2-ff00:0:222&l4=UDP",
+			Meta: Meta{
+				SrcIA: xtest.MustParseIA("1-ff00:0:110"),
+				DstIA: xtest.MustParseIA("2-ff00:0:222"),
+				L4:    common.L4UDP,
+			},
+			Match:    true,
+			Duration: DefaultDuration,
+		},
+		"other destination": {
+			Query:    "dst=2-ff00:0:222",
+			Meta:     Meta{DstIA: xtest.MustParseIA("2-ff00:0:221")},
+			Duration: DefaultDuration,
+		},
+		"dropped in queue": {
+			Query:    "queue=2&action=drop",
+			Meta:     Meta{Queue: 2, Action: conf.DROP},
+			Match:    true,
+			Duration: DefaultDuration,
+		},
+		"passed in queue": {
+			Query:    "queue=2&action=drop",
+			Meta:     Meta{Queue: 2, Action: conf.PASS},
+			Duration: DefaultDuration,
+		},
+		"bad action":   {Query: "action=reject", Err: true},
+		"bad l4":       {Query: "l4=quic", Err: true},
+		"bad ia":       {Query: "src=1-ff00", Err: true},
+		"bad duration": {Query: "duration=2h", Err: true},
+		"bad count":    {Query: "count=-1", Err: true},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			q, err := url.ParseQuery(test.Query)
+			require.NoError(t, err)
+			p, err := ParseParams(q)
+			if test.Err {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.Match, p.Filter.Match(&test.Meta))
+			assert.Equal(t, test.Count, p.Count)
+			assert.Equal(t, test.Duration, p.Duration)
+		})
+	}
+}
@@ -0,0 +1,167 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package capture
+
+import (
+	"encoding/binary"
+	"io"
+	"net"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/serrors"
+)
+
+const (
+	// snapLen is the snapshot length of the capture. Packets are never
+	// truncated.
+	snapLen = 65535
+	// pcapMagic is the magic number of pcap files with microsecond
+	// timestamps.
+	pcapMagic = 0xa1b2c3d4
+)
+
+// Handler starts a capture with the parameters from the request query, and
+// streams the matching packets in pcap format. See ParseParams for the
+// parameters.
+func Handler(w http.ResponseWriter, r *http.Request) {
+	p, err := ParseParams(r.URL.Query())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	w.Header().Set("Content-Type", "application/vnd.tcpdump.pcap")
+	w.Header().Set("Content-Disposition", "attachment; filename=capture.pcap")
+	s := register(p.Filter)
+	defer unregister(s)
+	logger := log.New("capture", log.NewDebugID())
+	logger.Info("Capture started", "remote", r.RemoteAddr, "query", r.URL.RawQuery)
+	n, err := stream(w, s, p, r.Context().Done())
+	if err != nil {
+		logger.Info("Capture aborted", "packets", n, "err", err)
+		return
+	}
+	logger.Info("Capture finished", "packets", n, "dropped", atomic.LoadUint64(&s.dropped))
+}
+
+// stream writes the packets of the session to w until the packet count or the
+// duration is reached, or done is closed. It returns the number of written
+// packets.
+func stream(w io.Writer, s *session, p Params, done <-chan struct{}) (int, error) {
+	if err := writeFileHeader(w); err != nil {
+		return 0, err
+	}
+	flush(w)
+	timer := time.NewTimer(p.Duration)
+	defer timer.Stop()
+	buf := gopacket.NewSerializeBuffer()
+	n := 0
+	for p.Count == 0 || n < p.Count {
+		select {
+		case <-done:
+			return n, nil
+		case <-timer.C:
+			return n, nil
+		case pkt := <-s.pkts:
+			if err := write(w, buf, pkt); err != nil {
+				return n, err
+			}
+			flush(w)
+			n++
+		}
+	}
+	return n, nil
+}
+
+func flush(w io.Writer) {
+	if f, ok := w.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// write writes the packet wrapped in its IP/UDP overlay header.
+func write(w io.Writer, buf gopacket.SerializeBuffer, pkt *Packet) error {
+	udp := &layers.UDP{}
+	var ip gopacket.NetworkLayer
+	src, dst := overlayAddr(pkt.Src), overlayAddr(pkt.Dst)
+	udp.SrcPort, udp.DstPort = layers.UDPPort(src.Port), layers.UDPPort(dst.Port)
+	if src.IP.To4() != nil && dst.IP.To4() != nil {
+		ip = &layers.IPv4{
+			Version:  4,
+			TTL:      64,
+			Protocol: layers.IPProtocolUDP,
+			SrcIP:    src.IP.To4(),
+			DstIP:    dst.IP.To4(),
+		}
+	} else {
+		ip = &layers.IPv6{
+			Version:    6,
+			HopLimit:   64,
+			NextHeader: layers.IPProtocolUDP,
+			SrcIP:      src.IP.To16(),
+			DstIP:      dst.IP.To16(),
+		}
+	}
+	if err := udp.SetNetworkLayerForChecksum(ip); err != nil {
+		return err
+	}
+	opts := gopacket.SerializeOptions{FixLengths: true, ComputeChecksums: true}
+	err := gopacket.SerializeLayers(buf, opts, ip.(gopacket.SerializableLayer), udp,
+		gopacket.Payload(pkt.Raw))
+	if err != nil {
+		return serrors.WrapStr("unable to serialize overlay header", err)
+	}
+	ts := pkt.Time
+	if ts.IsZero() {
+		ts = time.Now()
+	}
+	data := buf.Bytes()
+	hdr := make([]byte, 16)
+	binary.LittleEndian.PutUint32(hdr[0:], uint32(ts.Unix()))
+	binary.LittleEndian.PutUint32(hdr[4:], uint32(ts.Nanosecond()/1000))
+	binary.LittleEndian.PutUint32(hdr[8:], uint32(len(data)))
+	binary.LittleEndian.PutUint32(hdr[12:], uint32(len(data)))
+	if _, err := w.Write(hdr); err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+// writeFileHeader writes the pcap file header. The packets are raw IP packets.
+func writeFileHeader(w io.Writer) error {
+	hdr := make([]byte, 24)
+	binary.LittleEndian.PutUint32(hdr[0:], pcapMagic)
+	binary.LittleEndian.PutUint16(hdr[4:], 2)
+	binary.LittleEndian.PutUint16(hdr[6:], 4)
+	binary.LittleEndian.PutUint32(hdr[16:], snapLen)
+	binary.LittleEndian.PutUint32(hdr[20:], uint32(layers.LinkTypeRaw))
+	_, err := w.Write(hdr)
+	return err
+}
+
+// overlayAddr returns the address, or the unspecified IPv4 address if it is
+// not known.
+func overlayAddr(a *net.UDPAddr) *net.UDPAddr {
+	if a == nil || a.IP == nil {
+		return &net.UDPAddr{IP: net.IPv4zero}
+	}
+	return a
+}
@@ -0,0 +1,77 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package capture
+
+import (
+	"bytes"
+	"encoding/binary"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/common"
+)
+
+func TestStream(t *testing.T) {
+	s := register(Filter{})
+	defer unregister(s)
+	ts := time.Unix(1591012800, 5000)
+	for i := 0; i < 3; i++ {
+		s.pkts <- &Packet{
+			Time: ts,
+			Src:  &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 50000},
+			Dst:  &net.UDPAddr{IP: net.IPv4(127, 0, 0, 2), Port: 50001},
+			Raw:  common.RawBytes{0, 1, 2, 3, byte(i)},
+		}
+	}
+	var b bytes.Buffer
+	n, err := stream(&b, s, Params{Count: 2, Duration: time.Second}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+
+	raw := b.Bytes()
+	require.True(t, len(raw) > 24)
+	assert.Equal(t, uint32(pcapMagic), binary.LittleEndian.Uint32(raw[0:]))
+	assert.Equal(t, uint32(layers.LinkTypeRaw), binary.LittleEndian.Uint32(raw[20:]))
+	raw = raw[24:]
+	for i := 0; i < 2; i++ {
+		assert.Equal(t, uint32(ts.Unix()), binary.LittleEndian.Uint32(raw[0:]))
+		assert.Equal(t, uint32(5), binary.LittleEndian.Uint32(raw[4:]))
+		l := int(binary.LittleEndian.Uint32(raw[8:]))
+		pkt := gopacket.NewPacket(raw[16:16+l], layers.LayerTypeIPv4, gopacket.Default)
+		require.Nil(t, pkt.ErrorLayer())
+		udp := pkt.Layer(layers.LayerTypeUDP).(*layers.UDP)
+		assert.Equal(t, layers.UDPPort(50000), udp.SrcPort)
+		assert.Equal(t, layers.UDPPort(50001), udp.DstPort)
+		assert.Equal(t, []byte{0, 1, 2, 3, byte(i)}, udp.Payload)
+		raw = raw[16+l:]
+	}
+	assert.Empty(t, raw)
+}
+
+func TestStreamDuration(t *testing.T) {
+	s := register(Filter{})
+	defer unregister(s)
+	var b bytes.Buffer
+	n, err := stream(&b, s, Params{Duration: 10 * time.Millisecond}, nil)
+	require.NoError(t, err)
+	assert.Zero(t, n)
+	assert.Len(t, b.Bytes(), 24)
+}
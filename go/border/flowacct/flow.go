@@ -0,0 +1,160 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flowacct
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+)
+
+// NoQueue is the queue of packets that bypass the QoS queues.
+const NoQueue = -1
+
+// Host is a comparable representation of a host address.
+type Host struct {
+	Type addr.HostAddrType
+	Raw  string
+}
+
+// HostFromAddr converts a host address. A nil address results in an empty
+// host of type HostTypeNone.
+func HostFromAddr(h addr.HostAddr) Host {
+	if h == nil {
+		return Host{Type: addr.HostTypeNone}
+	}
+	return Host{Type: h.Type(), Raw: string(h.Pack())}
+}
+
+// Addr returns the host address, or nil if the host is empty or invalid.
+func (h Host) Addr() addr.HostAddr {
+	a, err := addr.HostFromRaw(common.RawBytes(h.Raw), h.Type)
+	if err != nil {
+		return nil
+	}
+	return a
+}
+
+func (h Host) String() string {
+	if a := h.Addr(); a != nil {
+		return a.String()
+	}
+	return ""
+}
+
+// Key identifies a flow.
+type Key struct {
+	SrcIA   addr.IA
+	DstIA   addr.IA
+	SrcHost Host
+	DstHost Host
+	L4      common.L4ProtocolType
+	// SrcPort and DstPort are the UDP ports. For SCMP, DstPort contains the
+	// class in the upper and the type in the lower byte.
+	SrcPort uint16
+	DstPort uint16
+	Ingress common.IFIDType
+	Egress  common.IFIDType
+	// Queue is the QoS queue, or NoQueue.
+	Queue int
+}
+
+// Record is the accounting of a flow.
+type Record struct {
+	Key
+	Packets uint64
+	Bytes   uint64
+	// Start and End are the times of the first and the last accounted packet.
+	Start time.Time
+	End   time.Time
+}
+
+// Table accounts packets to flow records. It is safe for concurrent use.
+type Table struct {
+	// MaxFlows is the maximum number of records in the table.
+	MaxFlows int
+	// SamplingInterval accounts every n-th packet.
+	SamplingInterval uint64
+	// ActiveTimeout is the time after which a record is expired even if the
+	// flow is still active.
+	ActiveTimeout time.Duration
+	// IdleTimeout is the time without packets after which a record is
+	// expired.
+	IdleTimeout time.Duration
+
+	mtx     sync.Mutex
+	flows   map[Key]*Record
+	seen    uint64
+	dropped uint64
+}
+
+// Sample returns whether the next packet is accounted according to the
+// sampling interval.
+func (t *Table) Sample() bool {
+	if t.SamplingInterval <= 1 {
+		return true
+	}
+	return atomic.AddUint64(&t.seen, 1)%t.SamplingInterval == 0
+}
+
+// Account accounts a packet of n bytes to the flow k. If the table is full and
+// k is a new flow, the packet is counted as dropped instead.
+func (t *Table) Account(k Key, n int, now time.Time) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	if t.flows == nil {
+		t.flows = make(map[Key]*Record)
+	}
+	r, ok := t.flows[k]
+	if !ok {
+		if len(t.flows) >= t.MaxFlows {
+			t.dropped++
+			return
+		}
+		r = &Record{Key: k, Start: now}
+		t.flows[k] = r
+	}
+	r.Packets++
+	r.Bytes += uint64(n)
+	r.End = now
+}
+
+// Expire removes and returns the records that reached the idle or the active
+// timeout. If all is set, all records are removed. It also returns the number
+// of packets that could not be accounted since the previous call.
+func (t *Table) Expire(now time.Time, all bool) ([]Record, uint64) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	var expired []Record
+	for k, r := range t.flows {
+		if all || now.Sub(r.End) >= t.IdleTimeout || now.Sub(r.Start) >= t.ActiveTimeout {
+			expired = append(expired, *r)
+			delete(t.flows, k)
+		}
+	}
+	dropped := t.dropped
+	t.dropped = 0
+	return expired, dropped
+}
+
+// Len returns the number of records in the table.
+func (t *Table) Len() int {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	return len(t.flows)
+}
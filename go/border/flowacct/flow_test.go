@@ -0,0 +1,174 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flowacct
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+)
+
+func testKey(srcPort uint16) Key {
+	return Key{
+		SrcIA:   addr.IA{I: 1, A: 0xff0000000110},
+		DstIA:   addr.IA{I: 2, A: 0xff0000000222},
+		SrcHost: HostFromAddr(addr.HostIPv4(net.IPv4(127, 0, 0, 1))),
+		DstHost: HostFromAddr(addr.SvcCS),
+		L4:      common.L4UDP,
+		SrcPort: srcPort,
+		DstPort: 30041,
+		Ingress: 1,
+		Egress:  2,
+		Queue:   3,
+	}
+}
+
+func TestTable(t *testing.T) {
+	now := time.Now()
+	tbl := &Table{
+		MaxFlows:      2,
+		ActiveTimeout: time.Minute,
+		IdleTimeout:   10 * time.Second,
+	}
+	tbl.Account(testKey(1), 100, now)
+	tbl.Account(testKey(1), 200, now.Add(time.Second))
+	tbl.Account(testKey(2), 100, now)
+	// The table is full, the third flow is not accounted.
+	tbl.Account(testKey(3), 100, now)
+	assert.Equal(t, 2, tbl.Len())
+
+	t.Run("idle timeout", func(t *testing.T) {
+		records, dropped := tbl.Expire(now.Add(10*time.Second), false)
+		assert.Equal(t, uint64(1), dropped)
+		require.Len(t, records, 1)
+		assert.Equal(t, testKey(2), records[0].Key)
+		assert.Equal(t, 1, tbl.Len())
+	})
+	t.Run("active timeout", func(t *testing.T) {
+		for i := 1; i < 60; i++ {
+			tbl.Account(testKey(1), 100, now.Add(time.Duration(i)*time.Second))
+		}
+		records, dropped := tbl.Expire(now.Add(59*time.Second), false)
+		assert.Empty(t, records)
+		assert.Zero(t, dropped)
+		records, _ = tbl.Expire(now.Add(time.Minute), false)
+		require.Len(t, records, 1)
+		assert.Equal(t, uint64(61), records[0].Packets)
+		assert.Equal(t, uint64(6200), records[0].Bytes)
+		assert.Equal(t, now, records[0].Start)
+		assert.Equal(t, now.Add(59*time.Second), records[0].End)
+		assert.Zero(t, tbl.Len())
+	})
+	t.Run("all", func(t *testing.T) {
+		tbl.Account(testKey(1), 100, now)
+		records, _ := tbl.Expire(now, true)
+		assert.Len(t, records, 1)
+	})
+}
+
+func TestSample(t *testing.T) {
+	tbl := &Table{SamplingInterval: 4}
+	sampled := 0
+	for i := 0; i < 100; i++ {
+		if tbl.Sample() {
+			sampled++
+		}
+	}
+	assert.Equal(t, 25, sampled)
+}
+
+type closeBuffer struct {
+	bytes.Buffer
+}
+
+func (b *closeBuffer) Close() error {
+	return nil
+}
+
+func TestJSONExporter(t *testing.T) {
+	var b closeBuffer
+	e := NewJSONExporter(&b, 10)
+	start := time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+	records := []Record{
+		{Key: testKey(1), Packets: 2, Bytes: 300, Start: start, End: start.Add(time.Second)},
+		{Key: testKey(2), Packets: 1, Bytes: 100, Start: start, End: start},
+	}
+	require.NoError(t, e.Export(records, start))
+	lines := bytes.Split(bytes.TrimSpace(b.Bytes()), []byte("\n"))
+	require.Len(t, lines, 2)
+	var r map[string]interface{}
+	require.NoError(t, json.Unmarshal(lines[0], &r))
+	assert.Equal(t, "1-ff00:0:110", r["src_ia"])
+	assert.Equal(t, "2-ff00:0:222", r["dst_ia"])
+	assert.Equal(t, "127.0.0.1", r["src_host"])
+	assert.Equal(t, "CS A (0x0002)", r["dst_host"])
+	assert.Equal(t, "UDP", r["l4"])
+	assert.Equal(t, float64(300), r["bytes"])
+	assert.Equal(t, float64(3), r["queue"])
+	assert.Equal(t, float64(10), r["sampling_interval"])
+	assert.Equal(t, "2020-06-01T12:00:01Z", r["end"])
+}
+
+func TestIPFIXEncode(t *testing.T) {
+	e := &IPFIXExporter{EnterpriseNumber: 12345, ObservationDomain: 7, SamplingInterval: 1}
+	now := time.Unix(1591012800, 0)
+	records := make([]Record, 40)
+	for i := range records {
+		records[i] = Record{Key: testKey(uint16(i)), Packets: 1, Bytes: 100, Start: now, End: now}
+	}
+	msg, n := e.encode(records, now)
+	require.True(t, n > 0 && n < len(records), "records must be split, n=%d", n)
+	assert.True(t, len(msg) <= IPFIXMaxMsgLen)
+	assert.Equal(t, uint16(ipfixVersion), binary.BigEndian.Uint16(msg[0:]))
+	assert.Equal(t, uint16(len(msg)), binary.BigEndian.Uint16(msg[2:]))
+	assert.Equal(t, uint32(now.Unix()), binary.BigEndian.Uint32(msg[4:]))
+	assert.Equal(t, uint32(0), binary.BigEndian.Uint32(msg[8:]))
+	assert.Equal(t, uint32(7), binary.BigEndian.Uint32(msg[12:]))
+
+	// Template set.
+	off := ipfixMsgHdrLen
+	assert.Equal(t, uint16(ipfixTemplateSet), binary.BigEndian.Uint16(msg[off:]))
+	tmplLen := int(binary.BigEndian.Uint16(msg[off+2:]))
+	assert.Equal(t, uint16(ipfixTemplateID), binary.BigEndian.Uint16(msg[off+4:]))
+	assert.Equal(t, uint16(len(ipfixFields)), binary.BigEndian.Uint16(msg[off+6:]))
+	assert.Equal(t, uint16(IESourceIA|ipfixEnterpriseBit), binary.BigEndian.Uint16(msg[off+8:]))
+	assert.Equal(t, uint32(12345), binary.BigEndian.Uint32(msg[off+12:]))
+
+	// Data set, check the first record.
+	off += tmplLen
+	assert.Equal(t, uint16(ipfixTemplateID), binary.BigEndian.Uint16(msg[off:]))
+	assert.Equal(t, len(msg)-off, int(binary.BigEndian.Uint16(msg[off+2:])))
+	rec := msg[off+ipfixSetHdrLen:]
+	k := testKey(0)
+	assert.Equal(t, uint64(k.SrcIA.IAInt()), binary.BigEndian.Uint64(rec[0:]))
+	assert.Equal(t, uint64(k.DstIA.IAInt()), binary.BigEndian.Uint64(rec[8:]))
+	assert.Equal(t, []byte{4, 127, 0, 0, 1}, []byte(rec[16:21]))
+	assert.Equal(t, uint8(2), rec[21], "SVC address length")
+	assert.Equal(t, uint8(3), rec[24], "queue")
+	assert.Equal(t, uint8(common.L4UDP), rec[25], "protocol")
+
+	// The sequence number of the next message counts the records sent.
+	msg, _ = e.encode(records[n:], now)
+	assert.Equal(t, uint32(n), binary.BigEndian.Uint32(msg[8:]))
+}
@@ -0,0 +1,171 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package flowacct accounts the packets forwarded by the router to flows. A
+// flow is identified by the source and destination IA and host, the L4
+// protocol and ports, the ingress and egress interface and the QoS queue.
+// The records are kept in a table of bounded size and exported periodically,
+// either as JSON lines or as IPFIX messages over UDP, once a flow has been
+// idle or active for longer than the configured timeouts.
+package flowacct
+
+import (
+	"io"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/scionproto/scion/go/border/brconf"
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/serrors"
+)
+
+// ExpireInterval is the interval between checks for expired records.
+const ExpireInterval = time.Second
+
+// Exporter exports flow records.
+type Exporter interface {
+	// Export exports the records. now is the time of the export.
+	Export(records []Record, now time.Time) error
+	Close() error
+}
+
+var (
+	// current holds the *Table that packets are accounted to. It is read for
+	// every forwarded packet, hence it is not protected by mtx.
+	current atomic.Value
+	// mtx protects the export state below.
+	mtx      sync.Mutex
+	table    *Table
+	exporter Exporter
+	stop     chan struct{}
+	stopped  chan struct{}
+)
+
+// Init sets up the flow accounting according to the configuration, and
+// starts exporting the records. It is a no-op if the flow accounting is
+// disabled.
+func Init(cfg *brconf.FlowAcct) error {
+	if !cfg.Enabled {
+		return nil
+	}
+	sampling := uint64(cfg.SamplingInterval)
+	var e Exporter
+	switch cfg.Export {
+	case brconf.FlowExportJSON:
+		var w io.WriteCloser = stdout{}
+		if cfg.File != "" {
+			var err error
+			w, err = os.OpenFile(cfg.File, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+			if err != nil {
+				return serrors.WrapStr("unable to open flow export file", err,
+					"file", cfg.File)
+			}
+		}
+		e = NewJSONExporter(w, sampling)
+	case brconf.FlowExportIPFIX:
+		var err error
+		e, err = NewIPFIXExporter(cfg.Collector, cfg.EnterpriseNumber,
+			cfg.ObservationDomain, sampling)
+		if err != nil {
+			return err
+		}
+	default:
+		return serrors.New("Unknown flow export", "export", cfg.Export)
+	}
+	t := &Table{
+		MaxFlows:         cfg.MaxFlows,
+		SamplingInterval: sampling,
+		ActiveTimeout:    cfg.ActiveTimeout.Duration,
+		IdleTimeout:      cfg.IdleTimeout.Duration,
+	}
+	start(t, e)
+	log.Info("Flow accounting enabled", "export", cfg.Export, "sampling", sampling,
+		"max_flows", cfg.MaxFlows)
+	return nil
+}
+
+func start(t *Table, e Exporter) {
+	mtx.Lock()
+	defer mtx.Unlock()
+	table, exporter = t, e
+	current.Store(t)
+	stop, stopped = make(chan struct{}), make(chan struct{})
+	go func() {
+		defer log.HandlePanic()
+		run(t, e, stop, stopped)
+	}()
+}
+
+// Close exports all remaining records and stops the export.
+func Close() error {
+	mtx.Lock()
+	defer mtx.Unlock()
+	if table == nil {
+		return nil
+	}
+	current.Store((*Table)(nil))
+	close(stop)
+	<-stopped
+	export(table, exporter, time.Now(), true)
+	err := exporter.Close()
+	table, exporter = nil, nil
+	return err
+}
+
+// Get returns the flow table, or nil if the flow accounting is disabled.
+func Get() *Table {
+	t, _ := current.Load().(*Table)
+	return t
+}
+
+func run(t *Table, e Exporter, stop, stopped chan struct{}) {
+	defer close(stopped)
+	ticker := time.NewTicker(ExpireInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case now := <-ticker.C:
+			export(t, e, now, false)
+		}
+	}
+}
+
+func export(t *Table, e Exporter, now time.Time, all bool) {
+	records, dropped := t.Expire(now, all)
+	if dropped > 0 {
+		log.Info("Flow table full, packets not accounted", "dropped", dropped,
+			"max_flows", t.MaxFlows)
+	}
+	if len(records) == 0 {
+		return
+	}
+	if err := e.Export(records, now); err != nil {
+		log.Error("Unable to export flow records", "records", len(records), "err", err)
+	}
+}
+
+// stdout writes to os.Stdout, closing it is a no-op.
+type stdout struct{}
+
+func (stdout) Write(b []byte) (int, error) {
+	return os.Stdout.Write(b)
+}
+
+func (stdout) Close() error {
+	return nil
+}
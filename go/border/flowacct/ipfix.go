@@ -0,0 +1,215 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flowacct
+
+import (
+	"encoding/binary"
+	"net"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/serrors"
+)
+
+// IPFIX (RFC 7011) constants.
+const (
+	ipfixVersion     = 10
+	ipfixMsgHdrLen   = 16
+	ipfixSetHdrLen   = 4
+	ipfixTemplateSet = 2
+	// ipfixTemplateID is the ID of the template, and the set ID of the data
+	// sets.
+	ipfixTemplateID = 256
+	// ipfixVarLen is the field length of variable length fields.
+	ipfixVarLen = 0xffff
+	// ipfixEnterpriseBit marks enterprise specific information elements.
+	ipfixEnterpriseBit = 0x8000
+	// IPFIXMaxMsgLen is the maximum length of an exported message, such that
+	// it fits a single UDP datagram on an Ethernet link.
+	IPFIXMaxMsgLen = 1472
+)
+
+// SCION specific information elements. They are enterprise specific and
+// qualified by the configured enterprise number.
+const (
+	IESourceIA        = 1
+	IEDestinationIA   = 2
+	IESourceHost      = 3
+	IEDestinationHost = 4
+	IEQoSQueue        = 5
+)
+
+type ipfixField struct {
+	id         uint16
+	length     uint16
+	enterprise bool
+}
+
+// ipfixFields is the template of the data records. The host addresses are
+// variable length fields, their length determines the address type.
+var ipfixFields = []ipfixField{
+	{id: IESourceIA, length: 8, enterprise: true},
+	{id: IEDestinationIA, length: 8, enterprise: true},
+	{id: IESourceHost, length: ipfixVarLen, enterprise: true},
+	{id: IEDestinationHost, length: ipfixVarLen, enterprise: true},
+	{id: IEQoSQueue, length: 1, enterprise: true},
+	{id: 4, length: 1},   // protocolIdentifier
+	{id: 7, length: 2},   // sourceTransportPort
+	{id: 11, length: 2},  // destinationTransportPort
+	{id: 10, length: 4},  // ingressInterface
+	{id: 14, length: 4},  // egressInterface
+	{id: 152, length: 8}, // flowStartMilliseconds
+	{id: 153, length: 8}, // flowEndMilliseconds
+	{id: 2, length: 8},   // packetDeltaCount
+	{id: 1, length: 8},   // octetDeltaCount
+	{id: 305, length: 4}, // samplingPacketInterval
+}
+
+var _ Exporter = (*IPFIXExporter)(nil)
+
+// IPFIXExporter sends the records as IPFIX messages over UDP. Every message
+// carries the template, such that collectors can decode the records
+// regardless of which messages they missed.
+type IPFIXExporter struct {
+	// EnterpriseNumber qualifies the SCION specific information elements.
+	EnterpriseNumber uint32
+	// ObservationDomain is the observation domain ID of the messages.
+	ObservationDomain uint32
+	// SamplingInterval is added to every record.
+	SamplingInterval uint64
+	conn             net.Conn
+	seq              uint32
+}
+
+// NewIPFIXExporter returns an exporter that sends to the collector address.
+func NewIPFIXExporter(collector string, enterpriseNumber, observationDomain uint32,
+	samplingInterval uint64) (*IPFIXExporter, error) {
+
+	conn, err := net.Dial("udp", collector)
+	if err != nil {
+		return nil, serrors.WrapStr("unable to connect to IPFIX collector", err,
+			"collector", collector)
+	}
+	return &IPFIXExporter{
+		EnterpriseNumber:  enterpriseNumber,
+		ObservationDomain: observationDomain,
+		SamplingInterval:  samplingInterval,
+		conn:              conn,
+	}, nil
+}
+
+func (e *IPFIXExporter) Export(records []Record, now time.Time) error {
+	for len(records) > 0 {
+		msg, n := e.encode(records, now)
+		records = records[n:]
+		if _, err := e.conn.Write(msg); err != nil {
+			return serrors.WrapStr("unable to send IPFIX message", err)
+		}
+	}
+	return nil
+}
+
+func (e *IPFIXExporter) Close() error {
+	return e.conn.Close()
+}
+
+// encode encodes as many records as fit into one message, but at least one.
+// It returns the message and the number of encoded records.
+func (e *IPFIXExporter) encode(records []Record, now time.Time) (common.RawBytes, int) {
+	b := make(common.RawBytes, ipfixMsgHdrLen, IPFIXMaxMsgLen)
+	b = e.appendTemplateSet(b)
+	dataSet := len(b)
+	b = append(b, make(common.RawBytes, ipfixSetHdrLen)...)
+	n := 0
+	for _, r := range records {
+		l := len(b)
+		b = e.appendRecord(b, &r)
+		if len(b) > IPFIXMaxMsgLen && n > 0 {
+			b = b[:l]
+			break
+		}
+		n++
+	}
+	binary.BigEndian.PutUint16(b[dataSet:], ipfixTemplateID)
+	binary.BigEndian.PutUint16(b[dataSet+2:], uint16(len(b)-dataSet))
+	binary.BigEndian.PutUint16(b[0:], ipfixVersion)
+	binary.BigEndian.PutUint16(b[2:], uint16(len(b)))
+	binary.BigEndian.PutUint32(b[4:], uint32(now.Unix()))
+	binary.BigEndian.PutUint32(b[8:], e.seq)
+	binary.BigEndian.PutUint32(b[12:], e.ObservationDomain)
+	// The sequence number counts the data records sent before this message.
+	e.seq += uint32(n)
+	return b, n
+}
+
+func (e *IPFIXExporter) appendTemplateSet(b common.RawBytes) common.RawBytes {
+	start := len(b)
+	b = appendUint16(b, ipfixTemplateSet)
+	b = appendUint16(b, 0)
+	b = appendUint16(b, ipfixTemplateID)
+	b = appendUint16(b, uint16(len(ipfixFields)))
+	for _, f := range ipfixFields {
+		if f.enterprise {
+			b = appendUint16(b, f.id|ipfixEnterpriseBit)
+			b = appendUint16(b, f.length)
+			b = appendUint32(b, e.EnterpriseNumber)
+			continue
+		}
+		b = appendUint16(b, f.id)
+		b = appendUint16(b, f.length)
+	}
+	binary.BigEndian.PutUint16(b[start+2:], uint16(len(b)-start))
+	return b
+}
+
+func (e *IPFIXExporter) appendRecord(b common.RawBytes, r *Record) common.RawBytes {
+	b = appendUint64(b, uint64(r.SrcIA.IAInt()))
+	b = appendUint64(b, uint64(r.DstIA.IAInt()))
+	b = appendVarLen(b, r.SrcHost.Raw)
+	b = appendVarLen(b, r.DstHost.Raw)
+	queue := uint8(0xff)
+	if r.Queue >= 0 && r.Queue < 0xff {
+		queue = uint8(r.Queue)
+	}
+	b = append(b, queue, uint8(r.L4))
+	b = appendUint16(b, r.SrcPort)
+	b = appendUint16(b, r.DstPort)
+	b = appendUint32(b, uint32(r.Ingress))
+	b = appendUint32(b, uint32(r.Egress))
+	b = appendUint64(b, uint64(r.Start.UnixNano()/int64(time.Millisecond)))
+	b = appendUint64(b, uint64(r.End.UnixNano()/int64(time.Millisecond)))
+	b = appendUint64(b, r.Packets)
+	b = appendUint64(b, r.Bytes)
+	return appendUint32(b, uint32(e.SamplingInterval))
+}
+
+// appendVarLen appends a variable length field. The host addresses are always
+// shorter than 255 bytes, so the short length encoding suffices.
+func appendVarLen(b common.RawBytes, v string) common.RawBytes {
+	b = append(b, uint8(len(v)))
+	return append(b, v...)
+}
+
+func appendUint16(b common.RawBytes, v uint16) common.RawBytes {
+	return append(b, uint8(v>>8), uint8(v))
+}
+
+func appendUint32(b common.RawBytes, v uint32) common.RawBytes {
+	return appendUint16(appendUint16(b, uint16(v>>16)), uint16(v))
+}
+
+func appendUint64(b common.RawBytes, v uint64) common.RawBytes {
+	return appendUint32(appendUint32(b, uint32(v>>32)), uint32(v))
+}
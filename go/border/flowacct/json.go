@@ -0,0 +1,90 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flowacct
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+)
+
+var _ Exporter = (*JSONExporter)(nil)
+
+// jsonRecord is the JSON representation of a flow record.
+type jsonRecord struct {
+	SrcIA            addr.IA         `json:"src_ia"`
+	DstIA            addr.IA         `json:"dst_ia"`
+	SrcHost          string          `json:"src_host"`
+	DstHost          string          `json:"dst_host"`
+	L4               string          `json:"l4"`
+	SrcPort          uint16          `json:"src_port"`
+	DstPort          uint16          `json:"dst_port"`
+	Ingress          common.IFIDType `json:"ingress"`
+	Egress           common.IFIDType `json:"egress"`
+	Queue            int             `json:"queue"`
+	Packets          uint64          `json:"packets"`
+	Bytes            uint64          `json:"bytes"`
+	Start            time.Time       `json:"start"`
+	End              time.Time       `json:"end"`
+	SamplingInterval uint64          `json:"sampling_interval"`
+}
+
+// JSONExporter writes every record as a JSON object on a separate line.
+type JSONExporter struct {
+	// SamplingInterval is added to every record.
+	SamplingInterval uint64
+	w                io.WriteCloser
+}
+
+// NewJSONExporter returns an exporter that writes to w. Close closes w.
+func NewJSONExporter(w io.WriteCloser, samplingInterval uint64) *JSONExporter {
+	return &JSONExporter{SamplingInterval: samplingInterval, w: w}
+}
+
+func (e *JSONExporter) Export(records []Record, now time.Time) error {
+	bw := bufio.NewWriter(e.w)
+	enc := json.NewEncoder(bw)
+	for _, r := range records {
+		jr := jsonRecord{
+			SrcIA:            r.SrcIA,
+			DstIA:            r.DstIA,
+			SrcHost:          r.SrcHost.String(),
+			DstHost:          r.DstHost.String(),
+			L4:               r.L4.String(),
+			SrcPort:          r.SrcPort,
+			DstPort:          r.DstPort,
+			Ingress:          r.Ingress,
+			Egress:           r.Egress,
+			Queue:            r.Queue,
+			Packets:          r.Packets,
+			Bytes:            r.Bytes,
+			Start:            r.Start,
+			End:              r.End,
+			SamplingInterval: e.SamplingInterval,
+		}
+		if err := enc.Encode(jr); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
+
+func (e *JSONExporter) Close() error {
+	return e.w.Close()
+}
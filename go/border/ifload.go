@@ -0,0 +1,54 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"time"
+
+	"github.com/scionproto/scion/go/border/ifload"
+	"github.com/scionproto/scion/go/border/rctx"
+	"github.com/scionproto/scion/go/lib/common"
+)
+
+// ifLoadInterval is the interval between two updates of the interface loads.
+const ifLoadInterval = time.Second
+
+// ifLoadUpdate periodically updates the interface loads that are exported to
+// the beacon service.
+func (r *Router) ifLoadUpdate() {
+	ticker := time.NewTicker(ifLoadInterval)
+	defer ticker.Stop()
+	for now := range ticker.C {
+		bandwidths := make(map[common.IFIDType]int)
+		if ctx := rctx.Get(); ctx != nil {
+			for ifid, info := range ctx.Conf.BR.IFs {
+				bandwidths[ifid] = info.Bandwidth
+			}
+		}
+		ifload.Update(now, bandwidths, r.maxQueueFill())
+	}
+}
+
+// maxQueueFill returns the fill level of the fullest QoS queue in percent. The
+// queues are shared by all interfaces.
+func (r *Router) maxQueueFill() int {
+	fill := 0
+	for _, q := range *r.qosConfig.GetQueues() {
+		if f := q.GetFillLevel(); f > fill {
+			fill = f
+		}
+	}
+	return fill
+}
@@ -0,0 +1,139 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ifload keeps track of the egress load of the interfaces of the
+// router. The output goroutines account the bytes they write per interface,
+// and a periodic update turns the byte counts into rates. The resulting load
+// summaries are exported over HTTP, so that the beacon service can attach
+// them to the beacons it propagates.
+package ifload
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/ctrl/seg"
+	"github.com/scionproto/scion/go/lib/log"
+)
+
+// Counter counts the bytes written on an interface.
+type Counter struct {
+	bytes uint64
+}
+
+// Add accounts n written bytes.
+func (c *Counter) Add(n int) {
+	atomic.AddUint64(&c.bytes, uint64(n))
+}
+
+func (c *Counter) load() uint64 {
+	return atomic.LoadUint64(&c.bytes)
+}
+
+// counters maps interface IDs to *Counter.
+var counters sync.Map
+
+// CounterFor returns the byte counter of the given interface.
+func CounterFor(ifid common.IFIDType) *Counter {
+	c, _ := counters.LoadOrStore(ifid, &Counter{})
+	return c.(*Counter)
+}
+
+type sample struct {
+	bytes uint64
+	time  time.Time
+}
+
+var (
+	mtx     sync.RWMutex
+	samples = make(map[common.IFIDType]sample)
+	loads   = make(map[common.IFIDType]*seg.InterfaceLoad)
+)
+
+// Update computes the load of all interfaces from the bytes written since the
+// previous update. bandwidths contains the link bandwidth in kbit/s per
+// interface; interfaces without a bandwidth get a utilization of 0.
+// routerQueueFill is the fill level of the fullest egress queue in percent.
+// The queues are shared by all interfaces, so all interfaces report it.
+func Update(now time.Time, bandwidths map[common.IFIDType]int, routerQueueFill int) {
+	mtx.Lock()
+	defer mtx.Unlock()
+	counters.Range(func(k, v interface{}) bool {
+		ifid := k.(common.IFIDType)
+		cur := sample{bytes: v.(*Counter).load(), time: now}
+		prev, ok := samples[ifid]
+		samples[ifid] = cur
+		if !ok || !cur.time.After(prev.time) {
+			return true
+		}
+		rate := uint64(float64(cur.bytes-prev.bytes) * 8 / cur.time.Sub(prev.time).Seconds())
+		l := &seg.InterfaceLoad{
+			IfID:            ifid,
+			Rate:            rate,
+			RouterQueueFill: clampPercent(routerQueueFill),
+		}
+		if bw := bandwidths[ifid]; bw > 0 {
+			l.Utilization = clampPercent(int(rate * 100 / (uint64(bw) * 1000)))
+		}
+		loads[ifid] = l
+		return true
+	})
+}
+
+// Loads returns the most recent load of all interfaces, sorted by interface ID.
+func Loads() []*seg.InterfaceLoad {
+	mtx.RLock()
+	defer mtx.RUnlock()
+	res := make([]*seg.InterfaceLoad, 0, len(loads))
+	for _, l := range loads {
+		c := *l
+		res = append(res, &c)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].IfID < res[j].IfID })
+	return res
+}
+
+// Handler serves the interface loads as JSON.
+func Handler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "    ")
+	if err := enc.Encode(Loads()); err != nil {
+		log.Error("Unable to encode interface loads", "err", err)
+	}
+}
+
+func clampPercent(v int) uint8 {
+	switch {
+	case v < 0:
+		return 0
+	case v > 100:
+		return 100
+	}
+	return uint8(v)
+}
+
+// reset clears all state. It is only used in tests.
+func reset() {
+	mtx.Lock()
+	defer mtx.Unlock()
+	counters = sync.Map{}
+	samples = make(map[common.IFIDType]sample)
+	loads = make(map[common.IFIDType]*seg.InterfaceLoad)
+}
@@ -0,0 +1,44 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ifload
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/ctrl/seg"
+)
+
+func TestUpdate(t *testing.T) {
+	reset()
+	now := time.Now()
+	bandwidths := map[common.IFIDType]int{1: 1000}
+	CounterFor(1).Add(100)
+	CounterFor(2).Add(100)
+	Update(now, bandwidths, 20)
+	assert.Empty(t, Loads(), "first update only takes a sample")
+
+	CounterFor(1).Add(62500)
+	CounterFor(2).Add(200000)
+	Update(now.Add(time.Second), bandwidths, 150)
+	expected := []*seg.InterfaceLoad{
+		{IfID: 1, Rate: 500000, Utilization: 50, RouterQueueFill: 100},
+		{IfID: 2, Rate: 1600000, Utilization: 0, RouterQueueFill: 100},
+	}
+	assert.Equal(t, expected, Loads())
+}
@@ -25,6 +25,7 @@ import (
 
 	"golang.org/x/net/ipv4"
 
+	"github.com/scionproto/scion/go/border/ifload"
 	"github.com/scionproto/scion/go/border/internal/metrics"
 	"github.com/scionproto/scion/go/border/rctx"
 	"github.com/scionproto/scion/go/border/rpkt"
@@ -215,6 +216,7 @@ func (r *Router) posixOutput(s *rctx.Sock, _, stopped chan struct{}) {
 	outputWrites := metrics.Output.Writes(l)
 	outputWriteErrs := metrics.Output.WriteErrors(l)
 	outputWriteLatency := metrics.Output.Duration(l)
+	outputLoad := ifload.CounterFor(s.Ifid)
 
 	// This loop is exited in two cases:
 	// 1. When the the ring is closed and fully drained.
@@ -268,6 +270,7 @@ func (r *Router) posixOutput(s *rctx.Sock, _, stopped chan struct{}) {
 		outputWriteLatency.Add(t)
 		outputPkts.Add(float64(pktsWritten))
 		outputBytes.Add(float64(bytes))
+		outputLoad.Add(bytes)
 		outputWrites.Inc()
 		epkts = shiftUnwrittenPkts(epkts, pktsWritten)
 	}
@@ -31,6 +31,9 @@ import (
 	"github.com/BurntSushi/toml"
 
 	"github.com/scionproto/scion/go/border/brconf"
+	"github.com/scionproto/scion/go/border/capture"
+	"github.com/scionproto/scion/go/border/flowacct"
+	"github.com/scionproto/scion/go/border/ifload"
 	"github.com/scionproto/scion/go/border/ifstate"
 	"github.com/scionproto/scion/go/lib/assert"
 	"github.com/scionproto/scion/go/lib/common"
@@ -73,6 +76,8 @@ func realMain() int {
 	http.HandleFunc("/info", env.InfoHandler)
 	http.HandleFunc("/status", statusHandler)
 	http.HandleFunc("/topology", itopo.TopologyHandler)
+	http.HandleFunc("/load", ifload.Handler)
+	http.HandleFunc("/capture", capture.Handler)
 	if err := setup(); err != nil {
 		log.Crit("Setup failed", "err", err)
 		return 1
@@ -81,6 +86,11 @@ func realMain() int {
 		log.Crit("Permissions checks failed", "err", err)
 		return 1
 	}
+	if err := flowacct.Init(&cfg.FlowAcct); err != nil {
+		log.Crit("Flow accounting setup failed", "err", err)
+		return 1
+	}
+	defer flowacct.Close()
 	var err error
 	if r, err = NewRouter(cfg.General.ID, cfg.General.ConfigDir); err != nil {
 		log.Crit("Startup failed", "err", err)
@@ -74,6 +74,10 @@ type ExternalConfig struct {
 	SchedulerConfig SchedulerConfig       `yaml:"Scheduler"`
 	ExternalQueues  []ExternalPacketQueue `yaml:"Queues"`
 	ExternalRules   []ExternalClassRule   `yaml:"Rules"`
+	// LogQueuedPackets enables a debug log entry for every queued packet, as
+	// needed by tools/qoslog to attribute packets to queues. It is off by
+	// default, as it parses the addresses of every forwarded packet.
+	LogQueuedPackets bool `yaml:"LogQueuedPackets"`
 }
 
 // LoadConfig reads the configuration file from path and returns the external configuration based
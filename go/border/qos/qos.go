@@ -22,6 +22,7 @@ import (
 	"strings"
 	"sync"
 
+	"github.com/scionproto/scion/go/border/capture"
 	"github.com/scionproto/scion/go/border/qos/conf"
 	"github.com/scionproto/scion/go/border/qos/queues"
 	"github.com/scionproto/scion/go/border/qos/scheduler"
@@ -46,6 +47,9 @@ type Configuration struct {
 	worker             workerConfiguration
 	workerChannels     [](chan *queues.QPkt)
 	Forwarder          func(rp *rpkt.RtrPkt)
+	// logQueuedPackets enables a debug entry for every queued packet, as used
+	// by tools/qoslog.
+	logQueuedPackets bool
 
 	droppedPackets int
 }
@@ -126,6 +130,7 @@ func ConvExternalToInternalConfig(qConfig *Configuration, extConf conf.ExternalC
 	var err error
 	qConfig.config, err = convertExternalToInteral(extConf)
 	qConfig.legacyConfig = extConf
+	qConfig.logQueuedPackets = extConf.LogQueuedPackets
 	return err
 }
 
@@ -202,11 +207,15 @@ func putOnQueue(qosConfig *Configuration, queueNo int, qp *queues.QPkt) {
 	act := queues.MergeAction(polAct, profAct)
 
 	qp.Act.SetAction(act)
+	if qosConfig.logQueuedPackets {
+		logQueuedPacket(qp, act)
+	}
+	capture.Capture(qp.Rp, queueNo, act)
 	switch act {
 	case conf.PASS:
-		qosConfig.config.Queues[queueNo].Enqueue(qp)
+		qosConfig.enqueue(queueNo, qp)
 	case conf.NOTIFY:
-		qosConfig.config.Queues[queueNo].Enqueue(qp)
+		qosConfig.enqueue(queueNo, qp)
 		qosConfig.SendNotification(qp)
 	case conf.DROPNOTIFY:
 		qosConfig.dropPacket(qp)
@@ -214,12 +223,33 @@ func putOnQueue(qosConfig *Configuration, queueNo int, qp *queues.QPkt) {
 	case conf.DROP:
 		qosConfig.dropPacket(qp)
 	default:
-		qosConfig.config.Queues[queueNo].Enqueue(qp)
+		qosConfig.enqueue(queueNo, qp)
 	}
 
 	*qosConfig.schedul.GetMessages() <- true
 }
 
+// enqueue puts the packet on the queue. Packets that carry the in-band telemetry
+// extension record the occupancy of the queue they wait in. The packet is
+// accounted to its flow with the queue it is put on.
+func (qosConfig *Configuration) enqueue(queueNo int, qp *queues.QPkt) {
+	q := qosConfig.config.Queues[queueNo]
+	qp.Rp.RecordTelemetry(queueNo, q.GetLength(), q.GetFillLevel())
+	qp.Rp.AccountFlow(queueNo)
+	q.Enqueue(qp)
+}
+
+// logQueuedPacket logs the queue and the action taken for a packet, such that
+// experiment logs can be correlated by packet ID (see tools/qoslog).
+func logQueuedPacket(qp *queues.QPkt, act conf.PoliceAction) {
+	srcIA, _ := qp.Rp.SrcIA()
+	srcHost, _ := qp.Rp.SrcHost()
+	dstIA, _ := qp.Rp.DstIA()
+	dstHost, _ := qp.Rp.DstHost()
+	log.Debug("Packet queued", "id", qp.Rp.Id, "queue", qp.QueueNo, "action", act,
+		"SrcIA", srcIA, "SrcHost", srcHost, "DstIA", dstIA, "DstHost", dstHost)
+}
+
 // SendNotification is needed for the part of @stygerma
 func (qosConfig *Configuration) SendNotification(qp *queues.QPkt) { //COMP:
 	// qp.Rp.RefInc(1) //should avoid the packet being dropped before we can create the scmp notification
@@ -404,7 +434,8 @@ func (qosConfig *Configuration) dropPacket(qp *queues.QPkt) {
 		defer qp.Rp.Release()
 	} //COMP
 	qosConfig.droppedPackets++
-	log.Info("Dropping packet", "qosConfig.droppedPackets", qosConfig.droppedPackets)
+	log.Info("Dropping packet", "qosConfig.droppedPackets", qosConfig.droppedPackets,
+		"id", qp.Rp.Id, "queue", qp.QueueNo)
 	var queLen = make([]int, len(*qosConfig.GetQueues()))
 	for i := 0; i < len(*qosConfig.GetQueues()); i++ {
 		queLen[i] = (*qosConfig.GetQueue(i)).GetLength()
@@ -21,8 +21,11 @@ import (
 	"sync"
 
 	"github.com/scionproto/scion/go/border/brconf"
+	"github.com/scionproto/scion/go/border/capture"
+	"github.com/scionproto/scion/go/border/flowacct"
 	"github.com/scionproto/scion/go/border/internal/metrics"
 	"github.com/scionproto/scion/go/border/qos"
+	"github.com/scionproto/scion/go/border/qos/conf"
 	"github.com/scionproto/scion/go/border/rcmn"
 	"github.com/scionproto/scion/go/border/rctrl"
 	"github.com/scionproto/scion/go/border/rctx"
@@ -87,6 +90,10 @@ func (r *Router) Start() {
 		defer log.HandlePanic()
 		r.stochNotify()
 	}()
+	go func() {
+		defer log.HandlePanic()
+		r.ifLoadUpdate()
+	}()
 }
 
 // ReloadConfig handles reloading the configuration when SIGHUP is received.
@@ -202,6 +209,9 @@ func (r *Router) processPacket(rp *rpkt.RtrPkt) {
 		return
 	}
 	if r.Id == "br1-ff00_0_113-1" || r.Id == "br1-ff00_0_0_113-2" { //|| r.Id == "br1-ff00_0_112-1" || r.Id == "br1-ff00_0_0_112-2" {
+		rp.AccountFlow(flowacct.NoQueue)
+		rp.RecordTelemetry(flowacct.NoQueue, 0, 0)
+		capture.Capture(rp, flowacct.NoQueue, conf.PASS)
 		r.forwardPacket(rp)
 	} else {
 		r.qosConfig.QueuePacket(rp)
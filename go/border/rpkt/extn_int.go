@@ -0,0 +1,148 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// This file contains the router's implementation of the in-band network
+// telemetry hop-by-hop extension.
+
+package rpkt
+
+import (
+	"time"
+
+	"github.com/scionproto/scion/go/border/flowacct"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/layers"
+	"github.com/scionproto/scion/go/lib/log"
+)
+
+var _ rExtension = (*rINT)(nil)
+
+// rINT is the router's representation of the INT extension. Records are
+// written directly into the raw packet.
+type rINT struct {
+	rp  *RtrPkt
+	raw common.RawBytes
+	log.Logger
+}
+
+func rINTFromRaw(rp *RtrPkt, start, end int) (*rINT, error) {
+	i := &rINT{rp: rp, raw: rp.Raw[start:end]}
+	// Parse once to validate the length and the record count.
+	if _, err := layers.ExtnINTFromRaw(i.raw); err != nil {
+		return nil, err
+	}
+	i.Logger = rp.Logger.New("ext", "int")
+	return i, nil
+}
+
+func (i *rINT) RegisterHooks(h *hooks) error {
+	return nil
+}
+
+// append writes the record into the next free slot. It returns false if the
+// extension is full.
+func (i *rINT) append(r *layers.INTRecord) bool {
+	n := int(i.raw[0])
+	off := layers.INTRecordsOff + n*layers.INTRecordLen
+	if off+layers.INTRecordLen > len(i.raw) {
+		return false
+	}
+	r.Write(i.raw[off:])
+	i.raw[0] = uint8(n + 1)
+	return true
+}
+
+func (i *rINT) Class() common.L4ProtocolType {
+	return common.HopByHopClass
+}
+
+func (i *rINT) Type() common.ExtnType {
+	return common.ExtnINTType
+}
+
+func (i *rINT) Len() int {
+	return len(i.raw)
+}
+
+func (i *rINT) String() string {
+	e, err := i.GetExtn()
+	if err != nil {
+		return "INT: " + err.Error()
+	}
+	return e.String()
+}
+
+func (i *rINT) GetExtn() (common.Extension, error) {
+	return layers.ExtnINTFromRaw(i.raw)
+}
+
+// RecordTelemetry appends a telemetry record with the given queue occupancy to
+// the INT extension of the packet. queueNo is the QoS queue the packet is put
+// on, or flowacct.NoQueue. It is a no-op for packets without the extension, or
+// if the extension is already full.
+func (rp *RtrPkt) RecordTelemetry(queueNo, queueLength, queueFill int) {
+	for _, e := range rp.HBHExt {
+		i, ok := e.(*rINT)
+		if !ok {
+			continue
+		}
+		r := &layers.INTRecord{
+			IA:          rp.Ctx.Conf.IA,
+			Timestamp:   time.Now(),
+			Ingress:     rp.Ingress.IfID,
+			QueueNo:     intQueueNo(queueNo),
+			QueueFill:   clampUint8(queueFill),
+			QueueLength: clampUint16(queueLength),
+		}
+		if ifNext, err := rp.IFNext(); err == nil && ifNext != nil {
+			r.Egress = *ifNext
+		}
+		if !i.append(r) {
+			i.Debug("INT extension full, record dropped")
+		}
+		return
+	}
+}
+
+// intQueueNo returns the queue number recorded for queueNo. Queue numbers that
+// do not fit are clamped below layers.INTNoQueue.
+func intQueueNo(queueNo int) uint8 {
+	if queueNo == flowacct.NoQueue {
+		return layers.INTNoQueue
+	}
+	if queueNo >= layers.INTNoQueue {
+		return layers.INTNoQueue - 1
+	}
+	return clampUint8(queueNo)
+}
+
+func clampUint8(v int) uint8 {
+	if v > 0xff {
+		return 0xff
+	}
+	if v < 0 {
+		return 0
+	}
+	return uint8(v)
+}
+
+func clampUint16(v int) uint16 {
+	if v > 0xffff {
+		return 0xffff
+	}
+	if v < 0 {
+		return 0
+	}
+	return uint16(v)
+}
@@ -0,0 +1,83 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rpkt
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/border/brconf"
+	"github.com/scionproto/scion/go/border/flowacct"
+	"github.com/scionproto/scion/go/border/rctx"
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/layers"
+	"github.com/scionproto/scion/go/lib/log"
+)
+
+func TestRecordTelemetry(t *testing.T) {
+	e, err := layers.NewExtnINT(2)
+	require.NoError(t, err)
+	raw, err := e.Pack()
+	require.NoError(t, err)
+
+	rp := NewRtrPkt()
+	rp.Raw = raw
+	rp.Logger = log.Root()
+	rp.Ctx = rctx.New(&brconf.BRConf{IA: addr.IA{I: 1, A: 0xff0000000110}})
+	rp.Ingress = addrIFPair{IfID: 5}
+	egress := common.IFIDType(7)
+	rp.ifNext = &egress
+	i, err := rINTFromRaw(rp, 0, len(raw))
+	require.NoError(t, err)
+	rp.HBHExt = []rExtension{i}
+
+	rp.RecordTelemetry(1, 42, 10)
+	rp.RecordTelemetry(300, 70000, -1)
+	// The extension is full, the third record is dropped.
+	rp.RecordTelemetry(0, 0, 0)
+
+	extn, err := i.GetExtn()
+	require.NoError(t, err)
+	records := extn.(*layers.ExtnINT).Records
+	require.Len(t, records, 2)
+	assert.Equal(t, rp.Ctx.Conf.IA, records[0].IA)
+	assert.Equal(t, common.IFIDType(5), records[0].Ingress)
+	assert.Equal(t, common.IFIDType(7), records[0].Egress)
+	assert.Equal(t, uint8(1), records[0].QueueNo)
+	assert.Equal(t, uint16(42), records[0].QueueLength)
+	assert.Equal(t, uint8(10), records[0].QueueFill)
+	assert.Equal(t, uint8(layers.INTNoQueue-1), records[1].QueueNo)
+	assert.Equal(t, uint16(0xffff), records[1].QueueLength)
+	assert.Equal(t, uint8(0), records[1].QueueFill)
+	assert.False(t, records[1].Timestamp.Before(records[0].Timestamp))
+
+	// Packets that bypass the queues are recorded with INTNoQueue.
+	bypass, err := layers.NewExtnINT(1)
+	require.NoError(t, err)
+	raw, err = bypass.Pack()
+	require.NoError(t, err)
+	rp.Raw = raw
+	bi, err := rINTFromRaw(rp, 0, len(raw))
+	require.NoError(t, err)
+	rp.HBHExt = []rExtension{bi}
+	rp.RecordTelemetry(flowacct.NoQueue, 0, 0)
+	bextn, err := bi.GetExtn()
+	require.NoError(t, err)
+	require.Len(t, bextn.(*layers.ExtnINT).Records, 1)
+	assert.Equal(t, uint8(layers.INTNoQueue), bextn.(*layers.ExtnINT).Records[0].QueueNo)
+}
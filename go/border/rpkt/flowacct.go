@@ -0,0 +1,62 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rpkt
+
+import (
+	"time"
+
+	"github.com/scionproto/scion/go/border/flowacct"
+	"github.com/scionproto/scion/go/lib/l4"
+	"github.com/scionproto/scion/go/lib/scmp"
+)
+
+// AccountFlow accounts the packet to its flow, if the flow accounting is
+// enabled. queueNo is the QoS queue the packet is put on, or flowacct.NoQueue.
+// It must be called before the packet is routed, as routing moves the path to
+// the next hop field.
+func (rp *RtrPkt) AccountFlow(queueNo int) {
+	t := flowacct.Get()
+	if t == nil || !t.Sample() {
+		return
+	}
+	t.Account(rp.FlowKey(queueNo), len(rp.Raw), time.Now())
+}
+
+// FlowKey returns the flow the packet belongs to. Fields that cannot be
+// determined are left empty.
+func (rp *RtrPkt) FlowKey(queueNo int) flowacct.Key {
+	k := flowacct.Key{Ingress: rp.Ingress.IfID, Queue: queueNo}
+	k.SrcIA, _ = rp.SrcIA()
+	k.DstIA, _ = rp.DstIA()
+	srcHost, _ := rp.SrcHost()
+	k.SrcHost = flowacct.HostFromAddr(srcHost)
+	dstHost, _ := rp.DstHost()
+	k.DstHost = flowacct.HostFromAddr(dstHost)
+	if ifNext, err := rp.IFNext(); err == nil && ifNext != nil {
+		k.Egress = *ifNext
+	}
+	l4h, err := rp.L4Hdr(false)
+	if err != nil || l4h == nil {
+		return k
+	}
+	k.L4 = rp.L4Type
+	switch h := l4h.(type) {
+	case *l4.UDP:
+		k.SrcPort, k.DstPort = h.SrcPort, h.DstPort
+	case *scmp.Hdr:
+		k.DstPort = uint16(h.Class)<<8 | uint16(h.Type)
+	}
+	return k
+}
@@ -0,0 +1,47 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rpkt
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/border/flowacct"
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+)
+
+func TestFlowKey(t *testing.T) {
+	r := prepareRtrPacketSample(t)
+	require.NoError(t, r.parseBasic())
+	require.NoError(t, r.parseHopExtns())
+
+	k := r.FlowKey(2)
+	assert.Equal(t, addr.IA{I: 1, A: 10}, k.SrcIA)
+	assert.Equal(t, addr.IA{I: 2, A: 25}, k.DstIA)
+	assert.True(t, k.SrcHost.Addr().IP().Equal(net.IPv4(127, 1, 1, 111)))
+	assert.True(t, k.DstHost.Addr().IP().Equal(net.IPv4(127, 2, 2, 222)))
+	assert.Equal(t, common.L4UDP, k.L4)
+	assert.Equal(t, uint16(44887), k.SrcPort)
+	assert.Equal(t, uint16(3000), k.DstPort)
+	assert.Equal(t, common.IFIDType(5), k.Ingress)
+	assert.Equal(t, 2, k.Queue)
+	// The key is comparable, a second call yields the same flow.
+	assert.Equal(t, k, r.FlowKey(2))
+	assert.NotEqual(t, k, r.FlowKey(flowacct.NoQueue))
+}
@@ -25,6 +25,7 @@ import (
 	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/assert"
 	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/l4"
 	"github.com/scionproto/scion/go/lib/ringbuf"
 	"github.com/scionproto/scion/go/lib/scmp"
 	"github.com/scionproto/scion/go/lib/serrors"
@@ -122,7 +123,7 @@ func (rp *RtrPkt) forwardFromExternal() (HookResult, error) {
 		}
 		dst := &net.UDPAddr{
 			IP:   rp.dstHost.IP(),
-			Port: int(topology.EndhostPort),
+			Port: rp.endhostPort(),
 		}
 		rp.Egress = append(rp.Egress, EgressPair{S: rp.Ctx.LocSockOut, Dst: dst})
 		return HookContinue, nil
@@ -147,6 +148,23 @@ func (rp *RtrPkt) forwardFromExternal() (HookResult, error) {
 	return HookContinue, nil
 }
 
+// endhostPort returns the overlay port of the local destination host. UDP
+// packets to a port in the direct port range are delivered to the application
+// itself, all others to the dispatcher.
+func (rp *RtrPkt) endhostPort() int {
+	if rp.Ctx.Conf.DirectPorts.Empty() {
+		return topology.EndhostPort
+	}
+	l4h, err := rp.L4Hdr(false)
+	if err != nil || l4h == nil {
+		return topology.EndhostPort
+	}
+	if udp, ok := l4h.(*l4.UDP); ok && rp.Ctx.Conf.DirectPorts.Contains(int(udp.DstPort)) {
+		return int(udp.DstPort)
+	}
+	return topology.EndhostPort
+}
+
 // xoverFromExternal handles XOVER hop fields at the ingress router, including
 // a lot of sanity/security checking.
 func (rp *RtrPkt) xoverFromExternal() error {
@@ -0,0 +1,45 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rpkt
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/topology"
+	"github.com/scionproto/scion/go/lib/topology/overlay"
+)
+
+func TestEndhostPort(t *testing.T) {
+	tests := map[string]struct {
+		Ports    overlay.PortRange
+		Expected int
+	}{
+		"no direct ports":   {Expected: topology.EndhostPort},
+		"port in range":     {Ports: overlay.PortRange{Min: 2000, Max: 4000}, Expected: 3000},
+		"port not in range": {Ports: overlay.DefaultDirectPorts, Expected: topology.EndhostPort},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			r := prepareRtrPacketSample(t)
+			r.Ctx.Conf.DirectPorts = test.Ports
+			require.NoError(t, r.parseBasic())
+			require.NoError(t, r.parseHopExtns())
+			assert.Equal(t, test.Expected, r.endhostPort())
+		})
+	}
+}
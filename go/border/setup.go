@@ -123,6 +123,7 @@ func (r *Router) loadNewConfig() (*brconf.BRConf, error) {
 	if config, err = brconf.Load(r.Id, r.confDir); err != nil {
 		return nil, common.NewBasicError("Failed to load topology config", err, "dir", r.confDir)
 	}
+	config.DirectPorts = cfg.BR.DirectPorts
 	log.Debug("Topology and AS config loaded", "IA", config.IA, "IfIDs", config.BR,
 		"dir", r.confDir)
 	return config, nil
@@ -0,0 +1,550 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package beacondbmem implements an in-memory backend for the beacon DB.
+//
+// The backend does not keep any state on disk. It is intended for tests and
+// small deployments. A transaction operates on a copy of the database state,
+// which replaces the state of the database on commit. Transactions and writes
+// outside of transactions are serialized, reads are never blocked by a
+// transaction and only observe committed state.
+package beacondbmem
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/scionproto/scion/go/cs/beacon"
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/ctrl/path_mgmt"
+	"github.com/scionproto/scion/go/lib/ctrl/seg"
+	"github.com/scionproto/scion/go/lib/infra/modules/db"
+	"github.com/scionproto/scion/go/lib/serrors"
+)
+
+var (
+	// ErrClosed is returned when operating on a closed database.
+	ErrClosed = serrors.New("database closed")
+	// ErrTxDone is returned when operating on a committed or rolled back
+	// transaction.
+	ErrTxDone = serrors.New("transaction already committed or rolled back")
+)
+
+var _ beacon.DB = (*Backend)(nil)
+
+// Backend is an in-memory beacon DB.
+type Backend struct {
+	*executor
+}
+
+// New returns a new empty in-memory backend.
+func New(ia addr.IA) *Backend {
+	return &Backend{
+		executor: &executor{
+			state:  newState(),
+			ia:     ia,
+			writer: make(chan struct{}, 1),
+			err:    ErrClosed,
+		},
+	}
+}
+
+// SetMaxOpenConns is a no-op, the in-memory backend has no connections.
+func (b *Backend) SetMaxOpenConns(_ int) {}
+
+// SetMaxIdleConns is a no-op, the in-memory backend has no connections.
+func (b *Backend) SetMaxIdleConns(_ int) {}
+
+// BeginTransaction begins a transaction on the database. It blocks until all
+// other transactions are committed or rolled back.
+func (b *Backend) BeginTransaction(ctx context.Context,
+	_ *sql.TxOptions) (beacon.Transaction, error) {
+
+	if err := acquire(ctx, b.writer); err != nil {
+		return nil, db.NewTxError("create tx", err)
+	}
+	b.RLock()
+	defer b.RUnlock()
+	if b.state == nil {
+		release(b.writer)
+		return nil, db.NewTxError("create tx", ErrClosed)
+	}
+	return &transaction{
+		executor: &executor{
+			state: b.state.clone(),
+			ia:    b.ia,
+			err:   ErrTxDone,
+		},
+		backend: b.executor,
+	}, nil
+}
+
+// Close closes the database and drops all state.
+func (b *Backend) Close() error {
+	b.Lock()
+	defer b.Unlock()
+	b.state = nil
+	return nil
+}
+
+var _ (beacon.Transaction) = (*transaction)(nil)
+
+type transaction struct {
+	*executor
+	backend *executor
+}
+
+func (tx *transaction) Commit() error {
+	tx.Lock()
+	defer tx.Unlock()
+	if tx.state == nil {
+		return ErrTxDone
+	}
+	tx.backend.Lock()
+	defer tx.backend.Unlock()
+	if tx.backend.state == nil {
+		return ErrClosed
+	}
+	tx.backend.state = tx.state
+	tx.state = nil
+	release(tx.backend.writer)
+	return nil
+}
+
+func (tx *transaction) Rollback() error {
+	tx.Lock()
+	defer tx.Unlock()
+	if tx.state == nil {
+		return ErrTxDone
+	}
+	tx.state = nil
+	release(tx.backend.writer)
+	return nil
+}
+
+// acquire acquires the write token.
+func acquire(ctx context.Context, writer chan struct{}) error {
+	select {
+	case writer <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// release releases the write token.
+func release(writer chan struct{}) {
+	<-writer
+}
+
+var _ (beacon.DBReadWrite) = (*executor)(nil)
+
+type executor struct {
+	sync.RWMutex
+	state *state
+	ia    addr.IA
+	// writer holds the write token while a write or a transaction is in
+	// progress. It is nil for transactions, which hold the token of the
+	// backend during their whole lifetime.
+	writer chan struct{}
+	// err is returned if the state has been dropped.
+	err error
+}
+
+func (e *executor) read(ctx context.Context, f func(s *state) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	e.RLock()
+	defer e.RUnlock()
+	if e.state == nil {
+		return e.err
+	}
+	return f(e.state)
+}
+
+func (e *executor) write(ctx context.Context, f func(s *state) error) error {
+	if e.writer != nil {
+		if err := acquire(ctx, e.writer); err != nil {
+			return err
+		}
+		defer release(e.writer)
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	e.Lock()
+	defer e.Unlock()
+	if e.state == nil {
+		return e.err
+	}
+	return f(e.state)
+}
+
+func (e *executor) AllBeacons(ctx context.Context) ([]beacon.StoredBeacon, error) {
+	var entries []*beaconEntry
+	err := e.read(ctx, func(s *state) error {
+		entries = make([]*beaconEntry, 0, len(s.beacons))
+		for _, entry := range s.beacons {
+			entries = append(entries, entry)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		switch {
+		case a.start.I != b.start.I:
+			return a.start.I < b.start.I
+		case a.start.A != b.start.A:
+			return a.start.A < b.start.A
+		case a.hops != b.hops:
+			return a.hops < b.hops
+		}
+		return a.seq < b.seq
+	})
+	beacons := make([]beacon.StoredBeacon, 0, len(entries))
+	for _, entry := range entries {
+		b, err := entry.beacon()
+		if err != nil {
+			return nil, err
+		}
+		beacons = append(beacons, beacon.StoredBeacon{
+			Beacon:      b,
+			Usage:       entry.usage,
+			LastUpdated: entry.lastUpdated,
+		})
+	}
+	return beacons, nil
+}
+
+func (e *executor) AllRevocations(ctx context.Context) (<-chan beacon.RevocationOrErr, error) {
+	var raw []common.RawBytes
+	err := e.read(ctx, func(s *state) error {
+		for _, rev := range s.revocations {
+			raw = append(raw, rev.packed)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	// Since we have everything in memory anyway we just fill the channel at the start.
+	res := make(chan beacon.RevocationOrErr, len(raw))
+	for _, r := range raw {
+		srev, err := path_mgmt.NewSignedRevInfoFromRaw(r)
+		if err != nil {
+			err = db.NewDataError(beacon.ErrParse, err)
+		}
+		res <- beacon.RevocationOrErr{Rev: srev, Err: err}
+	}
+	close(res)
+	return res, nil
+}
+
+func (e *executor) BeaconSources(ctx context.Context) ([]addr.IA, error) {
+	var ias []addr.IA
+	err := e.read(ctx, func(s *state) error {
+		seen := make(map[addr.IA]struct{})
+		for _, entry := range s.beacons {
+			if _, ok := seen[entry.start]; ok {
+				continue
+			}
+			seen[entry.start] = struct{}{}
+			ias = append(ias, entry.start)
+		}
+		return nil
+	})
+	return ias, err
+}
+
+func (e *executor) CandidateBeacons(ctx context.Context, setSize int, usage beacon.Usage,
+	src addr.IA) (<-chan beacon.BeaconOrErr, error) {
+
+	now := time.Now().Unix()
+	var entries []*beaconEntry
+	err := e.read(ctx, func(s *state) error {
+		for _, entry := range s.beacons {
+			if entry.usage&usage != usage {
+				continue
+			}
+			if !src.IsZero() && !src.Equal(entry.start) {
+				continue
+			}
+			if s.revoked(entry, now) {
+				continue
+			}
+			entries = append(entries, entry)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].hops != entries[j].hops {
+			return entries[i].hops < entries[j].hops
+		}
+		return entries[i].seq < entries[j].seq
+	})
+	if len(entries) > setSize {
+		entries = entries[:setSize]
+	}
+	// Since we have everything in memory anyway we just fill the channel at the start.
+	results := make(chan beacon.BeaconOrErr, len(entries))
+	for _, entry := range entries {
+		b, err := entry.beacon()
+		if err != nil {
+			results <- beacon.BeaconOrErr{Err: err}
+			break
+		}
+		results <- beacon.BeaconOrErr{Beacon: b}
+	}
+	close(results)
+	return results, nil
+}
+
+// InsertBeacon inserts the beacon if it is new or updates the changed
+// information.
+func (e *executor) InsertBeacon(ctx context.Context, b beacon.Beacon,
+	usage beacon.Usage) (beacon.InsertStats, error) {
+
+	ret := beacon.InsertStats{}
+	entry, err := newBeaconEntry(b, usage, e.ia)
+	if err != nil {
+		return ret, err
+	}
+	err = e.write(ctx, func(s *state) error {
+		existing, ok := s.beacons[entry.id]
+		if !ok {
+			s.seq++
+			entry.seq = s.seq
+			s.beacons[entry.id] = entry
+			ret.Inserted = 1
+			return nil
+		}
+		// Update the beacon data if it is newer.
+		if entry.infoTime.After(existing.infoTime) {
+			entry.seq = existing.seq
+			s.beacons[entry.id] = entry
+			ret.Updated = 1
+		}
+		return nil
+	})
+	return ret, err
+}
+
+func (e *executor) DeleteExpiredBeacons(ctx context.Context, now time.Time) (int, error) {
+	var deleted int
+	err := e.write(ctx, func(s *state) error {
+		for id, entry := range s.beacons {
+			if entry.expiration < now.Unix() {
+				delete(s.beacons, id)
+				deleted++
+			}
+		}
+		return nil
+	})
+	return deleted, err
+}
+
+func (e *executor) DeleteRevokedBeacons(ctx context.Context, now time.Time) (int, error) {
+	var deleted int
+	err := e.write(ctx, func(s *state) error {
+		for id, entry := range s.beacons {
+			if s.revoked(entry, now.Unix()) {
+				delete(s.beacons, id)
+				deleted++
+			}
+		}
+		return nil
+	})
+	return deleted, err
+}
+
+func (e *executor) InsertRevocation(ctx context.Context,
+	revocation *path_mgmt.SignedRevInfo) error {
+
+	revInfo, err := revocation.RevInfo()
+	if err != nil {
+		return db.NewInputDataError("extract revocation", err)
+	}
+	packedRev, err := revocation.Pack()
+	if err != nil {
+		return db.NewInputDataError("pack revocation", err)
+	}
+	key := intf{ia: revInfo.IA(), ifid: revInfo.IfID}
+	return e.write(ctx, func(s *state) error {
+		if existing, ok := s.revocations[key]; ok && existing.issuing > revInfo.RawTimestamp {
+			return nil
+		}
+		s.revocations[key] = &revEntry{
+			issuing:    revInfo.RawTimestamp,
+			expiration: revInfo.Expiration().Unix(),
+			packed:     packedRev,
+		}
+		return nil
+	})
+}
+
+func (e *executor) DeleteRevocation(ctx context.Context, ia addr.IA, ifid common.IFIDType) error {
+	return e.write(ctx, func(s *state) error {
+		delete(s.revocations, intf{ia: ia, ifid: ifid})
+		return nil
+	})
+}
+
+func (e *executor) DeleteExpiredRevocations(ctx context.Context, now time.Time) (int, error) {
+	var deleted int
+	err := e.write(ctx, func(s *state) error {
+		for key, rev := range s.revocations {
+			if rev.expiration < now.Unix() {
+				delete(s.revocations, key)
+				deleted++
+			}
+		}
+		return nil
+	})
+	return deleted, err
+}
+
+// state is the content of the database.
+type state struct {
+	beacons     map[string]*beaconEntry
+	revocations map[intf]*revEntry
+	// seq is the sequence number of the last inserted beacon.
+	seq uint64
+}
+
+func newState() *state {
+	return &state{
+		beacons:     make(map[string]*beaconEntry),
+		revocations: make(map[intf]*revEntry),
+	}
+}
+
+// clone returns a copy of the state. The entries are never modified, hence
+// they are shared between the copies.
+func (s *state) clone() *state {
+	c := &state{
+		beacons:     make(map[string]*beaconEntry, len(s.beacons)),
+		revocations: make(map[intf]*revEntry, len(s.revocations)),
+		seq:         s.seq,
+	}
+	for id, entry := range s.beacons {
+		c.beacons[id] = entry
+	}
+	for key, rev := range s.revocations {
+		c.revocations[key] = rev
+	}
+	return c
+}
+
+// revoked indicates whether any interface of the beacon is revoked by a
+// revocation that does not expire before now, in seconds since Unix epoch.
+func (s *state) revoked(entry *beaconEntry, now int64) bool {
+	for _, key := range entry.intfs {
+		if rev, ok := s.revocations[key]; ok && rev.expiration >= now {
+			return true
+		}
+	}
+	return false
+}
+
+type intf struct {
+	ia   addr.IA
+	ifid common.IFIDType
+}
+
+type beaconEntry struct {
+	id          string
+	seq         uint64
+	packed      common.RawBytes
+	inIfId      common.IFIDType
+	start       addr.IA
+	hops        int
+	infoTime    time.Time
+	expiration  int64
+	lastUpdated time.Time
+	usage       beacon.Usage
+	// intfs are the interfaces the beacon traverses. Peering interfaces are
+	// not included.
+	intfs []intf
+}
+
+func newBeaconEntry(b beacon.Beacon, usage beacon.Usage, localIA addr.IA) (*beaconEntry, error) {
+	segID, err := b.Segment.ID()
+	if err != nil {
+		return nil, db.NewInputDataError("extract id", err)
+	}
+	if _, err := b.Segment.FullId(); err != nil {
+		return nil, db.NewInputDataError("extract full id", err)
+	}
+	info, err := b.Segment.InfoF()
+	if err != nil {
+		return nil, db.NewInputDataError("extract infof", err)
+	}
+	packed, err := b.Segment.Pack()
+	if err != nil {
+		return nil, db.NewInputDataError("pack segment", err)
+	}
+	entry := &beaconEntry{
+		id:          string(segID),
+		packed:      packed,
+		inIfId:      b.InIfId,
+		start:       b.Segment.FirstIA(),
+		hops:        len(b.Segment.ASEntries),
+		infoTime:    info.Timestamp(),
+		expiration:  b.Segment.MaxExpiry().Unix(),
+		lastUpdated: time.Now(),
+		usage:       usage,
+	}
+	for _, as := range b.Segment.ASEntries {
+		hof, err := as.HopEntries[0].HopField()
+		if err != nil {
+			return nil, db.NewInputDataError("extract hop field", err)
+		}
+		// Ignore the null interfaces of the first and last hop.
+		if hof.ConsIngress != 0 {
+			entry.intfs = append(entry.intfs, intf{ia: as.IA(), ifid: hof.ConsIngress})
+		}
+		if hof.ConsEgress != 0 {
+			entry.intfs = append(entry.intfs, intf{ia: as.IA(), ifid: hof.ConsEgress})
+		}
+	}
+	entry.intfs = append(entry.intfs, intf{ia: localIA, ifid: b.InIfId})
+	return entry, nil
+}
+
+// beacon returns a copy of the stored beacon.
+func (e *beaconEntry) beacon() (beacon.Beacon, error) {
+	s, err := seg.NewBeaconFromRaw(e.packed)
+	if err != nil {
+		return beacon.Beacon{}, db.NewDataError(beacon.ErrParse, err)
+	}
+	return beacon.Beacon{Segment: s, InIfId: e.inIfId}, nil
+}
+
+type revEntry struct {
+	issuing uint32
+	// expiration is the expiration time in seconds since Unix epoch.
+	expiration int64
+	packed     common.RawBytes
+}
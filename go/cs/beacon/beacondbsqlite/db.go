@@ -124,6 +124,39 @@ type beaconMeta struct {
 	LastUpdated time.Time
 }
 
+func (e *executor) AllBeacons(ctx context.Context) ([]beacon.StoredBeacon, error) {
+	e.RLock()
+	defer e.RUnlock()
+	query := `
+		SELECT Beacon, InIntfID, Usage, LastUpdated
+		FROM Beacons
+		ORDER BY StartIsd, StartAs, HopsLength ASC
+	`
+	rows, err := e.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, db.NewReadError("Error selecting beacons", err)
+	}
+	defer rows.Close()
+	var beacons []beacon.StoredBeacon
+	for rows.Next() {
+		var rawBeacon sql.RawBytes
+		var b beacon.StoredBeacon
+		var lastUpdated int64
+		if err := rows.Scan(&rawBeacon, &b.InIfId, &b.Usage, &lastUpdated); err != nil {
+			return nil, db.NewReadError(beacon.ErrReadingRows, err)
+		}
+		if b.Segment, err = seg.NewBeaconFromRaw(common.RawBytes(rawBeacon)); err != nil {
+			return nil, db.NewDataError(beacon.ErrParse, err)
+		}
+		b.LastUpdated = time.Unix(0, lastUpdated)
+		beacons = append(beacons, b)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, db.NewReadError(beacon.ErrReadingRows, err)
+	}
+	return beacons, nil
+}
+
 func (e *executor) AllRevocations(ctx context.Context) (<-chan beacon.RevocationOrErr, error) {
 	e.RLock()
 	defer e.RUnlock()
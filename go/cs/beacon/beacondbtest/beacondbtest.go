@@ -115,6 +115,8 @@ func Test(t *testing.T, db Testable) {
 	}
 	t.Run("BeaconSources should report all sources",
 		testWrapper(testBeaconSources))
+	t.Run("AllBeacons should report all beacons",
+		testWrapper(testAllBeacons))
 	t.Run("InsertBeacon should correctly insert a new beacon",
 		testWrapper(testInsertBeacon))
 	t.Run("InsertBeacon should correctly update a new beacon",
@@ -154,6 +156,8 @@ func Test(t *testing.T, db Testable) {
 	t.Run("WithTransaction", func(t *testing.T) {
 		t.Run("BeaconSources should report all sources",
 			txTestWrapper(testBeaconSources))
+		t.Run("AllBeacons should report all beacons",
+			txTestWrapper(testAllBeacons))
 		t.Run("InsertBeacon should correctly insert a new beacon",
 			txTestWrapper(testInsertBeacon))
 		t.Run("InsertBeacon should correctly update a new beacon",
@@ -196,6 +200,32 @@ func testBeaconSources(t *testing.T, ctrl *gomock.Controller, db beacon.DBReadWr
 	assert.ElementsMatch(t, []addr.IA{ia311, ia330}, ias)
 }
 
+func testAllBeacons(t *testing.T, ctrl *gomock.Controller, db beacon.DBReadWrite) {
+	usages := []beacon.Usage{beacon.UsageProp, beacon.UsageUpReg | beacon.UsageDownReg,
+		beacon.UsageCoreReg}
+	var inserted []beacon.Beacon
+	for i, info := range [][]IfInfo{Info3, Info2, Info1} {
+		inserted = append(inserted, InsertBeacon(t, ctrl, db, info, 12, uint32(i), usages[i]))
+	}
+	ctx, cancelF := context.WithTimeout(context.Background(), timeout)
+	defer cancelF()
+	beacons, err := db.AllBeacons(ctx)
+	require.NoError(t, err)
+	require.Len(t, beacons, 3)
+	// Ordered by source and length.
+	for i, j := range []int{2, 1, 0} {
+		// Make sure the segment is properly initialized.
+		_, err := beacons[i].Segment.ID()
+		require.NoError(t, err)
+		_, err = beacons[i].Segment.FullId()
+		require.NoError(t, err)
+		assert.Equal(t, inserted[j].Segment, beacons[i].Segment, "Segment %d should match", i)
+		assert.Equal(t, inserted[j].InIfId, beacons[i].InIfId, "InIfId %d should match", i)
+		assert.Equal(t, usages[j], beacons[i].Usage, "Usage %d should match", i)
+		assert.False(t, beacons[i].LastUpdated.IsZero(), "LastUpdated %d should be set", i)
+	}
+}
+
 func testInsertBeacon(t *testing.T, ctrl *gomock.Controller, db beacon.DBReadWrite) {
 	TS := uint32(10)
 	b, _ := AllocBeacon(t, ctrl, Info3, 12, TS)
@@ -36,8 +36,21 @@ const (
 	ErrParse common.ErrMsg = "Failed to parse entry"
 )
 
+// StoredBeacon is a beacon together with the metadata kept in the beacon DB.
+type StoredBeacon struct {
+	Beacon
+	// Usage is the allowed usage of the beacon according to the policies.
+	Usage Usage
+	// LastUpdated is the time the beacon was last inserted or updated.
+	LastUpdated time.Time
+}
+
 // DBRead defines all read operations of the beacon DB.
 type DBRead interface {
+	// AllBeacons returns all beacons in the database, including the revoked
+	// and expired ones that are not deleted yet. The beacons are ordered by
+	// source ISD-AS and segment length from shortest to longest.
+	AllBeacons(ctx context.Context) ([]StoredBeacon, error)
 	// CandidateBeacons returns up to setSize beacons that are allowed for the
 	// given usage. The result channel either carries beacons or errors. The
 	// beacons in the channel are ordered by segment length from shortest to
@@ -128,6 +141,11 @@ func (u Usage) None() bool {
 }
 
 func (u Usage) String() string {
+	return fmt.Sprintf("Usage: [%s]", strings.Join(u.Names(), ","))
+}
+
+// Names returns the names of the usage flags that are set.
+func (u Usage) Names() []string {
 	names := []string{}
 	if u&UsageUpReg != 0 {
 		names = append(names, "UpRegistration")
@@ -141,5 +159,5 @@ func (u Usage) String() string {
 	if u&UsageProp != 0 {
 		names = append(names, "Propagation")
 	}
-	return fmt.Sprintf("Usage: [%s]", strings.Join(names, ","))
+	return names
 }
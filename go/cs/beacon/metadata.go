@@ -0,0 +1,120 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package beacon
+
+import (
+	"time"
+
+	"github.com/scionproto/scion/go/lib/ctrl/seg"
+)
+
+// beaconMetadata is the static metadata of a beacon, aggregated from the
+// static info extensions of its AS entries.
+type beaconMetadata struct {
+	// Latency is the sum of the latencies of the inter-AS links and of the
+	// intra-AS latencies between the interfaces of the traversed ASes.
+	Latency time.Duration
+	// LatencyComplete indicates whether the latency of every inter-AS link is
+	// known. Unknown intra-AS latencies are assumed to be zero.
+	LatencyComplete bool
+	// Bandwidth is the bottleneck bandwidth of the inter-AS links in kbit/s.
+	// It is 0 if the bandwidth of any link is unknown.
+	Bandwidth uint64
+}
+
+// newBeaconMetadata aggregates the static metadata of the beacon, including
+// the link to the AS the beacon was received in. The information about an
+// inter-AS link is taken from the AS entry of the sender. If it is missing
+// there, it is taken from the AS entry of the receiver, if available.
+func newBeaconMetadata(b Beacon) beaconMetadata {
+	entries := b.Segment.ASEntries
+	hops := make([]*seg.InterfaceStaticInfo, 0, 2*len(entries))
+	for _, entry := range entries {
+		if len(entry.HopEntries) == 0 {
+			return beaconMetadata{}
+		}
+		hopF, err := entry.HopEntries[0].HopField()
+		if err != nil {
+			return beaconMetadata{}
+		}
+		hops = append(hops, entry.Exts.StaticInfo.Get(hopF.ConsIngress),
+			entry.Exts.StaticInfo.Get(hopF.ConsEgress))
+	}
+	meta := beaconMetadata{LatencyComplete: true}
+	for i := 0; i < len(entries); i++ {
+		in, eg := hops[2*i], hops[2*i+1]
+		if i > 0 {
+			meta.Latency += intraLatency(in) + intraLatency(eg)
+		}
+		// Ingress information of the next AS about the same link.
+		var remote *seg.InterfaceStaticInfo
+		if i < len(entries)-1 {
+			remote = hops[2*i+2]
+		}
+		if latency := linkValue(eg, remote, linkLatency); latency == 0 {
+			meta.LatencyComplete = false
+		} else {
+			meta.Latency += time.Duration(latency) * time.Microsecond
+		}
+		bw := linkValue(eg, remote, linkBandwidth)
+		if i == 0 || bw < meta.Bandwidth {
+			meta.Bandwidth = bw
+		}
+	}
+	return meta
+}
+
+// linkValue returns the value of the link as reported by the local interface,
+// or the remote interface if the local one does not report it. It returns 0
+// if neither reports the value.
+func linkValue(local, remote *seg.InterfaceStaticInfo,
+	value func(*seg.InterfaceStaticInfo) uint64) uint64 {
+
+	if local != nil {
+		if v := value(local); v != 0 {
+			return v
+		}
+	}
+	if remote != nil {
+		return value(remote)
+	}
+	return 0
+}
+
+// intraLatency returns the intra-AS latency of the interface, 0 if unknown.
+func intraLatency(i *seg.InterfaceStaticInfo) time.Duration {
+	if i == nil {
+		return 0
+	}
+	return i.IntraLatency()
+}
+
+func linkLatency(i *seg.InterfaceStaticInfo) uint64   { return uint64(i.Latency) }
+func linkBandwidth(i *seg.InterfaceStaticInfo) uint64 { return i.Bandwidth }
+
+// lessLatency orders beacons by increasing latency. Beacons with incomplete
+// latency information are ordered after all others.
+func lessLatency(a, b beaconMetadata) bool {
+	if a.LatencyComplete != b.LatencyComplete {
+		return a.LatencyComplete
+	}
+	return a.Latency < b.Latency
+}
+
+// lessBandwidth orders beacons by decreasing bottleneck bandwidth. Beacons
+// with unknown bandwidth are ordered after all others.
+func lessBandwidth(a, b beaconMetadata) bool {
+	return a.Bandwidth > b.Bandwidth
+}
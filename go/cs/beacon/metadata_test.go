@@ -0,0 +1,157 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package beacon
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/ctrl/seg"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/spath"
+)
+
+func TestBeaconMetadata(t *testing.T) {
+	tests := map[string]struct {
+		Beacon   Beacon
+		Expected beaconMetadata
+	}{
+		"no metadata": {
+			Beacon:   newMetadataBeacon(hopInfo{}, hopInfo{}),
+			Expected: beaconMetadata{},
+		},
+		"complete": {
+			Beacon: newMetadataBeacon(
+				hopInfo{Eg: &seg.InterfaceStaticInfo{Latency: 1000, Bandwidth: 100}},
+				hopInfo{
+					In: &seg.InterfaceStaticInfo{InternalLatency: 200},
+					Eg: &seg.InterfaceStaticInfo{InternalLatency: 300, Latency: 2000,
+						Bandwidth: 50},
+				},
+			),
+			Expected: beaconMetadata{
+				Latency:         3500 * time.Microsecond,
+				LatencyComplete: true,
+				Bandwidth:       50,
+			},
+		},
+		"link info from receiver": {
+			Beacon: newMetadataBeacon(
+				hopInfo{Eg: &seg.InterfaceStaticInfo{Bandwidth: 100}},
+				hopInfo{
+					In: &seg.InterfaceStaticInfo{Latency: 1000, Bandwidth: 10},
+					Eg: &seg.InterfaceStaticInfo{Latency: 2000, Bandwidth: 50},
+				},
+			),
+			Expected: beaconMetadata{
+				Latency:         3000 * time.Microsecond,
+				LatencyComplete: true,
+				Bandwidth:       50,
+			},
+		},
+		"missing link": {
+			Beacon: newMetadataBeacon(
+				hopInfo{Eg: &seg.InterfaceStaticInfo{Latency: 1000, Bandwidth: 100}},
+				hopInfo{},
+			),
+			Expected: beaconMetadata{Latency: time.Millisecond},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.Expected, newBeaconMetadata(test.Beacon))
+		})
+	}
+}
+
+func TestMetricAlgo(t *testing.T) {
+	slow := newMetadataBeacon(hopInfo{Eg: &seg.InterfaceStaticInfo{Latency: 5000, Bandwidth: 100}})
+	fast := newMetadataBeacon(hopInfo{Eg: &seg.InterfaceStaticInfo{Latency: 1000, Bandwidth: 10}})
+	unknown := newMetadataBeacon(hopInfo{})
+	testErr := serrors.New("test error")
+
+	tests := map[string]struct {
+		Algo       selectionAlgorithm
+		Beacons    []BeaconOrErr
+		ResultSize int
+		Expected   []BeaconOrErr
+	}{
+		"latency": {
+			Algo: newSelectionAlgorithm(LatencyAlgorithm),
+			Beacons: []BeaconOrErr{{Beacon: unknown}, {Beacon: slow}, {Err: testErr},
+				{Beacon: fast}},
+			ResultSize: 2,
+			Expected:   []BeaconOrErr{{Beacon: fast}, {Beacon: slow}, {Err: testErr}},
+		},
+		"bandwidth": {
+			Algo:       newSelectionAlgorithm(BandwidthAlgorithm),
+			Beacons:    []BeaconOrErr{{Beacon: unknown}, {Beacon: fast}, {Beacon: slow}},
+			ResultSize: 5,
+			Expected:   []BeaconOrErr{{Beacon: slow}, {Beacon: fast}, {Beacon: unknown}},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			beacons := make(chan BeaconOrErr, len(test.Beacons))
+			for _, b := range test.Beacons {
+				beacons <- b
+			}
+			close(beacons)
+			results := make(chan BeaconOrErr, len(test.Beacons))
+			test.Algo.SelectAndServe(beacons, results, test.ResultSize)
+			close(results)
+			var served []BeaconOrErr
+			for res := range results {
+				served = append(served, res)
+			}
+			assert.Equal(t, test.Expected, served)
+		})
+	}
+}
+
+// hopInfo is the static info of the ingress and egress interface of an AS
+// entry.
+type hopInfo struct {
+	In, Eg *seg.InterfaceStaticInfo
+}
+
+// newMetadataBeacon creates a beacon with an AS entry per hop. The ingress
+// interface of the i-th AS entry is 2*i and the egress interface 2*i+1.
+func newMetadataBeacon(hops ...hopInfo) Beacon {
+	var entries []*seg.ASEntry
+	for i, hop := range hops {
+		in, eg := common.IFIDType(2*i), common.IFIDType(2*i+1)
+		hopF := &spath.HopField{ConsIngress: in, ConsEgress: eg}
+		entry := &seg.ASEntry{
+			HopEntries: []*seg.HopEntry{{RawHopField: hopF.Pack()}},
+		}
+		var infos []*seg.InterfaceStaticInfo
+		for ifid, info := range map[common.IFIDType]*seg.InterfaceStaticInfo{in: hop.In,
+			eg: hop.Eg} {
+			if info != nil {
+				info.IfID = ifid
+				infos = append(infos, info)
+			}
+		}
+		if len(infos) > 0 {
+			entry.Exts.StaticInfo = seg.NewStaticInfoExtn(infos)
+		}
+		entries = append(entries, entry)
+	}
+	return Beacon{Segment: &seg.PathSegment{ASEntries: entries}}
+}
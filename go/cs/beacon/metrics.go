@@ -206,6 +206,16 @@ func (e *executor) BeaconSources(ctx context.Context) ([]addr.IA, error) {
 	return ret, err
 }
 
+func (e *executor) AllBeacons(ctx context.Context) ([]StoredBeacon, error) {
+	var ret []StoredBeacon
+	var err error
+	e.metrics.Observe(ctx, "all_beacons", func(ctx context.Context) error {
+		ret, err = e.db.AllBeacons(ctx)
+		return err
+	})
+	return ret, err
+}
+
 func (e *executor) AllRevocations(ctx context.Context) (<-chan RevocationOrErr, error) {
 	var ret <-chan RevocationOrErr
 	var err error
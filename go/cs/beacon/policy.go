@@ -15,12 +15,17 @@
 package beacon
 
 import (
+	"fmt"
 	"io/ioutil"
+	"strings"
 
 	yaml "gopkg.in/yaml.v2"
 
 	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/pathpol"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/snet"
 	"github.com/scionproto/scion/go/lib/spath"
 )
 
@@ -38,6 +43,36 @@ const (
 	CoreRegPolicy PolicyType = "CoreSegmentRegistration"
 )
 
+// SelectionAlgorithm is the algorithm used to select the best beacons.
+type SelectionAlgorithm string
+
+const (
+	// HopCountAlgorithm prefers short beacons and tries to achieve some path
+	// diversity. It is the default algorithm.
+	HopCountAlgorithm SelectionAlgorithm = "HopCount"
+	// LatencyAlgorithm prefers beacons with low latency according to the
+	// static metadata of the beacons.
+	LatencyAlgorithm SelectionAlgorithm = "Latency"
+	// BandwidthAlgorithm prefers beacons with high bottleneck bandwidth
+	// according to the static metadata of the beacons.
+	BandwidthAlgorithm SelectionAlgorithm = "Bandwidth"
+)
+
+// UnmarshalYAML checks that the algorithm is known.
+func (a *SelectionAlgorithm) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var s string
+	if err := unmarshal(&s); err != nil {
+		return err
+	}
+	switch alg := SelectionAlgorithm(s); alg {
+	case "", HopCountAlgorithm, LatencyAlgorithm, BandwidthAlgorithm:
+		*a = alg
+		return nil
+	default:
+		return common.NewBasicError("Unknown selection algorithm", nil, "algorithm", s)
+	}
+}
+
 const (
 	// DefaultBestSetSize is the default BestSetSize value.
 	DefaultBestSetSize = 5
@@ -188,6 +223,8 @@ type Policy struct {
 	Filter Filter `yaml:"Filter"`
 	// Type is the policy type.
 	Type PolicyType `yaml:"Type"`
+	// SelectionAlgorithm is the algorithm used to select the best beacons.
+	SelectionAlgorithm SelectionAlgorithm `yaml:"SelectionAlgorithm"`
 }
 
 // InitDefaults initializes the default values for unset fields.
@@ -202,6 +239,9 @@ func (p *Policy) InitDefaults() {
 		m := DefaultMaxExpTime
 		p.MaxExpTime = &m
 	}
+	if p.SelectionAlgorithm == "" {
+		p.SelectionAlgorithm = HopCountAlgorithm
+	}
 	p.Filter.InitDefaults()
 }
 
@@ -247,6 +287,12 @@ type Filter struct {
 	IsdBlackList []addr.ISD `yaml:"IsdBlackList"`
 	// AllowIsdLoop indicates whether ISD loops should not be filtered.
 	AllowIsdLoop *bool `yaml:"AllowIsdLoop"`
+	// ACL is a path policy ACL that must allow the segment. The interface the
+	// beacon was received on in the local AS is included as last hop.
+	ACL *pathpol.ACL `yaml:"ACL"`
+	// Sequence is a path policy sequence that the segment must match. The
+	// local AS is included as last hop, as for the ACL.
+	Sequence *pathpol.Sequence `yaml:"Sequence"`
 }
 
 // InitDefaults initializes the default values for unset fields.
@@ -282,6 +328,27 @@ func (f Filter) Apply(beacon Beacon) error {
 			}
 		}
 	}
+	return f.applyPathPolicy(beacon)
+}
+
+// applyPathPolicy returns an error if the beacon is denied by the ACL or does
+// not match the sequence.
+func (f Filter) applyPathPolicy(beacon Beacon) error {
+	if f.ACL == nil && f.Sequence == nil {
+		return nil
+	}
+	path, err := newBeaconPath(beacon)
+	if err != nil {
+		return common.NewBasicError("Unable to apply path policy", err)
+	}
+	ps := pathpol.PathSet{path.Fingerprint(): path}
+	if len(f.ACL.Eval(ps)) == 0 {
+		return common.NewBasicError("Denied by ACL", nil, "path", path)
+	}
+	if len(f.Sequence.Eval(ps)) == 0 {
+		return common.NewBasicError("Sequence not matched", nil, "path", path,
+			"sequence", f.Sequence)
+	}
 	return nil
 }
 
@@ -342,3 +409,59 @@ func filterIsdLoop(hops []addr.IA) addr.ISD {
 	}
 	return 0
 }
+
+// beaconPath is the path of a beacon for path policies. It contains the
+// interfaces of the segment in construction direction, followed by the
+// interface the beacon was received on in the local AS.
+type beaconPath struct {
+	intfs []snet.PathInterface
+}
+
+func newBeaconPath(beacon Beacon) (beaconPath, error) {
+	entries := beacon.Segment.ASEntries
+	if len(entries) == 0 {
+		return beaconPath{}, serrors.New("segment without AS entries")
+	}
+	intfs := make([]snet.PathInterface, 0, 2*len(entries))
+	for i, asEntry := range entries {
+		if len(asEntry.HopEntries) == 0 {
+			return beaconPath{}, serrors.New("AS entry without hop entries",
+				"ia", asEntry.IA())
+		}
+		// The first hop entry is the one on the segment, the others are
+		// peering hop entries.
+		hopF, err := asEntry.HopEntries[0].HopField()
+		if err != nil {
+			return beaconPath{}, err
+		}
+		if i > 0 {
+			intfs = append(intfs, pathInterface{ia: asEntry.IA(), ifid: hopF.ConsIngress})
+		}
+		intfs = append(intfs, pathInterface{ia: asEntry.IA(), ifid: hopF.ConsEgress})
+	}
+	last := entries[len(entries)-1].HopEntries[0]
+	intfs = append(intfs, pathInterface{ia: last.OutIA(), ifid: last.RemoteOutIF})
+	return beaconPath{intfs: intfs}, nil
+}
+
+func (p beaconPath) Interfaces() []snet.PathInterface { return p.intfs }
+
+func (p beaconPath) Fingerprint() snet.PathFingerprint {
+	return snet.PathFingerprint(p.String())
+}
+
+func (p beaconPath) String() string {
+	parts := make([]string, 0, len(p.intfs))
+	for _, intf := range p.intfs {
+		parts = append(parts, fmt.Sprintf("%s#%d", intf.IA(), intf.ID()))
+	}
+	return strings.Join(parts, " ")
+}
+
+type pathInterface struct {
+	ia   addr.IA
+	ifid common.IFIDType
+}
+
+func (i pathInterface) IA() addr.IA         { return i.ia }
+func (i pathInterface) ID() common.IFIDType { return i.ifid }
@@ -18,10 +18,14 @@ import (
 	"testing"
 
 	. "github.com/smartystreets/goconvey/convey"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/scionproto/scion/go/cs/beacon"
 	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
 	"github.com/scionproto/scion/go/lib/ctrl/seg"
+	"github.com/scionproto/scion/go/lib/spath"
 	"github.com/scionproto/scion/go/lib/xtest"
 )
 
@@ -155,6 +159,40 @@ func TestFilterApply(t *testing.T) {
 	})
 }
 
+func TestFilterPathPolicy(t *testing.T) {
+	p, err := beacon.LoadPolicyFromYaml("testdata/pathPolicy.yml", beacon.UpRegPolicy)
+	require.NoError(t, err)
+	testCases := map[string]struct {
+		Beacon         beacon.Beacon
+		ErrorAssertion assert.ErrorAssertionFunc
+	}{
+		"accepted": {
+			Beacon: newTestPathBeacon(ia112, 5,
+				testHop{IA: ia110, Out: 1}, testHop{IA: ia111, In: 2, Out: 3}),
+			ErrorAssertion: assert.NoError,
+		},
+		"denied ingress interface": {
+			Beacon: newTestPathBeacon(ia112, 4,
+				testHop{IA: ia110, Out: 1}, testHop{IA: ia111, In: 2, Out: 3}),
+			ErrorAssertion: assert.Error,
+		},
+		"sequence not matched": {
+			Beacon: newTestPathBeacon(ia112, 5,
+				testHop{IA: ia110, Out: 2}, testHop{IA: ia111, In: 2, Out: 3}),
+			ErrorAssertion: assert.Error,
+		},
+		"hops missing": {
+			Beacon:         newTestBeacon(ia110, ia111),
+			ErrorAssertion: assert.Error,
+		},
+	}
+	for name, test := range testCases {
+		t.Run(name, func(t *testing.T) {
+			test.ErrorAssertion(t, p.Filter.Apply(test.Beacon))
+		})
+	}
+}
+
 func TestFilterLoop(t *testing.T) {
 	testCases := []struct {
 		Name         string
@@ -217,3 +255,58 @@ func newTestBeacon(hops ...addr.IA) beacon.Beacon {
 	}
 	return b
 }
+
+type testHop struct {
+	IA      addr.IA
+	In, Out common.IFIDType
+}
+
+// newTestPathBeacon creates a beacon along the hops, that is received by the
+// local AS on the interface localIn.
+func newTestPathBeacon(local addr.IA, localIn common.IFIDType, hops ...testHop) beacon.Beacon {
+	var entries []*seg.ASEntry
+	for i, hop := range hops {
+		outIA, remoteOutIF := local, localIn
+		if i < len(hops)-1 {
+			outIA, remoteOutIF = hops[i+1].IA, hops[i+1].In
+		}
+		hopF := &spath.HopField{ConsIngress: hop.In, ConsEgress: hop.Out}
+		entries = append(entries, &seg.ASEntry{
+			RawIA: hop.IA.IAInt(),
+			HopEntries: []*seg.HopEntry{{
+				RawOutIA:    outIA.IAInt(),
+				RemoteOutIF: remoteOutIF,
+				RawHopField: hopF.Pack(),
+			}},
+		})
+	}
+	return beacon.Beacon{
+		Segment: &seg.PathSegment{ASEntries: entries},
+		InIfId:  localIn,
+	}
+}
+
+func TestParseSelectionAlgorithm(t *testing.T) {
+	tests := map[string]struct {
+		Yaml      string
+		Algorithm beacon.SelectionAlgorithm
+		Err       bool
+	}{
+		"default":   {Yaml: "BestSetSize: 5", Algorithm: beacon.HopCountAlgorithm},
+		"hop count": {Yaml: "SelectionAlgorithm: HopCount", Algorithm: beacon.HopCountAlgorithm},
+		"latency":   {Yaml: "SelectionAlgorithm: Latency", Algorithm: beacon.LatencyAlgorithm},
+		"bandwidth": {Yaml: "SelectionAlgorithm: Bandwidth", Algorithm: beacon.BandwidthAlgorithm},
+		"unknown":   {Yaml: "SelectionAlgorithm: Jitter", Err: true},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			p, err := beacon.ParsePolicyYaml([]byte(test.Yaml), beacon.PropPolicy)
+			if test.Err {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.Algorithm, p.SelectionAlgorithm)
+		})
+	}
+}
@@ -14,7 +14,10 @@
 
 package beacon
 
-import "math"
+import (
+	"math"
+	"sort"
+)
 
 type selectionAlgorithm interface {
 	// SelectAndServe selects the n best beacons from the beacons channel and
@@ -22,6 +25,19 @@ type selectionAlgorithm interface {
 	SelectAndServe(beacons <-chan BeaconOrErr, results chan<- BeaconOrErr, resultSize int)
 }
 
+// newSelectionAlgorithm returns the selection algorithm with the given name.
+// Unknown names result in the default algorithm.
+func newSelectionAlgorithm(name SelectionAlgorithm) selectionAlgorithm {
+	switch name {
+	case LatencyAlgorithm:
+		return metricAlgo{less: lessLatency}
+	case BandwidthAlgorithm:
+		return metricAlgo{less: lessBandwidth}
+	default:
+		return baseAlgo{}
+	}
+}
+
 // baseAlgo implements a very simple selection algorithm that optimizes for
 // short paths, but also tries to achieve some path diversity.
 type baseAlgo struct{}
@@ -110,6 +126,50 @@ func (baseAlgo) serveMostDiverse(beacons <-chan BeaconOrErr, results chan<- Beac
 	results <- BeaconOrErr{Beacon: first}
 }
 
+// metricAlgo selects the best beacons according to the static metadata of
+// the beacons. Beacons that are equal according to the metric keep the order
+// they are provided in, i.e., shorter beacons are preferred.
+type metricAlgo struct {
+	// less reports whether the beacon with metadata a is better than the
+	// beacon with metadata b.
+	less func(a, b beaconMetadata) bool
+}
+
+// SelectAndServe consumes all beacons from the beacons channel and serves the
+// resultSize best ones. If the channel contains errors, the first one is
+// served after the beacons.
+func (a metricAlgo) SelectAndServe(beacons <-chan BeaconOrErr, results chan<- BeaconOrErr,
+	resultSize int) {
+
+	type candidate struct {
+		beacon Beacon
+		meta   beaconMetadata
+	}
+	var candidates []candidate
+	var err error
+	for res := range beacons {
+		if res.Err != nil {
+			if err == nil {
+				err = res.Err
+			}
+			continue
+		}
+		candidates = append(candidates, candidate{
+			beacon: res.Beacon,
+			meta:   newBeaconMetadata(res.Beacon),
+		})
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return a.less(candidates[i].meta, candidates[j].meta)
+	})
+	for i := 0; i < len(candidates) && i < resultSize; i++ {
+		results <- BeaconOrErr{Beacon: candidates[i].beacon}
+	}
+	if err != nil {
+		results <- BeaconOrErr{Err: err}
+	}
+}
+
 func max(a, b int) int {
 	if a > b {
 		return a
@@ -52,8 +52,7 @@ func NewBeaconStore(policies Policies, db DB) (*Store, error) {
 	}
 	s := &Store{
 		baseStore: baseStore{
-			db:   db,
-			algo: baseAlgo{},
+			db: db,
 		},
 		policies: policies,
 	}
@@ -92,11 +91,12 @@ func (s *Store) getBeacons(ctx context.Context, policy *Policy) (<-chan BeaconOr
 	if err != nil {
 		return nil, err
 	}
+	algo := newSelectionAlgorithm(policy.SelectionAlgorithm)
 	results := make(chan BeaconOrErr, min(maxResultChanSize, policy.BestSetSize))
 	go func() {
 		defer log.HandlePanic()
 		defer close(results)
-		s.algo.SelectAndServe(beacons, results, policy.BestSetSize)
+		algo.SelectAndServe(beacons, results, policy.BestSetSize)
 	}()
 	return results, nil
 }
@@ -131,8 +131,7 @@ func NewCoreBeaconStore(policies CorePolicies, db DB) (*CoreStore, error) {
 	}
 	s := &CoreStore{
 		baseStore: baseStore{
-			db:   db,
-			algo: baseAlgo{},
+			db: db,
 		},
 		policies: policies,
 	}
@@ -166,6 +165,7 @@ func (s *CoreStore) getBeacons(ctx context.Context, policy *Policy) (<-chan Beac
 	if err != nil {
 		return nil, err
 	}
+	algo := newSelectionAlgorithm(policy.SelectionAlgorithm)
 	results := make(chan BeaconOrErr, min(maxResultChanSize, len(srcs)*policy.BestSetSize))
 	wg := sync.WaitGroup{}
 	var errs []addr.IA
@@ -183,7 +183,7 @@ func (s *CoreStore) getBeacons(ctx context.Context, policy *Policy) (<-chan Beac
 		go func() {
 			defer log.HandlePanic()
 			defer wg.Done()
-			s.algo.SelectAndServe(beacons, results, policy.BestSetSize)
+			algo.SelectAndServe(beacons, results, policy.BestSetSize)
 		}()
 	}
 	go func() {
@@ -214,7 +214,6 @@ func (s *CoreStore) MaxExpTime(policyType PolicyType) spath.ExpTimeType {
 type baseStore struct {
 	db     DB
 	usager usager
-	algo   selectionAlgorithm
 }
 
 // PreFilter indicates whether the beacon will be filtered on insert by
@@ -261,6 +260,11 @@ func (s *baseStore) DeleteRevocation(ctx context.Context, ia addr.IA, ifid commo
 	return s.db.DeleteRevocation(ctx, ia, ifid)
 }
 
+// AllBeacons returns all beacons in the store, with their usage.
+func (s *baseStore) AllBeacons(ctx context.Context) ([]StoredBeacon, error) {
+	return s.db.AllBeacons(ctx)
+}
+
 // DeleteExpiredBeacons deletes expired Beacons from the store.
 func (s *baseStore) DeleteExpiredBeacons(ctx context.Context) (int, error) {
 	return s.db.DeleteExpiredBeacons(ctx, time.Now())
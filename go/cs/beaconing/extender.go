@@ -25,7 +25,9 @@ import (
 	"github.com/scionproto/scion/go/lib/log"
 	"github.com/scionproto/scion/go/lib/serrors"
 	"github.com/scionproto/scion/go/lib/spath"
+	"github.com/scionproto/scion/go/lib/topology"
 	"github.com/scionproto/scion/go/lib/util"
+	"github.com/scionproto/scion/go/proto"
 )
 
 // segExtender appends AS entries to provided path segments.
@@ -74,6 +76,8 @@ func (s *segExtender) extend(pseg *seg.PathSegment, inIfid, egIfid common.IFIDTy
 		MTU:        s.cfg.MTU,
 		HopEntries: hopEntries,
 	}
+	asEntry.Exts.LoadInfo = s.loadInfo(inIfid, egIfid, peers)
+	asEntry.Exts.StaticInfo = s.staticInfo(inIfid, egIfid, peers)
 	if err := pseg.AddASEntry(asEntry, s.cfg.Signer); err != nil {
 		return err
 	}
@@ -83,6 +87,81 @@ func (s *segExtender) extend(pseg *seg.PathSegment, inIfid, egIfid common.IFIDTy
 	return pseg.Validate(seg.ValidateBeacon)
 }
 
+// loadInfo creates the load information extension for the given interfaces.
+// It returns nil, if no load information is available.
+func (s *segExtender) loadInfo(inIfid, egIfid common.IFIDType,
+	peers []common.IFIDType) *seg.LoadInfoExtn {
+
+	if s.cfg.LoadInfo == nil {
+		return nil
+	}
+	var loads []*seg.InterfaceLoad
+	for _, ifid := range append([]common.IFIDType{inIfid, egIfid}, peers...) {
+		if ifid == 0 {
+			continue
+		}
+		if l := s.cfg.LoadInfo.Load(ifid); l != nil {
+			loads = append(loads, l)
+		}
+	}
+	if len(loads) == 0 {
+		return nil
+	}
+	return seg.NewLoadInfoExtn(loads)
+}
+
+// staticInfo creates the static info extension for the given interfaces from
+// the metadata in the topology. Interfaces without metadata are omitted.
+func (s *segExtender) staticInfo(inIfid, egIfid common.IFIDType,
+	peers []common.IFIDType) *seg.StaticInfoExtn {
+
+	if s.cfg.Intfs == nil {
+		return nil
+	}
+	var infos []*seg.InterfaceStaticInfo
+	for _, ifid := range append([]common.IFIDType{inIfid, egIfid}, peers...) {
+		if ifid == 0 {
+			continue
+		}
+		intf := s.cfg.Intfs.Get(ifid)
+		if intf == nil {
+			continue
+		}
+		if info := interfaceStaticInfo(ifid, intf.TopoInfo()); info != nil {
+			infos = append(infos, info)
+		}
+	}
+	if len(infos) == 0 {
+		return nil
+	}
+	return seg.NewStaticInfoExtn(infos)
+}
+
+// interfaceStaticInfo returns the static info of the interface, or nil if the
+// topology does not contain any metadata for it.
+func interfaceStaticInfo(ifid common.IFIDType, topoInfo topology.IFInfo) *seg.InterfaceStaticInfo {
+	info := &seg.InterfaceStaticInfo{
+		IfID:            ifid,
+		Latency:         uint32(topoInfo.Latency / time.Microsecond),
+		InternalLatency: uint32(topoInfo.InternalLatency / time.Microsecond),
+		LinkType:        proto.PhysicalLinkType(topoInfo.PhysLinkType),
+	}
+	if topoInfo.Bandwidth > 0 {
+		info.Bandwidth = uint64(topoInfo.Bandwidth)
+	}
+	if topoInfo.Geo != nil {
+		info.Geo = &seg.GeoCoordinates{
+			Latitude:  topoInfo.Geo.Latitude,
+			Longitude: topoInfo.Geo.Longitude,
+			Address:   topoInfo.Geo.Address,
+		}
+	}
+	if *info == (seg.InterfaceStaticInfo{IfID: ifid}) {
+		return nil
+	}
+	return info
+}
+
 func (s *segExtender) createHopEntries(inIfid, egIfid common.IFIDType, peers []common.IFIDType,
 	prev common.RawBytes, ts time.Time) ([]*seg.HopEntry, error) {
 
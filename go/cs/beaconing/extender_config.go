@@ -18,6 +18,8 @@ import (
 	"hash"
 
 	"github.com/scionproto/scion/go/cs/ifstate"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/ctrl/seg"
 	"github.com/scionproto/scion/go/lib/infra"
 	"github.com/scionproto/scion/go/lib/serrors"
 	"github.com/scionproto/scion/go/lib/spath"
@@ -28,6 +30,12 @@ const (
 	DefaultIfidSize = 12
 )
 
+// LoadProvider provides the current load of the interfaces in the AS.
+type LoadProvider interface {
+	// Load returns the load of the interface, or nil if it is unknown.
+	Load(ifid common.IFIDType) *seg.InterfaceLoad
+}
+
 // ExtenderConf is the configuration used when extending beacons.
 type ExtenderConf struct {
 	// Signer is used to sign path segments.
@@ -42,6 +50,9 @@ type ExtenderConf struct {
 	IfidSize uint8
 	// GetMaxExpTime returns the maximum relative expiration time.
 	GetMaxExpTime func() spath.ExpTimeType
+	// LoadInfo provides the interface loads that are attached to the AS
+	// entries. If nil, no load information is attached.
+	LoadInfo LoadProvider
 	// task contains an identifier specific to the task that uses the extender.
 	task string
 }
@@ -35,6 +35,7 @@ import (
 	"github.com/scionproto/scion/go/lib/keyconf"
 	"github.com/scionproto/scion/go/lib/scrypto"
 	"github.com/scionproto/scion/go/lib/spath"
+	"github.com/scionproto/scion/go/lib/topology"
 	"github.com/scionproto/scion/go/lib/util"
 	"github.com/scionproto/scion/go/lib/xtest"
 	"github.com/scionproto/scion/go/lib/xtest/graph"
@@ -337,3 +338,62 @@ func maxExpTimeFactory(max spath.ExpTimeType) func() spath.ExpTimeType {
 		return max
 	}
 }
+
+type staticLoads map[common.IFIDType]*seg.InterfaceLoad
+
+func (l staticLoads) Load(ifid common.IFIDType) *seg.InterfaceLoad {
+	return l[ifid]
+}
+
+func TestExtenderLoadInfo(t *testing.T) {
+	loads := staticLoads{
+		1: {IfID: 1, Rate: 100},
+		3: {IfID: 3, RouterQueueFill: 50},
+	}
+	Convey("Without a load provider, no load information is attached", t, func() {
+		s := &segExtender{}
+		SoMsg("ext", s.loadInfo(1, 2, []common.IFIDType{3}), ShouldBeNil)
+	})
+	Convey("Only known interfaces are included", t, func() {
+		s := &segExtender{cfg: ExtenderConf{LoadInfo: loads}}
+		ext := s.loadInfo(0, 1, []common.IFIDType{2, 3})
+		SoMsg("ext", ext, ShouldResemble, seg.NewLoadInfoExtn(
+			[]*seg.InterfaceLoad{loads[1], loads[3]}))
+		SoMsg("unknown", s.loadInfo(2, 0, nil), ShouldBeNil)
+	})
+}
+
+func TestExtenderStaticInfo(t *testing.T) {
+	intfs := ifstate.NewInterfaces(topology.IfInfoMap{
+		1: {
+			ID:              1,
+			Bandwidth:       1000,
+			Latency:         10 * time.Millisecond,
+			InternalLatency: 500 * time.Microsecond,
+			PhysLinkType:    topology.Direct,
+			Geo:             &topology.GeoCoordinates{Latitude: 47.5, Longitude: 8.5},
+		},
+		2: {ID: 2},
+		3: {ID: 3, InternalLatency: time.Millisecond},
+	}, ifstate.Config{})
+	Convey("Without interfaces, no static info is attached", t, func() {
+		s := &segExtender{}
+		SoMsg("ext", s.staticInfo(1, 2, nil), ShouldBeNil)
+	})
+	Convey("Only interfaces with metadata are included", t, func() {
+		s := &segExtender{cfg: ExtenderConf{Intfs: intfs}}
+		ext := s.staticInfo(0, 1, []common.IFIDType{2, 3, 4})
+		SoMsg("ext", ext, ShouldResemble, seg.NewStaticInfoExtn([]*seg.InterfaceStaticInfo{
+			{
+				IfID:            1,
+				Latency:         10000,
+				InternalLatency: 500,
+				Bandwidth:       1000,
+				LinkType:        proto.PhysicalLinkType_direct,
+				Geo:             &seg.GeoCoordinates{Latitude: 47.5, Longitude: 8.5},
+			},
+			{IfID: 3, InternalLatency: 1000},
+		}))
+		SoMsg("no metadata", s.staticInfo(2, 0, nil), ShouldBeNil)
+	})
+}
@@ -21,6 +21,7 @@ import (
 	"time"
 
 	"github.com/scionproto/scion/go/cs/beacon"
+	"github.com/scionproto/scion/go/cs/ifstate"
 	"github.com/scionproto/scion/go/cs/metrics"
 	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/common"
@@ -158,6 +159,8 @@ func (r *Registrar) registerRemote(ctx context.Context, segments <-chan beacon.B
 			segType:      r.segType,
 			msgr:         r.msgr,
 			topoProvider: r.topoProvider,
+			intfs:        r.cfg.Intfs,
+			now:          r.tick.now,
 			summary:      s,
 			wg:           &wg,
 		}
@@ -208,11 +211,21 @@ func (r *Registrar) registerLocal(ctx context.Context, segments <-chan beacon.Be
 	if err != nil {
 		return err
 	}
+	for _, id := range append(stats.InsertedSegs, stats.UpdatedSegs...) {
+		r.registered(beacons[id].InIfId)
+	}
 	r.lastSucc = r.tick.now
 	r.logSummary(logger, summarizeStats(stats, beacons))
 	return nil
 }
 
+// registered records the registration of a segment received on the interface.
+func (r *Registrar) registered(ifid common.IFIDType) {
+	if intf := r.cfg.Intfs.Get(ifid); intf != nil {
+		intf.Register(r.segType, r.tick.now)
+	}
+}
+
 func (r *Registrar) logSummary(logger log.Logger, s *summary) {
 	if r.tick.passed() {
 		logger.Info("[beaconing.Registrar] Registered beacons", "type", r.segType, "count", s.count,
@@ -230,6 +243,8 @@ type remoteRegistrar struct {
 	segType      proto.PathSegType
 	msgr         infra.Messenger
 	topoProvider topology.Provider
+	intfs        *ifstate.Interfaces
+	now          time.Time
 	summary      *summary
 	wg           *sync.WaitGroup
 }
@@ -275,6 +290,9 @@ func (r *remoteRegistrar) startSendSegReg(ctx context.Context, bseg beacon.Beaco
 		}
 		r.summary.AddSrc(bseg.Segment.FirstIA())
 		r.summary.Inc()
+		if intf := r.intfs.Get(bseg.InIfId); intf != nil {
+			intf.Register(r.segType, r.now)
+		}
 		l := metrics.RegistrarLabels{
 			SegType: r.segType.String(),
 			StartIA: bseg.Segment.FirstIA(),
@@ -222,6 +222,12 @@ func TestRegistrarRun(t *testing.T) {
 			}
 			r.Run(context.Background())
 			require.Len(t, sent, len(test.beacons))
+			// The registration is recorded on the ingress interfaces.
+			for _, desc := range test.beacons {
+				ifid := testBeaconOrErr(g, desc).Beacon.InIfId
+				assert.False(t, cfg.Config.Intfs.Get(ifid).LastRegister(test.segType).IsZero(),
+					"ifid %d", ifid)
+			}
 			for segIdx, s := range sent {
 				t.Run(fmt.Sprintf("seg idx %d", segIdx), func(t *testing.T) {
 					require.Len(t, s.Reg.Recs, 1)
@@ -15,10 +15,10 @@
 package beaconstorage
 
 const beaconDbSample = `
-# The type of trustdb backend. (default sqlite)
+# The type of beacondb backend, either "sqlite" or "mem". (default sqlite)
 backend = "sqlite"
 
-# Connection for the trust database.
+# Connection for the beacon database. Not used by the mem backend.
 connection = "/var/lib/scion/beacondb/%s.beacon.db"
 
 # The maximum number of open connections to the database. In case of the
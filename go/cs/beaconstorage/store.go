@@ -51,6 +51,9 @@ type Store interface {
 	// UpdatePolicy updates the policy. Beacons that are filtered by all
 	// policies after the update are removed.
 	UpdatePolicy(ctx context.Context, policy beacon.Policy) error
+	// AllBeacons returns all beacons in the store, with their usage. It is
+	// intended for introspection.
+	AllBeacons(ctx context.Context) ([]beacon.StoredBeacon, error)
 	// MaxExpTime returns the segment maximum expiration time for the given policy.
 	MaxExpTime(policyType beacon.PolicyType) spath.ExpTimeType
 	// DeleteExpired deletes expired Beacons from the store.
@@ -69,3 +69,17 @@ down_registration = ""
 # (default "")
 hidden_path_registration = ""
 `
+
+const loadSample = `
+# The URLs of the load endpoints of the border routers of the AS. The interface
+# loads are attached to the beacons. If empty, no load information is attached.
+# (default [])
+sources = []
+
+# The interval between fetching the interface loads. (default 1s)
+fetch_interval = "1s"
+
+# The maximum age of an interface load before it is no longer attached to
+# beacons. (default 10s)
+max_age = "10s"
+`
@@ -56,6 +56,12 @@ const (
 	// DefaultQueryInterval is the default interval after which the segment
 	// cache expires.
 	DefaultQueryInterval = 5 * time.Minute
+	// DefaultLoadFetchInterval is the default interval between fetching the
+	// interface loads from the border routers.
+	DefaultLoadFetchInterval = time.Second
+	// DefaultLoadMaxAge is the default maximum age of an interface load before
+	// it is no longer attached to beacons.
+	DefaultLoadMaxAge = 10 * time.Second
 )
 
 // Error values
@@ -159,10 +165,14 @@ type BSConfig struct {
 	RevOverlap util.DurWrap `toml:"rev_overlap,omitempty"`
 	// Policies contains the policy files.
 	Policies Policies `toml:"policies,omitempty"`
+	// Load contains the configuration for attaching interface load
+	// information to beacons.
+	Load LoadConfig `toml:"load,omitempty"`
 }
 
 // InitDefaults the default values for the durations that are equal to zero.
 func (cfg *BSConfig) InitDefaults() {
+	config.InitAll(&cfg.Load)
 }
 
 // Validate validates that all durations are set.
@@ -198,13 +208,13 @@ func (cfg *BSConfig) Validate() error {
 	if cfg.RevOverlap.Duration > cfg.RevTTL.Duration {
 		return serrors.New("rev_overlap cannot be greater than rev_ttl")
 	}
-	return nil
+	return config.ValidateAll(&cfg.Load)
 }
 
 // Sample generates a sample for the beacon server specific configuration.
 func (cfg *BSConfig) Sample(dst io.Writer, path config.Path, ctx config.CtxMap) {
 	config.WriteString(dst, bsSample)
-	config.WriteSample(dst, path, ctx, &cfg.Policies)
+	config.WriteSample(dst, path, ctx, &cfg.Policies, &cfg.Load)
 }
 
 // ConfigName is the toml key for the beacon server specific configuration.
@@ -224,23 +234,27 @@ type PSConfig struct {
 	// QueryInterval specifies after how much time segments
 	// for a destination should be refetched.
 	QueryInterval util.DurWrap `toml:"query_interval,omitempty"`
+	// Quota contains the quotas for segment requests.
+	Quota QuotaConfig `toml:"quota,omitempty"`
 }
 
 func (cfg *PSConfig) InitDefaults() {
 	if cfg.QueryInterval.Duration == 0 {
 		cfg.QueryInterval.Duration = DefaultQueryInterval
 	}
+	config.InitAll(&cfg.Quota)
 }
 
 func (cfg *PSConfig) Validate() error {
 	if cfg.QueryInterval.Duration == 0 {
 		return serrors.New("query_interval must not be zero")
 	}
-	return nil
+	return config.ValidateAll(&cfg.Quota)
 }
 
 func (cfg *PSConfig) Sample(dst io.Writer, path config.Path, ctx config.CtxMap) {
 	config.WriteString(dst, psSample)
+	config.WriteSample(dst, path, ctx, &cfg.Quota)
 }
 
 func (cfg *PSConfig) ConfigName() string {
@@ -283,3 +297,95 @@ func (cfg *Policies) Sample(dst io.Writer, _ config.Path, _ config.CtxMap) {
 func (cfg *Policies) ConfigName() string {
 	return "policies"
 }
+
+var _ config.Config = (*LoadConfig)(nil)
+
+// LoadConfig is the configuration for attaching interface load information to
+// beacons.
+type LoadConfig struct {
+	// Sources are the URLs of the load endpoints of the border routers. If
+	// empty, no load information is attached to beacons.
+	Sources []string `toml:"sources,omitempty"`
+	// FetchInterval is the interval between fetching the interface loads.
+	FetchInterval util.DurWrap `toml:"fetch_interval,omitempty"`
+	// MaxAge is the maximum age of an interface load before it is no longer
+	// attached to beacons.
+	MaxAge util.DurWrap `toml:"max_age,omitempty"`
+}
+
+// InitDefaults initializes the default values for the durations that are
+// equal to zero.
+func (cfg *LoadConfig) InitDefaults() {
+	initDurWrap(&cfg.FetchInterval, DefaultLoadFetchInterval)
+	initDurWrap(&cfg.MaxAge, DefaultLoadMaxAge)
+}
+
+// Validate validates that the maximum age covers at least one fetch interval.
+func (cfg *LoadConfig) Validate() error {
+	if cfg.MaxAge.Duration < cfg.FetchInterval.Duration {
+		return serrors.New("max_age must not be smaller than fetch_interval")
+	}
+	return nil
+}
+
+// Sample generates a sample for the load configuration.
+func (cfg *LoadConfig) Sample(dst io.Writer, _ config.Path, _ config.CtxMap) {
+	config.WriteString(dst, loadSample)
+}
+
+// ConfigName is the toml key for the load configuration.
+func (cfg *LoadConfig) ConfigName() string {
+	return "load"
+}
+
+var _ config.Config = (*QuotaConfig)(nil)
+
+// QuotaConfig is the configuration for the segment request quotas. A rate of
+// zero disables the corresponding limit.
+type QuotaConfig struct {
+	config.NoDefaulter
+	// IARate is the number of segment requests per second that are allowed
+	// per source ISD-AS.
+	IARate int `toml:"ia_rate,omitempty"`
+	// IABurst is the number of segment requests that a source ISD-AS can send
+	// in excess of the rate. If zero, it is set to the rate.
+	IABurst int `toml:"ia_burst,omitempty"`
+	// HostRate is the number of segment requests per second that are allowed
+	// per host.
+	HostRate int `toml:"host_rate,omitempty"`
+	// HostBurst is the number of segment requests that a host can send in
+	// excess of the rate. If zero, it is set to the rate.
+	HostBurst int `toml:"host_burst,omitempty"`
+	// NegativeTTL is the time for which segment requests that did not result
+	// in any segments are answered from cache. If zero, negative results are
+	// not cached.
+	NegativeTTL util.DurWrap `toml:"negative_ttl,omitempty"`
+}
+
+// Validate validates that the values are not negative, and sets the bursts
+// that are not set.
+func (cfg *QuotaConfig) Validate() error {
+	if cfg.IARate < 0 || cfg.IABurst < 0 || cfg.HostRate < 0 || cfg.HostBurst < 0 {
+		return serrors.New("quota rates and bursts must not be negative")
+	}
+	if cfg.NegativeTTL.Duration < 0 {
+		return serrors.New("negative_ttl must not be negative")
+	}
+	if cfg.IABurst == 0 {
+		cfg.IABurst = cfg.IARate
+	}
+	if cfg.HostBurst == 0 {
+		cfg.HostBurst = cfg.HostRate
+	}
+	return nil
+}
+
+// Sample generates a sample for the quota configuration.
+func (cfg *QuotaConfig) Sample(dst io.Writer, _ config.Path, _ config.CtxMap) {
+	config.WriteString(dst, quotaSample)
+}
+
+// ConfigName is the toml key for the quota configuration.
+func (cfg *QuotaConfig) ConfigName() string {
+	return "quota"
+}
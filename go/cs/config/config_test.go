@@ -57,6 +57,15 @@ func TestInvalidTTL(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestQuotaConfig(t *testing.T) {
+	cfg := QuotaConfig{IARate: 10, HostRate: 2, HostBurst: 5}
+	assert.NoError(t, cfg.Validate())
+	assert.Equal(t, 10, cfg.IABurst)
+	assert.Equal(t, 5, cfg.HostBurst)
+	cfg.HostRate = -1
+	assert.Error(t, cfg.Validate())
+}
+
 func InitTestConfig(cfg *Config) {
 	envtest.InitTest(&cfg.General, &cfg.Metrics, &cfg.Tracing, nil)
 	logtest.InitTestLogging(&cfg.Logging)
@@ -97,6 +106,9 @@ func CheckTestBSConfig(t *testing.T, cfg *BSConfig) {
 	assert.Equal(t, DefaultRevTTL, cfg.RevTTL.Duration)
 	assert.Equal(t, DefaultRevOverlap, cfg.RevOverlap.Duration)
 	CheckTestPolicies(t, &cfg.Policies)
+	assert.Empty(t, cfg.Load.Sources)
+	assert.Equal(t, DefaultLoadFetchInterval, cfg.Load.FetchInterval.Duration)
+	assert.Equal(t, DefaultLoadMaxAge, cfg.Load.MaxAge.Duration)
 }
 
 func CheckTestPolicies(t *testing.T, cfg *Policies) {
@@ -111,4 +123,7 @@ func InitTestPSConfig(cfg *PSConfig) {
 
 func CheckTestPSConfig(t *testing.T, cfg *PSConfig, id string) {
 	assert.Equal(t, DefaultQueryInterval, cfg.QueryInterval.Duration)
+	assert.Zero(t, cfg.Quota.IARate)
+	assert.Zero(t, cfg.Quota.HostRate)
+	assert.Zero(t, cfg.Quota.NegativeTTL.Duration)
 }
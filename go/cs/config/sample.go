@@ -20,3 +20,26 @@ const psSample = `
 # The time after which segments for a destination are refetched. (default 5m)
 query_interval = "5m"
 `
+
+const quotaSample = `
+# The number of segment requests per second that are allowed per source
+# ISD-AS. Requests over the quota are answered with a retry ack. Zero disables
+# the limit. (default 0)
+ia_rate = 0
+
+# The number of segment requests that a source ISD-AS can send in excess of
+# the rate. If zero, it is set to ia_rate. (default 0)
+ia_burst = 0
+
+# The number of segment requests per second that are allowed per host. Zero
+# disables the limit. (default 0)
+host_rate = 0
+
+# The number of segment requests that a host can send in excess of the rate.
+# If zero, it is set to host_rate. (default 0)
+host_burst = 0
+
+# The time for which segment requests that did not result in any segments are
+# answered from cache. Zero disables caching of negative results. (default 0s)
+negative_ttl = "0s"
+`
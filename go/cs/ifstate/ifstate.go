@@ -22,6 +22,7 @@ import (
 	"github.com/scionproto/scion/go/lib/ctrl/path_mgmt"
 	"github.com/scionproto/scion/go/lib/serrors"
 	"github.com/scionproto/scion/go/lib/topology"
+	"github.com/scionproto/scion/go/proto"
 )
 
 const (
@@ -134,6 +135,7 @@ type Interface struct {
 	revocation    *path_mgmt.SignedRevInfo
 	lastOriginate time.Time
 	lastPropagate time.Time
+	lastRegister  map[proto.PathSegType]time.Time
 	lastActivate  time.Time
 	cfg           Config
 }
@@ -232,6 +234,25 @@ func (intf *Interface) LastPropagate() time.Time {
 	return intf.lastPropagate
 }
 
+// Register sets the time a segment of the given type, that was received on
+// this interface, has been registered on last.
+func (intf *Interface) Register(segType proto.PathSegType, now time.Time) {
+	intf.mu.Lock()
+	defer intf.mu.Unlock()
+	if intf.lastRegister == nil {
+		intf.lastRegister = make(map[proto.PathSegType]time.Time)
+	}
+	intf.lastRegister[segType] = now
+}
+
+// LastRegister indicates the last time a segment of the given type, that was
+// received on this interface, has been registered.
+func (intf *Interface) LastRegister(segType proto.PathSegType) time.Time {
+	intf.mu.RLock()
+	defer intf.mu.RUnlock()
+	return intf.lastRegister[segType]
+}
+
 func (intf *Interface) reset() {
 	intf.mu.Lock()
 	defer intf.mu.Unlock()
@@ -239,6 +260,7 @@ func (intf *Interface) reset() {
 	intf.revocation = nil
 	intf.lastOriginate = time.Time{}
 	intf.lastPropagate = time.Time{}
+	intf.lastRegister = nil
 	// Set the starting point for the timeout interval.
 	intf.lastActivate = time.Now()
 }
@@ -24,6 +24,7 @@ import (
 
 	"github.com/scionproto/scion/go/lib/ctrl/path_mgmt"
 	"github.com/scionproto/scion/go/lib/topology"
+	"github.com/scionproto/scion/go/proto"
 )
 
 func TestInterfacesUpdate(t *testing.T) {
@@ -175,6 +176,18 @@ func TestInfoSetRevocation(t *testing.T) {
 	})
 }
 
+func TestInfoRegister(t *testing.T) {
+	intfs := testInterfaces(t)
+	intf := intfs.Get(1)
+	now := time.Now()
+	intf.Register(proto.PathSegType_up, now)
+	assert.Equal(t, now, intf.LastRegister(proto.PathSegType_up))
+	assert.True(t, intf.LastRegister(proto.PathSegType_down).IsZero())
+	// The registration times are reset with the state.
+	intfs.Reset()
+	assert.True(t, intf.LastRegister(proto.PathSegType_up).IsZero())
+}
+
 func testInterfaces(t *testing.T) *Interfaces {
 	topoMap := topology.IfInfoMap{
 		1: {BRName: "BR-1"},
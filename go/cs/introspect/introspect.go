@@ -0,0 +1,263 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package introspect provides HTTP handlers that expose the beaconing state of
+// the control service as JSON.
+//
+// Beacons
+//
+// The beacons handler lists all beacons in the beacon store, ordered by
+// source ISD-AS and length. Each beacon carries its usage flags and its
+// diversity, i.e., the number of links that do not appear in the shortest
+// beacon from the same source.
+//
+// Interfaces
+//
+// The interfaces handler lists the state of all interfaces, including the
+// last time the interface has been originated and propagated on, and the last
+// time a segment received on the interface has been registered.
+//
+// Segments
+//
+// The segments handler lists the path segments in the path database. The
+// query parameters start and end restrict the result to the segments that
+// start, respectively end, at the given ISD-AS.
+package introspect
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/scionproto/scion/go/cs/beacon"
+	"github.com/scionproto/scion/go/cs/ifstate"
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/ctrl/seg"
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/pathdb"
+	"github.com/scionproto/scion/go/lib/pathdb/query"
+	"github.com/scionproto/scion/go/proto"
+)
+
+// Timeout is the timeout for the database queries of a single request.
+const Timeout = 5 * time.Second
+
+// BeaconProvider provides all beacons in the beacon store.
+type BeaconProvider interface {
+	AllBeacons(ctx context.Context) ([]beacon.StoredBeacon, error)
+}
+
+// Beacon is the JSON representation of a beacon.
+type Beacon struct {
+	ID          string    `json:"id"`
+	StartIA     addr.IA   `json:"start_ia"`
+	InIfID      uint64    `json:"in_ifid"`
+	Hops        []Hop     `json:"hops"`
+	Usage       []string  `json:"usage"`
+	Diversity   int       `json:"diversity"`
+	LastUpdated time.Time `json:"last_updated"`
+	Expiration  time.Time `json:"expiration"`
+}
+
+// Interface is the JSON representation of the state of an interface.
+type Interface struct {
+	IfID          uint64               `json:"ifid"`
+	State         ifstate.State        `json:"state"`
+	RemoteIA      addr.IA              `json:"remote_ia"`
+	RemoteIfID    uint64               `json:"remote_ifid"`
+	LinkType      string               `json:"link_type"`
+	LastOriginate *time.Time           `json:"last_originate,omitempty"`
+	LastPropagate *time.Time           `json:"last_propagate,omitempty"`
+	LastRegister  map[string]time.Time `json:"last_register,omitempty"`
+}
+
+// Segment is the JSON representation of a path segment.
+type Segment struct {
+	ID          string    `json:"id"`
+	Type        string    `json:"type"`
+	StartIA     addr.IA   `json:"start_ia"`
+	EndIA       addr.IA   `json:"end_ia"`
+	Hops        []Hop     `json:"hops"`
+	LastUpdated time.Time `json:"last_updated"`
+	Expiration  time.Time `json:"expiration"`
+}
+
+// Hop is the JSON representation of an AS entry of a segment, in
+// construction direction.
+type Hop struct {
+	IA      addr.IA `json:"ia"`
+	Ingress uint64  `json:"ingress,omitempty"`
+	Egress  uint64  `json:"egress,omitempty"`
+}
+
+// NewBeaconsHandler returns a handler that lists all beacons in the store.
+func NewBeaconsHandler(store BeaconProvider) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancelF := context.WithTimeout(r.Context(), Timeout)
+		defer cancelF()
+		beacons, err := store.AllBeacons(ctx)
+		if err != nil {
+			log.Error("[introspect] Unable to list beacons", "err", err)
+			http.Error(w, "unable to list beacons", http.StatusInternalServerError)
+			return
+		}
+		writeJSON(w, toBeacons(beacons))
+	}
+}
+
+// NewInterfacesHandler returns a handler that lists the state of all
+// interfaces.
+func NewInterfacesHandler(intfs *ifstate.Interfaces) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		all := intfs.All()
+		res := make([]Interface, 0, len(all))
+		for ifid, intf := range all {
+			res = append(res, toInterface(ifid, intf))
+		}
+		sort.Slice(res, func(i, j int) bool { return res[i].IfID < res[j].IfID })
+		writeJSON(w, res)
+	}
+}
+
+// NewSegmentsHandler returns a handler that lists the path segments in the
+// path database, optionally filtered by start and end ISD-AS.
+func NewSegmentsHandler(db pathdb.Read) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		params := &query.Params{}
+		for key, dst := range map[string]*[]addr.IA{
+			"start": &params.StartsAt,
+			"end":   &params.EndsAt,
+		} {
+			raw := r.URL.Query().Get(key)
+			if raw == "" {
+				continue
+			}
+			ia, err := addr.IAFromString(raw)
+			if err != nil {
+				http.Error(w, fmt.Sprintf("invalid %s ISD-AS: %s", key, raw),
+					http.StatusBadRequest)
+				return
+			}
+			*dst = []addr.IA{ia}
+		}
+		ctx, cancelF := context.WithTimeout(r.Context(), Timeout)
+		defer cancelF()
+		results, err := db.Get(ctx, params)
+		if err != nil {
+			log.Error("[introspect] Unable to list segments", "err", err)
+			http.Error(w, "unable to list segments", http.StatusInternalServerError)
+			return
+		}
+		res := make([]Segment, 0, len(results))
+		for _, result := range results {
+			res = append(res, toSegment(result))
+		}
+		writeJSON(w, res)
+	}
+}
+
+// toBeacons converts the beacons, which must be ordered by source ISD-AS and
+// length. The diversity is computed relative to the first, i.e., the
+// shortest, beacon of each source.
+func toBeacons(beacons []beacon.StoredBeacon) []Beacon {
+	res := make([]Beacon, 0, len(beacons))
+	var shortest beacon.Beacon
+	for _, b := range beacons {
+		if shortest.Segment == nil || !shortest.Segment.FirstIA().Equal(b.Segment.FirstIA()) {
+			shortest = b.Beacon
+		}
+		res = append(res, Beacon{
+			ID:          b.Segment.GetLoggingID(),
+			StartIA:     b.Segment.FirstIA(),
+			InIfID:      uint64(b.InIfId),
+			Hops:        toHops(b.Segment),
+			Usage:       b.Usage.Names(),
+			Diversity:   b.Diversity(shortest),
+			LastUpdated: b.LastUpdated,
+			Expiration:  b.Segment.MaxExpiry(),
+		})
+	}
+	return res
+}
+
+func toInterface(ifid common.IFIDType, intf *ifstate.Interface) Interface {
+	info := intf.TopoInfo()
+	res := Interface{
+		IfID:          uint64(ifid),
+		State:         intf.State(),
+		RemoteIA:      info.IA,
+		RemoteIfID:    uint64(info.RemoteIFID),
+		LinkType:      info.LinkType.String(),
+		LastOriginate: optTime(intf.LastOriginate()),
+		LastPropagate: optTime(intf.LastPropagate()),
+	}
+	segTypes := []proto.PathSegType{proto.PathSegType_up, proto.PathSegType_down,
+		proto.PathSegType_core}
+	for _, segType := range segTypes {
+		last := intf.LastRegister(segType)
+		if last.IsZero() {
+			continue
+		}
+		if res.LastRegister == nil {
+			res.LastRegister = make(map[string]time.Time)
+		}
+		res.LastRegister[segType.String()] = last
+	}
+	return res
+}
+
+func toSegment(result *query.Result) Segment {
+	return Segment{
+		ID:          result.Seg.GetLoggingID(),
+		Type:        result.Type.String(),
+		StartIA:     result.Seg.FirstIA(),
+		EndIA:       result.Seg.LastIA(),
+		Hops:        toHops(result.Seg),
+		LastUpdated: result.LastUpdate,
+		Expiration:  result.Seg.MaxExpiry(),
+	}
+}
+
+func toHops(ps *seg.PathSegment) []Hop {
+	hops := make([]Hop, 0, len(ps.ASEntries))
+	for _, asEntry := range ps.ASEntries {
+		hop := Hop{IA: asEntry.IA()}
+		if hf, err := asEntry.HopEntries[0].HopField(); err == nil {
+			hop.Ingress = uint64(hf.ConsIngress)
+			hop.Egress = uint64(hf.ConsEgress)
+		}
+		hops = append(hops, hop)
+	}
+	return hops
+}
+
+func optTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "    ")
+	if err := enc.Encode(v); err != nil {
+		log.Error("[introspect] Unable to encode response", "err", err)
+	}
+}
@@ -0,0 +1,164 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package introspect_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/cs/beacon"
+	"github.com/scionproto/scion/go/cs/ifstate"
+	"github.com/scionproto/scion/go/cs/introspect"
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/pathdb/mock_pathdb"
+	"github.com/scionproto/scion/go/lib/pathdb/query"
+	"github.com/scionproto/scion/go/lib/topology"
+	"github.com/scionproto/scion/go/lib/xtest"
+	"github.com/scionproto/scion/go/lib/xtest/graph"
+	"github.com/scionproto/scion/go/proto"
+)
+
+type beaconProvider []beacon.StoredBeacon
+
+func (p beaconProvider) AllBeacons(_ context.Context) ([]beacon.StoredBeacon, error) {
+	return p, nil
+}
+
+func TestBeaconsHandler(t *testing.T) {
+	mctrl := gomock.NewController(t)
+	defer mctrl.Finish()
+	g := graph.NewDefaultGraph(mctrl)
+	now := time.Now().Round(time.Second)
+	store := beaconProvider{
+		{
+			Beacon: beacon.Beacon{
+				Segment: g.Beacon([]common.IFIDType{graph.If_120_X_111_B}),
+				InIfId:  graph.If_111_B_120_X,
+			},
+			Usage:       beacon.UsageUpReg | beacon.UsageProp,
+			LastUpdated: now,
+		},
+		{
+			Beacon: beacon.Beacon{
+				Segment: g.Beacon([]common.IFIDType{graph.If_120_A_130_B,
+					graph.If_130_B_111_A}),
+				InIfId: graph.If_111_A_130_B,
+			},
+			Usage:       beacon.UsageUpReg,
+			LastUpdated: now,
+		},
+	}
+	var beacons []introspect.Beacon
+	get(t, introspect.NewBeaconsHandler(store), "/beacons", &beacons)
+	require.Len(t, beacons, 2)
+	assert.Equal(t, xtest.MustParseIA("1-ff00:0:120"), beacons[0].StartIA)
+	assert.EqualValues(t, graph.If_111_B_120_X, beacons[0].InIfID)
+	assert.Equal(t, []string{"UpRegistration", "Propagation"}, beacons[0].Usage)
+	assert.True(t, now.Equal(beacons[0].LastUpdated))
+	assert.Len(t, beacons[0].Hops, 2)
+	// The shortest beacon from a source has no diversity.
+	assert.Equal(t, 0, beacons[0].Diversity)
+	assert.Equal(t, []string{"UpRegistration"}, beacons[1].Usage)
+	assert.Len(t, beacons[1].Hops, 3)
+	assert.Equal(t, store[1].Diversity(store[0].Beacon), beacons[1].Diversity)
+	assert.NotZero(t, beacons[1].Diversity)
+}
+
+func TestInterfacesHandler(t *testing.T) {
+	intfs := ifstate.NewInterfaces(topology.IfInfoMap{
+		2: {IA: xtest.MustParseIA("1-ff00:0:111"), LinkType: topology.Child},
+		1: {IA: xtest.MustParseIA("1-ff00:0:110"), LinkType: topology.Parent},
+	}, ifstate.Config{})
+	intfs.Get(1).Activate(11)
+	now := time.Now().Round(time.Second)
+	intfs.Get(1).Propagate(now)
+	intfs.Get(1).Register(proto.PathSegType_up, now)
+
+	var res []introspect.Interface
+	get(t, introspect.NewInterfacesHandler(intfs), "/interfaces", &res)
+	require.Len(t, res, 2)
+	assert.EqualValues(t, 1, res[0].IfID)
+	assert.EqualValues(t, 11, res[0].RemoteIfID)
+	assert.Equal(t, xtest.MustParseIA("1-ff00:0:110"), res[0].RemoteIA)
+	assert.Equal(t, ifstate.Active, res[0].State)
+	assert.Equal(t, "parent", res[0].LinkType)
+	assert.Nil(t, res[0].LastOriginate)
+	require.NotNil(t, res[0].LastPropagate)
+	assert.True(t, now.Equal(*res[0].LastPropagate))
+	require.Contains(t, res[0].LastRegister, "up")
+	assert.True(t, now.Equal(res[0].LastRegister["up"]))
+	assert.EqualValues(t, 2, res[1].IfID)
+	assert.Empty(t, res[1].LastRegister)
+}
+
+func TestSegmentsHandler(t *testing.T) {
+	t.Run("filters by start and end", func(t *testing.T) {
+		mctrl := gomock.NewController(t)
+		defer mctrl.Finish()
+		g := graph.NewDefaultGraph(mctrl)
+		db := mock_pathdb.NewMockPathDB(mctrl)
+		start, end := xtest.MustParseIA("1-ff00:0:120"), xtest.MustParseIA("1-ff00:0:111")
+		now := time.Now().Round(time.Second)
+		db.EXPECT().Get(gomock.Any(), &query.Params{
+			StartsAt: []addr.IA{start},
+			EndsAt:   []addr.IA{end},
+		}).Return(query.Results{
+			{
+				Seg:        g.Beacon([]common.IFIDType{graph.If_120_X_111_B}),
+				LastUpdate: now,
+				Type:       proto.PathSegType_down,
+			},
+		}, nil)
+
+		var res []introspect.Segment
+		get(t, introspect.NewSegmentsHandler(db),
+			"/segments?start=1-ff00:0:120&end=1-ff00:0:111", &res)
+		require.Len(t, res, 1)
+		assert.Equal(t, "down", res[0].Type)
+		assert.Equal(t, start, res[0].StartIA)
+		assert.Equal(t, end, res[0].EndIA)
+		assert.True(t, now.Equal(res[0].LastUpdated))
+		require.Len(t, res[0].Hops, 2)
+		assert.EqualValues(t, graph.If_120_X_111_B, res[0].Hops[0].Egress)
+		assert.EqualValues(t, graph.If_111_B_120_X, res[0].Hops[1].Ingress)
+	})
+	t.Run("invalid ISD-AS", func(t *testing.T) {
+		mctrl := gomock.NewController(t)
+		defer mctrl.Finish()
+		db := mock_pathdb.NewMockPathDB(mctrl)
+		rec := httptest.NewRecorder()
+		introspect.NewSegmentsHandler(db).ServeHTTP(rec,
+			httptest.NewRequest(http.MethodGet, "/segments?start=garbage", nil))
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
+
+func get(t *testing.T, h http.Handler, url string, v interface{}) {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, url, nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), v))
+}
@@ -0,0 +1,139 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package loadinfo collects the interface load summaries exported by the
+// border routers of the AS. The beaconing tasks attach them to the AS entries
+// they create.
+package loadinfo
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/ctrl/seg"
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/periodic"
+	"github.com/scionproto/scion/go/lib/serrors"
+)
+
+var _ periodic.Task = (*Fetcher)(nil)
+
+// FetcherConf is the configuration to create a new fetcher.
+type FetcherConf struct {
+	// Sources are the URLs of the load endpoints of the border routers.
+	Sources []string
+	// MaxAge is the maximum age of a load summary before it is ignored.
+	MaxAge time.Duration
+	// Client is the HTTP client used to fetch the load summaries. If nil,
+	// http.DefaultClient is used.
+	Client *http.Client
+}
+
+type entry struct {
+	load      seg.InterfaceLoad
+	timestamp time.Time
+}
+
+// Fetcher periodically fetches the interface loads from the border routers
+// and caches them.
+type Fetcher struct {
+	sources []string
+	maxAge  time.Duration
+	client  *http.Client
+
+	mtx   sync.RWMutex
+	loads map[common.IFIDType]entry
+}
+
+// New creates a new fetcher.
+func (cfg FetcherConf) New() *Fetcher {
+	client := cfg.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &Fetcher{
+		sources: cfg.Sources,
+		maxAge:  cfg.MaxAge,
+		client:  client,
+		loads:   make(map[common.IFIDType]entry),
+	}
+}
+
+// Name returns the tasks name.
+func (f *Fetcher) Name() string {
+	return "cs_loadinfo_fetcher"
+}
+
+// Run fetches the interface loads from all sources.
+func (f *Fetcher) Run(ctx context.Context) {
+	logger := log.FromCtx(ctx)
+	now := time.Now()
+	for _, src := range f.sources {
+		loads, err := f.fetch(ctx, src)
+		if err != nil {
+			logger.Info("[loadinfo.Fetcher] Unable to fetch interface loads", "src", src,
+				"err", err)
+			continue
+		}
+		f.mtx.Lock()
+		for _, l := range loads {
+			f.loads[l.IfID] = entry{load: *l, timestamp: now}
+		}
+		f.mtx.Unlock()
+	}
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	for ifid, e := range f.loads {
+		if now.Sub(e.timestamp) > f.maxAge {
+			delete(f.loads, ifid)
+		}
+	}
+}
+
+func (f *Fetcher) fetch(ctx context.Context, src string) ([]*seg.InterfaceLoad, error) {
+	req, err := http.NewRequest(http.MethodGet, src, nil)
+	if err != nil {
+		return nil, err
+	}
+	rsp, err := f.client.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer rsp.Body.Close()
+	if rsp.StatusCode != http.StatusOK {
+		return nil, serrors.New("unexpected status", "status", rsp.Status)
+	}
+	var loads []*seg.InterfaceLoad
+	if err := json.NewDecoder(rsp.Body).Decode(&loads); err != nil {
+		return nil, serrors.WrapStr("unable to decode interface loads", err)
+	}
+	return loads, nil
+}
+
+// Load returns the load of the given interface, or nil if there is no recent
+// load summary for it.
+func (f *Fetcher) Load(ifid common.IFIDType) *seg.InterfaceLoad {
+	f.mtx.RLock()
+	defer f.mtx.RUnlock()
+	e, ok := f.loads[ifid]
+	if !ok || time.Since(e.timestamp) > f.maxAge {
+		return nil
+	}
+	l := e.load
+	return &l
+}
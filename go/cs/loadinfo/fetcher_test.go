@@ -0,0 +1,52 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadinfo_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/scionproto/scion/go/cs/loadinfo"
+	"github.com/scionproto/scion/go/lib/ctrl/seg"
+)
+
+func TestFetcher(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"ifid": 1, "rate": 1000, "utilization": 10, "router_queue_fill": 5}]`)
+	}))
+	defer srv.Close()
+	broken := httptest.NewServer(http.NotFoundHandler())
+	defer broken.Close()
+
+	f := loadinfo.FetcherConf{
+		Sources: []string{broken.URL, srv.URL},
+		MaxAge:  time.Minute,
+	}.New()
+	assert.Nil(t, f.Load(1))
+	f.Run(context.Background())
+	assert.Equal(t, &seg.InterfaceLoad{IfID: 1, Rate: 1000, Utilization: 10, RouterQueueFill: 5},
+		f.Load(1))
+	assert.Nil(t, f.Load(2))
+
+	expired := loadinfo.FetcherConf{Sources: []string{srv.URL}}.New()
+	expired.Run(context.Background())
+	assert.Nil(t, expired.Load(1))
+}
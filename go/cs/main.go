@@ -39,7 +39,9 @@ import (
 	"github.com/scionproto/scion/go/cs/config"
 	"github.com/scionproto/scion/go/cs/handlers"
 	"github.com/scionproto/scion/go/cs/ifstate"
+	"github.com/scionproto/scion/go/cs/introspect"
 	"github.com/scionproto/scion/go/cs/keepalive"
+	"github.com/scionproto/scion/go/cs/loadinfo"
 	"github.com/scionproto/scion/go/cs/metrics"
 	"github.com/scionproto/scion/go/cs/onehop"
 	"github.com/scionproto/scion/go/cs/revocation"
@@ -280,7 +282,13 @@ func realMain() int {
 		},
 	))
 
-	segReqHandler := segreq.NewHandler(args)
+	segReqHandler := segreq.NewHandler(args, segreq.QuotaConfig{
+		IARate:      cfg.PS.Quota.IARate,
+		IABurst:     cfg.PS.Quota.IABurst,
+		HostRate:    cfg.PS.Quota.HostRate,
+		HostBurst:   cfg.PS.Quota.HostBurst,
+		NegativeTTL: cfg.PS.Quota.NegativeTTL.Duration,
+	})
 	msgr.AddHandler(infra.SegRequest, segReqHandler)
 	msgr.AddHandler(infra.SegReg, handlers.NewSegRegHandler(args))
 	if topo.Core() {
@@ -302,6 +310,9 @@ func realMain() int {
 	http.HandleFunc("/config", configHandler)
 	http.HandleFunc("/info", env.InfoHandler)
 	http.HandleFunc("/topology", itopo.TopologyHandler)
+	http.HandleFunc("/beacons", introspect.NewBeaconsHandler(beaconStore))
+	http.HandleFunc("/interfaces", introspect.NewInterfacesHandler(intfs))
+	http.HandleFunc("/segments", introspect.NewSegmentsHandler(pathDB))
 	cfg.Metrics.StartPrometheus()
 	go func() {
 		defer log.HandlePanic()
@@ -405,6 +416,9 @@ type periodicTasks struct {
 	revoker    *periodic.Runner
 	registrars segRegRunners
 
+	loadFetcher *loadinfo.Fetcher
+	loadInfo    *periodic.Runner
+
 	corePusher *periodic.Runner
 	reissuance *periodic.Runner
 
@@ -434,6 +448,7 @@ func (t *periodicTasks) Start() error {
 		return serrors.New("Unable to find topo address")
 	}
 
+	t.loadInfo = t.startLoadFetcher()
 	var err error
 	if t.registrars, err = t.startSegRegRunners(); err != nil {
 		return err
@@ -502,6 +517,31 @@ func (t *periodicTasks) startRevoker() (*periodic.Runner, error) {
 		cfg.BS.ExpiredCheckInterval.Duration), nil
 }
 
+// startLoadFetcher starts fetching the interface loads from the border
+// routers. If no sources are configured, no load information is attached to
+// beacons and nil is returned.
+func (t *periodicTasks) startLoadFetcher() *periodic.Runner {
+	if len(cfg.BS.Load.Sources) == 0 {
+		t.loadFetcher = nil
+		return nil
+	}
+	t.loadFetcher = loadinfo.FetcherConf{
+		Sources: cfg.BS.Load.Sources,
+		MaxAge:  cfg.BS.Load.MaxAge.Duration,
+	}.New()
+	return periodic.Start(t.loadFetcher, cfg.BS.Load.FetchInterval.Duration,
+		cfg.BS.Load.FetchInterval.Duration)
+}
+
+// loadProvider returns the provider for the interface loads that are attached
+// to beacons, or nil if load information is disabled.
+func (t *periodicTasks) loadProvider() beaconing.LoadProvider {
+	if t.loadFetcher == nil {
+		return nil
+	}
+	return t.loadFetcher
+}
+
 func (t *periodicTasks) startKeepaliveSender(a *net.UDPAddr) (*periodic.Runner, error) {
 	s := &keepalive.Sender{
 		Sender: &onehop.Sender{
@@ -543,6 +583,7 @@ func (t *periodicTasks) startOriginator(a *net.UDPAddr) (*periodic.Runner, error
 			MTU:           topo.MTU(),
 			Signer:        signer,
 			GetMaxExpTime: maxExpTimeFactory(t.store, beacon.PropPolicy),
+			LoadInfo:      t.loadProvider(),
 		},
 		Period: cfg.BS.OriginationInterval.Duration,
 	}.New()
@@ -579,6 +620,7 @@ func (t *periodicTasks) startPropagator(a *net.UDPAddr) (*periodic.Runner, error
 			MTU:           topo.MTU(),
 			Signer:        signer,
 			GetMaxExpTime: maxExpTimeFactory(t.store, beacon.PropPolicy),
+			LoadInfo:      t.loadProvider(),
 		},
 		Period: cfg.BS.PropagationInterval.Duration,
 	}.New()
@@ -631,6 +673,7 @@ func (t *periodicTasks) startRegistrar(topo topology.Topology, segType proto.Pat
 			MTU:           topo.MTU(),
 			Signer:        signer,
 			GetMaxExpTime: maxExpTimeFactory(t.store, policyType),
+			LoadInfo:      t.loadProvider(),
 		},
 	}.New()
 	if err != nil {
@@ -662,6 +705,7 @@ func (t *periodicTasks) Kill() {
 		return
 	}
 	t.registrars.Kill()
+	t.loadInfo.Kill()
 	t.revoker.Kill()
 	t.keepalive.Kill()
 	t.originator.Kill()
@@ -58,6 +58,9 @@ const (
 	ErrNotClassified = prom.ErrNotClassified
 	// ErrNoPath indicates no path is available to send a message.
 	ErrNoPath = "err_nopath"
+	// ErrRateLimited indicates a request was rejected because the requester
+	// exceeded its quota.
+	ErrRateLimited = "err_rate_limited"
 
 	// OkFiltered indicates beacon was filtered by policy.
 	OkFiltered = "ok_filtered"
@@ -34,6 +34,7 @@ func TestLabels(t *testing.T) {
 		metrics.RegistrarLabels{},
 		metrics.TypeOnlyLabel{},
 		metrics.OriginatorLabels{},
+		metrics.RequesterLabels{},
 	}
 	for _, test := range tests {
 		promtest.CheckLabelsStruct(t, test)
@@ -64,9 +64,32 @@ func (l RequestLabels) WithResult(result string) RequestLabels {
 	return l
 }
 
+// RequesterLabels contains the labels for the requests per requester.
+type RequesterLabels struct {
+	SrcIA  addr.IA
+	Result string
+}
+
+// Labels returns the labels.
+func (l RequesterLabels) Labels() []string {
+	return []string{"src_ia", "result"}
+}
+
+// Values returns the values.
+func (l RequesterLabels) Values() []string {
+	return []string{l.SrcIA.String(), l.Result}
+}
+
+// WithResult returns the labels with the modified result.
+func (l RequesterLabels) WithResult(result string) RequesterLabels {
+	l.Result = result
+	return l
+}
+
 // Request is for request metrics.
 type Request struct {
 	count       *prometheus.CounterVec
+	requesters  *prometheus.CounterVec
 	repliedSegs *prometheus.CounterVec
 	repliedRevs *prometheus.CounterVec
 }
@@ -77,6 +100,9 @@ func newRequests() Request {
 		count: prom.NewCounterVecWithLabels(PSNamespace, subsystem, "total",
 			"Number of segment requests total. \"result\" indicates the outcome.",
 			RequestLabels{}),
+		requesters: prom.NewCounterVecWithLabels(PSNamespace, subsystem, "requester_total",
+			"Number of segment requests per source ISD-AS. \"result\" indicates whether "+
+				"the request was admitted or rate limited.", RequesterLabels{}),
 		repliedSegs: prom.NewCounterVecWithLabels(PSNamespace, subsystem, "replied_segments_total",
 			"Number of segments in reply to segment requests.", RequestOkLabels{}),
 		repliedRevs: prom.NewCounterVecWithLabels(
@@ -94,6 +120,11 @@ func (r Request) Count(l RequestLabels) prometheus.Counter {
 	return r.count.WithLabelValues(l.Values()...)
 }
 
+// Requesters returns the counter for requests per requester.
+func (r Request) Requesters(l RequesterLabels) prometheus.Counter {
+	return r.requesters.WithLabelValues(l.Values()...)
+}
+
 // RepliedSegs returns the counter for the number of segments in a seg reply.
 func (r Request) RepliedSegs(l RequestOkLabels) prometheus.Counter {
 	return r.repliedSegs.WithLabelValues(l.Values()...)
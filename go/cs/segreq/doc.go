@@ -15,4 +15,8 @@
 // Package segreq contains everything that is needed to handle segment requests
 // in the path server. It relies on the segfetcher module and therefore has
 // many helper types to use the segfetcher module.
+//
+// The handler limits the rate of segment requests per source ISD-AS and per
+// host, and answers requests that recently did not result in any segments from
+// a negative cache.
 package segreq
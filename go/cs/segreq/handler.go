@@ -15,6 +15,7 @@
 package segreq
 
 import (
+	"context"
 	"time"
 
 	"github.com/scionproto/scion/go/cs/handlers"
@@ -33,9 +34,13 @@ import (
 type handler struct {
 	fetcher  *segfetcher.Fetcher
 	revCache revcache.RevCache
+	quota    *Quota
+	negative *NegativeCache
 }
 
-func NewHandler(args handlers.HandlerArgs) infra.Handler {
+// NewHandler creates a segment request handler that limits the requests
+// according to the quota configuration.
+func NewHandler(args handlers.HandlerArgs, quota QuotaConfig) infra.Handler {
 	core := args.TopoProvider.Get().Core()
 	localInfo := CreateLocalInfo(args, core)
 	args.PathDB = createPathDB(args.PathDB, localInfo)
@@ -53,6 +58,8 @@ func NewHandler(args handlers.HandlerArgs) infra.Handler {
 			LocalInfo:           localInfo,
 		}.New(),
 		revCache: args.RevCache,
+		quota:    NewQuota(quota),
+		negative: NewNegativeCache(quota.NegativeTTL),
 	}
 }
 
@@ -80,7 +87,21 @@ func (h *handler) Handle(request *infra.Request) *infra.HandlerResult {
 	}
 	sendAck := messenger.SendAckHelper(ctx, rw)
 
-	segs, err := h.fetcher.FetchSegs(ctx,
+	requester := RequesterFromAddr(request.Peer)
+	requesterLabels := metrics.RequesterLabels{SrcIA: requester.IA, Result: metrics.OkSuccess}
+	if first, err := h.quota.Admit(requester, time.Now()); err != nil {
+		if first {
+			logger.Info("[segReqHandler] Requester exceeded quota", "err", err,
+				"src_ia", requester.IA, "host", requester.Host)
+		}
+		sendAck(proto.Ack_ErrCode_retry, messenger.AckRetryRateLimited)
+		metrics.Requests.Requesters(requesterLabels.WithResult(metrics.ErrRateLimited)).Inc()
+		metrics.Requests.Count(labels.WithResult(metrics.ErrRateLimited)).Inc()
+		return infra.MetricsErrRateLimited
+	}
+	metrics.Requests.Requesters(requesterLabels).Inc()
+
+	segs, cached, err := h.fetchSegs(ctx,
 		segfetcher.Request{Src: segReq.SrcIA(), Dst: segReq.DstIA()})
 	if err != nil {
 		// TODO(lukedirtwalker): Define clearer the different errors that can
@@ -110,12 +131,30 @@ func (h *handler) Handle(request *infra.Request) *infra.HandlerResult {
 	}
 	logger.Debug("[segReqHandler] Replied with segments", "segs", len(reply.Recs.Recs))
 	labels = labels.WithResult(metrics.OkSuccess)
+	if cached {
+		labels = labels.WithResult(metrics.OkRequestCached)
+	}
 	metrics.Requests.Count(labels).Inc()
 	metrics.Requests.RepliedSegs(labels.RequestOkLabels).Add(float64(len(reply.Recs.Recs)))
 	metrics.Requests.RepliedRevs(labels.RequestOkLabels).Add(float64(len(reply.Recs.SRevInfos)))
 	return infra.MetricsResultOk
 }
 
+// fetchSegs fetches the segments for the request. Requests that recently did
+// not result in any segments are answered from the negative cache, which is
+// indicated by the returned boolean.
+func (h *handler) fetchSegs(ctx context.Context,
+	req segfetcher.Request) (segfetcher.Segments, bool, error) {
+
+	if cached, err := h.negative.Get(req, time.Now()); cached {
+		log.FromCtx(ctx).Debug("[segReqHandler] Answered from negative cache", "err", err)
+		return segfetcher.Segments{}, true, err
+	}
+	segs, err := h.fetcher.FetchSegs(ctx, req)
+	h.negative.Add(req, segs, err, time.Now())
+	return segs, false, err
+}
+
 func createValidator(args handlers.HandlerArgs, core bool) segfetcher.Validator {
 	base := BaseValidator{
 		CoreChecker: CoreChecker{Inspector: args.ASInspector},
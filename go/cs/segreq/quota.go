@@ -0,0 +1,270 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package segreq
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/infra/modules/segfetcher"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/snet"
+)
+
+// sweepInterval is the minimum interval between removing stale entries from
+// the limiters and the negative cache.
+const sweepInterval = time.Minute
+
+var (
+	// ErrIAQuota indicates that the source ISD-AS of a request exceeded its
+	// quota.
+	ErrIAQuota = serrors.New("ISD-AS quota exceeded")
+	// ErrHostQuota indicates that the host that sent a request exceeded its
+	// quota.
+	ErrHostQuota = serrors.New("host quota exceeded")
+)
+
+// QuotaConfig configures the segment request quotas.
+type QuotaConfig struct {
+	// IARate is the number of requests per second that are allowed per source
+	// ISD-AS. Zero disables the limit.
+	IARate int
+	// IABurst is the number of requests that a source ISD-AS can send in
+	// excess of the rate.
+	IABurst int
+	// HostRate is the number of requests per second that are allowed per
+	// host. Zero disables the limit.
+	HostRate int
+	// HostBurst is the number of requests that a host can send in excess of
+	// the rate.
+	HostBurst int
+	// NegativeTTL is the time for which requests that did not result in any
+	// segments are answered from cache. Zero disables negative caching.
+	NegativeTTL time.Duration
+}
+
+// Requester identifies the sender of a segment request.
+type Requester struct {
+	IA   addr.IA
+	Host string
+}
+
+// RequesterFromAddr returns the requester for the network address of the
+// sender of a request.
+func RequesterFromAddr(a net.Addr) Requester {
+	switch v := a.(type) {
+	case *snet.UDPAddr:
+		r := Requester{IA: v.IA}
+		if v.Host != nil {
+			r.Host = v.Host.IP.String()
+		}
+		return r
+	case nil:
+		return Requester{}
+	default:
+		return Requester{Host: a.String()}
+	}
+}
+
+// Quota limits the rate of segment requests per source ISD-AS and per host.
+// The limits are enforced with token buckets.
+type Quota struct {
+	ias   *limiter
+	hosts *limiter
+}
+
+// NewQuota creates the quota for the given configuration.
+func NewQuota(cfg QuotaConfig) *Quota {
+	return &Quota{
+		ias:   newLimiter(cfg.IARate, cfg.IABurst),
+		hosts: newLimiter(cfg.HostRate, cfg.HostBurst),
+	}
+}
+
+// Admit checks whether the request of the requester is within the quota and
+// consumes it. If it is not, either ErrIAQuota or ErrHostQuota is returned.
+// The returned boolean indicates whether this is the first request that
+// exceeds the quota after a request was admitted, which is useful to avoid
+// flooding the log.
+func (q *Quota) Admit(r Requester, now time.Time) (bool, error) {
+	// Only consume the ISD-AS quota if the host quota is not exceeded, such
+	// that a single host cannot exhaust the quota of its ISD-AS.
+	if ok, first := q.hosts.allow(r, now); !ok {
+		return first, ErrHostQuota
+	}
+	if ok, first := q.ias.allow(Requester{IA: r.IA}, now); !ok {
+		return first, ErrIAQuota
+	}
+	return false, nil
+}
+
+// limiter keeps a token bucket per requester.
+type limiter struct {
+	mu        sync.Mutex
+	rate      float64
+	burst     float64
+	buckets   map[Requester]*bucket
+	lastSweep time.Time
+}
+
+type bucket struct {
+	tokens  float64
+	last    time.Time
+	limited bool
+}
+
+func newLimiter(rate, burst int) *limiter {
+	if rate <= 0 {
+		return nil
+	}
+	if burst < 1 {
+		burst = 1
+	}
+	return &limiter{
+		rate:    float64(rate),
+		burst:   float64(burst),
+		buckets: make(map[Requester]*bucket),
+	}
+}
+
+// allow takes a token from the bucket of the requester. It returns false if
+// the bucket is empty. The second return value indicates whether this is the
+// first rejection after an admitted request. A nil limiter allows everything.
+func (l *limiter) allow(r Requester, now time.Time) (bool, bool) {
+	if l == nil {
+		return true, false
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.sweep(now)
+	b, ok := l.buckets[r]
+	if !ok {
+		b = &bucket{tokens: l.burst, last: now}
+		l.buckets[r] = b
+	}
+	if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens += elapsed.Seconds() * l.rate
+		if b.tokens > l.burst {
+			b.tokens = l.burst
+		}
+		b.last = now
+	}
+	if b.tokens < 1 {
+		first := !b.limited
+		b.limited = true
+		return false, first
+	}
+	b.tokens--
+	b.limited = false
+	return true, false
+}
+
+// sweep removes the buckets that are refilled, they are equivalent to a new
+// bucket.
+func (l *limiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < sweepInterval {
+		return
+	}
+	l.lastSweep = now
+	refill := time.Duration(l.burst / l.rate * float64(time.Second))
+	for r, b := range l.buckets {
+		if now.Sub(b.last) >= refill {
+			delete(l.buckets, r)
+		}
+	}
+}
+
+// NegativeCache caches the outcome of requests that did not result in any
+// segments, i.e., the requests that failed or resulted in an empty reply. A
+// nil cache caches nothing.
+type NegativeCache struct {
+	mu        sync.Mutex
+	ttl       time.Duration
+	entries   map[negativeKey]negativeEntry
+	lastSweep time.Time
+}
+
+type negativeKey struct {
+	src addr.IA
+	dst addr.IA
+}
+
+type negativeEntry struct {
+	err    error
+	expiry time.Time
+}
+
+// NewNegativeCache creates a cache that caches negative outcomes for the
+// given TTL. If the TTL is not positive, nil is returned.
+func NewNegativeCache(ttl time.Duration) *NegativeCache {
+	if ttl <= 0 {
+		return nil
+	}
+	return &NegativeCache{
+		ttl:     ttl,
+		entries: make(map[negativeKey]negativeEntry),
+	}
+}
+
+// Get indicates whether the outcome of the request is cached. If it is, the
+// returned error is the error of the request, and nil means the request
+// resulted in an empty reply.
+func (c *NegativeCache) Get(req segfetcher.Request, now time.Time) (bool, error) {
+	if c == nil {
+		return false, nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[negativeKey{src: req.Src, dst: req.Dst}]
+	if !ok || now.After(e.expiry) {
+		return false, nil
+	}
+	return true, e.err
+}
+
+// Add caches the outcome of the request if it is negative. Timeouts and
+// cancellations are not cached, because they are likely caused by the
+// requester.
+func (c *NegativeCache) Add(req segfetcher.Request, segs segfetcher.Segments, err error,
+	now time.Time) {
+
+	if c == nil {
+		return
+	}
+	if err == nil && (len(segs.Up) > 0 || len(segs.Core) > 0 || len(segs.Down) > 0) {
+		return
+	}
+	if err != nil && (serrors.IsTimeout(err) || errors.Is(err, context.Canceled)) {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if now.Sub(c.lastSweep) >= sweepInterval {
+		c.lastSweep = now
+		for k, e := range c.entries {
+			if now.After(e.expiry) {
+				delete(c.entries, k)
+			}
+		}
+	}
+	c.entries[negativeKey{src: req.Src, dst: req.Dst}] = negativeEntry{
+		err:    err,
+		expiry: now.Add(c.ttl),
+	}
+}
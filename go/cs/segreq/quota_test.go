@@ -0,0 +1,148 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package segreq_test
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/scionproto/scion/go/cs/segreq"
+	"github.com/scionproto/scion/go/lib/ctrl/seg"
+	"github.com/scionproto/scion/go/lib/infra/modules/segfetcher"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/lib/xtest"
+)
+
+func TestRequesterFromAddr(t *testing.T) {
+	ia := xtest.MustParseIA("1-ff00:0:110")
+	r := segreq.RequesterFromAddr(&snet.UDPAddr{
+		IA:   ia,
+		Host: &net.UDPAddr{IP: net.ParseIP("127.0.0.1"), Port: 40000},
+	})
+	assert.Equal(t, segreq.Requester{IA: ia, Host: "127.0.0.1"}, r)
+	assert.Equal(t, segreq.Requester{}, segreq.RequesterFromAddr(nil))
+}
+
+func TestQuotaAdmit(t *testing.T) {
+	ia110, ia111 := xtest.MustParseIA("1-ff00:0:110"), xtest.MustParseIA("1-ff00:0:111")
+	hostA := segreq.Requester{IA: ia110, Host: "10.0.0.1"}
+	hostB := segreq.Requester{IA: ia110, Host: "10.0.0.2"}
+	hostC := segreq.Requester{IA: ia111, Host: "10.0.0.1"}
+	now := time.Now()
+
+	t.Run("disabled quota admits everything", func(t *testing.T) {
+		q := segreq.NewQuota(segreq.QuotaConfig{})
+		for i := 0; i < 100; i++ {
+			_, err := q.Admit(hostA, now)
+			assert.NoError(t, err)
+		}
+	})
+	t.Run("host quota", func(t *testing.T) {
+		q := segreq.NewQuota(segreq.QuotaConfig{HostRate: 1, HostBurst: 2})
+		for i := 0; i < 2; i++ {
+			_, err := q.Admit(hostA, now)
+			assert.NoError(t, err)
+		}
+		first, err := q.Admit(hostA, now)
+		assert.Equal(t, segreq.ErrHostQuota, err)
+		assert.True(t, first)
+		first, err = q.Admit(hostA, now)
+		assert.Equal(t, segreq.ErrHostQuota, err)
+		assert.False(t, first)
+		// Other hosts have their own quota.
+		_, err = q.Admit(hostB, now)
+		assert.NoError(t, err)
+		// The bucket refills over time.
+		_, err = q.Admit(hostA, now.Add(time.Second))
+		assert.NoError(t, err)
+	})
+	t.Run("ISD-AS quota", func(t *testing.T) {
+		q := segreq.NewQuota(segreq.QuotaConfig{IARate: 1, IABurst: 2, HostRate: 10,
+			HostBurst: 10})
+		_, err := q.Admit(hostA, now)
+		assert.NoError(t, err)
+		_, err = q.Admit(hostB, now)
+		assert.NoError(t, err)
+		_, err = q.Admit(hostB, now)
+		assert.Equal(t, segreq.ErrIAQuota, err)
+		// Other ISD-ASes have their own quota.
+		_, err = q.Admit(hostC, now)
+		assert.NoError(t, err)
+	})
+	t.Run("rejected host does not consume ISD-AS quota", func(t *testing.T) {
+		q := segreq.NewQuota(segreq.QuotaConfig{IARate: 1, IABurst: 2, HostRate: 1,
+			HostBurst: 1})
+		_, err := q.Admit(hostA, now)
+		assert.NoError(t, err)
+		for i := 0; i < 5; i++ {
+			_, err = q.Admit(hostA, now)
+			assert.Equal(t, segreq.ErrHostQuota, err)
+		}
+		_, err = q.Admit(hostB, now)
+		assert.NoError(t, err)
+	})
+}
+
+func TestNegativeCache(t *testing.T) {
+	req := segfetcher.Request{
+		Src: xtest.MustParseIA("1-ff00:0:110"),
+		Dst: xtest.MustParseIA("2-ff00:0:210"),
+	}
+	testErr := serrors.New("test error")
+	now := time.Now()
+
+	t.Run("nil cache caches nothing", func(t *testing.T) {
+		c := segreq.NewNegativeCache(0)
+		assert.Nil(t, c)
+		c.Add(req, segfetcher.Segments{}, nil, now)
+		cached, _ := c.Get(req, now)
+		assert.False(t, cached)
+	})
+	t.Run("empty result is cached until the TTL expires", func(t *testing.T) {
+		c := segreq.NewNegativeCache(time.Second)
+		c.Add(req, segfetcher.Segments{}, nil, now)
+		cached, err := c.Get(req, now)
+		assert.True(t, cached)
+		assert.NoError(t, err)
+		cached, _ = c.Get(segfetcher.Request{Src: req.Dst, Dst: req.Src}, now)
+		assert.False(t, cached)
+		cached, _ = c.Get(req, now.Add(2*time.Second))
+		assert.False(t, cached)
+	})
+	t.Run("error is cached", func(t *testing.T) {
+		c := segreq.NewNegativeCache(time.Second)
+		c.Add(req, segfetcher.Segments{}, testErr, now)
+		cached, err := c.Get(req, now)
+		assert.True(t, cached)
+		assert.Equal(t, testErr, err)
+	})
+	t.Run("timeout is not cached", func(t *testing.T) {
+		c := segreq.NewNegativeCache(time.Second)
+		c.Add(req, segfetcher.Segments{}, serrors.Wrap(testErr, context.DeadlineExceeded), now)
+		cached, _ := c.Get(req, now)
+		assert.False(t, cached)
+	})
+	t.Run("segments are not cached", func(t *testing.T) {
+		c := segreq.NewNegativeCache(time.Second)
+		c.Add(req, segfetcher.Segments{Up: seg.Segments{&seg.PathSegment{}}}, nil, now)
+		cached, _ := c.Get(req, now)
+		assert.False(t, cached)
+	})
+}
@@ -26,6 +26,7 @@ import (
 
 	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/ctrl/seg"
 	"github.com/scionproto/scion/go/lib/infra/modules/combinator"
 	"github.com/scionproto/scion/go/lib/infra/modules/segfetcher"
 	"github.com/scionproto/scion/go/lib/serrors"
@@ -94,6 +95,12 @@ func (r *Router) translate(comb *combinator.Path, dst addr.IA) (path, error) {
 		mtu:        comb.Mtu,
 		expiry:     comb.ComputeExpTime(),
 	}
+	if len(comb.Load) > 0 {
+		p.load = append(p.load, comb.Load...)
+	}
+	if len(comb.StaticInfo) > 0 {
+		p.staticInfo = append(p.staticInfo, comb.StaticInfo...)
+	}
 	for _, intf := range comb.Interfaces {
 		p.interfaces = append(p.interfaces, pathInterface{ia: intf.IA(), ifid: intf.ID()})
 	}
@@ -107,6 +114,8 @@ type path struct {
 	mtu        uint16
 	expiry     time.Time
 	dst        addr.IA
+	load       []seg.InterfaceLoad
+	staticInfo []seg.InterfaceStaticInfo
 }
 
 func (p path) Fingerprint() snet.PathFingerprint {
@@ -165,6 +174,20 @@ func (p path) Expiry() time.Time {
 	return p.expiry
 }
 
+func (p path) Load() []seg.InterfaceLoad {
+	if p.load == nil {
+		return nil
+	}
+	return append(p.load[:0:0], p.load...)
+}
+
+func (p path) StaticInfo() []seg.InterfaceStaticInfo {
+	if p.staticInfo == nil {
+		return nil
+	}
+	return append(p.staticInfo[:0:0], p.staticInfo...)
+}
+
 func (p path) Copy() snet.Path {
 	return path{
 		interfaces: append(p.interfaces[:0:0], p.interfaces...),
@@ -172,6 +195,8 @@ func (p path) Copy() snet.Path {
 		spath:      p.Path(),           // creates copy
 		mtu:        p.mtu,
 		expiry:     p.expiry,
+		load:       p.Load(),       // creates copy
+		staticInfo: p.StaticInfo(), // creates copy
 	}
 }
 
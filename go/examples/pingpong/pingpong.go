@@ -43,6 +43,7 @@ import (
 	"github.com/scionproto/scion/go/lib/snet"
 	"github.com/scionproto/scion/go/lib/snet/squic"
 	"github.com/scionproto/scion/go/lib/sock/reliable"
+	"github.com/scionproto/scion/go/lib/topology/overlay"
 )
 
 const (
@@ -62,6 +63,8 @@ var (
 
 	count = flag.Int("count", 0,
 		fmt.Sprintf("Number of pings, between 0 and %d; a count of 0 means infinity", MaxPings))
+	direct = flag.String("direct", "",
+		"Bypass the dispatcher, binding to a port in the given range (e.g., 31000-32767)")
 	dispatcher = flag.String("dispatcher", "", "Path to dispatcher socket")
 	file       = flag.String("file", "",
 		"File containing the data to send, optional to test larger data (only client)")
@@ -217,15 +220,10 @@ func (c *client) run() {
 	c.setupPath()
 	defer c.Close()
 
-	ds := reliable.NewDispatcher(*dispatcher)
-	sciondConn, err := sd.NewService(*sciondAddr).Connect(context.Background())
+	network, err := newNetwork()
 	if err != nil {
 		LogFatal("Unable to initialize SCION network", "err", err)
 	}
-	network := snet.NewNetworkWithPR(local.IA, ds, sd.Querier{
-		Connector: sciondConn,
-		IA:        local.IA,
-	}, sd.RevHandler{Connector: sciondConn})
 
 	// Connect to remote address. Note that currently the SCION library
 	// does not support automatic binding to local addresses, so the local
@@ -334,21 +332,33 @@ func (c client) read() {
 	}
 }
 
+// newNetwork creates the SCION network, which uses the dispatcher unless the
+// direct flag is set.
+func newNetwork() (*snet.SCIONNetwork, error) {
+	sciondConn, err := sd.NewService(*sciondAddr).Connect(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	querier := sd.Querier{Connector: sciondConn, IA: local.IA}
+	revHandler := sd.RevHandler{Connector: sciondConn}
+	if *direct != "" {
+		ports, err := overlay.PortRangeFromString(*direct)
+		if err != nil {
+			return nil, err
+		}
+		return snet.NewDirectNetworkWithPR(local.IA, ports, querier, revHandler), nil
+	}
+	ds := reliable.NewDispatcher(*dispatcher)
+	return snet.NewNetworkWithPR(local.IA, ds, querier, revHandler), nil
+}
+
 type server struct {
 }
 
 // run listens on a SCION address and replies to any ping message.
 // On any error, the server exits.
 func (s server) run() {
-	ds := reliable.NewDispatcher(*dispatcher)
-	sciondConn, err := sd.NewService(*sciondAddr).Connect(context.Background())
-	if err != nil {
-		LogFatal("Unable to initialize SCION network", "err", err)
-	}
-	network := snet.NewNetworkWithPR(local.IA, ds, &sd.Querier{
-		Connector: sciondConn,
-		IA:        local.IA,
-	}, sd.RevHandler{Connector: sciondConn})
+	network, err := newNetwork()
 	if err != nil {
 		LogFatal("Unable to initialize SCION network", "err", err)
 	}
@@ -15,6 +15,7 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"os"
 	"time"
@@ -28,6 +29,9 @@ const (
 	cmd  = "./bin/pingpong"
 )
 
+var direct = flag.String("direct", "",
+	"Run pingpong without the dispatcher, on ports in the given range (e.g., 31000-32767)")
+
 func main() {
 	os.Exit(realMain())
 }
@@ -40,6 +44,9 @@ func realMain() int {
 	defer log.HandlePanic()
 	defer log.Flush()
 	cmnArgs := []string{"-log.console", "debug", "-sciond", integration.SCIOND}
+	if *direct != "" {
+		cmnArgs = append(cmnArgs, "-direct", *direct)
+	}
 	clientArgs := []string{"-mode", "client", "-count", "1",
 		"-local", integration.SrcAddrPattern + ":0",
 		"-remote", integration.DstAddrPattern + ":" + integration.ServerPortReplace}
@@ -0,0 +1,115 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dispatcher
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"sort"
+	"sync/atomic"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/log"
+)
+
+// Registration describes the registration of an application, and the
+// packets the dispatcher routed to it.
+type Registration struct {
+	IA      addr.IA `json:"ia"`
+	Address net.IP  `json:"address"`
+	Port    int     `json:"port"`
+	// SVC is the SVC address of the registration, empty if there is none.
+	SVC        string    `json:"svc,omitempty"`
+	Registered time.Time `json:"registered"`
+	// Age is the age of the connection, in seconds.
+	Age float64 `json:"age"`
+	// Pkts and Bytes are the packets and bytes delivered to the application.
+	Pkts  uint64 `json:"pkts"`
+	Bytes uint64 `json:"bytes"`
+	// RingDrops are the packets dropped because the application's ingress
+	// ring was full.
+	RingDrops uint64 `json:"ring_drops"`
+	// RateDrops are the packets dropped because they exceeded the rate of
+	// the registration.
+	RateDrops uint64 `json:"rate_drops"`
+	// Queued are the packets waiting in the ingress ring to be read by the
+	// application.
+	Queued int `json:"queued"`
+	// RingSize, Rate and Burst are the quota of the registration. A rate of
+	// 0 is unlimited.
+	RingSize int `json:"ring_size"`
+	Rate     int `json:"rate"`
+	Burst    int `json:"burst"`
+	// SCMP are the delivered SCMP messages, CongWarns the congestion warnings
+	// among them.
+	SCMP      uint64 `json:"scmp"`
+	CongWarns uint64 `json:"cong_warns"`
+}
+
+// Registrations returns all current registrations, sorted by IA and port.
+func (as *Server) Registrations(now time.Time) []Registration {
+	as.entriesMtx.Lock()
+	regs := make([]Registration, 0, len(as.entries))
+	for e := range as.entries {
+		regs = append(regs, e.registration(now))
+	}
+	as.entriesMtx.Unlock()
+	sort.Slice(regs, func(i, j int) bool {
+		if regs[i].IA != regs[j].IA {
+			return regs[i].IA.IAInt() < regs[j].IA.IAInt()
+		}
+		return regs[i].Port < regs[j].Port
+	})
+	return regs
+}
+
+// RegistrationsHandler serves the current registrations as JSON.
+func (as *Server) RegistrationsHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "    ")
+	if err := enc.Encode(as.Registrations(time.Now())); err != nil {
+		log.Error("Unable to encode registrations", "err", err)
+	}
+}
+
+func (e *TableEntry) registration(now time.Time) Registration {
+	reg := Registration{
+		IA:         e.ia,
+		Registered: e.registered,
+		Age:        now.Sub(e.registered).Seconds(),
+		Pkts:       atomic.LoadUint64(&e.stats.pkts),
+		Bytes:      atomic.LoadUint64(&e.stats.bytes),
+		RingDrops:  atomic.LoadUint64(&e.stats.ringDrops),
+		RateDrops:  atomic.LoadUint64(&e.stats.rateDrops),
+		Queued:     e.appIngressRing.Readable(),
+		RingSize:   e.quota.ringSize(),
+		Rate:       e.quota.Rate,
+		SCMP:       atomic.LoadUint64(&e.stats.scmp),
+		CongWarns:  atomic.LoadUint64(&e.stats.congWarns),
+	}
+	if e.quota.Rate > 0 {
+		reg.Burst = e.quota.burst()
+	}
+	if a := e.ref.UDPAddr(); a != nil {
+		reg.Address, reg.Port = a.IP, a.Port
+	}
+	if svc := e.ref.SVCAddr(); svc != addr.SvcNone {
+		reg.SVC = svc.String()
+	}
+	return reg
+}
@@ -0,0 +1,102 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dispatcher
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/godispatcher/internal/respool"
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/l4"
+	"github.com/scionproto/scion/go/lib/scmp"
+	"github.com/scionproto/scion/go/lib/topology/overlay"
+	"github.com/scionproto/scion/go/lib/xtest"
+)
+
+func TestRegistrations(t *testing.T) {
+	as := &Server{
+		routingTable: NewIATable(1024, 65535, overlay.PortRange{}),
+		entries:      make(map[*TableEntry]struct{}),
+	}
+	ia := xtest.MustParseIA("1-ff00:0:110")
+	ip := net.IP{127, 0, 0, 1}
+	cs, _, err := as.Register(nil, ia, &net.UDPAddr{IP: ip, Port: 30252}, addr.SvcCS)
+	require.NoError(t, err)
+	app, _, err := as.Register(nil, ia, &net.UDPAddr{IP: ip, Port: 40000}, addr.SvcNone)
+	require.NoError(t, err)
+
+	udp := respool.GetPacket()
+	udp.Info.L4 = &l4.UDP{DstPort: 40000}
+	dst := &UDPDestination{IP: ip, Port: 40000}
+	deliver(as, ia, dst, udp)
+	cw := respool.GetPacket()
+	cw.Info.L4 = &scmp.Hdr{Class: scmp.C_General, Type: scmp.T_G_BasicCongWarn}
+	deliver(as, ia, dst, cw)
+
+	now := time.Now()
+	regs := as.Registrations(now)
+	require.Len(t, regs, 2)
+	assert.Equal(t, addr.SvcCS.String(), regs[0].SVC)
+	assert.Equal(t, 30252, regs[0].Port)
+	assert.Zero(t, regs[0].Pkts)
+	assert.Equal(t, ia, regs[1].IA)
+	assert.True(t, ip.Equal(regs[1].Address))
+	assert.Equal(t, 40000, regs[1].Port)
+	assert.Empty(t, regs[1].SVC)
+	assert.Equal(t, uint64(2), regs[1].Pkts)
+	assert.Equal(t, uint64(1), regs[1].SCMP)
+	assert.Equal(t, uint64(1), regs[1].CongWarns)
+	assert.True(t, regs[1].Age >= 0)
+
+	require.NoError(t, app.Close())
+	regs = as.Registrations(now)
+	require.Len(t, regs, 1)
+	assert.Equal(t, 30252, regs[0].Port)
+	require.NoError(t, cs.Close())
+	assert.Empty(t, as.Registrations(now))
+}
+
+func TestRegisterDirectPorts(t *testing.T) {
+	direct := overlay.DefaultDirectPorts
+	as := &Server{
+		routingTable: NewIATable(int(direct.Min)-1, int(direct.Max)+1, direct),
+		entries:      make(map[*TableEntry]struct{}),
+	}
+	ia := xtest.MustParseIA("1-ff00:0:110")
+	ip := net.IP{127, 0, 0, 1}
+	_, _, err := as.Register(nil, ia, &net.UDPAddr{IP: ip, Port: int(direct.Min)}, addr.SvcNone)
+	assert.Error(t, err, "registration in the direct port range")
+
+	var ports []uint16
+	for i := 0; i < 2; i++ {
+		conn, port, err := as.Register(nil, ia, &net.UDPAddr{IP: ip}, addr.SvcNone)
+		require.NoError(t, err)
+		defer conn.Close()
+		ports = append(ports, port)
+	}
+	assert.Equal(t, []uint16{direct.Min - 1, direct.Max + 1}, ports,
+		"allocated ports skip the direct port range")
+}
+
+func deliver(as *Server, ia addr.IA, d Destination, pkt *respool.Packet) {
+	pkt.Info.DstIA = ia
+	dp := &NetToRingDataplane{RoutingTable: as.routingTable}
+	d.Send(dp, pkt)
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/scionproto/scion/go/lib/scmp"
 	"github.com/scionproto/scion/go/lib/serrors"
 	"github.com/scionproto/scion/go/lib/spkt"
+	"github.com/scionproto/scion/go/lib/topology/overlay"
 )
 
 // OverflowLoggingInterval is the minimum amount of time that needs to
@@ -33,11 +34,21 @@ type Server struct {
 	routingTable *IATable
 	ipv4Conn     net.PacketConn
 	ipv6Conn     net.PacketConn
+	// Quotas are the quotas of the registrations. They must not be modified
+	// once the server serves registrations.
+	Quotas Quotas
+	// entries contains the entries of all current registrations, for
+	// introspection.
+	entriesMtx sync.Mutex
+	entries    map[*TableEntry]struct{}
 }
 
 // NewServer creates new instance of Server. Internally, it opens the dispatcher ports
 // for both IPv4 and IPv6. Returns error if the ports can't be opened.
-func NewServer(address string) (*Server, error) {
+//
+// Applications cannot register on the ports in directPorts, which must match
+// the direct port range of the border routers.
+func NewServer(address string, directPorts overlay.PortRange) (*Server, error) {
 	metaLogger := &throttledMetaLogger{
 		Logger:      log.Root(),
 		MinInterval: OverflowLoggingInterval,
@@ -53,9 +64,10 @@ func NewServer(address string) (*Server, error) {
 	}
 
 	return &Server{
-		routingTable: NewIATable(1024, 65535),
+		routingTable: NewIATable(1024, 65535, directPorts),
 		ipv4Conn:     ipv4Conn,
 		ipv6Conn:     ipv6Conn,
+		entries:      make(map[*TableEntry]struct{}),
 	}, nil
 }
 
@@ -86,11 +98,13 @@ func (as *Server) Serve() error {
 func (as *Server) Register(ctx context.Context, ia addr.IA, address *net.UDPAddr,
 	svc addr.HostSVC) (net.PacketConn, uint16, error) {
 
-	tableEntry := newTableEntry()
+	tableEntry := newTableEntry(ia, as.Quotas.Lookup(address.Port, svc))
 	ref, err := as.routingTable.Register(ia, address, nil, svc, tableEntry)
 	if err != nil {
 		return nil, 0, err
 	}
+	tableEntry.ref = ref
+	as.addEntry(tableEntry)
 	var ovConn net.PacketConn
 	if address.IP.To4() == nil {
 		ovConn = as.ipv6Conn
@@ -101,10 +115,25 @@ func (as *Server) Register(ctx context.Context, ia addr.IA, address *net.UDPAddr
 		conn:         ovConn,
 		ring:         tableEntry.appIngressRing,
 		regReference: ref,
+		close: func() {
+			as.removeEntry(tableEntry)
+		},
 	}
 	return conn, uint16(ref.UDPAddr().Port), nil
 }
 
+func (as *Server) addEntry(e *TableEntry) {
+	as.entriesMtx.Lock()
+	defer as.entriesMtx.Unlock()
+	as.entries[e] = struct{}{}
+}
+
+func (as *Server) removeEntry(e *TableEntry) {
+	as.entriesMtx.Lock()
+	defer as.entriesMtx.Unlock()
+	delete(as.entries, e)
+}
+
 func (as *Server) Close() {
 	as.ipv4Conn.Close()
 	as.ipv6Conn.Close()
@@ -118,6 +147,8 @@ type Conn struct {
 	ring *ringbuf.Ring
 	// regReference is the reference to the registration in the routing table.
 	regReference registration.RegReference
+	// close is called when the connection is closed.
+	close func()
 }
 
 func (ac *Conn) WriteTo(p []byte, addr net.Addr) (int, error) {
@@ -161,6 +192,9 @@ func (ac *Conn) Read() *respool.Packet {
 }
 
 func (ac *Conn) Close() error {
+	if ac.close != nil {
+		ac.close()
+	}
 	ac.regReference.Free()
 	ac.ring.Close()
 	return nil
@@ -16,6 +16,7 @@ package dispatcher
 
 import (
 	"net"
+	"time"
 
 	"github.com/scionproto/scion/go/godispatcher/internal/metrics"
 	"github.com/scionproto/scion/go/godispatcher/internal/respool"
@@ -258,14 +259,25 @@ func (d *SCMPAppDestination) Send(dp *NetToRingDataplane, pkt *respool.Packet) {
 }
 
 // sendPacket puts pkt on the routing entry's ring buffer, and releases the
-// reference to pkt.
+// reference to pkt. Packets in excess of the entry's quota are dropped.
 func sendPacket(routingEntry *TableEntry, pkt *respool.Packet) {
+	if !routingEntry.limiter.allow(time.Now()) {
+		routingEntry.stats.limited()
+		pkt.Free()
+		return
+	}
 	// Move packet reference to other goroutine.
+	// Read the packet info before handing off the reference.
+	n := pkt.Len()
+	scmpHdr, _ := pkt.Info.L4.(*scmp.Hdr)
 	count, _ := routingEntry.appIngressRing.Write(ringbuf.EntryList{pkt}, false)
 	if count <= 0 {
+		routingEntry.stats.dropped()
 		// Release buffer if we couldn't transmit it to the other goroutine.
 		pkt.Free()
+		return
 	}
+	routingEntry.stats.delivered(n, scmpHdr)
 }
 
 var _ Destination = (*SCMPHandlerDestination)(nil)
@@ -0,0 +1,120 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dispatcher
+
+import (
+	"sync"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/addr"
+)
+
+// DefaultRingSize is the number of packets buffered for an application
+// without an explicit quota.
+const DefaultRingSize = 128
+
+// Quota limits the packets the dispatcher buffers for, and delivers to, a
+// single registration. Packets in excess of the quota are dropped, without
+// affecting the other registrations.
+type Quota struct {
+	// RingSize is the number of packets buffered for the application. If 0,
+	// DefaultRingSize is used.
+	RingSize int
+	// Rate is the number of packets per second delivered to the application.
+	// If 0, the rate is not limited.
+	Rate int
+	// Burst is the number of packets delivered at once in excess of the rate.
+	// If 0, it is equal to the rate.
+	Burst int
+}
+
+func (q Quota) ringSize() int {
+	if q.RingSize <= 0 {
+		return DefaultRingSize
+	}
+	return q.RingSize
+}
+
+func (q Quota) burst() int {
+	if q.Burst <= 0 {
+		return q.Rate
+	}
+	return q.Burst
+}
+
+// Quotas assigns the quotas to registrations.
+type Quotas struct {
+	// Default is the quota of registrations without a more specific quota.
+	Default Quota
+	// Ports contains the quotas of registrations on specific ports. They
+	// only apply if the application requests the port explicitly, and take
+	// precedence over the quotas of SVC addresses.
+	Ports map[int]Quota
+	// SVCs contains the quotas of registrations for SVC addresses.
+	SVCs map[addr.HostSVC]Quota
+}
+
+// Lookup returns the quota of a registration on port for svc.
+func (q *Quotas) Lookup(port int, svc addr.HostSVC) Quota {
+	if quota, ok := q.Ports[port]; ok && port != 0 {
+		return quota
+	}
+	if quota, ok := q.SVCs[svc]; ok && svc != addr.SvcNone {
+		return quota
+	}
+	return q.Default
+}
+
+// limiter is a token bucket that limits the packet rate of a registration.
+// A nil limiter does not limit the rate.
+type limiter struct {
+	mtx    sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newLimiter(q Quota) *limiter {
+	if q.Rate <= 0 {
+		return nil
+	}
+	burst := float64(q.burst())
+	return &limiter{rate: float64(q.Rate), burst: burst, tokens: burst}
+}
+
+// allow returns whether a packet arriving at now is within the rate, and
+// consumes a token if it is.
+func (l *limiter) allow(now time.Time) bool {
+	if l == nil {
+		return true
+	}
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	if !l.last.IsZero() && now.After(l.last) {
+		l.tokens += now.Sub(l.last).Seconds() * l.rate
+		if l.tokens > l.burst {
+			l.tokens = l.burst
+		}
+	}
+	if now.After(l.last) {
+		l.last = now
+	}
+	if l.tokens < 1 {
+		return false
+	}
+	l.tokens--
+	return true
+}
@@ -0,0 +1,100 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dispatcher
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/godispatcher/internal/respool"
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/l4"
+	"github.com/scionproto/scion/go/lib/topology/overlay"
+	"github.com/scionproto/scion/go/lib/xtest"
+)
+
+func TestQuotasLookup(t *testing.T) {
+	q := Quotas{
+		Default: Quota{Rate: 100},
+		Ports:   map[int]Quota{30252: {RingSize: 1024}},
+		SVCs:    map[addr.HostSVC]Quota{addr.SvcCS: {Rate: 10}},
+	}
+	assert.Equal(t, Quota{RingSize: 1024}, q.Lookup(30252, addr.SvcCS))
+	assert.Equal(t, Quota{Rate: 10}, q.Lookup(30255, addr.SvcCS))
+	assert.Equal(t, Quota{Rate: 100}, q.Lookup(0, addr.SvcNone))
+	assert.Equal(t, DefaultRingSize, q.Lookup(0, addr.SvcNone).ringSize())
+}
+
+func TestLimiter(t *testing.T) {
+	assert.True(t, (*limiter)(nil).allow(time.Now()))
+
+	l := newLimiter(Quota{Rate: 10, Burst: 2})
+	now := time.Now()
+	assert.True(t, l.allow(now))
+	assert.True(t, l.allow(now))
+	assert.False(t, l.allow(now))
+	// A token is added every 100ms.
+	now = now.Add(150 * time.Millisecond)
+	assert.True(t, l.allow(now))
+	assert.False(t, l.allow(now))
+	// The tokens never exceed the burst.
+	now = now.Add(time.Hour)
+	assert.True(t, l.allow(now))
+	assert.True(t, l.allow(now))
+	assert.False(t, l.allow(now))
+}
+
+func TestQuotaIsolation(t *testing.T) {
+	as := &Server{
+		routingTable: NewIATable(1024, 65535, overlay.PortRange{}),
+		entries:      make(map[*TableEntry]struct{}),
+		Quotas: Quotas{
+			Default: Quota{RingSize: 4},
+			Ports:   map[int]Quota{40001: {RingSize: 4, Rate: 1, Burst: 2}},
+		},
+	}
+	ia := xtest.MustParseIA("1-ff00:0:110")
+	ip := net.IP{127, 0, 0, 1}
+	_, _, err := as.Register(nil, ia, &net.UDPAddr{IP: ip, Port: 40000}, addr.SvcNone)
+	require.NoError(t, err)
+	_, _, err = as.Register(nil, ia, &net.UDPAddr{IP: ip, Port: 40001}, addr.SvcNone)
+	require.NoError(t, err)
+
+	// Flood both applications, neither of which reads its packets.
+	for i := 0; i < 10; i++ {
+		for _, port := range []int{40000, 40001} {
+			pkt := respool.GetPacket()
+			pkt.Info.L4 = &l4.UDP{DstPort: uint16(port)}
+			deliver(as, ia, &UDPDestination{IP: ip, Port: port}, pkt)
+		}
+	}
+
+	regs := as.Registrations(time.Now())
+	require.Len(t, regs, 2)
+	assert.Equal(t, uint64(4), regs[0].Pkts)
+	assert.Equal(t, uint64(6), regs[0].RingDrops)
+	assert.Zero(t, regs[0].RateDrops)
+	assert.Equal(t, 4, regs[0].Queued)
+	assert.Equal(t, uint64(2), regs[1].Pkts)
+	assert.Zero(t, regs[1].RingDrops)
+	assert.Equal(t, uint64(8), regs[1].RateDrops)
+	assert.Equal(t, 2, regs[1].Queued)
+	assert.Equal(t, 1, regs[1].Rate)
+	assert.Equal(t, 2, regs[1].Burst)
+}
@@ -16,32 +16,90 @@ package dispatcher
 
 import (
 	"net"
+	"sync/atomic"
+	"time"
 
 	"github.com/scionproto/scion/go/godispatcher/internal/registration"
 	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/ringbuf"
+	"github.com/scionproto/scion/go/lib/scmp"
+	"github.com/scionproto/scion/go/lib/topology/overlay"
 )
 
 type TableEntry struct {
 	appIngressRing *ringbuf.Ring
+	// ia and registered describe the registration, they are set when the
+	// entry is created.
+	ia         addr.IA
+	registered time.Time
+	// ref is the reference to the registration, it is set once the
+	// registration succeeded.
+	ref registration.RegReference
+	// quota limits the packets delivered to the application, limiter
+	// enforces its rate.
+	quota   Quota
+	limiter *limiter
+	stats   entryStats
 }
 
-func newTableEntry() *TableEntry {
+func newTableEntry(ia addr.IA, quota Quota) *TableEntry {
 	// Construct application ingress ring buffer
-	appIngressRing := ringbuf.New(128, nil, "net_to_app_ring")
+	appIngressRing := ringbuf.New(quota.ringSize(), nil, "net_to_app_ring")
 	return &TableEntry{
 		appIngressRing: appIngressRing,
+		ia:             ia,
+		registered:     time.Now(),
+		quota:          quota,
+		limiter:        newLimiter(quota),
 	}
 }
 
+// entryStats counts the packets routed to a registration. The counters are
+// updated atomically.
+type entryStats struct {
+	pkts      uint64
+	bytes     uint64
+	ringDrops uint64
+	rateDrops uint64
+	scmp      uint64
+	congWarns uint64
+}
+
+// delivered accounts a packet that was put on the ingress ring.
+func (s *entryStats) delivered(n int, scmpHdr *scmp.Hdr) {
+	atomic.AddUint64(&s.pkts, 1)
+	atomic.AddUint64(&s.bytes, uint64(n))
+	if scmpHdr == nil {
+		return
+	}
+	atomic.AddUint64(&s.scmp, 1)
+	if scmpHdr.Class == scmp.C_General && (scmpHdr.Type == scmp.T_G_BasicCongWarn ||
+		scmpHdr.Type == scmp.T_G_StochasticCongWarn) {
+		atomic.AddUint64(&s.congWarns, 1)
+	}
+}
+
+// dropped accounts a packet that did not fit the ingress ring.
+func (s *entryStats) dropped() {
+	atomic.AddUint64(&s.ringDrops, 1)
+}
+
+// limited accounts a packet that exceeded the rate of the registration.
+func (s *entryStats) limited() {
+	atomic.AddUint64(&s.rateDrops, 1)
+}
+
 // IATable is a type-safe convenience wrapper around a generic routing table.
 type IATable struct {
 	registration.IATable
 }
 
-func NewIATable(minPort, maxPort int) *IATable {
+// NewIATable creates a routing table that allocates ports between minPort and
+// maxPort. The ports in directPorts are neither allocated nor accepted for
+// registrations, as the border routers deliver the packets to them directly.
+func NewIATable(minPort, maxPort int, directPorts overlay.PortRange) *IATable {
 	return &IATable{
-		IATable: registration.NewIATable(minPort, maxPort),
+		IATable: registration.NewReservedIATable(minPort, maxPort, directPorts),
 	}
 }
 
@@ -20,12 +20,14 @@ import (
 	"fmt"
 	"io"
 
+	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/config"
 	"github.com/scionproto/scion/go/lib/env"
 	"github.com/scionproto/scion/go/lib/log"
 	"github.com/scionproto/scion/go/lib/serrors"
 	"github.com/scionproto/scion/go/lib/sock/reliable"
 	"github.com/scionproto/scion/go/lib/topology"
+	"github.com/scionproto/scion/go/lib/topology/overlay"
 	"github.com/scionproto/scion/go/lib/util"
 )
 
@@ -51,6 +53,83 @@ type Dispatcher struct {
 	// DeleteSocket specifies whether the dispatcher should delete the
 	// socket file prior to attempting to create a new one.
 	DeleteSocket bool `toml:"delete_socket,omitempty"`
+	// AppQuota is the default quota of the application registrations.
+	AppQuota AppQuota `toml:"app_quota,omitempty"`
+	// AppQuotaOverrides override the default quota for the registrations on
+	// specific ports or SVC addresses.
+	AppQuotaOverrides []AppQuotaOverride `toml:"app_quota_override,omitempty"`
+	// DirectPorts is the range of ports of the applications that do not use
+	// the dispatcher. It must match the direct port range of the border
+	// routers, which deliver the packets to these ports directly. The
+	// dispatcher does not allocate ports in the range, and rejects
+	// registrations on them. (default "")
+	DirectPorts overlay.PortRange `toml:"direct_ports,omitempty"`
+}
+
+// AppQuota limits the packets the dispatcher buffers for, and delivers to, an
+// application registration.
+type AppQuota struct {
+	// RingSize is the number of packets buffered for the application.
+	// (default 128)
+	RingSize int `toml:"ring_size,omitempty"`
+	// Rate is the number of packets per second delivered to the application,
+	// 0 is unlimited. (default 0)
+	Rate int `toml:"rate,omitempty"`
+	// Burst is the number of packets delivered at once in excess of the rate.
+	// (default rate)
+	Burst int `toml:"burst,omitempty"`
+}
+
+func (q *AppQuota) Validate() error {
+	if q.RingSize < 0 || q.Rate < 0 || q.Burst < 0 {
+		return serrors.New("quota must not be negative", "ring_size", q.RingSize,
+			"rate", q.Rate, "burst", q.Burst)
+	}
+	return nil
+}
+
+// AppQuotaOverride is the quota of the registrations on a port or SVC address.
+// Exactly one of Port and SVC must be set. Unset limits are taken from the
+// default quota.
+type AppQuotaOverride struct {
+	// Port is the port the application registers on.
+	Port int `toml:"port,omitempty"`
+	// SVC is the SVC address the application registers for, e.g., CS.
+	SVC      string `toml:"svc,omitempty"`
+	RingSize int    `toml:"ring_size,omitempty"`
+	Rate     int    `toml:"rate,omitempty"`
+	Burst    int    `toml:"burst,omitempty"`
+}
+
+func (o *AppQuotaOverride) Validate() error {
+	if (o.Port == 0) == (o.SVC == "") {
+		return serrors.New("exactly one of port and svc must be set", "port", o.Port,
+			"svc", o.SVC)
+	}
+	if o.Port < 0 || o.Port > 65535 {
+		return serrors.New("invalid port", "port", o.Port)
+	}
+	if o.SVC != "" && addr.HostSVCFromString(o.SVC) == addr.SvcNone {
+		return serrors.New("invalid SVC address", "svc", o.SVC)
+	}
+	q := AppQuota{RingSize: o.RingSize, Rate: o.Rate, Burst: o.Burst}
+	return q.Validate()
+}
+
+// Quota returns the quota of the override, with the unset limits taken from
+// def.
+func (o *AppQuotaOverride) Quota(def AppQuota) AppQuota {
+	q := AppQuota{RingSize: o.RingSize, Rate: o.Rate, Burst: o.Burst}
+	if q.RingSize == 0 {
+		q.RingSize = def.RingSize
+	}
+	if q.Rate == 0 {
+		q.Rate = def.Rate
+	}
+	if q.Burst == 0 {
+		q.Burst = def.Burst
+	}
+	return q
 }
 
 func (cfg *Config) InitDefaults() {
@@ -69,6 +148,14 @@ func (cfg *Config) Validate() error {
 	if cfg.Dispatcher.ID == "" {
 		return serrors.New("id must be set")
 	}
+	if err := cfg.Dispatcher.AppQuota.Validate(); err != nil {
+		return serrors.WrapStr("invalid app_quota", err)
+	}
+	for i := range cfg.Dispatcher.AppQuotaOverrides {
+		if err := cfg.Dispatcher.AppQuotaOverrides[i].Validate(); err != nil {
+			return serrors.WrapStr("invalid app_quota_override", err, "index", i)
+		}
+	}
 	return config.ValidateAll(&cfg.Logging, &cfg.Metrics)
 }
 
@@ -44,6 +44,8 @@ func InitTestConfig(cfg *Config) {
 	envtest.InitTest(nil, &cfg.Metrics, nil, nil)
 	logtest.InitTestLogging(&cfg.Logging)
 	cfg.Dispatcher.DeleteSocket = true
+	cfg.Dispatcher.AppQuota.RingSize = 1
+	cfg.Dispatcher.AppQuota.Rate = 1
 }
 
 func CheckTestConfig(t *testing.T, cfg *Config, id string) {
@@ -54,4 +56,36 @@ func CheckTestConfig(t *testing.T, cfg *Config, id string) {
 	assert.Equal(t, reliable.DefaultDispSocketFileMode, int(cfg.Dispatcher.SocketFileMode))
 	assert.Equal(t, topology.EndhostPort, cfg.Dispatcher.OverlayPort)
 	assert.False(t, cfg.Dispatcher.DeleteSocket)
+	assert.Equal(t, 128, cfg.Dispatcher.AppQuota.RingSize)
+	assert.Zero(t, cfg.Dispatcher.AppQuota.Rate)
+	assert.Zero(t, cfg.Dispatcher.AppQuota.Burst)
+	assert.Empty(t, cfg.Dispatcher.AppQuotaOverrides)
+}
+
+func TestAppQuotaOverride(t *testing.T) {
+	def := AppQuota{RingSize: 128, Rate: 1000}
+	tests := map[string]struct {
+		Override AppQuotaOverride
+		Valid    bool
+	}{
+		"port":         {Override: AppQuotaOverride{Port: 30252, RingSize: 1024}, Valid: true},
+		"svc":          {Override: AppQuotaOverride{SVC: "CS", Rate: 10}, Valid: true},
+		"neither":      {Override: AppQuotaOverride{RingSize: 1024}},
+		"both":         {Override: AppQuotaOverride{Port: 30252, SVC: "CS"}},
+		"invalid svc":  {Override: AppQuotaOverride{SVC: "XX"}},
+		"invalid port": {Override: AppQuotaOverride{Port: 70000}},
+		"negative":     {Override: AppQuotaOverride{Port: 30252, Rate: -1}},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := test.Override.Validate()
+			if test.Valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+	o := AppQuotaOverride{Port: 30252, RingSize: 1024}
+	assert.Equal(t, AppQuota{RingSize: 1024, Rate: 1000}, o.Quota(def))
 }
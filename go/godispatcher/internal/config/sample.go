@@ -32,4 +32,30 @@ underlay_port = 30041
 
 # Remove the socket file (if it exists) on start. (default false)
 delete_socket = false
+
+# The range of ports of the applications that do not use the dispatcher, of
+# the form min-max. It must match the direct port range of the border routers.
+# Applications cannot register with the dispatcher on ports in the range.
+# (default "")
+direct_ports = ""
+
+# The default quota of the applications. Packets in excess of the quota are
+# dropped, without affecting the other applications.
+[dispatcher.app_quota]
+# Number of packets buffered for the application. (default 128)
+ring_size = 128
+
+# Number of packets per second delivered to the application, 0 is unlimited.
+# (default 0)
+rate = 0
+
+# Number of packets delivered at once in excess of the rate. (default rate)
+burst = 0
+
+# Overrides of the default quota for the applications registered on a port
+# or for an SVC address. Exactly one of port and svc must be set, unset
+# limits are taken from the default quota.
+# [[dispatcher.app_quota_override]]
+# svc = "CS"
+# ring_size = 1024
 `
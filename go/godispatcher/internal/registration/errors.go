@@ -26,4 +26,5 @@ const (
 	ErrNilAddress         common.ErrMsg = "nil address"
 	ErrSvcNone            common.ErrMsg = "svc none"
 	ErrNoPorts            common.ErrMsg = "no free ports"
+	ErrReservedPort       common.ErrMsg = "reserved port"
 )
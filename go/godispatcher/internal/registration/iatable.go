@@ -20,6 +20,7 @@ import (
 
 	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/topology/overlay"
 )
 
 const (
@@ -102,23 +103,32 @@ type IATable interface {
 //
 // If minPort is <= 0 or maxPort is > 65535, the function panics.
 func NewIATable(minPort, maxPort int) IATable {
-	return newIATable(minPort, maxPort)
+	return newIATable(minPort, maxPort, overlay.PortRange{})
+}
+
+// NewReservedIATable creates a new UDP/IP port registration table, like
+// NewIATable. Ports in reserved are never allocated, and registrations on a
+// port in reserved are rejected.
+func NewReservedIATable(minPort, maxPort int, reserved overlay.PortRange) IATable {
+	return newIATable(minPort, maxPort, reserved)
 }
 
 var _ IATable = (*iaTable)(nil)
 
 type iaTable struct {
-	mtx     sync.RWMutex
-	ia      map[addr.IA]*Table
-	minPort int
-	maxPort int
+	mtx      sync.RWMutex
+	ia       map[addr.IA]*Table
+	minPort  int
+	maxPort  int
+	reserved overlay.PortRange
 }
 
-func newIATable(minPort, maxPort int) *iaTable {
+func newIATable(minPort, maxPort int, reserved overlay.PortRange) *iaTable {
 	return &iaTable{
-		ia:      make(map[addr.IA]*Table),
-		minPort: minPort,
-		maxPort: maxPort,
+		ia:       make(map[addr.IA]*Table),
+		minPort:  minPort,
+		maxPort:  maxPort,
+		reserved: reserved,
 	}
 }
 
@@ -135,7 +145,7 @@ func (t *iaTable) Register(ia addr.IA, public *net.UDPAddr, bind net.IP, svc add
 	}
 	table, ok := t.ia[ia]
 	if !ok {
-		table = NewTable(t.minPort, t.maxPort)
+		table = NewReservedTable(t.minPort, t.maxPort, t.reserved)
 		t.ia[ia] = table
 	}
 	reference, err := table.Register(public, bind, svc, value)
@@ -19,6 +19,7 @@ import (
 
 	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/topology/overlay"
 )
 
 // Table manages the UDP/IP port registrations for a single AS.
@@ -39,8 +40,14 @@ type Table struct {
 }
 
 func NewTable(minPort, maxPort int) *Table {
+	return NewReservedTable(minPort, maxPort, overlay.PortRange{})
+}
+
+// NewReservedTable creates a table that does not allocate or accept the ports
+// in reserved.
+func NewReservedTable(minPort, maxPort int, reserved overlay.PortRange) *Table {
 	return &Table{
-		udpPortTable: NewUDPPortTable(minPort, maxPort),
+		udpPortTable: NewReservedUDPPortTable(minPort, maxPort, reserved),
 		svcTable:     NewSVCTable(),
 		scmpTable:    NewSCMPTable(),
 	}
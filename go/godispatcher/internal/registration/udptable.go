@@ -19,21 +19,32 @@ import (
 	"net"
 
 	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/topology/overlay"
 )
 
 // UDPPortTable stores port allocations for UDP/IPv4 and UDP/IPv6 sockets.
 //
 // Additionally, it allocates ports dynamically if the requested port is 0.
+// Ports in the reserved range are neither allocated nor accepted.
 type UDPPortTable struct {
 	v4PortTable map[int]IPTable
 	v6PortTable map[int]IPTable
 	allocator   *UDPPortAllocator
+	reserved    overlay.PortRange
 }
 
 func NewUDPPortTable(minPort, maxPort int) *UDPPortTable {
 	return NewUDPPortTableFromMap(minPort, maxPort, make(map[int]IPTable), make(map[int]IPTable))
 }
 
+// NewReservedUDPPortTable creates a table that does not allocate or accept
+// the ports in reserved.
+func NewReservedUDPPortTable(minPort, maxPort int, reserved overlay.PortRange) *UDPPortTable {
+	t := NewUDPPortTable(minPort, maxPort)
+	t.reserved = reserved
+	return t
+}
+
 func NewUDPPortTableFromMap(minPort, maxPort int, v4, v6 map[int]IPTable) *UDPPortTable {
 	return &UDPPortTable{
 		v4PortTable: v4,
@@ -79,6 +90,10 @@ func (t *UDPPortTable) Insert(address *net.UDPAddr, value interface{}) (*net.UDP
 	if value == nil {
 		return nil, common.NewBasicError(ErrNoValue, nil)
 	}
+	if t.reserved.Contains(address.Port) {
+		return nil, common.NewBasicError(ErrReservedPort, nil, "address", address,
+			"reserved", t.reserved)
+	}
 	address = copyUDPAddr(address)
 	newAddress, err := t.computeAddressWithPort(address)
 	if err != nil {
@@ -207,7 +222,7 @@ func (a *UDPPortAllocator) Allocate(ip net.IP, t *UDPPortTable) (int, error) {
 		if a.nextPort == a.maxPort+1 {
 			a.nextPort = a.minPort
 		}
-		if !t.overlapsWith(candidate) {
+		if !t.reserved.Contains(candidate.Port) && !t.overlapsWith(candidate) {
 			return candidate.Port, nil
 		}
 	}
@@ -20,6 +20,8 @@ import (
 	"testing"
 
 	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/scionproto/scion/go/lib/topology/overlay"
 )
 
 var docIPv6AddressStr = "2001:db8::1"
@@ -263,6 +265,31 @@ func TestUDPPortTableRemove(t *testing.T) {
 	})
 }
 
+func TestUDPPortTableReserved(t *testing.T) {
+	reserved := overlay.PortRange{Min: 1001, Max: 1002}
+	value := "test value"
+	Convey("Given a table with reserved ports", t, func() {
+		table := NewReservedUDPPortTable(1000, 1003, reserved)
+		Convey("Inserting an address with a reserved port fails", func() {
+			address, err := table.Insert(&net.UDPAddr{IP: net.IP{10, 2, 3, 4}, Port: 1001},
+				value)
+			SoMsg("err", err, ShouldNotBeNil)
+			SoMsg("address", address, ShouldBeNil)
+		})
+		Convey("Allocated ports skip the reserved ports", func() {
+			var ports []int
+			for i := 0; i < 2; i++ {
+				address, err := table.Insert(&net.UDPAddr{IP: net.IP{10, 2, 3, 4}}, value)
+				SoMsg("err", err, ShouldBeNil)
+				ports = append(ports, address.Port)
+			}
+			SoMsg("ports", ports, ShouldResemble, []int{1000, 1003})
+			_, err := table.Insert(&net.UDPAddr{IP: net.IP{10, 2, 3, 4}}, value)
+			SoMsg("err", err, ShouldNotBeNil)
+		})
+	})
+}
+
 func TestUDPPortAllocator(t *testing.T) {
 	address := net.IP{10, 2, 3, 4}
 	value := "test value"
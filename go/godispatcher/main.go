@@ -26,8 +26,10 @@ import (
 
 	"github.com/BurntSushi/toml"
 
+	"github.com/scionproto/scion/go/godispatcher/dispatcher"
 	"github.com/scionproto/scion/go/godispatcher/internal/config"
 	"github.com/scionproto/scion/go/godispatcher/network"
+	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/common"
 	"github.com/scionproto/scion/go/lib/env"
 	"github.com/scionproto/scion/go/lib/fatal"
@@ -139,11 +141,34 @@ func RunDispatcher(deleteSocketFlag bool, applicationSocket string, socketFileMo
 		OverlaySocket:     fmt.Sprintf(":%d", overlayPort),
 		ApplicationSocket: applicationSocket,
 		SocketFileMode:    socketFileMode,
+		Mux:               http.DefaultServeMux,
+		Quotas:            appQuotas(&cfg.Dispatcher),
+		DirectPorts:       cfg.Dispatcher.DirectPorts,
 	}
 	log.Debug("Dispatcher starting", "appSocket", applicationSocket, "overlayPort", overlayPort)
 	return dispatcher.ListenAndServe()
 }
 
+// appQuotas returns the quotas of the application registrations.
+func appQuotas(cfg *config.Dispatcher) dispatcher.Quotas {
+	quota := func(q config.AppQuota) dispatcher.Quota {
+		return dispatcher.Quota{RingSize: q.RingSize, Rate: q.Rate, Burst: q.Burst}
+	}
+	quotas := dispatcher.Quotas{
+		Default: quota(cfg.AppQuota),
+		Ports:   make(map[int]dispatcher.Quota),
+		SVCs:    make(map[addr.HostSVC]dispatcher.Quota),
+	}
+	for _, o := range cfg.AppQuotaOverrides {
+		if o.Port != 0 {
+			quotas.Ports[o.Port] = quota(o.Quota(cfg.AppQuota))
+		} else {
+			quotas.SVCs[addr.HostSVCFromString(o.SVC)] = quota(o.Quota(cfg.AppQuota))
+		}
+	}
+	return quotas
+}
+
 func deleteSocket(socket string) error {
 	if _, err := os.Stat(socket); err != nil {
 		// File does not exist, or we can't read it, nothing to delete
@@ -15,26 +15,40 @@
 package network
 
 import (
+	"net/http"
 	"os"
 
 	"github.com/scionproto/scion/go/godispatcher/dispatcher"
 	"github.com/scionproto/scion/go/lib/common"
 	"github.com/scionproto/scion/go/lib/log"
 	"github.com/scionproto/scion/go/lib/sock/reliable"
+	"github.com/scionproto/scion/go/lib/topology/overlay"
 )
 
 type Dispatcher struct {
 	OverlaySocket     string
 	ApplicationSocket string
 	SocketFileMode    os.FileMode
+	// Quotas are the quotas of the application registrations.
+	Quotas dispatcher.Quotas
+	// DirectPorts is the direct port range of the border routers. The
+	// applications cannot register on ports in the range.
+	DirectPorts overlay.PortRange
+	// Mux is the HTTP mux the introspection endpoints are registered on. If
+	// nil, the endpoints are not served.
+	Mux *http.ServeMux
 }
 
 func (d *Dispatcher) ListenAndServe() error {
-	dispServer, err := dispatcher.NewServer(d.OverlaySocket)
+	dispServer, err := dispatcher.NewServer(d.OverlaySocket, d.DirectPorts)
 	if err != nil {
 		return err
 	}
 	defer dispServer.Close()
+	dispServer.Quotas = d.Quotas
+	if d.Mux != nil {
+		d.Mux.HandleFunc("/registrations", dispServer.RegistrationsHandler)
+	}
 
 	dispServerConn, err := reliable.Listen(d.ApplicationSocket)
 	if err != nil {
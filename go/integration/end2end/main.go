@@ -36,6 +36,7 @@ import (
 	"github.com/scionproto/scion/go/lib/snet"
 	"github.com/scionproto/scion/go/lib/sock/reliable"
 	"github.com/scionproto/scion/go/lib/topology"
+	"github.com/scionproto/scion/go/lib/topology/overlay"
 	"github.com/scionproto/scion/go/lib/tracing"
 	"github.com/scionproto/scion/go/lib/util"
 )
@@ -48,6 +49,10 @@ const (
 var (
 	remote  snet.UDPAddr
 	timeout = &util.DurWrap{Duration: 2 * time.Second}
+	direct  string
+	// directPorts is the port range of the applications that do not use the
+	// dispatcher. If empty, the dispatcher is used.
+	directPorts overlay.PortRange
 )
 
 func main() {
@@ -77,9 +82,23 @@ func realMain() int {
 func addFlags() {
 	flag.Var(&remote, "remote", "(Mandatory for clients) address to connect to")
 	flag.Var(timeout, "timeout", "The timeout for each attempt")
+	flag.StringVar(&direct, "direct", "",
+		"Bypass the dispatcher, binding to a port in the given range (e.g., 31000-32767)")
+}
+
+// newDispatcher returns the dispatcher the application registers with.
+func newDispatcher() reliable.Dispatcher {
+	if !directPorts.Empty() {
+		return &snet.DirectDispatcher{Ports: directPorts}
+	}
+	return reliable.NewDispatcher("")
 }
 
 func validateFlags() {
+	var err error
+	if directPorts, err = overlay.PortRangeFromString(direct); err != nil {
+		integration.LogFatal("Invalid direct port range", "err", err)
+	}
 	if integration.Mode == integration.ModeClient {
 		if remote.Host == nil {
 			integration.LogFatal("Missing remote address")
@@ -98,7 +117,7 @@ type server struct {
 
 func (s server) run() {
 	connFactory := &snet.DefaultPacketDispatcherService{
-		Dispatcher: reliable.NewDispatcher(""),
+		Dispatcher: newDispatcher(),
 		SCMPHandler: snet.NewSCMPHandler(
 			sciond.RevHandler{Connector: integration.SDConn()},
 		),
@@ -153,7 +172,7 @@ type client struct {
 
 func (c client) run() int {
 	connFactory := &snet.DefaultPacketDispatcherService{
-		Dispatcher: reliable.NewDispatcher(""),
+		Dispatcher: newDispatcher(),
 		SCMPHandler: snet.NewSCMPHandler(
 			sciond.RevHandler{Connector: integration.SDConn()},
 		),
@@ -205,6 +224,9 @@ func (c client) ping(ctx context.Context, n int) error {
 			IP:   remote.Host.IP,
 			Port: topology.EndhostPort,
 		}
+		if directPorts.Contains(remote.Host.Port) {
+			remote.NextHop.Port = remote.Host.Port
+		}
 	}
 	var debugID [common.ExtnFirstLineLen]byte
 	// API guarantees return values are ok
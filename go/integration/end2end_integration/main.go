@@ -38,6 +38,7 @@ var (
 	subset   string
 	attempts int
 	runAll   bool
+	direct   string
 	timeout  = &util.DurWrap{Duration: 5 * time.Second}
 )
 
@@ -67,6 +68,10 @@ func realMain() int {
 		"-sciond", integration.SCIOND,
 		"-local", integration.DstAddrPattern + ":0",
 	}
+	if direct != "" {
+		clientArgs = append(clientArgs, "-direct", direct)
+		serverArgs = append(serverArgs, "-direct", direct)
+	}
 	in := integration.NewBinaryIntegration(name, cmd, clientArgs, serverArgs)
 	pairs, err := getPairs()
 	if err != nil {
@@ -87,6 +92,8 @@ func addFlags() {
 	flag.Var(timeout, "timeout", "The timeout for each attempt")
 	flag.StringVar(&subset, "subset", "all", "Subset of pairs to run (all|core-core|"+
 		"noncore-localcore|noncore-core|noncore-noncore)")
+	flag.StringVar(&direct, "direct", "", "Run the end2end applications without the dispatcher, "+
+		"on ports in the given range (e.g., 31000-32767)")
 }
 
 // runTests runs the end2end tests for all pairs. In case of an error the
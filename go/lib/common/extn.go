@@ -27,6 +27,7 @@ var (
 	ExtnSCMPType                = ExtnType{HopByHopClass, 0}
 	ExtnOneHopPathType          = ExtnType{HopByHopClass, 1}
 	ExtnSIBRAType               = ExtnType{HopByHopClass, 2}
+	ExtnINTType                 = ExtnType{HopByHopClass, 3} // In-band network telemetry
 	ExtnPathTransType           = ExtnType{End2EndClass, 0}
 	ExtnPathProbeType           = ExtnType{End2EndClass, 1}
 	ExtnSCIONPacketSecurityType = ExtnType{End2EndClass, 2}
@@ -44,6 +45,8 @@ func (e ExtnType) String() string {
 		return "OneHopPath"
 	case ExtnSIBRAType:
 		return "SIBRA"
+	case ExtnINTType:
+		return "INT"
 	case ExtnPathTransType:
 		return "PathTrans"
 	case ExtnPathProbeType:
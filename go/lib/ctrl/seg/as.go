@@ -38,6 +38,8 @@ type ASEntry struct {
 		RoutingPolicy common.RawBytes    `capnp:"-"` // Not supported yet
 		Sibra         common.RawBytes    `capnp:"-"` // Not supported yet
 		HiddenPathSeg *HiddenPathSegExtn `capnp:"hiddenPathSeg"`
+		LoadInfo      *LoadInfoExtn      `capnp:"loadInfo"`
+		StaticInfo    *StaticInfoExtn    `capnp:"staticInfo"`
 	}
 }
 
@@ -82,7 +84,14 @@ func (ase *ASEntry) ProtoId() proto.ProtoIdType {
 }
 
 func (ase *ASEntry) String() string {
-	return fmt.Sprintf("%s Trc: %d Cert: %d Ifid size: %d Hops: %d MTU: %d Hidden: %v",
+	s := fmt.Sprintf("%s Trc: %d Cert: %d Ifid size: %d Hops: %d MTU: %d Hidden: %v",
 		ase.IA(), ase.TrcVer, ase.CertVer, ase.IfIDSize, len(ase.HopEntries),
 		ase.MTU, ase.Exts.HiddenPathSeg)
+	if ase.Exts.LoadInfo != nil {
+		s += fmt.Sprintf(" Load: %v", ase.Exts.LoadInfo)
+	}
+	if ase.Exts.StaticInfo != nil {
+		s += fmt.Sprintf(" StaticInfo: %v", ase.Exts.StaticInfo)
+	}
+	return s
 }
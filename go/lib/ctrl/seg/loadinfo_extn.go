@@ -0,0 +1,96 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// This file contains the Go representation of the load information segment
+// extension. It carries the utilization and queue pressure of the interfaces
+// of an AS entry, as exported by the border routers of that AS.
+
+package seg
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/proto"
+)
+
+var _ proto.Cerealizable = (*LoadInfoExtn)(nil)
+
+type LoadInfoExtn struct {
+	Set        bool
+	Interfaces []*InterfaceLoad
+}
+
+func NewLoadInfoExtn(interfaces []*InterfaceLoad) *LoadInfoExtn {
+	return &LoadInfoExtn{Set: true, Interfaces: interfaces}
+}
+
+// Get returns the load of the given interface, or nil if the extension does
+// not contain information about it.
+func (liExt *LoadInfoExtn) Get(ifid common.IFIDType) *InterfaceLoad {
+	if liExt == nil {
+		return nil
+	}
+	for _, l := range liExt.Interfaces {
+		if l != nil && l.IfID == ifid {
+			return l
+		}
+	}
+	return nil
+}
+
+func (liExt *LoadInfoExtn) ProtoId() proto.ProtoIdType {
+	return proto.LoadInfoExtn_TypeID
+}
+
+func (liExt *LoadInfoExtn) String() string {
+	if liExt == nil {
+		return fmt.Sprintf("%v", false)
+	}
+	s := make([]string, 0, len(liExt.Interfaces))
+	for _, l := range liExt.Interfaces {
+		s = append(s, l.String())
+	}
+	return fmt.Sprintf("[%s]", strings.Join(s, " "))
+}
+
+var _ proto.Cerealizable = (*InterfaceLoad)(nil)
+
+// InterfaceLoad is the load summary of a single interface.
+type InterfaceLoad struct {
+	IfID common.IFIDType `capnp:"ifID" json:"ifid"`
+	// Rate is the egress rate in bits per second.
+	Rate uint64 `json:"rate"`
+	// Utilization is the egress rate relative to the link bandwidth in
+	// percent. It is 0 if the bandwidth of the link is unknown.
+	Utilization uint8 `json:"utilization"`
+	// RouterQueueFill is the fill level of the fullest egress queue of the
+	// router in percent. The queues are shared by all interfaces of the
+	// router, so it is the same for all interfaces of a router and indicates
+	// router-wide pressure, not the pressure on this interface.
+	RouterQueueFill uint8 `json:"router_queue_fill"`
+}
+
+func (l *InterfaceLoad) ProtoId() proto.ProtoIdType {
+	return proto.InterfaceLoad_TypeID
+}
+
+func (l *InterfaceLoad) String() string {
+	if l == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("%d: %dbps %d%% router queue %d%%", l.IfID, l.Rate, l.Utilization,
+		l.RouterQueueFill)
+}
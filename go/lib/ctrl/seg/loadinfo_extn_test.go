@@ -0,0 +1,58 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package seg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/spath"
+)
+
+func TestASEntryLoadInfoRoundTrip(t *testing.T) {
+	entry := &ASEntry{
+		RawIA:    as110.IAInt(),
+		MTU:      1500,
+		IfIDSize: 12,
+		HopEntries: []*HopEntry{
+			{
+				RemoteOutIF: 23,
+				RawOutIA:    as111.IAInt(),
+				RawHopField: make(common.RawBytes, spath.HopFieldLength),
+			},
+		},
+	}
+	entry.Exts.LoadInfo = NewLoadInfoExtn([]*InterfaceLoad{
+		{IfID: 1, Rate: 12000000, Utilization: 12, RouterQueueFill: 3},
+		{IfID: 2, Rate: 5000, RouterQueueFill: 90},
+	})
+	raw, err := entry.Pack()
+	require.NoError(t, err)
+	parsed, err := NewASEntryFromRaw(raw)
+	require.NoError(t, err)
+	assert.Equal(t, entry.Exts.LoadInfo, parsed.Exts.LoadInfo)
+	assert.Equal(t, uint8(90), parsed.Exts.LoadInfo.Get(2).RouterQueueFill)
+	assert.Nil(t, parsed.Exts.LoadInfo.Get(3))
+
+	entry.Exts.LoadInfo = nil
+	raw, err = entry.Pack()
+	require.NoError(t, err)
+	parsed, err = NewASEntryFromRaw(raw)
+	require.NoError(t, err)
+	assert.Nil(t, parsed.Exts.LoadInfo)
+}
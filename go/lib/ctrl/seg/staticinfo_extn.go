@@ -0,0 +1,131 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// This file contains the Go representation of the static info segment
+// extension. It carries the static metadata of the interfaces of an AS entry,
+// i.e., latency, capacity, location and physical link type, as configured in
+// the topology of that AS.
+
+package seg
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/proto"
+)
+
+var _ proto.Cerealizable = (*StaticInfoExtn)(nil)
+
+type StaticInfoExtn struct {
+	Set        bool
+	Interfaces []*InterfaceStaticInfo
+}
+
+func NewStaticInfoExtn(interfaces []*InterfaceStaticInfo) *StaticInfoExtn {
+	return &StaticInfoExtn{Set: true, Interfaces: interfaces}
+}
+
+// Get returns the static info of the given interface, or nil if the extension
+// does not contain information about it.
+func (siExt *StaticInfoExtn) Get(ifid common.IFIDType) *InterfaceStaticInfo {
+	if siExt == nil {
+		return nil
+	}
+	for _, i := range siExt.Interfaces {
+		if i != nil && i.IfID == ifid {
+			return i
+		}
+	}
+	return nil
+}
+
+func (siExt *StaticInfoExtn) ProtoId() proto.ProtoIdType {
+	return proto.StaticInfoExtn_TypeID
+}
+
+func (siExt *StaticInfoExtn) String() string {
+	if siExt == nil {
+		return fmt.Sprintf("%v", false)
+	}
+	s := make([]string, 0, len(siExt.Interfaces))
+	for _, i := range siExt.Interfaces {
+		s = append(s, i.String())
+	}
+	return fmt.Sprintf("[%s]", strings.Join(s, " "))
+}
+
+var _ proto.Cerealizable = (*InterfaceStaticInfo)(nil)
+
+// InterfaceStaticInfo is the static metadata of a single interface. Zero
+// values indicate that the information is not available.
+type InterfaceStaticInfo struct {
+	IfID common.IFIDType `capnp:"ifID" json:"ifid"`
+	// Latency is the latency of the inter-AS link in microseconds.
+	Latency uint32 `json:"latency"`
+	// InternalLatency is the latency between the interface and the internal
+	// network of the AS in microseconds.
+	InternalLatency uint32 `json:"internal_latency"`
+	// Bandwidth is the capacity of the inter-AS link in kbit/s.
+	Bandwidth uint64 `json:"bandwidth"`
+	// LinkType is the physical type of the inter-AS link.
+	LinkType proto.PhysicalLinkType `json:"link_type"`
+	// Geo is the location of the interface.
+	Geo *GeoCoordinates `json:"geo,omitempty"`
+}
+
+// LinkLatency returns the latency of the inter-AS link.
+func (i *InterfaceStaticInfo) LinkLatency() time.Duration {
+	return time.Duration(i.Latency) * time.Microsecond
+}
+
+// IntraLatency returns the latency between the interface and the internal
+// network of the AS.
+func (i *InterfaceStaticInfo) IntraLatency() time.Duration {
+	return time.Duration(i.InternalLatency) * time.Microsecond
+}
+
+func (i *InterfaceStaticInfo) ProtoId() proto.ProtoIdType {
+	return proto.InterfaceStaticInfo_TypeID
+}
+
+func (i *InterfaceStaticInfo) String() string {
+	if i == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("%d: latency %v internal %v %dkbps %s geo %v", i.IfID,
+		i.LinkLatency(), i.IntraLatency(), i.Bandwidth, i.LinkType, i.Geo)
+}
+
+var _ proto.Cerealizable = (*GeoCoordinates)(nil)
+
+// GeoCoordinates is a geographical location.
+type GeoCoordinates struct {
+	Latitude  float32 `json:"latitude"`
+	Longitude float32 `json:"longitude"`
+	Address   string  `json:"address,omitempty"`
+}
+
+func (g *GeoCoordinates) ProtoId() proto.ProtoIdType {
+	return proto.GeoCoordinates_TypeID
+}
+
+func (g *GeoCoordinates) String() string {
+	if g == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("(%v, %v) %q", g.Latitude, g.Longitude, g.Address)
+}
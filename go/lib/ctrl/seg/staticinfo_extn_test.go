@@ -0,0 +1,72 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package seg
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/spath"
+	"github.com/scionproto/scion/go/proto"
+)
+
+func TestASEntryStaticInfoRoundTrip(t *testing.T) {
+	entry := &ASEntry{
+		RawIA:    as110.IAInt(),
+		MTU:      1500,
+		IfIDSize: 12,
+		HopEntries: []*HopEntry{
+			{
+				RemoteOutIF: 23,
+				RawOutIA:    as111.IAInt(),
+				RawHopField: make(common.RawBytes, spath.HopFieldLength),
+			},
+		},
+	}
+	entry.Exts.StaticInfo = NewStaticInfoExtn([]*InterfaceStaticInfo{
+		{
+			IfID:            1,
+			Latency:         10000,
+			InternalLatency: 500,
+			Bandwidth:       1000000,
+			LinkType:        proto.PhysicalLinkType_direct,
+			Geo: &GeoCoordinates{
+				Latitude:  47.3769,
+				Longitude: 8.5417,
+				Address:   "Zurich",
+			},
+		},
+		{IfID: 2, InternalLatency: 300, LinkType: proto.PhysicalLinkType_opennet},
+	})
+	raw, err := entry.Pack()
+	require.NoError(t, err)
+	parsed, err := NewASEntryFromRaw(raw)
+	require.NoError(t, err)
+	assert.Equal(t, entry.Exts.StaticInfo, parsed.Exts.StaticInfo)
+	assert.Equal(t, 10*time.Millisecond, parsed.Exts.StaticInfo.Get(1).LinkLatency())
+	assert.Nil(t, parsed.Exts.StaticInfo.Get(2).Geo)
+	assert.Nil(t, parsed.Exts.StaticInfo.Get(3))
+
+	entry.Exts.StaticInfo = nil
+	raw, err = entry.Pack()
+	require.NoError(t, err)
+	parsed, err = NewASEntryFromRaw(raw)
+	require.NoError(t, err)
+	assert.Nil(t, parsed.Exts.StaticInfo)
+}
@@ -35,9 +35,10 @@ func (m MsgIdType) Time() time.Time {
 
 // union represents the contents of the unnamed capnp union.
 type union struct {
-	Which   proto.SIGCtrl_Which
-	PollReq *PollReq
-	PollRep *PollRep
+	Which     proto.SIGCtrl_Which
+	PollReq   *PollReq
+	PollRep   *PollRep
+	PrefixAnn *PrefixAnn
 }
 
 func (u *union) set(c proto.Cerealizable) error {
@@ -48,6 +49,9 @@ func (u *union) set(c proto.Cerealizable) error {
 	case *PollRep:
 		u.Which = proto.SIGCtrl_Which_pollRep
 		u.PollRep = p
+	case *PrefixAnn:
+		u.Which = proto.SIGCtrl_Which_prefixAnn
+		u.PrefixAnn = p
 	default:
 		return common.NewBasicError("Unsupported SIG ctrl union type (set)", nil,
 			"type", common.TypeOf(c))
@@ -61,6 +65,8 @@ func (u *union) get() (proto.Cerealizable, error) {
 		return u.PollReq, nil
 	case proto.SIGCtrl_Which_pollRep:
 		return u.PollRep, nil
+	case proto.SIGCtrl_Which_prefixAnn:
+		return u.PrefixAnn, nil
 	}
 	return nil, common.NewBasicError("Unsupported SIG ctrl union type (get)", nil,
 		"type", u.Which)
@@ -26,6 +26,9 @@ var _ proto.Cerealizable = (*Poll)(nil)
 type Poll struct {
 	Addr    *Addr
 	Session SessionType
+	// FEC are the FEC parameters requested, or accepted, for the session. It
+	// is nil if FEC is not used.
+	FEC *FEC `capnp:"fec"`
 }
 
 func newPoll(a *Addr, s SessionType) *Poll {
@@ -41,9 +44,28 @@ func (p *Poll) Write(b common.RawBytes) (int, error) {
 }
 
 func (p *Poll) String() string {
+	if p.FEC != nil {
+		return fmt.Sprintf("%s Session: %s FEC: %s", p.Addr, p.Session, p.FEC)
+	}
 	return fmt.Sprintf("%s Session: %s", p.Addr, p.Session)
 }
 
+var _ proto.Cerealizable = (*FEC)(nil)
+
+// FEC are the parameters of forward error correction on a session.
+type FEC struct {
+	DataFrames   uint8
+	ParityFrames uint8
+}
+
+func (f *FEC) ProtoId() proto.ProtoIdType {
+	return proto.SIGFEC_TypeID
+}
+
+func (f *FEC) String() string {
+	return fmt.Sprintf("%d+%d", f.DataFrames, f.ParityFrames)
+}
+
 type PollReq struct {
 	*Poll
 }
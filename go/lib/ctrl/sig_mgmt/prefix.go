@@ -0,0 +1,119 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sig_mgmt
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/proto"
+)
+
+var _ proto.Cerealizable = (*PrefixAnn)(nil)
+
+// PrefixAnn announces the IP prefixes served by the sender's AS.
+type PrefixAnn struct {
+	// Version is increased by the sender whenever the prefixes change.
+	Version uint64
+	// Prefixes is the complete list of announced prefixes.
+	Prefixes []*Prefix
+	// Chain is the raw certificate chain of the sender. It is unset in
+	// unsigned announcements.
+	Chain common.RawBytes
+}
+
+// NewPrefixAnn creates an announcement of the networks.
+func NewPrefixAnn(version uint64, nets []*net.IPNet) *PrefixAnn {
+	a := &PrefixAnn{Version: version, Prefixes: make([]*Prefix, 0, len(nets))}
+	for _, n := range nets {
+		a.Prefixes = append(a.Prefixes, NewPrefix(n))
+	}
+	return a
+}
+
+func (a *PrefixAnn) ProtoId() proto.ProtoIdType {
+	return proto.SIGPrefixAnn_TypeID
+}
+
+func (a *PrefixAnn) Write(b common.RawBytes) (int, error) {
+	return proto.WriteRoot(a, b)
+}
+
+// Nets returns the announced networks. It returns an error if a prefix is
+// malformed.
+func (a *PrefixAnn) Nets() ([]*net.IPNet, error) {
+	nets := make([]*net.IPNet, 0, len(a.Prefixes))
+	for _, p := range a.Prefixes {
+		n, err := p.IPNet()
+		if err != nil {
+			return nil, err
+		}
+		nets = append(nets, n)
+	}
+	return nets, nil
+}
+
+func (a *PrefixAnn) String() string {
+	prefixes := make([]string, 0, len(a.Prefixes))
+	for _, p := range a.Prefixes {
+		prefixes = append(prefixes, p.String())
+	}
+	return fmt.Sprintf("Version: %d Prefixes: [%s]", a.Version, strings.Join(prefixes, " "))
+}
+
+var _ proto.Cerealizable = (*Prefix)(nil)
+
+// Prefix is an announced IP prefix.
+type Prefix struct {
+	IP     common.RawBytes `capnp:"ip"`
+	Length uint8
+}
+
+// NewPrefix creates the prefix of the network. IPv4 networks are encoded
+// with 4 bytes.
+func NewPrefix(n *net.IPNet) *Prefix {
+	ip := n.IP.Mask(n.Mask)
+	if ip4 := ip.To4(); ip4 != nil {
+		ip = ip4
+	}
+	ones, _ := n.Mask.Size()
+	return &Prefix{IP: common.RawBytes(ip), Length: uint8(ones)}
+}
+
+func (p *Prefix) ProtoId() proto.ProtoIdType {
+	return proto.SIGPrefix_TypeID
+}
+
+// IPNet returns the network of the prefix.
+func (p *Prefix) IPNet() (*net.IPNet, error) {
+	if len(p.IP) != net.IPv4len && len(p.IP) != net.IPv6len {
+		return nil, common.NewBasicError("Invalid prefix address length", nil,
+			"len", len(p.IP))
+	}
+	bits := 8 * len(p.IP)
+	if int(p.Length) > bits {
+		return nil, common.NewBasicError("Invalid prefix length", nil,
+			"length", p.Length, "max", bits)
+	}
+	mask := net.CIDRMask(int(p.Length), bits)
+	ip := net.IP(append(common.RawBytes(nil), p.IP...)).Mask(mask)
+	return &net.IPNet{IP: ip, Mask: mask}, nil
+}
+
+func (p *Prefix) String() string {
+	return fmt.Sprintf("%s/%d", net.IP(p.IP), p.Length)
+}
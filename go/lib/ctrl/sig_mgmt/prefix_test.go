@@ -0,0 +1,92 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sig_mgmt_test
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/ctrl"
+	"github.com/scionproto/scion/go/lib/ctrl/sig_mgmt"
+	"github.com/scionproto/scion/go/lib/infra"
+)
+
+func mustParseCIDR(t *testing.T, s string) *net.IPNet {
+	_, n, err := net.ParseCIDR(s)
+	require.NoError(t, err)
+	return n
+}
+
+func TestPrefixAnnRoundTrip(t *testing.T) {
+	nets := []*net.IPNet{
+		mustParseCIDR(t, "192.0.2.0/24"),
+		mustParseCIDR(t, "2001:db8::/32"),
+	}
+	pld, err := sig_mgmt.NewPld(1, sig_mgmt.NewPrefixAnn(7, nets))
+	require.NoError(t, err)
+	cpld, err := ctrl.NewPld(pld, nil)
+	require.NoError(t, err)
+	spld, err := cpld.SignedPld(infra.NullSigner)
+	require.NoError(t, err)
+	raw, err := spld.PackPld()
+	require.NoError(t, err)
+
+	spld, err = ctrl.NewSignedPldFromRaw(raw)
+	require.NoError(t, err)
+	cpld, err = spld.UnsafePld()
+	require.NoError(t, err)
+	u, err := cpld.Union()
+	require.NoError(t, err)
+	u, err = u.(*sig_mgmt.Pld).Union()
+	require.NoError(t, err)
+	ann, ok := u.(*sig_mgmt.PrefixAnn)
+	require.True(t, ok)
+	assert.Equal(t, uint64(7), ann.Version)
+	parsed, err := ann.Nets()
+	require.NoError(t, err)
+	assert.Equal(t, nets, parsed)
+}
+
+func TestPrefixIPNet(t *testing.T) {
+	tests := map[string]struct {
+		Prefix *sig_mgmt.Prefix
+		Net    string
+	}{
+		"host bits are masked": {
+			Prefix: &sig_mgmt.Prefix{IP: []byte{10, 1, 2, 3}, Length: 8},
+			Net:    "10.0.0.0/8",
+		},
+		"invalid address length": {
+			Prefix: &sig_mgmt.Prefix{IP: []byte{10, 1, 2}, Length: 8},
+		},
+		"invalid prefix length": {
+			Prefix: &sig_mgmt.Prefix{IP: []byte{10, 1, 2, 3}, Length: 33},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			n, err := test.Prefix.IPNet()
+			if test.Net == "" {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, mustParseCIDR(t, test.Net), n)
+		})
+	}
+}
@@ -26,6 +26,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/ctrl/seg"
 	"github.com/scionproto/scion/go/lib/infra/messenger"
 	"github.com/scionproto/scion/go/lib/infra/messenger/mock_messenger"
 	"github.com/scionproto/scion/go/lib/snet"
@@ -478,6 +479,14 @@ func (t *testPath) Expiry() time.Time {
 	panic("not implemented")
 }
 
+func (t *testPath) Load() []seg.InterfaceLoad {
+	panic("not implemented")
+}
+
+func (t *testPath) StaticInfo() []seg.InterfaceStaticInfo {
+	panic("not implemented")
+}
+
 func (t *testPath) Copy() snet.Path {
 	panic("not implemented")
 }
@@ -28,6 +28,7 @@ const (
 	AckRejectFailedToVerify = "Failed to verfiy"
 	AckRejectPolicyError    = "Message rejected due to policy"
 	AckRetryDBError         = "DB Error"
+	AckRetryRateLimited     = "Rate limit exceeded"
 )
 
 // SendAckHelper binds the given arguments and returns a function that is convenient to call.
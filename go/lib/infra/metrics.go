@@ -39,6 +39,9 @@ type HandlerResult struct {
 var (
 	MetricsErrInternal = &HandlerResult{Result: "err_internal", Status: prom.StatusErr}
 	MetricsErrInvalid  = &HandlerResult{Result: "err_invalid_req", Status: prom.StatusErr}
+	// MetricsErrRateLimited is the result of requests that are rejected
+	// because the requester exceeded its quota.
+	MetricsErrRateLimited = &HandlerResult{Result: "err_rate_limited", Status: prom.StatusErr}
 
 	metricsErrMsger        = &HandlerResult{Result: "err_msger", Status: prom.StatusErr}
 	metricsErrMsgerTimeout = &HandlerResult{Result: "err_msger_to", Status: prom.StatusTimeout}
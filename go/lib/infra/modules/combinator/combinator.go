@@ -22,6 +22,10 @@
 //
 // Returned paths are sorted by weight in descending order. The weight is
 // defined as the number of transited AS hops in the path.
+//
+// Call CombineRanked to obtain the paths ranked by a list of pluggable
+// scorers, e.g., HopCount, Latency, Expiration and Diversity, optionally
+// capping the number of paths that traverse the same set of interfaces.
 package combinator
 
 import (
@@ -71,6 +75,14 @@ type Path struct {
 	Weight     int
 	Mtu        uint16
 	Interfaces []sciond.PathInterface
+	// Load contains the load of the interfaces, as announced in the path
+	// segments. It is aligned with Interfaces and empty if none of the
+	// segments carries load information.
+	Load []seg.InterfaceLoad
+	// StaticInfo contains the static metadata of the interfaces, as announced
+	// in the path segments. It is aligned with Interfaces and empty if none
+	// of the segments carries static info.
+	StaticInfo []seg.InterfaceStaticInfo
 }
 
 func (p *Path) writeTestString(w io.Writer) {
@@ -97,8 +109,21 @@ func (p *Path) reverseDownSegment() {
 
 func (p *Path) aggregateInterfaces() {
 	p.Interfaces = []sciond.PathInterface{}
+	p.Load = []seg.InterfaceLoad{}
+	p.StaticInfo = []seg.InterfaceStaticInfo{}
+	hasLoad, hasStaticInfo := false, false
 	for _, segment := range p.Segments {
 		p.Interfaces = append(p.Interfaces, segment.Interfaces...)
+		p.Load = append(p.Load, segment.Load...)
+		p.StaticInfo = append(p.StaticInfo, segment.StaticInfo...)
+		hasLoad = hasLoad || segment.hasLoad()
+		hasStaticInfo = hasStaticInfo || segment.hasStaticInfo()
+	}
+	if !hasLoad {
+		p.Load = nil
+	}
+	if !hasStaticInfo {
+		p.StaticInfo = nil
 	}
 }
 
@@ -137,6 +162,10 @@ type Segment struct {
 	HopFields  []*HopField
 	Type       proto.PathSegType
 	Interfaces []sciond.PathInterface
+	// Load is aligned with Interfaces.
+	Load []seg.InterfaceLoad
+	// StaticInfo is aligned with Interfaces.
+	StaticInfo []seg.InterfaceStaticInfo
 }
 
 // initInfoFieldFrom copies the info field in pathSegment, and sets it as the
@@ -175,6 +204,34 @@ func (segment *Segment) reverse() {
 	for i, j := 0, len(segment.Interfaces)-1; i < j; i, j = i+1, j-1 {
 		segment.Interfaces[i], segment.Interfaces[j] = segment.Interfaces[j], segment.Interfaces[i]
 	}
+	for i, j := 0, len(segment.Load)-1; i < j; i, j = i+1, j-1 {
+		segment.Load[i], segment.Load[j] = segment.Load[j], segment.Load[i]
+	}
+	for i, j := 0, len(segment.StaticInfo)-1; i < j; i, j = i+1, j-1 {
+		segment.StaticInfo[i], segment.StaticInfo[j] = segment.StaticInfo[j], segment.StaticInfo[i]
+	}
+}
+
+// hasLoad returns whether any interface of the segment carries load
+// information.
+func (segment *Segment) hasLoad() bool {
+	for _, l := range segment.Load {
+		if l.IfID != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// hasStaticInfo returns whether any interface of the segment carries static
+// info.
+func (segment *Segment) hasStaticInfo() bool {
+	for _, i := range segment.StaticInfo {
+		if i.IfID != 0 {
+			return true
+		}
+	}
+	return false
 }
 
 func (segment *Segment) ComputeExpTime() time.Time {
@@ -355,8 +355,11 @@ func (solution *PathSolution) GetFwdPathMetadata() *Path {
 				// The first HE in a segment has MTU 0, so we ignore those
 				path.Mtu = minUint16(path.Mtu, forwardingLinkMtu)
 			}
-			currentSeg.Interfaces = append(currentSeg.Interfaces,
-				getPathInterfaces(asEntry.IA(), inIFID, outIFID)...)
+			intfs := getPathInterfaces(asEntry.IA(), inIFID, outIFID)
+			currentSeg.Interfaces = append(currentSeg.Interfaces, intfs...)
+			currentSeg.Load = append(currentSeg.Load, getPathLoads(asEntry, intfs)...)
+			currentSeg.StaticInfo = append(currentSeg.StaticInfo,
+				getPathStaticInfo(asEntry, intfs)...)
 		}
 	}
 	path.reverseDownSegment()
@@ -451,6 +454,32 @@ func getPathInterfaces(ia addr.IA, inIFID, outIFID common.IFIDType) []sciond.Pat
 	return result
 }
 
+// getPathLoads returns the loads of the interfaces as announced in the AS
+// entry. Interfaces without load information get an empty entry.
+func getPathLoads(asEntry *seg.ASEntry, intfs []sciond.PathInterface) []seg.InterfaceLoad {
+	result := make([]seg.InterfaceLoad, len(intfs))
+	for i, intf := range intfs {
+		if l := asEntry.Exts.LoadInfo.Get(intf.IfID); l != nil {
+			result[i] = *l
+		}
+	}
+	return result
+}
+
+// getPathStaticInfo returns the static info of the interfaces as announced in
+// the AS entry. Interfaces without static info get an empty entry.
+func getPathStaticInfo(asEntry *seg.ASEntry,
+	intfs []sciond.PathInterface) []seg.InterfaceStaticInfo {
+
+	result := make([]seg.InterfaceStaticInfo, len(intfs))
+	for i, intf := range intfs {
+		if si := asEntry.Exts.StaticInfo.Get(intf.IfID); si != nil {
+			result[i] = *si
+		}
+	}
+	return result
+}
+
 // validNextSeg returns whether nextSeg is a valid next segment in a path from the given currSeg.
 // A path can only contain at most 1 up, 1 core, and 1 down segment.
 func validNextSeg(currSeg, nextSeg *InputSegment) bool {
@@ -0,0 +1,74 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package combinator
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/scionproto/scion/go/lib/ctrl/seg"
+	"github.com/scionproto/scion/go/lib/sciond"
+	"github.com/scionproto/scion/go/lib/xtest"
+	"github.com/scionproto/scion/go/proto"
+)
+
+func TestPathLoad(t *testing.T) {
+	ia := xtest.MustParseIA("1-ff00:0:111")
+	intfs := []sciond.PathInterface{
+		{RawIsdas: ia.IAInt(), IfID: 1},
+		{RawIsdas: ia.IAInt(), IfID: 2},
+	}
+	Convey("Loads are aligned with the interfaces", t, func() {
+		asEntry := &seg.ASEntry{}
+		asEntry.Exts.LoadInfo = seg.NewLoadInfoExtn([]*seg.InterfaceLoad{
+			{IfID: 2, Rate: 100, RouterQueueFill: 10},
+		})
+		loads := getPathLoads(asEntry, intfs)
+		SoMsg("loads", loads, ShouldResemble, []seg.InterfaceLoad{
+			{},
+			{IfID: 2, Rate: 100, RouterQueueFill: 10},
+		})
+	})
+	Convey("Paths without load information have no loads", t, func() {
+		path := &Path{
+			Segments: []*Segment{
+				{
+					Type:       proto.PathSegType_up,
+					Interfaces: intfs,
+					Load:       getPathLoads(&seg.ASEntry{}, intfs),
+				},
+			},
+		}
+		path.aggregateInterfaces()
+		SoMsg("interfaces", path.Interfaces, ShouldResemble, intfs)
+		SoMsg("loads", path.Load, ShouldBeNil)
+	})
+	Convey("Down segments reverse the loads", t, func() {
+		path := &Path{
+			Segments: []*Segment{
+				{
+					Type:       proto.PathSegType_down,
+					Interfaces: append([]sciond.PathInterface(nil), intfs...),
+					Load:       []seg.InterfaceLoad{{IfID: 1, Rate: 1}, {}},
+				},
+			},
+		}
+		path.reverseDownSegment()
+		path.aggregateInterfaces()
+		SoMsg("interfaces", path.Interfaces[1].IfID, ShouldEqual, 1)
+		SoMsg("loads", path.Load, ShouldResemble, []seg.InterfaceLoad{{}, {IfID: 1, Rate: 1}})
+	})
+}
@@ -0,0 +1,205 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package combinator
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/ctrl/seg"
+	"github.com/scionproto/scion/go/lib/sciond"
+	"github.com/scionproto/scion/go/lib/serrors"
+)
+
+// Scorer scores paths for ranking. Paths with a lower score are preferred.
+// The score may depend on the paths that are already selected, which allows
+// to prefer paths that are diverse.
+type Scorer interface {
+	Score(path *Path, selected []*Path) float64
+}
+
+// ScorerFunc is a function adapter for the Scorer interface.
+type ScorerFunc func(path *Path, selected []*Path) float64
+
+// Score calls f(path, selected).
+func (f ScorerFunc) Score(path *Path, selected []*Path) float64 {
+	return f(path, selected)
+}
+
+var (
+	// HopCount prefers paths with fewer AS hops.
+	HopCount Scorer = ScorerFunc(func(path *Path, _ []*Path) float64 {
+		return float64(path.Weight)
+	})
+	// Latency prefers paths with a lower aggregate latency, as announced in
+	// the static info of the path segments. Paths whose latency is not known
+	// for every inter-AS link are ranked last.
+	Latency Scorer = ScorerFunc(func(path *Path, _ []*Path) float64 {
+		latency, ok := path.Latency()
+		if !ok {
+			return math.Inf(1)
+		}
+		return float64(latency)
+	})
+	// Expiration prefers paths that expire later.
+	Expiration Scorer = ScorerFunc(func(path *Path, _ []*Path) float64 {
+		return -float64(path.ComputeExpTime().Unix())
+	})
+	// Diversity prefers paths that share fewer interfaces with the already
+	// selected paths.
+	Diversity Scorer = ScorerFunc(func(path *Path, selected []*Path) float64 {
+		used := make(map[sciond.PathInterface]struct{})
+		for _, other := range selected {
+			for _, intf := range other.Interfaces {
+				used[intf] = struct{}{}
+			}
+		}
+		var shared int
+		for _, intf := range path.Interfaces {
+			if _, ok := used[intf]; ok {
+				shared++
+			}
+		}
+		return float64(shared)
+	})
+)
+
+// ParseScorer returns the scorer with the name, one of "hops", "latency",
+// "expiration" or "diversity".
+func ParseScorer(name string) (Scorer, error) {
+	switch name {
+	case "hops":
+		return HopCount, nil
+	case "latency":
+		return Latency, nil
+	case "expiration":
+		return Expiration, nil
+	case "diversity":
+		return Diversity, nil
+	}
+	return nil, serrors.New("unknown scorer", "name", name)
+}
+
+// RankOptions configures the ranking of paths.
+type RankOptions struct {
+	// Scorers are applied in order, i.e., a scorer is only consulted if all
+	// the previous scorers tie. Paths that tie on all scorers keep their
+	// relative order. If empty, paths are ranked by HopCount.
+	Scorers []Scorer
+	// MaxPerInterfaceSet is the maximum number of paths that traverse the
+	// same set of interfaces. Lower ranked paths over the cap are dropped.
+	// Zero means no cap.
+	MaxPerInterfaceSet int
+}
+
+// CombineRanked constructs paths between src and dst using the supplied
+// segments, like Combine, and returns them ranked according to the options.
+func CombineRanked(src, dst addr.IA, ups, cores, downs []*seg.PathSegment,
+	opts RankOptions) []*Path {
+
+	return Rank(Combine(src, dst, ups, cores, downs), opts)
+}
+
+// Rank returns the paths ranked according to the options, best path first.
+// The paths are selected greedily, i.e., at each step the best path given the
+// already selected paths is appended to the result.
+func Rank(paths []*Path, opts RankOptions) []*Path {
+	scorers := opts.Scorers
+	if len(scorers) == 0 {
+		scorers = []Scorer{HopCount}
+	}
+	remaining := append([]*Path(nil), paths...)
+	perSet := make(map[string]int)
+	ranked := make([]*Path, 0, len(paths))
+	scores := make([]float64, len(scorers))
+	bestScores := make([]float64, len(scorers))
+	for len(remaining) > 0 {
+		best := 0
+		for i, path := range remaining {
+			for k, scorer := range scorers {
+				scores[k] = scorer.Score(path, ranked)
+			}
+			if i == 0 || lessScores(scores, bestScores) {
+				best = i
+				scores, bestScores = bestScores, scores
+			}
+		}
+		path := remaining[best]
+		remaining = append(remaining[:best], remaining[best+1:]...)
+		if opts.MaxPerInterfaceSet > 0 {
+			key := interfaceSetKey(path.Interfaces)
+			if perSet[key] >= opts.MaxPerInterfaceSet {
+				continue
+			}
+			perSet[key]++
+		}
+		ranked = append(ranked, path)
+	}
+	return ranked
+}
+
+// Latency returns the aggregate latency of the path, as announced in the
+// static info of the path segments. The latency of an inter-AS link is the
+// maximum of the latencies announced on either end, and the latency within an
+// AS is the sum of the internal latencies of the traversed interfaces.
+// Unknown intra-AS latencies are counted as zero. The returned boolean is
+// false if the latency of any inter-AS link is unknown, like
+// sciond.PathMetadata.LatencyComplete.
+func (p *Path) Latency() (time.Duration, bool) {
+	if len(p.StaticInfo) != len(p.Interfaces) {
+		return 0, false
+	}
+	var total time.Duration
+	for i := range p.StaticInfo {
+		total += p.StaticInfo[i].IntraLatency()
+	}
+	// The interfaces come in pairs, one pair per inter-AS link.
+	for i := 0; i+1 < len(p.StaticInfo); i += 2 {
+		a, b := p.StaticInfo[i].LinkLatency(), p.StaticInfo[i+1].LinkLatency()
+		if a == 0 && b == 0 {
+			return 0, false
+		}
+		if a > b {
+			total += a
+		} else {
+			total += b
+		}
+	}
+	return total, true
+}
+
+func lessScores(a, b []float64) bool {
+	for i := range a {
+		if a[i] != b[i] {
+			return a[i] < b[i]
+		}
+	}
+	return false
+}
+
+// interfaceSetKey returns a key that is equal for interface lists that
+// contain the same interfaces, regardless of the order.
+func interfaceSetKey(intfs []sciond.PathInterface) string {
+	keys := make([]string, 0, len(intfs))
+	for _, intf := range intfs {
+		keys = append(keys, fmt.Sprintf("%s#%d", intf.IA(), intf.IfID))
+	}
+	sort.Strings(keys)
+	return strings.Join(keys, " ")
+}
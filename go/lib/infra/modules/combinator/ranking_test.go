@@ -0,0 +1,159 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package combinator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/ctrl/seg"
+	"github.com/scionproto/scion/go/lib/sciond"
+	"github.com/scionproto/scion/go/lib/xtest"
+	"github.com/scionproto/scion/go/lib/xtest/graph"
+)
+
+func TestRank(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	g := graph.NewDefaultGraph(ctrl)
+	src, dst := xtest.MustParseIA("1-ff00:0:111"), xtest.MustParseIA("1-ff00:0:121")
+	// The segments result in two peering shortcuts over the same interfaces,
+	// one path via 1-ff00:0:120, and one path via 1-ff00:0:130 and
+	// 1-ff00:0:120.
+	ups := []*seg.PathSegment{
+		g.Beacon([]common.IFIDType{graph.If_120_X_111_B}),
+		g.Beacon([]common.IFIDType{graph.If_130_B_111_A}),
+	}
+	cores := []*seg.PathSegment{g.Beacon([]common.IFIDType{graph.If_120_A_130_B})}
+	downs := []*seg.PathSegment{g.Beacon([]common.IFIDType{graph.If_120_B_121_X})}
+	peering := []sciond.PathInterface{
+		{RawIsdas: src.IAInt(), IfID: graph.If_111_C_121_X},
+		{RawIsdas: dst.IAInt(), IfID: graph.If_121_X_111_C},
+	}
+
+	Convey("Hop count ranks the peering shortcuts first", t, func() {
+		paths := CombineRanked(src, dst, ups, cores, downs, RankOptions{})
+		So(paths, ShouldHaveLength, 4)
+		So(paths[0].Interfaces, ShouldResemble, peering)
+		So(paths[1].Interfaces, ShouldResemble, peering)
+		So(paths[2].Weight, ShouldEqual, 2)
+		So(paths[3].Weight, ShouldEqual, 3)
+	})
+	Convey("The cap per interface set drops duplicate paths", t, func() {
+		paths := CombineRanked(src, dst, ups, cores, downs, RankOptions{MaxPerInterfaceSet: 1})
+		So(paths, ShouldHaveLength, 3)
+		So(paths[0].Interfaces, ShouldResemble, peering)
+		So(paths[1].Weight, ShouldEqual, 2)
+		So(paths[2].Weight, ShouldEqual, 3)
+	})
+	Convey("Diversity prefers paths over unused interfaces", t, func() {
+		paths := CombineRanked(src, dst, ups, cores, downs,
+			RankOptions{Scorers: []Scorer{Diversity, HopCount}})
+		So(paths, ShouldHaveLength, 4)
+		So(paths[0].Interfaces, ShouldResemble, peering)
+		So(paths[1].Weight, ShouldEqual, 2)
+		So(paths[2].Interfaces, ShouldResemble, peering)
+		So(paths[3].Weight, ShouldEqual, 3)
+	})
+	Convey("Latency prefers paths with lower announced latency", t, func() {
+		up := g.Beacon([]common.IFIDType{graph.If_120_X_111_B})
+		up.ASEntries[len(up.ASEntries)-1].Exts.StaticInfo = seg.NewStaticInfoExtn(
+			[]*seg.InterfaceStaticInfo{
+				{IfID: graph.If_111_C_121_X, Latency: 50000},
+				{IfID: graph.If_111_B_120_X, Latency: 1000},
+			},
+		)
+		down := g.Beacon([]common.IFIDType{graph.If_120_B_121_X})
+		down.ASEntries[len(down.ASEntries)-1].Exts.StaticInfo = seg.NewStaticInfoExtn(
+			[]*seg.InterfaceStaticInfo{
+				{IfID: graph.If_121_X_120_B, Latency: 2000},
+			},
+		)
+		paths := CombineRanked(src, dst, []*seg.PathSegment{up}, nil,
+			[]*seg.PathSegment{down}, RankOptions{Scorers: []Scorer{Latency, HopCount}})
+		So(paths, ShouldHaveLength, 2)
+		So(paths[0].Weight, ShouldEqual, 2)
+		latency, ok := paths[0].Latency()
+		So(ok, ShouldBeTrue)
+		So(latency, ShouldEqual, 3*time.Millisecond)
+		So(paths[1].Interfaces, ShouldResemble, peering)
+	})
+}
+
+func TestRankScorers(t *testing.T) {
+	Convey("Expiration prefers paths that expire later", t, func() {
+		early := &Path{Segments: []*Segment{buildTestSegment(0, 1)}}
+		late := &Path{Segments: []*Segment{buildTestSegment(100, 1)}}
+		paths := Rank([]*Path{early, late}, RankOptions{Scorers: []Scorer{Expiration}})
+		So(paths, ShouldResemble, []*Path{late, early})
+	})
+	Convey("Paths without latency information are ranked last", t, func() {
+		ia := xtest.MustParseIA("1-ff00:0:111")
+		intfs := []sciond.PathInterface{{RawIsdas: ia.IAInt(), IfID: 1}}
+		unknown := &Path{Interfaces: intfs}
+		known := &Path{
+			Interfaces: intfs,
+			StaticInfo: []seg.InterfaceStaticInfo{{IfID: 1, InternalLatency: 10}},
+		}
+		paths := Rank([]*Path{unknown, known}, RankOptions{Scorers: []Scorer{Latency}})
+		So(paths, ShouldResemble, []*Path{known, unknown})
+	})
+	Convey("Paths with incomplete latency information are ranked last", t, func() {
+		ia := xtest.MustParseIA("1-ff00:0:111")
+		intfs := []sciond.PathInterface{
+			{RawIsdas: ia.IAInt(), IfID: 1},
+			{RawIsdas: ia.IAInt(), IfID: 2},
+			{RawIsdas: ia.IAInt(), IfID: 3},
+			{RawIsdas: ia.IAInt(), IfID: 4},
+		}
+		complete := &Path{
+			Interfaces: intfs[:2],
+			StaticInfo: []seg.InterfaceStaticInfo{{IfID: 1, Latency: 5000}, {IfID: 2}},
+		}
+		incomplete := &Path{
+			Interfaces: intfs,
+			StaticInfo: []seg.InterfaceStaticInfo{
+				{IfID: 1, Latency: 10}, {IfID: 2}, {IfID: 3}, {IfID: 4},
+			},
+		}
+		_, ok := incomplete.Latency()
+		So(ok, ShouldBeFalse)
+		paths := Rank([]*Path{incomplete, complete}, RankOptions{Scorers: []Scorer{Latency}})
+		So(paths, ShouldResemble, []*Path{complete, incomplete})
+	})
+	Convey("Ties keep the input order", t, func() {
+		a, b := &Path{Weight: 1}, &Path{Weight: 1}
+		paths := Rank([]*Path{a, b}, RankOptions{})
+		So(paths[0], ShouldEqual, a)
+		So(paths[1], ShouldEqual, b)
+		paths = Rank([]*Path{b, a}, RankOptions{})
+		So(paths[0], ShouldEqual, b)
+		So(paths[1], ShouldEqual, a)
+	})
+}
+
+func TestParseScorer(t *testing.T) {
+	Convey("ParseScorer returns the scorer with the name", t, func() {
+		scorer, err := ParseScorer("hops")
+		So(err, ShouldBeNil)
+		So(scorer.Score(&Path{Weight: 3}, nil), ShouldEqual, 3)
+		_, err = ParseScorer("bandwidth")
+		So(err, ShouldNotBeNil)
+	})
+}
@@ -0,0 +1,73 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package combinator
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/scionproto/scion/go/lib/ctrl/seg"
+	"github.com/scionproto/scion/go/lib/sciond"
+	"github.com/scionproto/scion/go/lib/xtest"
+	"github.com/scionproto/scion/go/proto"
+)
+
+func TestPathStaticInfo(t *testing.T) {
+	ia := xtest.MustParseIA("1-ff00:0:111")
+	intfs := []sciond.PathInterface{
+		{RawIsdas: ia.IAInt(), IfID: 1},
+		{RawIsdas: ia.IAInt(), IfID: 2},
+	}
+	Convey("Static info is aligned with the interfaces", t, func() {
+		asEntry := &seg.ASEntry{}
+		asEntry.Exts.StaticInfo = seg.NewStaticInfoExtn([]*seg.InterfaceStaticInfo{
+			{IfID: 2, Latency: 100, Bandwidth: 1000},
+		})
+		infos := getPathStaticInfo(asEntry, intfs)
+		SoMsg("infos", infos, ShouldResemble, []seg.InterfaceStaticInfo{
+			{},
+			{IfID: 2, Latency: 100, Bandwidth: 1000},
+		})
+	})
+	Convey("Paths without static info have no static info", t, func() {
+		path := &Path{
+			Segments: []*Segment{
+				{
+					Type:       proto.PathSegType_up,
+					Interfaces: intfs,
+					StaticInfo: getPathStaticInfo(&seg.ASEntry{}, intfs),
+				},
+			},
+		}
+		path.aggregateInterfaces()
+		SoMsg("infos", path.StaticInfo, ShouldBeNil)
+	})
+	Convey("Down segments reverse the static info", t, func() {
+		path := &Path{
+			Segments: []*Segment{
+				{
+					Type:       proto.PathSegType_down,
+					Interfaces: append([]sciond.PathInterface(nil), intfs...),
+					StaticInfo: []seg.InterfaceStaticInfo{{IfID: 1, Latency: 1}, {}},
+				},
+			},
+		}
+		path.reverseDownSegment()
+		path.aggregateInterfaces()
+		SoMsg("infos", path.StaticInfo, ShouldResemble,
+			[]seg.InterfaceStaticInfo{{}, {IfID: 1, Latency: 1}})
+	})
+}
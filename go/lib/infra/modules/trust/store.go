@@ -182,3 +182,13 @@ func (s Store) loadChain(ctx context.Context, file string) error {
 	}
 	return unsafeInserter.InsertChain(ctx, dec, s.DB.GetTRC)
 }
+
+// InsertChain decodes the raw certificate chain, verifies it against the TRCs
+// in the database, and inserts it.
+func (s Store) InsertChain(ctx context.Context, raw []byte) error {
+	dec, err := decoded.DecodeChain(raw)
+	if err != nil {
+		return err
+	}
+	return s.Inserter.InsertChain(ctx, dec, s.DB.GetTRC)
+}
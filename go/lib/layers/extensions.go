@@ -28,6 +28,8 @@ func ExtensionFactory(class common.L4ProtocolType, extension *Extension) (common
 			return NewExtnSCMPFromLayer(extension)
 		case common.ExtnOneHopPathType.Type:
 			return NewExtnOHPFromLayer(extension)
+		case common.ExtnINTType.Type:
+			return NewExtnINTFromLayer(extension)
 		default:
 			return NewExtnUnknownFromLayer(common.HopByHopClass, extension)
 		}
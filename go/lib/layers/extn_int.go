@@ -0,0 +1,206 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// This file contains the in-band network telemetry (INT) hop-by-hop extension.
+// The sender reserves room for a number of records, and every border router
+// on the path fills the next free record with its IA, interfaces, a timestamp
+// and the occupancy of the QoS queue the packet was put on.
+//
+// Layout (after the 3 byte extension subheader):
+//
+//	 0: number of filled records
+//	 1: reserved (4 bytes)
+//	 5: records, INTRecordLen bytes each
+//
+// Record layout:
+//
+//	 0: IA (8 bytes)
+//	 8: timestamp, nanoseconds since the Unix epoch (8 bytes)
+//	16: ingress interface (2 bytes), 0 if the packet came from the local AS
+//	18: egress interface (2 bytes), 0 if the packet is delivered locally
+//	20: queue number, INTNoQueue if the packet bypassed the QoS queues
+//	21: queue fill level in percent
+//	22: queue length in packets (2 bytes)
+//
+// The extension is kept when a packet is reversed, such that replies to e.g.
+// SCMP echo requests carry the records of both directions.
+
+package layers
+
+import (
+	"encoding/binary"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/serrors"
+)
+
+const (
+	// INTRecordLen is the length of a single telemetry record.
+	INTRecordLen = 3 * common.LineLen
+	// INTRecordsOff is the offset of the first record in the extension.
+	INTRecordsOff = common.ExtnFirstLineLen
+	// MaxINTRecords is the number of records that fit the largest extension.
+	MaxINTRecords = (255*common.LineLen - common.LineLen) / INTRecordLen
+	// INTNoQueue is the queue number of records of packets that bypassed the
+	// QoS queues.
+	INTNoQueue = 0xff
+)
+
+// INTRecord is the telemetry recorded by a single border router.
+type INTRecord struct {
+	IA          addr.IA
+	Timestamp   time.Time
+	Ingress     common.IFIDType
+	Egress      common.IFIDType
+	QueueNo     uint8
+	QueueFill   uint8
+	QueueLength uint16
+}
+
+// INTRecordFromRaw parses a record. b must be at least INTRecordLen long.
+func INTRecordFromRaw(b common.RawBytes) INTRecord {
+	return INTRecord{
+		IA:          addr.IAFromRaw(b[0:]),
+		Timestamp:   time.Unix(0, int64(binary.BigEndian.Uint64(b[8:]))),
+		Ingress:     common.IFIDType(binary.BigEndian.Uint16(b[16:])),
+		Egress:      common.IFIDType(binary.BigEndian.Uint16(b[18:])),
+		QueueNo:     b[20],
+		QueueFill:   b[21],
+		QueueLength: binary.BigEndian.Uint16(b[22:]),
+	}
+}
+
+// Write writes the record to b, which must be at least INTRecordLen long.
+func (r *INTRecord) Write(b common.RawBytes) {
+	r.IA.Write(b[0:])
+	binary.BigEndian.PutUint64(b[8:], uint64(r.Timestamp.UnixNano()))
+	binary.BigEndian.PutUint16(b[16:], uint16(r.Ingress))
+	binary.BigEndian.PutUint16(b[18:], uint16(r.Egress))
+	b[20] = r.QueueNo
+	b[21] = r.QueueFill
+	binary.BigEndian.PutUint16(b[22:], r.QueueLength)
+}
+
+func (r *INTRecord) String() string {
+	return fmt.Sprintf("%s %d>%d %s queue %d: %d pkts (%d%%)", r.IA, r.Ingress, r.Egress,
+		r.Timestamp.UTC().Format(common.TimeFmt), r.QueueNo, r.QueueLength, r.QueueFill)
+}
+
+var _ common.Extension = (*ExtnINT)(nil)
+
+// ExtnINT is the in-band network telemetry extension.
+type ExtnINT struct {
+	// Capacity is the number of records the extension has room for.
+	Capacity int
+	// Records are the filled records, in the order of the border routers on
+	// the path.
+	Records []INTRecord
+}
+
+// NewExtnINT returns an empty extension with room for capacity records.
+func NewExtnINT(capacity int) (*ExtnINT, error) {
+	if capacity < 1 || capacity > MaxINTRecords {
+		return nil, serrors.New("invalid INT capacity", "capacity", capacity,
+			"max", MaxINTRecords)
+	}
+	return &ExtnINT{Capacity: capacity}, nil
+}
+
+func NewExtnINTFromLayer(extension *Extension) (*ExtnINT, error) {
+	return ExtnINTFromRaw(extension.Data)
+}
+
+// ExtnINTFromRaw parses the extension, b must not contain the subheader.
+func ExtnINTFromRaw(b common.RawBytes) (*ExtnINT, error) {
+	if len(b) < INTRecordsOff+INTRecordLen || (len(b)-INTRecordsOff)%INTRecordLen != 0 {
+		return nil, serrors.New("bad length for INT extension", "actual", len(b))
+	}
+	e := &ExtnINT{Capacity: (len(b) - INTRecordsOff) / INTRecordLen}
+	n := int(b[0])
+	if n > e.Capacity {
+		return nil, serrors.New("INT extension with more records than capacity",
+			"records", n, "capacity", e.Capacity)
+	}
+	e.Records = make([]INTRecord, 0, n)
+	for i := 0; i < n; i++ {
+		e.Records = append(e.Records, INTRecordFromRaw(b[INTRecordsOff+i*INTRecordLen:]))
+	}
+	return e, nil
+}
+
+func (e *ExtnINT) Write(b common.RawBytes) error {
+	if len(e.Records) > e.Capacity {
+		return serrors.New("INT extension with more records than capacity",
+			"records", len(e.Records), "capacity", e.Capacity)
+	}
+	if len(b) < e.Len() {
+		return serrors.New("buffer too short", "expected", e.Len(), "actual", len(b))
+	}
+	b[0] = uint8(len(e.Records))
+	copy(b[1:INTRecordsOff], make(common.RawBytes, INTRecordsOff-1))
+	for i := range e.Records {
+		e.Records[i].Write(b[INTRecordsOff+i*INTRecordLen:])
+	}
+	unused := b[INTRecordsOff+len(e.Records)*INTRecordLen : e.Len()]
+	copy(unused, make(common.RawBytes, len(unused)))
+	return nil
+}
+
+func (e *ExtnINT) Pack() (common.RawBytes, error) {
+	b := make(common.RawBytes, e.Len())
+	if err := e.Write(b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+func (e *ExtnINT) Copy() common.Extension {
+	if e == nil {
+		return nil
+	}
+	return &ExtnINT{
+		Capacity: e.Capacity,
+		Records:  append([]INTRecord(nil), e.Records...),
+	}
+}
+
+func (e *ExtnINT) Reverse() (bool, error) {
+	// The records are kept, the routers on the reverse path append theirs.
+	return true, nil
+}
+
+func (e *ExtnINT) Len() int {
+	return INTRecordsOff + e.Capacity*INTRecordLen
+}
+
+func (e *ExtnINT) Class() common.L4ProtocolType {
+	return common.HopByHopClass
+}
+
+func (e *ExtnINT) Type() common.ExtnType {
+	return common.ExtnINTType
+}
+
+func (e *ExtnINT) String() string {
+	s := make([]string, 0, len(e.Records))
+	for i := range e.Records {
+		s = append(s, e.Records[i].String())
+	}
+	return fmt.Sprintf("INT Ext(%dB): %d/%d [%s]", e.Len(), len(e.Records), e.Capacity,
+		strings.Join(s, ", "))
+}
@@ -0,0 +1,93 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package layers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+)
+
+func TestExtnINTRoundTrip(t *testing.T) {
+	e, err := NewExtnINT(3)
+	require.NoError(t, err)
+	assert.Equal(t, 5+3*INTRecordLen, e.Len())
+	assert.Zero(t, (e.Len()+common.ExtnSubHdrLen)%common.LineLen)
+	e.Records = []INTRecord{
+		{
+			IA:          addr.IA{I: 1, A: 0xff0000000110},
+			Timestamp:   time.Unix(1590000000, 123456789),
+			Egress:      41,
+			QueueNo:     2,
+			QueueFill:   37,
+			QueueLength: 1500,
+		},
+		{
+			IA:        addr.IA{I: 1, A: 0xff0000000111},
+			Timestamp: time.Unix(1590000000, 223456789),
+			Ingress:   1,
+			Egress:    2,
+		},
+	}
+	raw, err := e.Pack()
+	require.NoError(t, err)
+	hdr := []byte{0, uint8((len(raw) + common.ExtnSubHdrLen) / common.LineLen),
+		common.ExtnINTType.Type}
+	extn, err := ExtensionFactory(common.HopByHopClass,
+		mustCreateExtensionLayer(append(hdr, raw...)))
+	require.NoError(t, err)
+	parsed, ok := extn.(*ExtnINT)
+	require.True(t, ok)
+	assert.Equal(t, e.Capacity, parsed.Capacity)
+	require.Len(t, parsed.Records, 2)
+	for i := range e.Records {
+		assert.True(t, e.Records[i].Timestamp.Equal(parsed.Records[i].Timestamp))
+		parsed.Records[i].Timestamp = e.Records[i].Timestamp
+	}
+	assert.Equal(t, e.Records, parsed.Records)
+}
+
+func TestExtnINTFromRaw(t *testing.T) {
+	tests := map[string]struct {
+		Raw   []byte
+		Valid bool
+	}{
+		"empty":           {Raw: make([]byte, 5)},
+		"unaligned":       {Raw: make([]byte, 5+INTRecordLen+1)},
+		"one free record": {Raw: make([]byte, 5+INTRecordLen), Valid: true},
+		"too many records": {
+			Raw: append([]byte{2, 0, 0, 0, 0}, make([]byte, INTRecordLen)...),
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := ExtnINTFromRaw(test.Raw)
+			if test.Valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+	_, err := NewExtnINT(0)
+	assert.Error(t, err)
+	_, err = NewExtnINT(MaxINTRecords + 1)
+	assert.Error(t, err)
+}
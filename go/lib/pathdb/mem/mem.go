@@ -0,0 +1,624 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package mem implements an in-memory backend for the PathDB.
+//
+// A transaction operates on a copy of the database state, which replaces the
+// state of the database on commit. Transactions and writes outside of
+// transactions are serialized, reads are never blocked by a transaction and
+// only observe committed state.
+package mem
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/ctrl/seg"
+	"github.com/scionproto/scion/go/lib/pathdb"
+	"github.com/scionproto/scion/go/lib/pathdb/query"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/proto"
+)
+
+var (
+	// ErrClosed is returned when operating on a closed database.
+	ErrClosed = serrors.New("database closed")
+	// ErrTxDone is returned when operating on a committed or rolled back
+	// transaction.
+	ErrTxDone = serrors.New("transaction already committed or rolled back")
+)
+
+var noInsertion = pathdb.InsertStats{}
+
+var _ pathdb.PathDB = (*Backend)(nil)
+
+// Backend is an in-memory PathDB.
+type Backend struct {
+	*executor
+}
+
+// New returns a new empty in-memory backend.
+func New() *Backend {
+	return &Backend{
+		executor: &executor{
+			state:  newState(),
+			writer: make(chan struct{}, 1),
+			err:    ErrClosed,
+		},
+	}
+}
+
+// SetMaxOpenConns is a no-op, the in-memory backend has no connections.
+func (b *Backend) SetMaxOpenConns(_ int) {}
+
+// SetMaxIdleConns is a no-op, the in-memory backend has no connections.
+func (b *Backend) SetMaxIdleConns(_ int) {}
+
+// BeginTransaction begins a transaction on the database. It blocks until all
+// other transactions are committed or rolled back.
+func (b *Backend) BeginTransaction(ctx context.Context,
+	_ *sql.TxOptions) (pathdb.Transaction, error) {
+
+	if err := acquire(ctx, b.writer); err != nil {
+		return nil, common.NewBasicError("Failed to create transaction", err)
+	}
+	b.RLock()
+	defer b.RUnlock()
+	if b.state == nil {
+		release(b.writer)
+		return nil, common.NewBasicError("Failed to create transaction", ErrClosed)
+	}
+	return &transaction{
+		executor: &executor{
+			state: b.state.clone(),
+			err:   ErrTxDone,
+		},
+		backend: b.executor,
+	}, nil
+}
+
+// Close closes the database and drops all state.
+func (b *Backend) Close() error {
+	b.Lock()
+	defer b.Unlock()
+	b.state = nil
+	return nil
+}
+
+var _ (pathdb.Transaction) = (*transaction)(nil)
+
+type transaction struct {
+	*executor
+	backend *executor
+}
+
+func (tx *transaction) Commit() error {
+	tx.Lock()
+	defer tx.Unlock()
+	if tx.state == nil {
+		return ErrTxDone
+	}
+	tx.backend.Lock()
+	defer tx.backend.Unlock()
+	if tx.backend.state == nil {
+		return ErrClosed
+	}
+	tx.backend.state = tx.state
+	tx.state = nil
+	release(tx.backend.writer)
+	return nil
+}
+
+func (tx *transaction) Rollback() error {
+	tx.Lock()
+	defer tx.Unlock()
+	if tx.state == nil {
+		return ErrTxDone
+	}
+	tx.state = nil
+	release(tx.backend.writer)
+	return nil
+}
+
+// acquire acquires the write token.
+func acquire(ctx context.Context, writer chan struct{}) error {
+	select {
+	case writer <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// release releases the write token.
+func release(writer chan struct{}) {
+	<-writer
+}
+
+var _ (pathdb.ReadWrite) = (*executor)(nil)
+
+type executor struct {
+	sync.RWMutex
+	state *state
+	// writer holds the write token while a write or a transaction is in
+	// progress. It is nil for transactions, which hold the token of the
+	// backend during their whole lifetime.
+	writer chan struct{}
+	// err is returned if the state has been dropped.
+	err error
+}
+
+func (e *executor) read(ctx context.Context, f func(s *state) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	e.RLock()
+	defer e.RUnlock()
+	if e.state == nil {
+		return e.err
+	}
+	return f(e.state)
+}
+
+func (e *executor) write(ctx context.Context, f func(s *state) error) error {
+	if e.writer != nil {
+		if err := acquire(ctx, e.writer); err != nil {
+			return err
+		}
+		defer release(e.writer)
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	e.Lock()
+	defer e.Unlock()
+	if e.state == nil {
+		return e.err
+	}
+	return f(e.state)
+}
+
+func (e *executor) Insert(ctx context.Context, segMeta *seg.Meta) (pathdb.InsertStats, error) {
+	return e.InsertWithHPCfgIDs(ctx, segMeta, []*query.HPCfgID{&query.NullHpCfgID})
+}
+
+func (e *executor) InsertWithHPCfgIDs(ctx context.Context, segMeta *seg.Meta,
+	hpCfgIDs []*query.HPCfgID) (pathdb.InsertStats, error) {
+
+	entry, err := newSegEntry(segMeta.Segment)
+	if err != nil {
+		return noInsertion, err
+	}
+	ret := noInsertion
+	err = e.write(ctx, func(s *state) error {
+		existing, ok := s.segs[entry.id]
+		if ok && !entry.infoTime.After(existing.infoTime) {
+			return nil
+		}
+		if ok {
+			// Update the existing path segment and keep its types and
+			// hpCfgIDs.
+			entry.seq = existing.seq
+			entry.types = existing.types
+			entry.hpCfgIDs = existing.hpCfgIDs
+			ret.Updated = 1
+		} else {
+			s.seq++
+			entry.seq = s.seq
+			ret.Inserted = 1
+		}
+		entry.addType(segMeta.Type)
+		for _, hpCfgID := range hpCfgIDs {
+			entry.addHPCfgID(*hpCfgID)
+		}
+		s.segs[entry.id] = entry
+		return nil
+	})
+	if err != nil {
+		return noInsertion, err
+	}
+	return ret, nil
+}
+
+func (e *executor) Delete(ctx context.Context, params *query.Params) (int, error) {
+	var deleted int
+	err := e.write(ctx, func(s *state) error {
+		for id, entry := range s.segs {
+			if _, ok := entry.match(params); ok {
+				delete(s.segs, id)
+				deleted++
+			}
+		}
+		return nil
+	})
+	return deleted, err
+}
+
+func (e *executor) DeleteExpired(ctx context.Context, now time.Time) (int, error) {
+	var deleted int
+	err := e.write(ctx, func(s *state) error {
+		for id, entry := range s.segs {
+			if entry.expiration < now.Unix() {
+				delete(s.segs, id)
+				deleted++
+			}
+		}
+		return nil
+	})
+	return deleted, err
+}
+
+func (e *executor) Get(ctx context.Context, params *query.Params) (query.Results, error) {
+	var matches []match
+	err := e.read(ctx, func(s *state) error {
+		matches = s.query(params)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	res := make(query.Results, 0, len(matches))
+	for _, m := range matches {
+		r, err := m.result()
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, r)
+	}
+	return res, nil
+}
+
+func (e *executor) GetAll(ctx context.Context) (<-chan query.ResultOrErr, error) {
+	var matches []match
+	err := e.read(ctx, func(s *state) error {
+		matches = s.query(nil)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	// Since we have everything in memory anyway we just fill the channel at the start.
+	resCh := make(chan query.ResultOrErr, len(matches))
+	for _, m := range matches {
+		r, err := m.result()
+		if err != nil {
+			resCh <- query.ResultOrErr{Err: err}
+			break
+		}
+		resCh <- query.ResultOrErr{Result: r}
+	}
+	close(resCh)
+	return resCh, nil
+}
+
+func (e *executor) InsertNextQuery(ctx context.Context, src, dst addr.IA, policy pathdb.PolicyHash,
+	nextQuery time.Time) (bool, error) {
+
+	key := newNQKey(src, dst, policy)
+	var inserted bool
+	err := e.write(ctx, func(s *state) error {
+		if existing, ok := s.nextQueries[key]; ok && !nextQuery.After(existing) {
+			return nil
+		}
+		s.nextQueries[key] = nextQuery
+		inserted = true
+		return nil
+	})
+	return inserted, err
+}
+
+func (e *executor) GetNextQuery(ctx context.Context, src, dst addr.IA,
+	policy pathdb.PolicyHash) (time.Time, error) {
+
+	key := newNQKey(src, dst, policy)
+	var nextQuery time.Time
+	err := e.read(ctx, func(s *state) error {
+		nextQuery = s.nextQueries[key]
+		return nil
+	})
+	return nextQuery, err
+}
+
+func (e *executor) DeleteExpiredNQ(ctx context.Context, now time.Time) (int, error) {
+	var deleted int
+	err := e.write(ctx, func(s *state) error {
+		for key, nextQuery := range s.nextQueries {
+			if nextQuery.Before(now) {
+				delete(s.nextQueries, key)
+				deleted++
+			}
+		}
+		return nil
+	})
+	return deleted, err
+}
+
+func (e *executor) DeleteNQ(ctx context.Context, src, dst addr.IA,
+	policy pathdb.PolicyHash) (int, error) {
+
+	var deleted int
+	err := e.write(ctx, func(s *state) error {
+		for key := range s.nextQueries {
+			if !src.IsZero() && !src.Equal(key.src) {
+				continue
+			}
+			if !dst.IsZero() && !dst.Equal(key.dst) {
+				continue
+			}
+			if policy != nil && string(policy) != key.policy {
+				continue
+			}
+			delete(s.nextQueries, key)
+			deleted++
+		}
+		return nil
+	})
+	return deleted, err
+}
+
+// state is the content of the database.
+type state struct {
+	segs        map[string]*segEntry
+	nextQueries map[nqKey]time.Time
+	// seq is the sequence number of the last inserted segment.
+	seq uint64
+}
+
+func newState() *state {
+	return &state{
+		segs:        make(map[string]*segEntry),
+		nextQueries: make(map[nqKey]time.Time),
+	}
+}
+
+// clone returns a copy of the state. The entries are never modified once they
+// are stored, hence they are shared between the copies.
+func (s *state) clone() *state {
+	c := &state{
+		segs:        make(map[string]*segEntry, len(s.segs)),
+		nextQueries: make(map[nqKey]time.Time, len(s.nextQueries)),
+		seq:         s.seq,
+	}
+	for id, entry := range s.segs {
+		c.segs[id] = entry
+	}
+	for key, nextQuery := range s.nextQueries {
+		c.nextQueries[key] = nextQuery
+	}
+	return c
+}
+
+// query returns the segments matching the parameters ordered by the last
+// update.
+func (s *state) query(params *query.Params) []match {
+	var matches []match
+	for _, entry := range s.segs {
+		if m, ok := entry.match(params); ok {
+			matches = append(matches, m)
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		a, b := matches[i].entry, matches[j].entry
+		if !a.lastUpdated.Equal(b.lastUpdated) {
+			return a.lastUpdated.Before(b.lastUpdated)
+		}
+		return a.seq < b.seq
+	})
+	return matches
+}
+
+type nqKey struct {
+	src    addr.IA
+	dst    addr.IA
+	policy string
+}
+
+func newNQKey(src, dst addr.IA, policy pathdb.PolicyHash) nqKey {
+	if policy == nil {
+		policy = pathdb.NoPolicy
+	}
+	return nqKey{src: src, dst: dst, policy: string(policy)}
+}
+
+type segEntry struct {
+	id          string
+	seq         uint64
+	packed      common.RawBytes
+	start       addr.IA
+	end         addr.IA
+	infoTime    time.Time
+	expiration  int64
+	lastUpdated time.Time
+	types       []proto.PathSegType
+	hpCfgIDs    []query.HPCfgID
+	intfs       []query.IntfSpec
+}
+
+func newSegEntry(pseg *seg.PathSegment) (*segEntry, error) {
+	segID, err := pseg.ID()
+	if err != nil {
+		return nil, err
+	}
+	info, err := pseg.InfoF()
+	if err != nil {
+		return nil, err
+	}
+	packed, err := pseg.Pack()
+	if err != nil {
+		return nil, err
+	}
+	entry := &segEntry{
+		id:          string(segID),
+		packed:      packed,
+		start:       pseg.FirstIA(),
+		end:         pseg.LastIA(),
+		infoTime:    info.Timestamp(),
+		expiration:  pseg.MaxExpiry().Unix(),
+		lastUpdated: time.Now(),
+	}
+	for _, as := range pseg.ASEntries {
+		ia := as.IA()
+		for idx, hop := range as.HopEntries {
+			hof, err := hop.HopField()
+			if err != nil {
+				return nil, common.NewBasicError("Failed to extract hop field", err)
+			}
+			if hof.ConsIngress != 0 {
+				entry.intfs = append(entry.intfs, query.IntfSpec{IA: ia, IfID: hof.ConsIngress})
+			}
+			// Only consider the Egress interface for the first hop entry in an AS entry.
+			if idx == 0 && hof.ConsEgress != 0 {
+				entry.intfs = append(entry.intfs, query.IntfSpec{IA: ia, IfID: hof.ConsEgress})
+			}
+		}
+	}
+	return entry, nil
+}
+
+// addType adds the type to the entry. It must only be called before the entry
+// is stored.
+func (e *segEntry) addType(segType proto.PathSegType) {
+	for _, t := range e.types {
+		if t == segType {
+			return
+		}
+	}
+	e.types = append(e.types[:len(e.types):len(e.types)], segType)
+}
+
+// addHPCfgID adds the hpCfgID to the entry. It must only be called before the
+// entry is stored.
+func (e *segEntry) addHPCfgID(hpCfgID query.HPCfgID) {
+	for _, id := range e.hpCfgIDs {
+		if id.Equal(&hpCfgID) {
+			return
+		}
+	}
+	e.hpCfgIDs = append(e.hpCfgIDs[:len(e.hpCfgIDs):len(e.hpCfgIDs)], hpCfgID)
+}
+
+// match checks whether the entry matches the parameters. If it does, the
+// returned match contains the types and hpCfgIDs that match the parameters.
+func (e *segEntry) match(params *query.Params) (match, bool) {
+	m := match{entry: e, types: e.types, hpCfgIDs: e.hpCfgIDs}
+	if params == nil {
+		return m, true
+	}
+	if len(params.SegIDs) > 0 && !e.matchSegIDs(params.SegIDs) {
+		return match{}, false
+	}
+	if len(params.SegTypes) > 0 {
+		m.types = nil
+		for _, t := range e.types {
+			for _, segType := range params.SegTypes {
+				if t == segType {
+					m.types = append(m.types, t)
+					break
+				}
+			}
+		}
+		if len(m.types) == 0 {
+			return match{}, false
+		}
+	}
+	if len(params.HpCfgIDs) > 0 {
+		m.hpCfgIDs = nil
+		for i := range e.hpCfgIDs {
+			for _, hpCfgID := range params.HpCfgIDs {
+				if e.hpCfgIDs[i].Equal(hpCfgID) {
+					m.hpCfgIDs = append(m.hpCfgIDs, e.hpCfgIDs[i])
+					break
+				}
+			}
+		}
+		if len(m.hpCfgIDs) == 0 {
+			return match{}, false
+		}
+	}
+	if len(params.Intfs) > 0 && !e.matchIntfs(params.Intfs) {
+		return match{}, false
+	}
+	if len(params.StartsAt) > 0 && !matchIA(e.start, params.StartsAt) {
+		return match{}, false
+	}
+	if len(params.EndsAt) > 0 && !matchIA(e.end, params.EndsAt) {
+		return match{}, false
+	}
+	if params.MinLastUpdate != nil && !e.lastUpdated.After(*params.MinLastUpdate) {
+		return match{}, false
+	}
+	return m, true
+}
+
+func (e *segEntry) matchSegIDs(segIDs []common.RawBytes) bool {
+	for _, segID := range segIDs {
+		if bytes.Equal(segID, []byte(e.id)) {
+			return true
+		}
+	}
+	return false
+}
+
+func (e *segEntry) matchIntfs(specs []*query.IntfSpec) bool {
+	for _, intf := range e.intfs {
+		for _, spec := range specs {
+			if intf.IA.Equal(spec.IA) && intf.IfID == spec.IfID {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// matchIA checks whether ia matches any of the given ISD-ASes. An ISD-AS with
+// a zero AS matches any AS in the ISD.
+func matchIA(ia addr.IA, ias []addr.IA) bool {
+	for _, other := range ias {
+		if other.I == ia.I && (other.A == 0 || other.A == ia.A) {
+			return true
+		}
+	}
+	return false
+}
+
+// match is a segment matching a query.
+type match struct {
+	entry    *segEntry
+	types    []proto.PathSegType
+	hpCfgIDs []query.HPCfgID
+}
+
+// result returns the query result containing a copy of the stored segment.
+func (m match) result() (*query.Result, error) {
+	pseg, err := seg.NewSegFromRaw(m.entry.packed)
+	if err != nil {
+		return nil, common.NewBasicError("Error unmarshalling segment", err)
+	}
+	res := &query.Result{
+		Seg:        pseg,
+		LastUpdate: m.entry.lastUpdated,
+		Type:       m.types[0],
+	}
+	for _, hpCfgID := range m.hpCfgIDs {
+		hpCfgID := hpCfgID
+		res.HpCfgIDs = append(res.HpCfgIDs, &hpCfgID)
+	}
+	return res, nil
+}
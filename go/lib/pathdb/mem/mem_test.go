@@ -0,0 +1,37 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mem
+
+import (
+	"context"
+	"testing"
+
+	"github.com/scionproto/scion/go/lib/pathdb/pathdbtest"
+)
+
+var _ pathdbtest.TestablePathDB = (*TestPathDB)(nil)
+
+type TestPathDB struct {
+	*Backend
+}
+
+func (b *TestPathDB) Prepare(t *testing.T, _ context.Context) {
+	b.Backend = New()
+}
+
+func TestPathDBSuite(t *testing.T) {
+	tdb := &TestPathDB{}
+	pathdbtest.TestPathDB(t, tdb)
+}
@@ -13,6 +13,7 @@ import (
 	pathmgr "github.com/scionproto/scion/go/lib/pathmgr"
 	pathpol "github.com/scionproto/scion/go/lib/pathpol"
 	sciond "github.com/scionproto/scion/go/lib/sciond"
+	snet "github.com/scionproto/scion/go/lib/snet"
 	spathmeta "github.com/scionproto/scion/go/lib/spath/spathmeta"
 	reflect "reflect"
 )
@@ -40,18 +41,18 @@ func (m *MockPolicy) EXPECT() *MockPolicyMockRecorder {
 	return m.recorder
 }
 
-// Filter mocks base method
-func (m *MockPolicy) Filter(arg0 pathpol.PathSet) pathpol.PathSet {
+// Select mocks base method
+func (m *MockPolicy) Select(arg0 pathpol.PathSet) []pathpol.Path {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Filter", arg0)
-	ret0, _ := ret[0].(pathpol.PathSet)
+	ret := m.ctrl.Call(m, "Select", arg0)
+	ret0, _ := ret[0].([]pathpol.Path)
 	return ret0
 }
 
-// Filter indicates an expected call of Filter
-func (mr *MockPolicyMockRecorder) Filter(arg0 interface{}) *gomock.Call {
+// Select indicates an expected call of Select
+func (mr *MockPolicyMockRecorder) Select(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Filter", reflect.TypeOf((*MockPolicy)(nil).Filter), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Select", reflect.TypeOf((*MockPolicy)(nil).Select), arg0)
 }
 
 // MockQuerier is a mock of Querier interface
@@ -129,10 +130,10 @@ func (mr *MockResolverMockRecorder) Query(arg0, arg1, arg2, arg3 interface{}) *g
 }
 
 // QueryFilter mocks base method
-func (m *MockResolver) QueryFilter(arg0 context.Context, arg1, arg2 addr.IA, arg3 pathmgr.Policy) spathmeta.AppPathSet {
+func (m *MockResolver) QueryFilter(arg0 context.Context, arg1, arg2 addr.IA, arg3 pathmgr.Policy) []snet.Path {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "QueryFilter", arg0, arg1, arg2, arg3)
-	ret0, _ := ret[0].(spathmeta.AppPathSet)
+	ret0, _ := ret[0].([]snet.Path)
 	return ret0
 }
 
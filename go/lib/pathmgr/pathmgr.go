@@ -23,9 +23,11 @@
 //
 // Periodic path queries are added via 'Watch', which returns a pointer to a
 // thread-safe SyncPaths object; calling Load on the object returns the data
-// associated with the watch, which includes the set of paths. When updating
-// paths, the resolver will atomically change the value within the SyncPaths
-// object. The data can be accessed by calling Load again.
+// associated with the watch, which includes the set of paths and the paths
+// ordered by preference. A watch subscribes to the paths in SCIOND, and SCIOND
+// pushes an update whenever paths appear, expire or are revoked. On every update, the resolver will
+// atomically change the value within the SyncPaths object. The data can be
+// accessed by calling Load again.
 //
 // An example of how this package can be used can be found in the associated
 // infra test file.
@@ -47,32 +49,27 @@ import (
 
 // Timers is used to customize the timers for a new Path Manager.
 type Timers struct {
-	// Wait time after a successful path lookup (for periodic lookups)
-	NormalRefire time.Duration
-	// Wait time after a failed (error or empty) path lookup (for periodic lookups)
+	// Wait time after a path subscription failed or ended before subscribing
+	// again (for watches)
 	ErrorRefire time.Duration
+	// Time allocated for a one-shot path query to SCIOND, and the time a
+	// watch waits for the first subscription update before it falls back to
+	// a one-shot query
+	QueryTimeout time.Duration
 }
 
 func (timers *Timers) initDefaults() {
-	if timers.NormalRefire == 0 {
-		timers.NormalRefire = DefaultNormalRefire
-	}
 	if timers.ErrorRefire == 0 {
 		timers.ErrorRefire = DefaultErrorRefire
 	}
-}
-
-func (timers *Timers) GetWait(isError bool) time.Duration {
-	if isError {
-		return timers.ErrorRefire
+	if timers.QueryTimeout == 0 {
+		timers.QueryTimeout = DefaultQueryTimeout
 	}
-	return timers.NormalRefire
 }
 
 const (
-	// DefaultNormalRefire is the wait time after a successful path lookup (for periodic lookups)
-	DefaultNormalRefire = 10 * time.Second
-	// DefaultErorrRefire is the wait time after a failed path lookup (for periodic lookups)
+	// DefaultErrorRefire is the wait time after a failed path subscription
+	// (for watches)
 	DefaultErrorRefire = time.Second
 	// DefaultQueryTimeout is the time allocated for a query to SCIOND
 	DefaultQueryTimeout = 5 * time.Second
@@ -80,9 +77,10 @@ const (
 	DefaultPathCount = 5
 )
 
-// Policy is used to filter paths
+// Policy is used to filter and order paths.
 type Policy interface {
-	Filter(pathpol.PathSet) pathpol.PathSet
+	// Select returns the paths that pass the policy, ordered best first.
+	Select(pathpol.PathSet) []pathpol.Path
 }
 
 type Querier interface {
@@ -92,14 +90,17 @@ type Querier interface {
 
 type Resolver interface {
 	Querier
-	// QueryFilter returns a set of paths between src and dst that satisfy
-	// policy. A nil policy will not delete any paths.
-	QueryFilter(ctx context.Context, src, dst addr.IA, policy Policy) spathmeta.AppPathSet
-	// Watch returns an object that periodically polls for paths between src
-	// and dst.
+	// QueryFilter returns the paths between src and dst that satisfy policy,
+	// ordered by the preference of policy. A nil policy will not delete any
+	// paths, and keeps the order of SCIOND.
+	QueryFilter(ctx context.Context, src, dst addr.IA, policy Policy) []snet.Path
+	// Watch returns an object that is kept up to date with the paths between
+	// src and dst pushed by SCIOND.
 	//
-	// The function blocks until the first answer from SCIOND is received. The
-	// amount of time is dictated by ctx. Note that the resolver might
+	// The function blocks until the first answer from SCIOND is received, or
+	// until ctx is done. If the subscription does not deliver an update within
+	// the query timeout, the paths are filled with a one-shot query instead,
+	// so the wait is bounded even if ctx is not. Note that the resolver might
 	// asynchronously change the paths at any time. Calling Load on the
 	// returned object returns a reference to a structure containing the
 	// currently available paths.
@@ -108,16 +109,18 @@ type Resolver interface {
 	// lifetime or until Destroy is called on the SyncPaths object.
 	Watch(ctx context.Context, src, dst addr.IA) (*SyncPaths, error)
 	// WatchFilter returns a pointer to a SyncPaths object that contains paths from
-	// src to dst that adhere to the specified filter. On path changes the list is
-	// refreshed automatically.
+	// src to dst that adhere to the specified filter, ordered by the preference
+	// of the filter. On path changes the list is refreshed automatically.
 	//
 	// A nil filter will not delete any paths.
 	WatchFilter(ctx context.Context, src, dst addr.IA, filter Policy) (*SyncPaths, error)
 	// WatchCount returns the number of active watchers.
 	WatchCount() int
-	// RevokeRaw informs SCIOND of a revocation.
+	// RevokeRaw informs SCIOND of a revocation. SCIOND pushes the updated
+	// paths to the affected watches.
 	RevokeRaw(ctx context.Context, rawSRevInfo common.RawBytes)
-	// Revoke informs SCIOND of a revocation.
+	// Revoke informs SCIOND of a revocation. SCIOND pushes the updated paths
+	// to the affected watches.
 	Revoke(ctx context.Context, sRevInfo *path_mgmt.SignedRevInfo)
 }
 
@@ -152,51 +155,55 @@ func New(conn sciond.Connector, timers Timers, pathCount uint16) Resolver {
 func (r *resolver) Query(ctx context.Context, src, dst addr.IA,
 	flags sciond.PathReqFlags) spathmeta.AppPathSet {
 
+	return spathmeta.NewAppPathSet(r.query(ctx, src, dst, flags))
+}
+
+func (r *resolver) QueryFilter(ctx context.Context, src, dst addr.IA,
+	policy Policy) []snet.Path {
+
+	paths := r.query(ctx, src, dst, sciond.PathReqFlags{})
+	if policy == nil {
+		return paths
+	}
+	return selectPaths(policy, paths)
+}
+
+func (r *resolver) query(ctx context.Context, src, dst addr.IA,
+	flags sciond.PathReqFlags) []snet.Path {
+
 	if flags.PathCount == 0 {
 		flags.PathCount = r.pathCount
 	}
 	paths, err := r.sciondConn.Paths(ctx, dst, src, flags)
 	if err != nil {
 		r.logger(ctx).Error("SCIOND network error", "err", err)
-		return make(spathmeta.AppPathSet)
+		return nil
 	}
-	return spathmeta.NewAppPathSet(paths)
-}
-
-func (r *resolver) QueryFilter(ctx context.Context, src, dst addr.IA,
-	policy Policy) spathmeta.AppPathSet {
-
-	aps := r.Query(ctx, src, dst, sciond.PathReqFlags{})
-	if policy == nil {
-		return aps
-	}
-	return psToAps(policy.Filter(apsToPs(aps)))
+	return paths
 }
 
 func (r *resolver) WatchFilter(ctx context.Context, src, dst addr.IA,
 	filter Policy) (*SyncPaths, error) {
 
-	aps := r.Query(ctx, src, dst, sciond.PathReqFlags{})
-	if filter != nil {
-		aps = psToAps(filter.Filter(apsToPs(aps)))
-	}
 	sp := NewSyncPaths()
-	sp.Update(aps)
-
 	query := &queryConfig{
-		querier: Querier(r),
-		src:     src,
-		dst:     dst,
-		filter:  filter,
+		conn:   r.sciondConn,
+		src:    src,
+		dst:    dst,
+		flags:  sciond.PathReqFlags{PathCount: r.pathCount},
+		filter: filter,
 	}
-	pp := NewPollingPolicy(filter != nil, r.timers)
-	w := r.watchFactory.New(sp, query, pp)
+	w := r.watchFactory.New(sp, query)
 	sp.setDestructor(w.Destroy)
 
 	go func() {
 		defer log.HandlePanic()
 		w.Run()
 	}()
+	select {
+	case <-w.Ready():
+	case <-ctx.Done():
+	}
 	return sp, nil
 }
 
@@ -233,19 +240,7 @@ func (r *resolver) Revoke(ctx context.Context, sRevInfo *path_mgmt.SignedRevInfo
 	}
 	switch reply.Result {
 	case sciond.RevUnknown, sciond.RevValid:
-		// Each watcher contains a cache; purge paths matched by the revocation
-		// immediately from each cache.
-		pi := sciond.PathInterface{RawIsdas: revInfo.IA().IAInt(),
-			IfID: common.IFIDType(revInfo.IfID)}
-		f := func(w *WatchRunner) {
-			pathsBeforeRev := w.sp.Load().APS
-			pathsAfterRev := dropRevoked(pathsBeforeRev, pi)
-			w.sp.Update(pathsAfterRev)
-			if len(pathsAfterRev) == 0 && len(pathsBeforeRev) > 0 {
-				w.pp.PollNow()
-			}
-		}
-		r.watchFactory.apply(f)
+		logger.Debug("Informed SCIOND about revocation", "revInfo", revInfo)
 	case sciond.RevStale:
 		logger.Warn("Found stale revocation notification", "revInfo", revInfo)
 	case sciond.RevInvalid:
@@ -257,37 +252,17 @@ func (r *resolver) logger(ctx context.Context) log.Logger {
 	return log.FromCtx(ctx).New("lib", "PathResolver")
 }
 
-func dropRevoked(aps spathmeta.AppPathSet, pi sciond.PathInterface) spathmeta.AppPathSet {
-	other := make(spathmeta.AppPathSet)
-	for key, path := range aps {
-		if !matches(path, pi) {
-			other[key] = path
-		}
-	}
-	return other
-}
-
-func matches(path snet.Path, predicatePI sciond.PathInterface) bool {
-	for _, pi := range path.Interfaces() {
-		if pi.IA().Equal(predicatePI.IA()) && pi.ID() == predicatePI.ID() {
-			return true
-		}
+// selectPaths returns the paths selected by policy, in the order of the
+// policy.
+func selectPaths(policy Policy, paths []snet.Path) []snet.Path {
+	ps := make(pathpol.PathSet, len(paths))
+	for _, path := range paths {
+		ps[path.Fingerprint()] = path
 	}
-	return false
-}
-
-func apsToPs(aps spathmeta.AppPathSet) pathpol.PathSet {
-	ps := make(pathpol.PathSet, len(aps))
-	for key, path := range aps {
-		ps[key] = path
-	}
-	return ps
-}
-
-func psToAps(ps pathpol.PathSet) spathmeta.AppPathSet {
-	aps := make(spathmeta.AppPathSet)
-	for _, path := range ps {
-		aps[path.Fingerprint()] = path.(snet.Path)
+	selected := policy.Select(ps)
+	result := make([]snet.Path, 0, len(selected))
+	for _, path := range selected {
+		result = append(result, path.(snet.Path))
 	}
-	return aps
+	return result
 }
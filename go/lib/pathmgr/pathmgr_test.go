@@ -112,7 +112,7 @@ func TestQueryFilter(t *testing.T) {
 		"Deny policy": {
 			Policy: func(ctrl *gomock.Controller) pathmgr.Policy {
 				pol := mock_pathmgr.NewMockPolicy(ctrl)
-				pol.EXPECT().Filter(gomock.Any()).Return(make(pathpol.PathSet))
+				pol.EXPECT().Select(gomock.Any()).Return(nil)
 				return pol
 			},
 			ExpectedPaths: 0,
@@ -120,9 +120,13 @@ func TestQueryFilter(t *testing.T) {
 		"Accept policy": {
 			Policy: func(_ *gomock.Controller) pathmgr.Policy {
 				pol := mock_pathmgr.NewMockPolicy(ctrl)
-				pol.EXPECT().Filter(gomock.Any()).DoAndReturn(
-					func(ps pathpol.PathSet) pathpol.PathSet {
-						return ps
+				pol.EXPECT().Select(gomock.Any()).DoAndReturn(
+					func(ps pathpol.PathSet) []pathpol.Path {
+						var paths []pathpol.Path
+						for _, path := range ps {
+							paths = append(paths, path)
+						}
+						return paths
 					},
 				)
 				return pol
@@ -146,6 +150,38 @@ func TestQueryFilter(t *testing.T) {
 	}
 }
 
+func TestQueryFilterOrder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	sd := mock_sciond.NewMockConnector(ctrl)
+	pm := pathmgr.New(sd, pathmgr.Timers{}, 5)
+
+	srcIA := xtest.MustParseIA("1-ff00:0:133")
+	dstIA := xtest.MustParseIA("1-ff00:0:131")
+
+	paths := buildSDAnswer(t, ctrl,
+		fmt.Sprintf("%s#1019 1-ff00:0:132#1910 1-ff00:0:132#1916 %s#1619", srcIA, dstIA),
+		fmt.Sprintf("%s#1020 1-ff00:0:132#2010 1-ff00:0:132#1916 %s#1619", srcIA, dstIA),
+	)
+	sd.EXPECT().Paths(gomock.Any(), dstIA, srcIA, gomock.Any()).Return(paths, nil)
+
+	// The policy prefers the path that SCIOND returned last.
+	pol := mock_pathmgr.NewMockPolicy(ctrl)
+	pol.EXPECT().Select(gomock.Any()).DoAndReturn(
+		func(ps pathpol.PathSet) []pathpol.Path {
+			return []pathpol.Path{
+				ps[paths[1].Fingerprint()],
+				ps[paths[0].Fingerprint()],
+			}
+		},
+	)
+	selected := pm.QueryFilter(context.Background(), srcIA, dstIA, pol)
+	require.Len(t, selected, 2)
+	assert.Equal(t, paths[1].Fingerprint(), selected[0].Fingerprint())
+	assert.Equal(t, paths[0].Fingerprint(), selected[1].Fingerprint())
+}
+
 func TestWatchCount(t *testing.T) {
 	t.Log("Given a path manager and adding a watch")
 
@@ -158,7 +194,11 @@ func TestWatchCount(t *testing.T) {
 	src := xtest.MustParseIA("1-ff00:0:111")
 	dst := xtest.MustParseIA("1-ff00:0:110")
 
-	sd.EXPECT().Paths(gomock.Any(), dst, src, gomock.Any()).AnyTimes()
+	updates := make(chan sciond.PathUpdate, 1)
+	updates <- sciond.PathUpdate{}
+	sd.EXPECT().SubscribePaths(gomock.Any(), dst, src, gomock.Any()).DoAndReturn(
+		mockSubscription(updates),
+	)
 
 	assert.Equal(t, pr.WatchCount(), 0, " the count is initially 0")
 	sp, err := pr.Watch(context.Background(), src, dst)
@@ -168,31 +208,43 @@ func TestWatchCount(t *testing.T) {
 	assert.Equal(t, pr.WatchCount(), 0, "the number of watches decreases to 0")
 }
 
-func TestWatchPolling(t *testing.T) {
+func TestWatchUpdates(t *testing.T) {
 	t.Log("Given a path manager and adding a watch that retrieves zero paths")
 
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	sd := mock_sciond.NewMockConnector(ctrl)
-	pr := pathmgr.New(sd, pathmgr.Timers{ErrorRefire: getDuration(1)}, 5)
+	pr := pathmgr.New(sd, pathmgr.Timers{}, 5)
 
 	src := xtest.MustParseIA("1-ff00:0:111")
 	dst := xtest.MustParseIA("1-ff00:0:110")
-	gomock.InOrder(
-		sd.EXPECT().Paths(gomock.Any(), dst, src, gomock.Any()),
-		sd.EXPECT().Paths(gomock.Any(), dst, src, gomock.Any()).Return(
-			buildSDAnswer(t, ctrl,
-				"1-ff00:0:111#105 1-ff00:0:130#1002 1-ff00:0:130#1004 1-ff00:0:110#2",
-			), nil,
-		).MinTimes(1),
-	)
+	updates := make(chan sciond.PathUpdate, 1)
+	updates <- sciond.PathUpdate{}
+	sd.EXPECT().SubscribePaths(gomock.Any(), dst, src,
+		sciond.PathReqFlags{PathCount: 5}).DoAndReturn(mockSubscription(updates))
 
 	sp, err := pr.Watch(context.Background(), src, dst)
 	require.NoError(t, err)
+	defer sp.Destroy()
 	assert.Len(t, sp.Load().APS, 0, "there are 0 paths currently available")
-	time.Sleep(getDuration(4))
-	assert.Len(t, sp.Load().APS, 1, "and after waiting, we get pathmgr.New paths")
+
+	updates <- sciond.PathUpdate{
+		Paths: buildSDAnswer(t, ctrl,
+			"1-ff00:0:111#105 1-ff00:0:130#1002 1-ff00:0:130#1004 1-ff00:0:110#2",
+		),
+	}
+	waitForPaths(t, sp, 1, "after SCIOND pushes a path, we get the new path")
+
+	// The channel has a buffer of one, the third send only completes once the
+	// first error has been handled.
+	for i := 0; i < 3; i++ {
+		updates <- sciond.PathUpdate{Err: errors.New("no paths")}
+	}
+	assert.Len(t, sp.Load().APS, 1, "after SCIOND pushes an error, the path is kept")
+
+	updates <- sciond.PathUpdate{}
+	waitForPaths(t, sp, 0, "after SCIOND pushes zero paths, there are no paths")
 }
 
 func TestWatchFilter(t *testing.T) {
@@ -201,150 +253,177 @@ func TestWatchFilter(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	sd := mock_sciond.NewMockConnector(ctrl)
-	pr := pathmgr.New(sd, pathmgr.Timers{ErrorRefire: getDuration(1)}, 5)
+	pr := pathmgr.New(sd, pathmgr.Timers{}, 5)
 
 	src := xtest.MustParseIA("1-ff00:0:111")
 	dst := xtest.MustParseIA("1-ff00:0:110")
-	gomock.InOrder(
-		sd.EXPECT().Paths(gomock.Any(), dst, src, gomock.Any()).Return(
-			buildSDAnswer(t, ctrl,
-				"1-ff00:0:111#104 1-ff00:0:120#5 1-ff00:0:120#6 1-ff00:0:110#1",
-			), nil,
+	updates := make(chan sciond.PathUpdate, 1)
+	updates <- sciond.PathUpdate{
+		Paths: buildSDAnswer(t, ctrl,
+			"1-ff00:0:111#104 1-ff00:0:120#5 1-ff00:0:120#6 1-ff00:0:110#1",
 		),
-		sd.EXPECT().Paths(gomock.Any(), dst, src, gomock.Any()).Return(
-			buildSDAnswer(t, ctrl,
-				"1-ff00:0:111#105 1-ff00:0:130#1002 1-ff00:0:130#1004 1-ff00:0:110#2",
-				"1-ff00:0:111#104 1-ff00:0:120#5 1-ff00:0:120#6 1-ff00:0:110#1",
-			), nil,
-		).AnyTimes(),
+	}
+	sd.EXPECT().SubscribePaths(gomock.Any(), dst, src, gomock.Any()).DoAndReturn(
+		mockSubscription(updates),
 	)
 
 	policy := mock_pathmgr.NewMockPolicy(ctrl)
-	policy.EXPECT().Filter(gomock.Any()).DoAndReturn(
-		func(ps pathpol.PathSet) pathpol.PathSet {
-			replySet := make(pathpol.PathSet)
-			for key, v := range ps {
+	policy.EXPECT().Select(gomock.Any()).DoAndReturn(
+		func(ps pathpol.PathSet) []pathpol.Path {
+			var paths []pathpol.Path
+			for _, v := range ps {
 				for _, intf := range v.Interfaces() {
 					if intf.IA().Equal(src) && intf.ID() == 105 {
-						replySet[key] = v
+						paths = append(paths, v)
 						break
 					}
 				}
 			}
-			return replySet
+			return paths
 		},
 	).AnyTimes()
 
 	sp, err := pr.WatchFilter(context.Background(), src, dst, policy)
 	require.NoError(t, err)
+	defer sp.Destroy()
 	assert.Len(t, sp.Load().APS, 0, "there are 0 paths due to filtering")
-	time.Sleep(getDuration(4))
-	assert.Len(t, sp.Load().APS, 1, "and after waiting, we get 1 path that is not filtered")
+	updates <- sciond.PathUpdate{
+		Paths: buildSDAnswer(t, ctrl,
+			"1-ff00:0:111#105 1-ff00:0:130#1002 1-ff00:0:130#1004 1-ff00:0:110#2",
+			"1-ff00:0:111#104 1-ff00:0:120#5 1-ff00:0:120#6 1-ff00:0:110#1",
+		),
+	}
+	waitForPaths(t, sp, 1, "after the update, we get 1 path that is not filtered")
 }
 
-func TestRevokeFastRecovery(t *testing.T) {
-	t.Log("Given a path manager with a long normal timer and very small error timer")
-	t.Log("A revocation that deletes everything triggers an immediate requery")
+func TestWatchResubscribe(t *testing.T) {
+	t.Log("Given a path manager and adding a watch whose subscription fails")
 
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
+	sd := mock_sciond.NewMockConnector(ctrl)
+	pr := pathmgr.New(sd, pathmgr.Timers{ErrorRefire: getDuration(1)}, 5)
+
 	src := xtest.MustParseIA("1-ff00:0:111")
 	dst := xtest.MustParseIA("1-ff00:0:110")
+	ended := make(chan sciond.PathUpdate, 1)
+	ended <- sciond.PathUpdate{}
+	close(ended)
+	updates := make(chan sciond.PathUpdate, 1)
+	updates <- sciond.PathUpdate{
+		Paths: buildSDAnswer(t, ctrl,
+			"1-ff00:0:111#105 1-ff00:0:130#1002 1-ff00:0:130#1004 1-ff00:0:110#2",
+		),
+	}
+	gomock.InOrder(
+		sd.EXPECT().SubscribePaths(gomock.Any(), dst, src, gomock.Any()).Return(
+			nil, errors.New("SCIOND unavailable"),
+		),
+		sd.EXPECT().SubscribePaths(gomock.Any(), dst, src, gomock.Any()).DoAndReturn(
+			mockSubscription(ended),
+		),
+		sd.EXPECT().SubscribePaths(gomock.Any(), dst, src, gomock.Any()).DoAndReturn(
+			mockSubscription(updates),
+		),
+	)
+	sd.EXPECT().Paths(gomock.Any(), dst, src, gomock.Any()).Return(
+		nil, errors.New("SCIOND unavailable"),
+	).AnyTimes()
+
+	sp, err := pr.Watch(context.Background(), src, dst)
+	require.NoError(t, err)
+	defer sp.Destroy()
+	assert.Len(t, sp.Load().APS, 0, "there are 0 paths currently available")
+	waitForPaths(t, sp, 1, "after subscribing again, we get the path")
+}
+
+func TestWatchSubscribeFallback(t *testing.T) {
+	t.Log("Given a path manager and adding a watch whose subscription fails")
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
 
 	sd := mock_sciond.NewMockConnector(ctrl)
-	pr := pathmgr.New(sd, pathmgr.Timers{
-		NormalRefire: getDuration(100),
-		ErrorRefire:  getDuration(1),
-	}, 5)
+	pr := pathmgr.New(sd, pathmgr.Timers{}, 5)
+
+	src := xtest.MustParseIA("1-ff00:0:111")
+	dst := xtest.MustParseIA("1-ff00:0:110")
+	sd.EXPECT().SubscribePaths(gomock.Any(), dst, src, gomock.Any()).Return(
+		nil, errors.New("SCIOND unavailable"),
+	).AnyTimes()
+	sd.EXPECT().Paths(gomock.Any(), dst, src, sciond.PathReqFlags{PathCount: 5}).Return(
+		buildSDAnswer(t, ctrl,
+			"1-ff00:0:111#105 1-ff00:0:130#1002 1-ff00:0:130#1004 1-ff00:0:110#2",
+		), nil,
+	).MinTimes(1)
+
+	sp, err := pr.Watch(context.Background(), src, dst)
+	require.NoError(t, err)
+	defer sp.Destroy()
+	assert.Len(t, sp.Load().APS, 1, "the paths are filled with a one-shot query")
+}
+
+func TestWatchSilentSubscription(t *testing.T) {
+	t.Log("Given a path manager and adding a watch whose subscription never delivers")
 
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	sd := mock_sciond.NewMockConnector(ctrl)
+	pr := pathmgr.New(sd, pathmgr.Timers{QueryTimeout: getDuration(20)}, 5)
+
+	src := xtest.MustParseIA("1-ff00:0:111")
+	dst := xtest.MustParseIA("1-ff00:0:110")
+	updates := make(chan sciond.PathUpdate)
+	sd.EXPECT().SubscribePaths(gomock.Any(), dst, src, gomock.Any()).DoAndReturn(
+		mockSubscription(updates),
+	)
 	sd.EXPECT().Paths(gomock.Any(), dst, src, gomock.Any()).Return(
 		buildSDAnswer(t, ctrl,
 			"1-ff00:0:111#105 1-ff00:0:130#1002 1-ff00:0:130#1004 1-ff00:0:110#2",
 		), nil,
 	)
 
-	_, err := pr.Watch(context.Background(), src, dst)
+	// The context never expires, the wait must be bounded by the query timeout.
+	sp, err := pr.Watch(context.TODO(), src, dst)
 	require.NoError(t, err)
+	defer sp.Destroy()
+	assert.Len(t, sp.Load().APS, 1, "the paths are filled with a one-shot query")
 
-	// Once everything is revoked a fast request is immediately
-	// triggered. We check for at least 2 iterations to make sure we
-	// are in error recovery mode, and the aggressive timer is used.
-	// We actually test that the mock .{Revnotifications,Paths} functions are
-	// being called within a 5 time units. It will fail with "missing
-	// call(s)" error message
-	gomock.InOrder(
-		sd.EXPECT().RevNotification(gomock.Any(), gomock.Any()).Return(
-			&sciond.RevReply{Result: sciond.RevValid}, nil,
+	updates <- sciond.PathUpdate{
+		Paths: buildSDAnswer(t, ctrl,
+			"1-ff00:0:111#105 1-ff00:0:130#1002 1-ff00:0:130#1004 1-ff00:0:110#2",
+			"1-ff00:0:111#104 1-ff00:0:120#5 1-ff00:0:120#6 1-ff00:0:110#1",
 		),
-		sd.EXPECT().Paths(gomock.Any(), dst, src, gomock.Any()).MinTimes(2),
-	)
-	pr.Revoke(context.Background(), NewTestRev(t, xtest.MustParseIA("1-ff00:0:130"), 1002))
-	time.Sleep(getDuration(5))
+	}
+	waitForPaths(t, sp, 2, "after SCIOND pushes paths, the subscription takes over")
 }
 
 func TestRevoke(t *testing.T) {
-	t.Log("Given a path manager and a watch that")
+	t.Log("Given a path manager that informs SCIOND about a revocation")
 
-	src := xtest.MustParseIA("1-ff00:0:111")
-	dst := xtest.MustParseIA("1-ff00:0:110")
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	paths := []string{
-		"1-ff00:0:111#105 1-ff00:0:130#1002 1-ff00:0:130#1004 1-ff00:0:110#2",
-		"1-ff00:0:111#104 1-ff00:0:120#5 1-ff00:0:120#6 1-ff00:0:110#1",
-	}
-
 	tests := map[string]struct {
-		Paths         []string
 		RevReply      *sciond.RevReply
 		RevReplyError error
-		Revocation    *path_mgmt.SignedRevInfo
-		Remaining     int
 	}{
-		"retrieves one path, revokes an IFID that matches the path": {
-			Paths:      paths[:1],
-			RevReply:   &sciond.RevReply{Result: sciond.RevValid},
-			Revocation: NewTestRev(t, xtest.MustParseIA("1-ff00:0:130"), 1002),
-			Remaining:  0,
+		"the revocation is valid": {
+			RevReply: &sciond.RevReply{Result: sciond.RevValid},
 		},
-
-		"retrieves one path, revokes an IFID that does not match the path": {
-			Paths:      paths[:1],
-			RevReply:   &sciond.RevReply{Result: sciond.RevValid},
-			Revocation: NewTestRev(t, xtest.MustParseIA("2-ff00:0:1"), 1),
-			Remaining:  1,
-		},
-		"tries to revoke an IFID, but SCIOND encounters an error": {
-			Paths:         paths[:1],
+		"SCIOND encounters an error": {
 			RevReplyError: errors.New("some error"),
-			Revocation:    NewTestRev(t, xtest.MustParseIA("1-ff00:0:130"), 1002),
-			Remaining:     1,
-		},
-		"tries to revoke an IFID, but the revocation is invalid": {
-			Paths:      paths[:1],
-			RevReply:   &sciond.RevReply{Result: sciond.RevInvalid},
-			Revocation: NewTestRev(t, xtest.MustParseIA("1-ff00:0:130"), 1002),
-			Remaining:  1,
 		},
-		"tries to revoke an IFID, but the revocation is stale": {
-			Paths:      paths[:1],
-			RevReply:   &sciond.RevReply{Result: sciond.RevStale},
-			Revocation: NewTestRev(t, xtest.MustParseIA("1-ff00:0:130"), 1002),
-			Remaining:  1,
+		"the revocation is invalid": {
+			RevReply: &sciond.RevReply{Result: sciond.RevInvalid},
 		},
-		"tries to revoke an IFID, but the revocation is unknown": {
-			Paths:      paths[:1],
-			RevReply:   &sciond.RevReply{Result: sciond.RevUnknown},
-			Revocation: NewTestRev(t, xtest.MustParseIA("1-ff00:0:130"), 1002),
-			Remaining:  0,
+		"the revocation is stale": {
+			RevReply: &sciond.RevReply{Result: sciond.RevStale},
 		},
-		"retrieves two paths, revokes an IFID that matches one path": {
-			Paths:      paths,
-			RevReply:   &sciond.RevReply{Result: sciond.RevValid},
-			Revocation: NewTestRev(t, xtest.MustParseIA("1-ff00:0:130"), 1002),
-			Remaining:  1,
+		"the revocation is unknown": {
+			RevReply: &sciond.RevReply{Result: sciond.RevUnknown},
 		},
 	}
 
@@ -352,21 +431,13 @@ func TestRevoke(t *testing.T) {
 		t.Run(name, func(t *testing.T) {
 			sd := mock_sciond.NewMockConnector(ctrl)
 			pr := pathmgr.New(sd, pathmgr.Timers{}, 5)
-
-			sd.EXPECT().Paths(gomock.Any(), dst, src, gomock.Any()).Return(
-				buildSDAnswer(t, ctrl, test.Paths...), nil,
-			)
-			sd.EXPECT().Paths(gomock.Any(), dst, src, gomock.Any()).AnyTimes()
-			sp, err := pr.Watch(context.Background(), src, dst)
-			require.NoError(t, err)
-			sd.EXPECT().RevNotification(gomock.Any(), gomock.Any()).Return(
+			rev := NewTestRev(t, xtest.MustParseIA("1-ff00:0:130"), 1002)
+			sd.EXPECT().RevNotification(gomock.Any(), rev).Return(
 				test.RevReply, test.RevReplyError,
 			)
-			pr.Revoke(context.Background(), test.Revocation)
-			assert.Len(t, sp.Load().APS, test.Remaining)
+			pr.Revoke(context.Background(), rev)
 		})
 	}
-
 }
 
 func NewTestRev(t *testing.T, ia addr.IA, ifID common.IFIDType) *path_mgmt.SignedRevInfo {
@@ -20,16 +20,17 @@ import (
 	"sync/atomic"
 	"time"
 
+	"github.com/scionproto/scion/go/lib/snet"
 	"github.com/scionproto/scion/go/lib/spath/spathmeta"
 )
 
-// SyncPaths contains a concurrency-safe reference to an spathmeta.AppPathSet that is
-// continuously kept up to date by the path manager.  Callers can safely `Load`
-// the reference and use the paths within. At any moment, the path resolver can
-// change the value of the reference within a SyncPaths to a different slice
-// containing new paths. Calling code should reload the reference often to make
-// sure the paths are fresh. Timestamp() can be called to get the time of the
-// last write.
+// SyncPaths contains a concurrency-safe reference to the paths to a destination
+// that are kept up to date by the path manager with the path updates pushed by SCIOND.
+// Callers can safely `Load` the reference and use the paths within. At any
+// moment, the path resolver can change the value of the reference within a
+// SyncPaths to a different slice containing new paths. Calling code should
+// reload the reference often to make sure the paths are fresh. Timestamp() can
+// be called to get the time of the last write.
 //
 // A SyncPaths must never be copied.
 type SyncPaths struct {
@@ -41,9 +42,11 @@ type SyncPaths struct {
 }
 
 // SyncPathsData is the atomic value inside a SyncPaths object. It provides a
-// snapshot of a SyncPaths object. Callers must not change APS.
+// snapshot of a SyncPaths object. Callers must not change APS or Paths.
 type SyncPathsData struct {
-	APS         spathmeta.AppPathSet
+	APS spathmeta.AppPathSet
+	// Paths contains the paths of APS, ordered by preference, best first.
+	Paths       []snet.Path
 	ModifyTime  time.Time
 	RefreshTime time.Time
 }
@@ -63,14 +66,13 @@ func NewSyncPaths() *SyncPaths {
 	return sp
 }
 
-// Update adds and removes paths in sp to match newAPS. If a path was added or
-// removed, the modified timestamp is updated. The refresh timestamp is always
-// updated.
-// FIXME(scrye): Add SCIOND support s.t. the refresh timestamp is changed only
-// when paths (including path metadata) change.
-func (sp *SyncPaths) Update(newAPS spathmeta.AppPathSet) {
+// Update replaces the paths in sp with paths, which are ordered by preference.
+// If a path was added or removed, the modified timestamp is updated. The
+// refresh timestamp is always updated.
+func (sp *SyncPaths) Update(paths []snet.Path) {
 	sp.mutex.Lock()
 	defer sp.mutex.Unlock()
+	newAPS := spathmeta.NewAppPathSet(paths)
 	value := sp.Load()
 	value.RefreshTime = time.Now()
 	toAdd := setSubtract(newAPS, value.APS)
@@ -79,6 +81,7 @@ func (sp *SyncPaths) Update(newAPS spathmeta.AppPathSet) {
 		value.ModifyTime = value.RefreshTime
 	}
 	value.APS = newAPS
+	value.Paths = paths
 	sp.value.Store(value)
 }
 
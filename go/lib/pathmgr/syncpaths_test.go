@@ -22,7 +22,6 @@ import (
 	. "github.com/smartystreets/goconvey/convey"
 
 	"github.com/scionproto/scion/go/lib/pathmgr"
-	"github.com/scionproto/scion/go/lib/spath/spathmeta"
 )
 
 func TestSyncPathsTimestamp(t *testing.T) {
@@ -36,7 +35,7 @@ func TestSyncPathsTimestamp(t *testing.T) {
 
 		Convey("Call store again without changing anything", func() {
 			beforeStore := time.Now()
-			sp.Update(spathmeta.AppPathSet(nil))
+			sp.Update(nil)
 			afterStore := time.Now()
 			data := sp.Load()
 			Convey("Modify timestamp should not change", func() {
@@ -51,7 +50,7 @@ func TestSyncPathsTimestamp(t *testing.T) {
 		Convey("Update must not modify snapshot", func() {
 			data := sp.Load()
 			snap := *data
-			sp.Update(spathmeta.AppPathSet(nil))
+			sp.Update(nil)
 			Convey("Modify timestamp should not change", func() {
 				SoMsg("timestamp", data.ModifyTime, ShouldResemble, snap.ModifyTime)
 			})
@@ -16,16 +16,20 @@
 package pathmgr_test
 
 import (
+	"context"
 	"crypto/sha256"
 	"encoding/binary"
 	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 
 	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/pathmgr"
+	"github.com/scionproto/scion/go/lib/sciond"
 	"github.com/scionproto/scion/go/lib/snet"
 	"github.com/scionproto/scion/go/lib/snet/mock_snet"
 	"github.com/scionproto/scion/go/lib/xtest"
@@ -85,3 +89,47 @@ type intf struct {
 
 func (i intf) IA() addr.IA         { return i.ia }
 func (i intf) ID() common.IFIDType { return i.id }
+
+// mockSubscription returns a function that mocks SubscribePaths. The returned
+// subscriptions forward the updates from in, and end once in is closed or the
+// subscription context is done.
+func mockSubscription(in <-chan sciond.PathUpdate) func(context.Context, addr.IA, addr.IA,
+	sciond.PathReqFlags) (<-chan sciond.PathUpdate, error) {
+
+	return func(ctx context.Context, _, _ addr.IA,
+		_ sciond.PathReqFlags) (<-chan sciond.PathUpdate, error) {
+
+		out := make(chan sciond.PathUpdate)
+		go func() {
+			defer close(out)
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case update, ok := <-in:
+					if !ok {
+						return
+					}
+					select {
+					case out <- update:
+					case <-ctx.Done():
+						return
+					}
+				}
+			}
+		}()
+		return out, nil
+	}
+}
+
+// waitForPaths waits until sp contains the expected number of paths.
+func waitForPaths(t *testing.T, sp *pathmgr.SyncPaths, expected int, msg string) {
+	t.Helper()
+	for start := time.Now(); time.Since(start) < time.Second; {
+		if len(sp.Load().APS) == expected {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("%s: expected %d paths, got %d", msg, expected, len(sp.Load().APS))
+}
@@ -17,13 +17,16 @@ package pathmgr
 import (
 	"context"
 	"sync"
+	"time"
 
 	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/log"
 	"github.com/scionproto/scion/go/lib/sciond"
-	"github.com/scionproto/scion/go/lib/spath/spathmeta"
+	"github.com/scionproto/scion/go/lib/snet"
 )
 
-// WatchFactory creates and tracks path watches, i.e., path polling goroutines.
+// WatchFactory creates and tracks path watches, i.e., goroutines that apply
+// the path updates of a SCIOND path subscription.
 type WatchFactory struct {
 	timers Timers
 	// mtx protects the map operations below
@@ -38,13 +41,21 @@ func NewWatchFactory(timers Timers) *WatchFactory {
 	}
 }
 
-func (factory *WatchFactory) New(sp *SyncPaths, bq *queryConfig, pp PollingPolicy) *WatchReference {
-	ref := &WatchReference{parent: factory}
+// New creates a watch that keeps sp up to date with the paths described by
+// query.
+func (factory *WatchFactory) New(sp *SyncPaths, query *queryConfig) *WatchReference {
+	ctx, stopF := context.WithCancel(context.Background())
+	ready := make(chan struct{})
+	ref := &WatchReference{parent: factory, ready: ready}
+	factory.mtx.Lock()
+	defer factory.mtx.Unlock()
 	factory.instances[ref] = &WatchRunner{
-		sp:      sp,
-		querier: bq,
-		pp:      pp,
-		closeC:  make(chan struct{}),
+		sp:     sp,
+		query:  query,
+		timers: factory.timers,
+		ctx:    ctx,
+		stopF:  stopF,
+		ready:  ready,
 	}
 	return ref
 }
@@ -63,21 +74,13 @@ func (factory *WatchFactory) length() int {
 	return len(factory.instances)
 }
 
-func (factory *WatchFactory) apply(f func(*WatchRunner)) {
-	factory.mtx.RLock()
-	defer factory.mtx.RUnlock()
-	for _, w := range factory.instances {
-		f(w)
-	}
-}
-
 func (factory *WatchFactory) run(ref *WatchReference) {
 	// Run must execute outside the lock, because it is a long-running worker.
 	watch := factory.getRunner(ref)
 	if watch != nil {
 		// The caller destroyed the reference before it got to run. Because the
-		// polling loop usually runs in its own goroutine, this can happen if
-		// the caller quickly calls destroy.
+		// subscription loop usually runs in its own goroutine, this can happen
+		// if the caller quickly calls destroy.
 		watch.Run()
 	}
 }
@@ -95,6 +98,7 @@ func (factory *WatchFactory) getRunner(ref *WatchReference) *WatchRunner {
 // Calling Run after a reference has been destroyed will result in a no-op.
 type WatchReference struct {
 	parent *WatchFactory
+	ready  <-chan struct{}
 }
 
 func (ref *WatchReference) Run() {
@@ -105,54 +109,151 @@ func (ref *WatchReference) Destroy() {
 	ref.parent.destroy(ref)
 }
 
-// WatchRunner polls SCIOND in accordance to a polling policy, updating a
-// concurrency-safe store of paths after every poll.
+// Ready returns a channel that is closed once the watch applied the first
+// path update, or fell back to a one-shot path query.
+func (ref *WatchReference) Ready() <-chan struct{} {
+	return ref.ready
+}
+
+// WatchRunner subscribes to the paths in SCIOND, updating a concurrency-safe
+// store of paths after every update pushed by SCIOND. If the subscription
+// does not deliver an update within the query timeout, cannot be established,
+// or ends, the runner falls back to a one-shot path query and subscribes again
+// after the error refire timer.
 //
 // Call Stop to shut down the running goroutine. It is safe to call Stop
 // multiple times from different goroutines.
 type WatchRunner struct {
-	pp      PollingPolicy
-	sp      *SyncPaths
-	querier *queryConfig
-	closeC  chan struct{}
+	sp     *SyncPaths
+	query  *queryConfig
+	timers Timers
+	// ctx is done once the runner is stopped. All subscriptions are derived
+	// from it.
+	ctx       context.Context
+	stopF     context.CancelFunc
+	ready     chan struct{}
+	readyOnce sync.Once
 }
 
 func (w *WatchRunner) Run() {
 	for {
-		w.pp.UpdateState(w.sp.Load().APS)
+		w.runSubscription()
+		if w.ctx.Err() != nil {
+			return
+		}
+		// The subscription failed or ended, poll the paths so that they do
+		// not go stale until the next subscription delivers an update.
+		w.poll()
+		w.markReady()
+		timer := time.NewTimer(w.timers.ErrorRefire)
 		select {
-		case <-w.closeC:
-			w.pp.Destroy()
+		case <-w.ctx.Done():
+			timer.Stop()
 			return
-		case flags := <-w.pp.PollC():
-			ctx, cancelF := context.WithTimeout(context.Background(), DefaultQueryTimeout)
-			w.sp.Update(w.querier.Do(ctx, flags))
-			cancelF()
+		case <-timer.C:
 		}
 	}
 }
 
-func (w *WatchRunner) Stop() {
-	select {
-	case <-w.closeC:
-	default:
-		close(w.closeC)
+// runSubscription subscribes to the paths and applies the updates until the
+// subscription ends. If no update arrives within the query timeout, the paths
+// are polled once.
+func (w *WatchRunner) runSubscription() {
+	ctx, cancelF := context.WithCancel(w.ctx)
+	defer cancelF()
+	updates, err := w.query.Subscribe(ctx)
+	if err != nil {
+		log.Error("Unable to subscribe to paths", "src", w.query.src, "dst", w.query.dst,
+			"err", err)
+		return
 	}
+	timer := time.NewTimer(w.timers.QueryTimeout)
+	defer timer.Stop()
+	// timeout is reset to nil after the first update, so that a late timer
+	// does not overwrite the pushed paths.
+	timeout := timer.C
+	for {
+		select {
+		case update, ok := <-updates:
+			if !ok {
+				return
+			}
+			timeout = nil
+			if update.Err != nil {
+				// A failed lookup does not mean the current paths are
+				// gone, keep them until SCIOND pushes new ones.
+				log.Error("SCIOND path update had an error, keeping the current paths",
+					"src", w.query.src, "dst", w.query.dst, "err", update.Err)
+			} else {
+				w.sp.Update(w.query.Apply(update))
+			}
+			w.markReady()
+		case <-timeout:
+			timeout = nil
+			log.Info("No path update received from subscription, querying paths",
+				"src", w.query.src, "dst", w.query.dst, "timeout", w.timers.QueryTimeout)
+			w.poll()
+			w.markReady()
+		}
+	}
+}
+
+// poll queries the paths once and stores them. On error, the current paths
+// are kept.
+func (w *WatchRunner) poll() {
+	ctx, cancelF := context.WithTimeout(w.ctx, w.timers.QueryTimeout)
+	defer cancelF()
+	paths, err := w.query.Query(ctx)
+	if err != nil {
+		log.Error("Unable to query paths", "src", w.query.src, "dst", w.query.dst,
+			"err", err)
+		return
+	}
+	w.sp.Update(paths)
+}
+
+func (w *WatchRunner) markReady() {
+	w.readyOnce.Do(func() { close(w.ready) })
+}
+
+func (w *WatchRunner) Stop() {
+	w.stopF()
 }
 
 // queryConfig describes the persistent query information associated with a
-// path polling loop.
+// path subscription.
 type queryConfig struct {
-	querier Querier
-	src     addr.IA
-	dst     addr.IA
-	filter  Policy
+	conn   sciond.Connector
+	src    addr.IA
+	dst    addr.IA
+	flags  sciond.PathReqFlags
+	filter Policy
+}
+
+// Subscribe subscribes to the paths in SCIOND. The subscription ends when ctx
+// is done.
+func (q *queryConfig) Subscribe(ctx context.Context) (<-chan sciond.PathUpdate, error) {
+	return q.conn.SubscribePaths(ctx, q.dst, q.src, q.flags)
+}
+
+// Query queries the paths in SCIOND once, and returns the ones that pass the
+// filter, in the order of the filter.
+func (q *queryConfig) Query(ctx context.Context) ([]snet.Path, error) {
+	paths, err := q.conn.Paths(ctx, q.dst, q.src, q.flags)
+	if err != nil {
+		return nil, err
+	}
+	if q.filter == nil {
+		return paths, nil
+	}
+	return selectPaths(q.filter, paths), nil
 }
 
-func (bq *queryConfig) Do(ctx context.Context, flags sciond.PathReqFlags) spathmeta.AppPathSet {
-	aps := bq.querier.Query(ctx, bq.src, bq.dst, flags)
-	if bq.filter != nil {
-		aps = psToAps(bq.filter.Filter(apsToPs(aps)))
+// Apply returns the paths contained in the update that pass the filter, in
+// the order of the filter.
+func (q *queryConfig) Apply(update sciond.PathUpdate) []snet.Path {
+	if q.filter == nil {
+		return update.Paths
 	}
-	return aps
+	return selectPaths(q.filter, update.Paths)
 }
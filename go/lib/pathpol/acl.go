@@ -64,6 +64,26 @@ func (a *ACL) UnmarshalJSON(b []byte) error {
 	return json.Unmarshal(b, &a.Entries)
 }
 
+// MarshalYAML marshals the ACL as a list of entries.
+func (a *ACL) MarshalYAML() (interface{}, error) {
+	return a.Entries, nil
+}
+
+// UnmarshalYAML unmarshals the ACL from a list of entries. In contrast to
+// JSON, the presence of a default entry is checked.
+func (a *ACL) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var entries []*ACLEntry
+	if err := unmarshal(&entries); err != nil {
+		return err
+	}
+	acl, err := NewACL(entries...)
+	if err != nil {
+		return err
+	}
+	*a = *acl
+	return nil
+}
+
 func (a *ACL) evalPath(path Path) ACLAction {
 	for i, iface := range path.Interfaces() {
 		if a.evalInterface(iface, i%2 != 0) == Deny {
@@ -128,6 +148,20 @@ func (ae *ACLEntry) UnmarshalJSON(b []byte) error {
 	return ae.LoadFromString(str)
 }
 
+// MarshalYAML marshals the entry as a string.
+func (ae *ACLEntry) MarshalYAML() (interface{}, error) {
+	return ae.String(), nil
+}
+
+// UnmarshalYAML unmarshals the entry from a string.
+func (ae *ACLEntry) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var str string
+	if err := unmarshal(&str); err != nil {
+		return err
+	}
+	return ae.LoadFromString(str)
+}
+
 func getAction(symbol string) (ACLAction, error) {
 	if symbol == allowSymbol {
 		return true, nil
@@ -19,6 +19,8 @@ import (
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	yaml "gopkg.in/yaml.v2"
 
 	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/common"
@@ -265,3 +267,45 @@ func TestACLPanic(t *testing.T) {
 	paths := pp.GetPaths(xtest.MustParseIA("2-ff00:0:212"), xtest.MustParseIA("2-ff00:0:211"))
 	assert.Panics(t, func() { acl.Eval(paths) })
 }
+
+func TestACLYAML(t *testing.T) {
+	tests := map[string]struct {
+		Input          string
+		Entries        []string
+		ErrorAssertion assert.ErrorAssertionFunc
+	}{
+		"ACL with default": {
+			Input:          "- \"- 1-ff00:0:110#3\"\n- \"+ 1-0#0\"\n- \"-\"\n",
+			Entries:        []string{"- 1-ff00:0:110#3", "+ 1-0#0", "-"},
+			ErrorAssertion: assert.NoError,
+		},
+		"ACL without default": {
+			Input:          "- \"+ 1-0#0\"\n",
+			ErrorAssertion: assert.Error,
+		},
+		"bad entry": {
+			Input:          "- \"+ 0 0\"\n- \"-\"\n",
+			ErrorAssertion: assert.Error,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var acl ACL
+			err := yaml.Unmarshal([]byte(test.Input), &acl)
+			test.ErrorAssertion(t, err)
+			if err != nil {
+				return
+			}
+			var entries []string
+			for _, e := range acl.Entries {
+				entries = append(entries, e.String())
+			}
+			assert.Equal(t, test.Entries, entries)
+			raw, err := yaml.Marshal(&acl)
+			require.NoError(t, err)
+			var parsed ACL
+			require.NoError(t, yaml.Unmarshal(raw, &parsed))
+			assert.Equal(t, acl, parsed)
+		})
+	}
+}
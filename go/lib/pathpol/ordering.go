@@ -0,0 +1,203 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pathpol
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/snet"
+)
+
+// Criterion is a criterion by which paths are ordered. Each criterion prefers
+// paths in a fixed direction, e.g., fewer hops or a later expiration. Paths
+// that lack the information a criterion needs are ordered last.
+type Criterion string
+
+const (
+	// CriterionHops prefers paths with fewer hops.
+	CriterionHops Criterion = "hops"
+	// CriterionExpiration prefers paths that expire later. It requires paths
+	// to have an Expiry() time.Time method.
+	CriterionExpiration Criterion = "expiration"
+	// CriterionLatency prefers paths with a lower latency. It requires paths
+	// to have a Latency() (time.Duration, bool) method.
+	CriterionLatency Criterion = "latency"
+	// CriterionMTU prefers paths with a larger MTU. It requires paths to have
+	// an MTU() uint16 method.
+	CriterionMTU Criterion = "mtu"
+	// CriterionDiversity prefers paths that share fewer interfaces with the
+	// paths that are already chosen.
+	CriterionDiversity Criterion = "diversity"
+)
+
+var criteria = map[Criterion]func(path Path, chosen []Path) float64{
+	CriterionHops: func(path Path, _ []Path) float64 {
+		return float64(len(path.Interfaces()))
+	},
+	CriterionExpiration: func(path Path, _ []Path) float64 {
+		p, ok := path.(interface{ Expiry() time.Time })
+		if !ok || p.Expiry().IsZero() {
+			return math.Inf(1)
+		}
+		return -float64(p.Expiry().Unix())
+	},
+	CriterionLatency: func(path Path, _ []Path) float64 {
+		p, ok := path.(interface{ Latency() (time.Duration, bool) })
+		if !ok {
+			return math.Inf(1)
+		}
+		latency, ok := p.Latency()
+		if !ok {
+			return math.Inf(1)
+		}
+		return float64(latency)
+	},
+	CriterionMTU: func(path Path, _ []Path) float64 {
+		p, ok := path.(interface{ MTU() uint16 })
+		if !ok || p.MTU() == 0 {
+			return math.Inf(1)
+		}
+		return -float64(p.MTU())
+	},
+	CriterionDiversity: func(path Path, chosen []Path) float64 {
+		used := make(map[string]struct{})
+		for _, other := range chosen {
+			for _, intf := range other.Interfaces() {
+				used[intfKey(intf)] = struct{}{}
+			}
+		}
+		var shared int
+		for _, intf := range path.Interfaces() {
+			if _, ok := used[intfKey(intf)]; ok {
+				shared++
+			}
+		}
+		return float64(shared)
+	},
+}
+
+func (c *Criterion) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	if _, ok := criteria[Criterion(s)]; !ok {
+		return serrors.New("unknown ordering criterion", "criterion", s)
+	}
+	*c = Criterion(s)
+	return nil
+}
+
+// Ordering orders paths by a list of criteria, and optionally selects only the
+// best paths.
+type Ordering struct {
+	// By lists the criteria in order of precedence, i.e., a criterion is only
+	// consulted if the paths tie on all the previous criteria. Paths that tie
+	// on all criteria are ordered by fingerprint.
+	By []Criterion `json:"by,omitempty"`
+	// Top is the maximum number of paths that are selected. Zero selects all
+	// paths.
+	Top int `json:"top,omitempty"`
+}
+
+// UnmarshalJSON unmarshals the ordering and rejects a negative top.
+func (o *Ordering) UnmarshalJSON(b []byte) error {
+	type ordering Ordering
+	var parsed ordering
+	if err := json.Unmarshal(b, &parsed); err != nil {
+		return err
+	}
+	return o.set(Ordering(parsed))
+}
+
+// UnmarshalYAML unmarshals the ordering and rejects unknown criteria and a
+// negative top.
+func (o *Ordering) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	type ordering Ordering
+	var parsed ordering
+	if err := unmarshal(&parsed); err != nil {
+		return err
+	}
+	return o.set(Ordering(parsed))
+}
+
+func (o *Ordering) set(parsed Ordering) error {
+	for _, c := range parsed.By {
+		if _, ok := criteria[c]; !ok {
+			return serrors.New("unknown ordering criterion", "criterion", c)
+		}
+	}
+	if parsed.Top < 0 {
+		return serrors.New("top must not be negative", "top", parsed.Top)
+	}
+	*o = parsed
+	return nil
+}
+
+// Order returns the paths ordered best first, and truncated to the top paths.
+// The paths are chosen greedily, i.e., at each step the best path given the
+// already chosen paths is appended. A nil ordering orders the paths by
+// fingerprint.
+func (o *Ordering) Order(paths PathSet) []Path {
+	var by []Criterion
+	var top int
+	if o != nil {
+		by, top = o.By, o.Top
+	}
+	if top == 0 || top > len(paths) {
+		top = len(paths)
+	}
+	remaining := make([]Path, 0, len(paths))
+	for _, path := range paths {
+		remaining = append(remaining, path)
+	}
+	chosen := make([]Path, 0, top)
+	scores := make([]float64, len(by))
+	bestScores := make([]float64, len(by))
+	for len(chosen) < top {
+		best := 0
+		for i, path := range remaining {
+			for k, criterion := range by {
+				scores[k] = criteria[criterion](path, chosen)
+			}
+			if i == 0 || less(scores, bestScores, path, remaining[best]) {
+				best = i
+				scores, bestScores = bestScores, scores
+			}
+		}
+		chosen = append(chosen, remaining[best])
+		remaining = append(remaining[:best], remaining[best+1:]...)
+	}
+	return chosen
+}
+
+// less returns whether the path a with scores sa is ordered before the path b
+// with scores sb.
+func less(sa, sb []float64, a, b Path) bool {
+	for i := range sa {
+		if sa[i] != sb[i] {
+			return sa[i] < sb[i]
+		}
+	}
+	return a.Fingerprint() < b.Fingerprint()
+}
+
+func intfKey(intf snet.PathInterface) string {
+	return fmt.Sprintf("%s#%d", intf.IA(), intf.ID())
+}
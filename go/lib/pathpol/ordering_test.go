@@ -0,0 +1,153 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pathpol
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	yaml "gopkg.in/yaml.v2"
+
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/lib/xtest"
+)
+
+func TestOrderingOrder(t *testing.T) {
+	ia := xtest.MustParseIA("1-ff00:0:110")
+	intfs := func(ifids ...int) []snet.PathInterface {
+		res := make([]snet.PathInterface, 0, len(ifids))
+		for _, ifid := range ifids {
+			res = append(res, testPathIntf{ia: ia, ifid: common.IFIDType(ifid)})
+		}
+		return res
+	}
+	now := time.Now()
+	a := &metaPath{
+		testPath: testPath{interfaces: intfs(1, 2, 3, 4), key: "a"},
+		expiry:   now.Add(time.Hour),
+		mtu:      1400,
+		latency:  10 * time.Millisecond,
+	}
+	b := &metaPath{
+		testPath: testPath{interfaces: intfs(1, 2), key: "b"},
+		expiry:   now.Add(2 * time.Hour),
+		mtu:      1200,
+	}
+	c := &metaPath{
+		testPath: testPath{interfaces: intfs(5, 6, 7, 8), key: "c"},
+		mtu:      1472,
+		latency:  5 * time.Millisecond,
+	}
+	paths := PathSet{a.key: a, b.key: b, c.key: c}
+
+	tests := map[string]struct {
+		Ordering *Ordering
+		Expected []Path
+	}{
+		"nil ordering sorts by fingerprint": {
+			Expected: []Path{a, b, c},
+		},
+		"hops": {
+			Ordering: &Ordering{By: []Criterion{CriterionHops}},
+			Expected: []Path{b, a, c},
+		},
+		"expiration, unknown last": {
+			Ordering: &Ordering{By: []Criterion{CriterionExpiration}},
+			Expected: []Path{b, a, c},
+		},
+		"latency, unknown last": {
+			Ordering: &Ordering{By: []Criterion{CriterionLatency}},
+			Expected: []Path{c, a, b},
+		},
+		"mtu": {
+			Ordering: &Ordering{By: []Criterion{CriterionMTU}},
+			Expected: []Path{c, a, b},
+		},
+		"diversity prefers unused interfaces": {
+			Ordering: &Ordering{By: []Criterion{CriterionDiversity, CriterionHops}},
+			Expected: []Path{b, c, a},
+		},
+		"ties are broken by the next criterion": {
+			Ordering: &Ordering{By: []Criterion{CriterionHops, CriterionMTU}},
+			Expected: []Path{b, c, a},
+		},
+		"top": {
+			Ordering: &Ordering{By: []Criterion{CriterionLatency}, Top: 1},
+			Expected: []Path{c},
+		},
+		"top larger than the set": {
+			Ordering: &Ordering{Top: 5},
+			Expected: []Path{a, b, c},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.Expected, test.Ordering.Order(paths))
+		})
+	}
+}
+
+func TestOrderingJSON(t *testing.T) {
+	t.Run("round trip", func(t *testing.T) {
+		o := &Ordering{By: []Criterion{CriterionLatency, CriterionDiversity}, Top: 3}
+		raw, err := json.Marshal(o)
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"by": ["latency", "diversity"], "top": 3}`, string(raw))
+		var parsed Ordering
+		assert.NoError(t, json.Unmarshal(raw, &parsed))
+		assert.Equal(t, o, &parsed)
+	})
+	t.Run("unknown criterion", func(t *testing.T) {
+		var parsed Ordering
+		assert.Error(t, json.Unmarshal([]byte(`{"by": ["bandwidth"]}`), &parsed))
+	})
+	t.Run("negative top", func(t *testing.T) {
+		var parsed Ordering
+		assert.Error(t, json.Unmarshal([]byte(`{"by": ["hops"], "top": -1}`), &parsed))
+	})
+}
+
+func TestOrderingYAML(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		var parsed Ordering
+		assert.NoError(t, yaml.Unmarshal([]byte("by: [latency, diversity]\ntop: 3"), &parsed))
+		expected := Ordering{By: []Criterion{CriterionLatency, CriterionDiversity}, Top: 3}
+		assert.Equal(t, expected, parsed)
+	})
+	t.Run("unknown criterion", func(t *testing.T) {
+		var parsed Ordering
+		assert.Error(t, yaml.Unmarshal([]byte("by: [bandwidth]"), &parsed))
+	})
+	t.Run("negative top", func(t *testing.T) {
+		var parsed Ordering
+		assert.Error(t, yaml.Unmarshal([]byte("by: [hops]\ntop: -1"), &parsed))
+	})
+}
+
+type metaPath struct {
+	testPath
+	expiry  time.Time
+	mtu     uint16
+	latency time.Duration
+}
+
+func (p *metaPath) Expiry() time.Time { return p.expiry }
+func (p *metaPath) MTU() uint16       { return p.mtu }
+func (p *metaPath) Latency() (time.Duration, bool) {
+	return p.latency, p.latency != 0
+}
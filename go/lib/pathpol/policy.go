@@ -14,9 +14,11 @@
 // limitations under the License.
 
 // Package pathpol implements path policies, documentation in doc/PathPolicy.md
-// Currently implemented: ACL, Sequence, Extends and Options.
+// Currently implemented: ACL, Sequence, Extends, Options and Ordering.
 //
-// A policy has an Act() method that takes an AppPathSet and returns a filtered AppPathSet
+// A policy has a Filter method that takes a PathSet and returns a filtered
+// PathSet, and a Select method that additionally returns the filtered paths as
+// a slice, ordered and truncated according to the ordering of the policy.
 package pathpol
 
 import (
@@ -48,6 +50,7 @@ type Policy struct {
 	ACL      *ACL      `json:"acl,omitempty"`
 	Sequence *Sequence `json:"sequence,omitempty"`
 	Options  []Option  `json:"options,omitempty"`
+	Ordering *Ordering `json:"ordering,omitempty"`
 }
 
 // NewPolicy creates a Policy and sorts its Options
@@ -82,6 +85,16 @@ func (p *Policy) FilterOpt(paths PathSet, opts FilterOptions) PathSet {
 	return resultSet
 }
 
+// Select filters the path set according to the policy, and returns the
+// remaining paths ordered best first according to the ordering of the policy.
+// The ordering of options is ignored.
+func (p *Policy) Select(paths PathSet) []Path {
+	if p == nil {
+		return (*Ordering)(nil).Order(paths)
+	}
+	return p.Ordering.Order(p.Filter(paths))
+}
+
 // PolicyFromExtPolicy creates a Policy from an extending Policy and the extended policies
 func PolicyFromExtPolicy(extPolicy *ExtPolicy, extended []*ExtPolicy) (*Policy, error) {
 	policy := extPolicy.Policy
@@ -127,6 +140,10 @@ func (p *Policy) applyExtended(extends []string, exPolicies []*ExtPolicy) error
 		if p.Sequence == nil {
 			p.Sequence = policy.Sequence
 		}
+		// Replace Ordering
+		if p.Ordering == nil {
+			p.Ordering = policy.Ordering
+		}
 	}
 	return nil
 }
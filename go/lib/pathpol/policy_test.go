@@ -295,6 +295,31 @@ func TestExtends(t *testing.T) {
 				},
 			},
 		},
+		"use ordering of extended policy unless set": {
+			Policy: &ExtPolicy{
+				Extends: []string{"policy1", "policy2"},
+				Policy: &Policy{
+					Ordering: &Ordering{Top: 1},
+				},
+			},
+			Extended: []*ExtPolicy{
+				{
+					Policy: &Policy{Name: "policy1",
+						Ordering: &Ordering{By: []Criterion{CriterionLatency}},
+						Sequence: newSequence(t, "0+ 1-ff00:0:111 0+"),
+					},
+				},
+				{
+					Policy: &Policy{Name: "policy2",
+						Ordering: &Ordering{By: []Criterion{CriterionHops}},
+					},
+				},
+			},
+			ExtendedPolicy: &Policy{
+				Ordering: &Ordering{Top: 1},
+				Sequence: newSequence(t, "0+ 1-ff00:0:111 0+"),
+			},
+		},
 		"use option of extended policy": {
 			Policy: &ExtPolicy{
 				Extends: []string{"policy1"},
@@ -586,6 +611,39 @@ func TestFilterOpt(t *testing.T) {
 	}
 }
 
+func TestSelect(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	pp := NewPathProvider(ctrl)
+	src := xtest.MustParseIA("1-ff00:0:110")
+	dst := xtest.MustParseIA("2-ff00:0:220")
+	paths := pp.GetPaths(src, dst)
+
+	t.Run("nil policy selects all paths", func(t *testing.T) {
+		var policy *Policy
+		assert.Len(t, policy.Select(paths), len(paths))
+	})
+	t.Run("paths are filtered, ordered and truncated", func(t *testing.T) {
+		acl, err := NewACL(
+			&ACLEntry{Action: Deny, Rule: mustHopPredicate(t, "2-ff00:0:210")},
+			&ACLEntry{Action: Allow, Rule: mustHopPredicate(t, "0-0#0")},
+		)
+		require.NoError(t, err)
+		policy := NewPolicy("", acl, nil, nil)
+		policy.Ordering = &Ordering{By: []Criterion{CriterionHops}, Top: 1}
+		filtered := policy.Filter(paths)
+		require.Len(t, filtered, 2)
+		selected := policy.Select(paths)
+		require.Len(t, selected, 1)
+		// The remaining paths have the same number of hops, the tie is broken
+		// by fingerprint.
+		for fp := range filtered {
+			assert.LessOrEqual(t, string(selected[0].Fingerprint()), string(fp))
+		}
+		assert.Contains(t, filtered, selected[0].Fingerprint())
+	})
+}
+
 func TestPolicyJsonConversion(t *testing.T) {
 	policy := NewPolicy("", nil, nil, []Option{
 		{
@@ -602,6 +660,7 @@ func TestPolicyJsonConversion(t *testing.T) {
 			Weight: 0,
 		},
 	})
+	policy.Ordering = &Ordering{By: []Criterion{CriterionLatency, CriterionHops}, Top: 2}
 	jsonPol, err := json.Marshal(policy)
 	require.NoError(t, err)
 	var pol Policy
@@ -129,6 +129,25 @@ func (s *Sequence) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
+// MarshalYAML marshals the sequence as a string.
+func (s *Sequence) MarshalYAML() (interface{}, error) {
+	return s.srcstr, nil
+}
+
+// UnmarshalYAML unmarshals the sequence from a string.
+func (s *Sequence) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var str string
+	if err := unmarshal(&str); err != nil {
+		return err
+	}
+	sn, err := NewSequence(str)
+	if err != nil {
+		return err
+	}
+	*s = *sn
+	return nil
+}
+
 type errorListener struct {
 	*antlr.DefaultErrorListener
 	msg string
@@ -20,6 +20,8 @@ import (
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	yaml "gopkg.in/yaml.v2"
 
 	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/xtest"
@@ -41,6 +43,16 @@ func TestNewSequence(t *testing.T) {
 	}
 }
 
+func TestSequenceYAML(t *testing.T) {
+	var seq Sequence
+	require.NoError(t, yaml.Unmarshal([]byte(`"1-ff00:0:133#0 0*"`), &seq))
+	assert.Equal(t, "1-ff00:0:133#0 0*", seq.String())
+	raw, err := yaml.Marshal(&seq)
+	require.NoError(t, err)
+	assert.Equal(t, "1-ff00:0:133#0 0*\n", string(raw))
+	assert.Error(t, yaml.Unmarshal([]byte(`"0#0#0"`), &seq))
+}
+
 func TestSequenceEval(t *testing.T) {
 	tests := map[string]struct {
 		Seq        *Sequence
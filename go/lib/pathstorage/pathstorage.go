@@ -23,6 +23,7 @@ import (
 	"github.com/scionproto/scion/go/lib/infra/modules/db"
 	"github.com/scionproto/scion/go/lib/log"
 	"github.com/scionproto/scion/go/lib/pathdb"
+	mempathdb "github.com/scionproto/scion/go/lib/pathdb/mem"
 	sqlitepathdb "github.com/scionproto/scion/go/lib/pathdb/sqlite"
 	"github.com/scionproto/scion/go/lib/revcache"
 	"github.com/scionproto/scion/go/lib/revcache/memrevcache"
@@ -100,7 +101,7 @@ func (cfg *PathDBConf) Validate() error {
 
 func (cfg *PathDBConf) validateBackend() error {
 	switch cfg.Backend() {
-	case BackendSqlite:
+	case BackendSqlite, BackendMem:
 		return nil
 	case BackendNone:
 		return serrors.New("No backend set")
@@ -109,7 +110,7 @@ func (cfg *PathDBConf) validateBackend() error {
 }
 
 func (cfg *PathDBConf) validateConnection() error {
-	if cfg.Connection() == "" {
+	if cfg.Backend() != BackendMem && cfg.Connection() == "" {
 		return serrors.New("Empty connection not allowed")
 	}
 	return nil
@@ -214,6 +215,8 @@ func newPathDB(conf PathDBConf) (pathdb.PathDB, error) {
 	switch conf.Backend() {
 	case BackendSqlite:
 		pdb, err = sqlitepathdb.New(conf.Connection())
+	case BackendMem:
+		pdb = mempathdb.New()
 	case BackendNone:
 		return nil, nil
 	default:
@@ -229,7 +232,7 @@ func newPathDB(conf PathDBConf) (pathdb.PathDB, error) {
 
 func newRevCache(conf PathDBConf) (revcache.RevCache, error) {
 	switch conf.Backend() {
-	case BackendSqlite:
+	case BackendSqlite, BackendMem:
 		log.Info("Connecting RevCache", "backend", "memory")
 		return memrevcache.New(), nil
 	default:
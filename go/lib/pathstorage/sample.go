@@ -15,10 +15,10 @@
 package pathstorage
 
 const pathDbSample = `
-# The type of pathdb backend.
+# The type of pathdb backend, either "sqlite" or "mem".
 backend = "sqlite"
 
-# Path to the path database. (required)
+# Path to the path database. (required, unless the backend is "mem")
 connection = "/var/lib/scion/pathdb/%s.path.db"
 
 # The maximum number of open connections to the database. In case of the
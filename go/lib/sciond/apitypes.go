@@ -25,6 +25,7 @@ import (
 
 	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/ctrl/seg"
 	"github.com/scionproto/scion/go/lib/log"
 	"github.com/scionproto/scion/go/lib/serrors"
 	"github.com/scionproto/scion/go/lib/snet"
@@ -104,6 +105,9 @@ type Path struct {
 	mtu        uint16
 	expiry     time.Time
 	dst        addr.IA
+	load       []seg.InterfaceLoad
+	staticInfo []seg.InterfaceStaticInfo
+	quality    *PathQuality
 }
 
 func pathReplyToPaths(pathReply *PathReply, dst addr.IA) ([]snet.Path, error) {
@@ -139,18 +143,35 @@ func pathReplyEntryToPath(pe PathReplyEntry, dst addr.IA) (Path, error) {
 		mtu:        pe.Path.Mtu,
 		expiry:     pe.Path.Expiry(),
 	}
+	if len(pe.Path.Load) == len(pe.Path.Interfaces) {
+		p.load = append(p.load, pe.Path.Load...)
+	}
+	if len(pe.Path.StaticInfo) == len(pe.Path.Interfaces) {
+		p.staticInfo = append(p.staticInfo, pe.Path.StaticInfo...)
+	}
+	p.quality = pe.Path.Quality.Copy()
 	for _, intf := range pe.Path.Interfaces {
 		p.interfaces = append(p.interfaces, pathInterface{ia: intf.IA(), id: intf.ID()})
 	}
 	return p, nil
 }
 
+// PathReplyEntryToPath converts a path reply entry to a path with destination
+// dst.
+func PathReplyEntryToPath(pe PathReplyEntry, dst addr.IA) (snet.Path, error) {
+	return pathReplyEntryToPath(pe, dst)
+}
+
 func (p Path) Fingerprint() snet.PathFingerprint {
-	if len(p.interfaces) == 0 {
+	return fingerprint(p.Interfaces())
+}
+
+func fingerprint(intfs []snet.PathInterface) snet.PathFingerprint {
+	if len(intfs) == 0 {
 		return ""
 	}
 	h := sha256.New()
-	for _, intf := range p.interfaces {
+	for _, intf := range intfs {
 		binary.Write(h, common.Order, intf.IA().IAInt())
 		binary.Write(h, common.Order, intf.ID())
 	}
@@ -201,6 +222,56 @@ func (p Path) Expiry() time.Time {
 	return p.expiry
 }
 
+// Load returns the load of the interfaces on the path, as announced in the
+// path segments. It is aligned with Interfaces. Entries with IfID 0 carry no
+// information. If no load information is available, the result is nil.
+func (p Path) Load() []seg.InterfaceLoad {
+	if p.load == nil {
+		return nil
+	}
+	return append(p.load[:0:0], p.load...)
+}
+
+// StaticInfo returns the static metadata of the interfaces on the path, as
+// announced in the path segments. It is aligned with Interfaces. Entries with
+// IfID 0 carry no information. If no static info is available, the result is
+// nil.
+func (p Path) StaticInfo() []seg.InterfaceStaticInfo {
+	if p.staticInfo == nil {
+		return nil
+	}
+	return append(p.staticInfo[:0:0], p.staticInfo...)
+}
+
+// Quality returns the quality of the path, as measured by SCIOND. If SCIOND
+// did not probe the path, the result is nil.
+func (p Path) Quality() *PathQuality {
+	return p.quality.Copy()
+}
+
+// Latency returns the one-way latency of the path. If SCIOND measured the
+// path, it is half the measured round-trip time. Otherwise, it is the latency
+// announced in the static metadata, if it is known for every link. The
+// returned boolean is false if the latency is unknown.
+func (p Path) Latency() (time.Duration, bool) {
+	if p.quality != nil && p.quality.RTT != 0 {
+		return p.quality.Latency() / 2, true
+	}
+	if m := p.Metadata(); m != nil && m.LatencyComplete {
+		return m.Latency, true
+	}
+	return 0, false
+}
+
+// Metadata returns the static metadata aggregated over the path. If no static
+// info is available, the result is nil.
+func (p Path) Metadata() *PathMetadata {
+	if p.staticInfo == nil {
+		return nil
+	}
+	return newPathMetadata(p.staticInfo)
+}
+
 func (p Path) Copy() snet.Path {
 	return Path{
 		interfaces: append(p.interfaces[:0:0], p.interfaces...),
@@ -208,6 +279,9 @@ func (p Path) Copy() snet.Path {
 		spath:      p.Path(),           // creates copy
 		mtu:        p.mtu,
 		expiry:     p.expiry,
+		load:       p.Load(),       // creates copy
+		staticInfo: p.StaticInfo(), // creates copy
+		quality:    p.Quality(),    // creates copy
 	}
 }
 
@@ -13,6 +13,8 @@ import (
 	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/common"
 	"github.com/scionproto/scion/go/lib/ctrl/path_mgmt"
+	"github.com/scionproto/scion/go/lib/ctrl/seg"
+	"github.com/scionproto/scion/go/lib/log"
 	"github.com/scionproto/scion/go/lib/sciond"
 	"github.com/scionproto/scion/go/lib/serrors"
 	"github.com/scionproto/scion/go/lib/snet"
@@ -118,6 +120,14 @@ func (p Path) Expiry() time.Time {
 	return p.expirationTime
 }
 
+func (p Path) Load() []seg.InterfaceLoad {
+	return nil
+}
+
+func (p Path) StaticInfo() []seg.InterfaceStaticInfo {
+	return nil
+}
+
 func (p Path) Copy() snet.Path {
 	return &Path{
 		JSONFingerprint: p.JSONFingerprint,
@@ -187,6 +197,39 @@ func (c connector) Paths(_ context.Context, _, _ addr.IA,
 	return c.adapter(entry.Paths[:intMax]), nil
 }
 
+// SubscribePaths pushes the paths of each entry in the script once its reply
+// start timestamp has passed.
+func (c connector) SubscribePaths(ctx context.Context, _, _ addr.IA,
+	flags sciond.PathReqFlags) (<-chan sciond.PathUpdate, error) {
+
+	updates := make(chan sciond.PathUpdate)
+	go func() {
+		defer log.HandlePanic()
+		defer close(updates)
+		for _, entry := range c.script.Entries {
+			start := time.Duration(entry.ReplyStartTimestamp) * time.Second
+			timer := time.NewTimer(time.Until(c.creationTime.Add(start)))
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			}
+			paths := entry.Paths
+			if int(flags.PathCount) < len(paths) {
+				paths = paths[:flags.PathCount]
+			}
+			select {
+			case updates <- sciond.PathUpdate{Paths: c.adapter(paths)}:
+			case <-ctx.Done():
+				return
+			}
+		}
+		<-ctx.Done()
+	}()
+	return updates, nil
+}
+
 func (c connector) adapter(paths []*Path) []snet.Path {
 	var snetPaths []snet.Path
 	for _, path := range paths {
@@ -138,6 +138,50 @@ func TestPaths(t *testing.T) {
 	assert.True(t, paths[0].Expiry().Before(time.Now().Add(4*time.Hour)))
 }
 
+func TestSubscribePaths(t *testing.T) {
+	nextHop := &fake.UDPAddr{IP: net.IP{10, 0, 0, 1}, Port: 80}
+	script := &fake.Script{
+		Entries: []*fake.Entry{
+			{
+				ReplyStartTimestamp: 0,
+				Paths: []*fake.Path{
+					{JSONFingerprint: "Foo", JSONNextHop: nextHop},
+					{JSONFingerprint: "Bar", JSONNextHop: nextHop},
+				},
+			},
+			{
+				ReplyStartTimestamp: 1,
+				Paths: []*fake.Path{
+					{JSONFingerprint: "Foo2", JSONNextHop: nextHop},
+				},
+			},
+		},
+	}
+	c := fake.New(script)
+	ctx, cancelF := context.WithCancel(context.Background())
+	updates, err := c.SubscribePaths(
+		ctx,
+		xtest.MustParseIA("1-ff00:0:1"),
+		xtest.MustParseIA("1-ff00:0:2"),
+		sciond.PathReqFlags{PathCount: 1},
+	)
+	require.NoError(t, err)
+
+	update := <-updates
+	require.NoError(t, update.Err)
+	require.Equal(t, 1, len(update.Paths))
+	assert.Equal(t, "Foo", string(update.Paths[0].Fingerprint()))
+
+	update = <-updates
+	require.NoError(t, update.Err)
+	require.Equal(t, 1, len(update.Paths))
+	assert.Equal(t, "Foo2", string(update.Paths[0].Fingerprint()))
+
+	cancelF()
+	_, ok := <-updates
+	assert.False(t, ok)
+}
+
 func TestPathCopy(t *testing.T) {
 	path := fake.Path{
 		JSONFingerprint: "foo",
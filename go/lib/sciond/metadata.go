@@ -0,0 +1,100 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sciond
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/ctrl/seg"
+	"github.com/scionproto/scion/go/proto"
+)
+
+// PathMetadata is the static metadata of a path, aggregated from the static
+// info of its interfaces.
+type PathMetadata struct {
+	// Latency is the sum of the latencies of the inter-AS links and of the
+	// intra-AS latencies between the interfaces of the traversed ASes.
+	Latency time.Duration
+	// LatencyComplete indicates whether the latency of every inter-AS link is
+	// known. Unknown intra-AS latencies are assumed to be zero.
+	LatencyComplete bool
+	// Bandwidth is the bottleneck bandwidth of the inter-AS links in kbit/s.
+	// It is 0 if the bandwidth of any link is unknown.
+	Bandwidth uint64
+	// Geo contains the location of each interface on the path. It is aligned
+	// with the interfaces of the path. Entries are nil if unknown.
+	Geo []*seg.GeoCoordinates
+	// LinkTypes contains the physical type of each inter-AS link on the path,
+	// in path order.
+	LinkTypes []proto.PhysicalLinkType
+}
+
+// newPathMetadata aggregates the static info of the interfaces of a path. The
+// interfaces are expected in path order, i.e., the interfaces 2i and 2i+1 are
+// the two ends of an inter-AS link. The information about a link is taken from
+// either end, preferring the first one.
+func newPathMetadata(infos []seg.InterfaceStaticInfo) *PathMetadata {
+	m := &PathMetadata{
+		LatencyComplete: true,
+		Geo:             make([]*seg.GeoCoordinates, len(infos)),
+		LinkTypes:       make([]proto.PhysicalLinkType, 0, len(infos)/2),
+	}
+	for i := range infos {
+		if infos[i].Geo != nil {
+			geo := *infos[i].Geo
+			m.Geo[i] = &geo
+		}
+	}
+	for i := 0; i+1 < len(infos); i += 2 {
+		local, remote := &infos[i], &infos[i+1]
+		if i > 0 {
+			// Intra-AS latency between the previous ingress and this egress.
+			m.Latency += infos[i-1].IntraLatency() + local.IntraLatency()
+		}
+		if latency := first(uint64(local.Latency), uint64(remote.Latency)); latency == 0 {
+			m.LatencyComplete = false
+		} else {
+			m.Latency += time.Duration(latency) * time.Microsecond
+		}
+		bw := first(local.Bandwidth, remote.Bandwidth)
+		if i == 0 || bw < m.Bandwidth {
+			m.Bandwidth = bw
+		}
+		linkType := local.LinkType
+		if linkType == proto.PhysicalLinkType_unset {
+			linkType = remote.LinkType
+		}
+		m.LinkTypes = append(m.LinkTypes, linkType)
+	}
+	return m
+}
+
+func (m *PathMetadata) String() string {
+	latency := m.Latency.String()
+	if !m.LatencyComplete {
+		latency = ">=" + latency
+	}
+	return fmt.Sprintf("Latency: %s Bandwidth: %dkbps LinkTypes: %v", latency, m.Bandwidth,
+		m.LinkTypes)
+}
+
+// first returns the first non-zero value, or 0.
+func first(a, b uint64) uint64 {
+	if a != 0 {
+		return a
+	}
+	return b
+}
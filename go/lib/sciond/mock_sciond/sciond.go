@@ -196,3 +196,18 @@ func (mr *MockConnectorMockRecorder) SVCInfo(arg0, arg1 interface{}) *gomock.Cal
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SVCInfo", reflect.TypeOf((*MockConnector)(nil).SVCInfo), arg0, arg1)
 }
+
+// SubscribePaths mocks base method
+func (m *MockConnector) SubscribePaths(arg0 context.Context, arg1, arg2 addr.IA, arg3 sciond.PathReqFlags) (<-chan sciond.PathUpdate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubscribePaths", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(<-chan sciond.PathUpdate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SubscribePaths indicates an expected call of SubscribePaths
+func (mr *MockConnectorMockRecorder) SubscribePaths(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribePaths", reflect.TypeOf((*MockConnector)(nil).SubscribePaths), arg0, arg1, arg2, arg3)
+}
@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"net"
 	"sync"
+	"time"
 
 	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/common"
@@ -50,6 +51,9 @@ const (
 type Status struct {
 	Status         StatusName
 	AdditionalInfo string
+	// Latency is the round-trip time of the probe. It is only set if the path
+	// is alive.
+	Latency time.Duration
 }
 
 // Predefined path status
@@ -108,8 +112,10 @@ func (p Prober) GetStatuses(ctx context.Context,
 	// with invalid address via the path. The border router at the destination
 	// is going to reply with SCMP error. Receiving the error means that
 	// the path is alive.
-	pathStatuses := make(map[string]Status, len(paths))
-	scmpH := &scmpHandler{statuses: pathStatuses}
+	scmpH := &scmpHandler{
+		statuses: make(map[string]Status, len(paths)),
+		sent:     make(map[string]time.Time, len(paths)),
+	}
 	network := snet.NewCustomNetworkWithPR(p.LocalIA,
 		&snet.DefaultPacketDispatcherService{
 			Dispatcher:  reliable.NewDispatcher(""),
@@ -124,7 +130,7 @@ func (p Prober) GetStatuses(ctx context.Context,
 	snetConn.SetDeadline(deadline)
 	var sendErrors common.MultiError
 	for _, path := range paths {
-		scmpH.setStatus(PathKey(path), timeout)
+		scmpH.setSent(PathKey(path))
 		if err := p.send(snetConn, path); err != nil {
 			sendErrors = append(sendErrors, err)
 		}
@@ -182,6 +188,8 @@ var errSCMP = errors.New("scmp: other")
 type scmpHandler struct {
 	mtx      sync.Mutex
 	statuses map[string]Status
+	// sent contains the time at which the probe was sent on each path.
+	sent map[string]time.Time
 }
 
 func (h *scmpHandler) Handle(pkt *snet.Packet) error {
@@ -192,7 +200,7 @@ func (h *scmpHandler) Handle(pkt *snet.Packet) error {
 			return err
 		}
 		if hdr.Class == scmp.C_Routing && hdr.Type == scmp.T_R_BadHost {
-			h.setStatus(path, alive)
+			h.setAlive(path)
 			return errBadHost
 		}
 		h.setStatus(path, Status{Status: StatusSCMP, AdditionalInfo: hdr.String()})
@@ -209,6 +217,25 @@ func (h *scmpHandler) path(pkt *snet.Packet) (string, error) {
 	return string(path.Raw), nil
 }
 
+// setSent marks the path as timed out until the reply to the probe that is
+// sent now arrives.
+func (h *scmpHandler) setSent(path string) {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+	h.statuses[path] = timeout
+	h.sent[path] = time.Now()
+}
+
+func (h *scmpHandler) setAlive(path string) {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+	status := alive
+	if sent, ok := h.sent[path]; ok {
+		status.Latency = time.Since(sent)
+	}
+	h.statuses[path] = status
+}
+
 func (h *scmpHandler) setStatus(path string, status Status) {
 	h.mtx.Lock()
 	defer h.mtx.Unlock()
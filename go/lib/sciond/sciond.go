@@ -20,7 +20,9 @@
 // calling Connect or ConnectTimeout on the service. The connections implement
 // interface Connector, whose methods can be used to talk to SCIOND.
 //
-// Connector method calls return the entire answer of SCIOND.
+// Connector method calls return the entire answer of SCIOND. The exception is
+// SubscribePaths, which keeps the connection to SCIOND open and returns a
+// channel on which SCIOND pushes path updates.
 //
 // Fields prefixed with Raw (e.g., RawErrorCode) contain data in the format
 // received from SCIOND.  These are used internally, and the accessors without
@@ -38,6 +40,7 @@ import (
 	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/common"
 	"github.com/scionproto/scion/go/lib/ctrl/path_mgmt"
+	"github.com/scionproto/scion/go/lib/log"
 	"github.com/scionproto/scion/go/lib/sciond/internal/metrics"
 	"github.com/scionproto/scion/go/lib/serrors"
 	"github.com/scionproto/scion/go/lib/snet"
@@ -85,6 +88,12 @@ type Connector interface {
 	LocalIA(ctx context.Context) (addr.IA, error)
 	// Paths requests from SCIOND a set of end to end paths between the source and destination.
 	Paths(ctx context.Context, dst, src addr.IA, f PathReqFlags) ([]snet.Path, error)
+	// SubscribePaths subscribes to the end to end paths between the source
+	// and destination. SCIOND pushes the currently available paths, and an
+	// update whenever paths appear, expire or are revoked. The returned
+	// channel is closed once ctx is done or the connection to SCIOND is lost.
+	SubscribePaths(ctx context.Context, dst, src addr.IA,
+		f PathReqFlags) (<-chan PathUpdate, error)
 	// ASInfo requests from SCIOND information about AS ia.
 	ASInfo(ctx context.Context, ia addr.IA) (*ASInfoReply, error)
 	// IFInfo requests from SCIOND addresses and ports of interfaces. Slice
@@ -105,6 +114,14 @@ type Connector interface {
 	Close(ctx context.Context) error
 }
 
+// PathUpdate is a set of paths pushed by SCIOND to a path subscription.
+type PathUpdate struct {
+	// Paths contains the currently available paths.
+	Paths []snet.Path
+	// Err is set if SCIOND failed to look up the paths.
+	Err error
+}
+
 type conn struct {
 	address string
 }
@@ -178,6 +195,66 @@ func (c *conn) Paths(ctx context.Context, dst, src addr.IA,
 	return pathReplyToPaths(reply.PathReply, dst)
 }
 
+func (c *conn) SubscribePaths(ctx context.Context, dst, src addr.IA,
+	f PathReqFlags) (<-chan PathUpdate, error) {
+
+	conn, err := c.connect(ctx)
+	if err != nil {
+		metrics.PathSubscriptions.Inc(errorToPrometheusLabel(err))
+		return nil, serrors.Wrap(ErrUnableToConnect, err)
+	}
+	err = Send(
+		&Pld{
+			TraceId: tracing.IDFromCtx(ctx),
+			Which:   proto.SCIONDMsg_Which_pathSubReq,
+			PathSubReq: &PathReq{
+				Dst:   dst.IAInt(),
+				Src:   src.IAInt(),
+				Flags: f,
+			},
+		},
+		conn,
+	)
+	if err != nil {
+		conn.Close()
+		metrics.PathSubscriptions.Inc(errorToPrometheusLabel(err))
+		return nil, serrors.WrapStr("[sciond-API] Failed to subscribe to Paths", err)
+	}
+	metrics.PathSubscriptions.Inc(metrics.OkSuccess)
+	updates := make(chan PathUpdate)
+	done := make(chan struct{})
+	go func() {
+		defer log.HandlePanic()
+		// Closing the connection unblocks the receiving goroutine.
+		select {
+		case <-ctx.Done():
+		case <-done:
+		}
+		conn.Close()
+	}()
+	go func() {
+		defer log.HandlePanic()
+		defer close(updates)
+		defer close(done)
+		for {
+			pld, err := receive(conn)
+			if err != nil {
+				return
+			}
+			if pld.Which != proto.SCIONDMsg_Which_pathUpdate || pld.PathUpdate == nil {
+				continue
+			}
+			paths, err := pathReplyToPaths(pld.PathUpdate, dst)
+			select {
+			case updates <- PathUpdate{Paths: paths, Err: err}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return updates, nil
+}
+
 func (c *conn) LocalIA(ctx context.Context) (addr.IA, error) {
 	asInfo, err := c.ASInfo(ctx, addr.IA{})
 	if err != nil {
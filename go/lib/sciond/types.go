@@ -23,7 +23,9 @@ import (
 	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/common"
 	"github.com/scionproto/scion/go/lib/ctrl/path_mgmt"
+	"github.com/scionproto/scion/go/lib/ctrl/seg"
 	"github.com/scionproto/scion/go/lib/hostinfo"
+	"github.com/scionproto/scion/go/lib/snet"
 	"github.com/scionproto/scion/go/lib/util"
 	"github.com/scionproto/scion/go/proto"
 )
@@ -74,6 +76,8 @@ type Pld struct {
 	IfInfoReply        *IFInfoReply
 	ServiceInfoRequest *ServiceInfoRequest
 	ServiceInfoReply   *ServiceInfoReply
+	PathSubReq         *PathReq
+	PathUpdate         *PathReply
 }
 
 func NewPldFromRaw(b common.RawBytes) (*Pld, error) {
@@ -118,6 +122,10 @@ func (p *Pld) union() (interface{}, error) {
 		return p.ServiceInfoRequest, nil
 	case proto.SCIONDMsg_Which_serviceInfoReply:
 		return p.ServiceInfoReply, nil
+	case proto.SCIONDMsg_Which_pathSubReq:
+		return p.PathSubReq, nil
+	case proto.SCIONDMsg_Which_pathUpdate:
+		return p.PathUpdate, nil
 	}
 	return nil, common.NewBasicError("Unsupported SCIOND union type", nil, "type", p.Which)
 }
@@ -188,6 +196,17 @@ type FwdPathMeta struct {
 	Mtu        uint16
 	Interfaces []PathInterface
 	ExpTime    uint32
+	// Load contains the load of the interfaces as announced in the path
+	// segments. It is aligned with Interfaces. Entries with IfID 0 carry no
+	// information. It is empty if no load information is available.
+	Load []seg.InterfaceLoad
+	// StaticInfo contains the static metadata of the interfaces as announced
+	// in the path segments. It is aligned with Interfaces. Entries with IfID 0
+	// carry no information. It is empty if no static info is available.
+	StaticInfo []seg.InterfaceStaticInfo
+	// Quality contains the measurements of SCIOND for this path. It is nil if
+	// SCIOND does not probe paths, or has not probed this path yet.
+	Quality *PathQuality
 }
 
 func (fpm *FwdPathMeta) SrcIA() addr.IA {
@@ -210,6 +229,16 @@ func (fpm *FwdPathMeta) Expiry() time.Time {
 	return util.SecsToTime(fpm.ExpTime)
 }
 
+// Fingerprint returns the fingerprint of the path. It is equal to the
+// fingerprint of the snet.Path that is constructed from the path.
+func (fpm *FwdPathMeta) Fingerprint() snet.PathFingerprint {
+	intfs := make([]snet.PathInterface, 0, len(fpm.Interfaces))
+	for _, intf := range fpm.Interfaces {
+		intfs = append(intfs, intf)
+	}
+	return fingerprint(intfs)
+}
+
 func (fpm *FwdPathMeta) Copy() *FwdPathMeta {
 	if fpm == nil {
 		return nil
@@ -220,6 +249,15 @@ func (fpm *FwdPathMeta) Copy() *FwdPathMeta {
 		res.Interfaces = make([]PathInterface, len(fpm.Interfaces))
 		copy(res.Interfaces, fpm.Interfaces)
 	}
+	if fpm.Load != nil {
+		res.Load = make([]seg.InterfaceLoad, len(fpm.Load))
+		copy(res.Load, fpm.Load)
+	}
+	if fpm.StaticInfo != nil {
+		res.StaticInfo = make([]seg.InterfaceStaticInfo, len(fpm.StaticInfo))
+		copy(res.StaticInfo, fpm.StaticInfo)
+	}
+	res.Quality = fpm.Quality.Copy()
 	return res
 }
 
@@ -245,6 +283,46 @@ func (fpm *FwdPathMeta) fmtIfaces() []string {
 	return hops
 }
 
+// PathQuality contains the quality of a path, as measured by SCIOND by probing
+// the path periodically. The measurements are based on the recent history of
+// probes.
+type PathQuality struct {
+	// Alive indicates whether the last probe was answered.
+	Alive bool
+	// RTT is the average round-trip time of the answered probes in
+	// microseconds. It is 0 if no probe was answered.
+	RTT uint32 `capnp:"rtt"`
+	// Loss is the fraction of probes that were not answered, between 0 and 1.
+	Loss float32
+	// Probes is the number of probes the measurements are based on.
+	Probes uint32
+	// LastProbe is the time of the last probe in seconds since epoch.
+	LastProbe uint32
+}
+
+// Latency returns the average round-trip time of the answered probes.
+func (q *PathQuality) Latency() time.Duration {
+	return time.Duration(q.RTT) * time.Microsecond
+}
+
+// LastProbeTime returns the time of the last probe.
+func (q *PathQuality) LastProbeTime() time.Time {
+	return util.SecsToTime(q.LastProbe)
+}
+
+func (q *PathQuality) Copy() *PathQuality {
+	if q == nil {
+		return nil
+	}
+	res := *q
+	return &res
+}
+
+func (q *PathQuality) String() string {
+	return fmt.Sprintf("Alive: %t RTT: %s Loss: %.2f Probes: %d", q.Alive, q.Latency(),
+		q.Loss, q.Probes)
+}
+
 type PathInterface struct {
 	RawIsdas addr.IAInt `capnp:"isdas"`
 	IfID     common.IFIDType
@@ -54,6 +54,9 @@ type RegPld struct {
 	Id   sig_mgmt.MsgIdType
 	P    interface{}
 	Addr *snet.UDPAddr
+	// Signed is the signed payload the message was received in, such that
+	// handlers can verify its signature.
+	Signed *ctrl.SignedPld
 }
 
 type RegPldChan chan *RegPld
@@ -65,14 +68,16 @@ var (
 
 type dispRegistry struct {
 	sync.RWMutex
-	PollReqC RegPldChan
-	pollRep  map[RegPollKey]RegPldChan
+	PollReqC   RegPldChan
+	PrefixAnnC RegPldChan
+	pollRep    map[RegPollKey]RegPldChan
 }
 
 func newDispReg() *dispRegistry {
 	return &dispRegistry{
-		PollReqC: make(RegPldChan, 16),
-		pollRep:  make(map[RegPollKey]RegPldChan),
+		PollReqC:   make(RegPldChan, 16),
+		PrefixAnnC: make(RegPldChan, 16),
+		pollRep:    make(map[RegPollKey]RegPldChan),
 	}
 }
 
@@ -100,7 +105,9 @@ func (dm *dispRegistry) Unregister(regType RegType, key RegPollKey) error {
 	return nil
 }
 
-func (dm *dispRegistry) sigCtrl(pld *sig_mgmt.Pld, addr *snet.UDPAddr) {
+func (dm *dispRegistry) sigCtrl(pld *sig_mgmt.Pld, addr *snet.UDPAddr,
+	signed *ctrl.SignedPld) {
+
 	dm.Lock()
 	defer dm.Unlock()
 	u, err := pld.Union()
@@ -124,6 +131,12 @@ func (dm *dispRegistry) sigCtrl(pld *sig_mgmt.Pld, addr *snet.UDPAddr) {
 			return
 		}
 		entry <- regPld
+	case *sig_mgmt.PrefixAnn:
+		select {
+		case dm.PrefixAnnC <- &RegPld{Id: msgId, P: pld, Addr: addr, Signed: signed}:
+		default:
+			log.Warn("Dropping SIG PrefixAnn, handler is busy", "src", addr)
+		}
 	default:
 		log.Error("Unsupported ctrl payload type", "type", common.TypeOf(pld), "src", addr)
 	}
@@ -160,7 +173,7 @@ func dispFunc(dp *pktdisp.DispPkt) {
 	}
 	switch pld := u.(type) {
 	case *sig_mgmt.Pld:
-		Dispatcher.sigCtrl(pld, src)
+		Dispatcher.sigCtrl(pld, src, scpld)
 	default:
 		log.Error("Unsupported ctrl payload type", "type", common.TypeOf(pld))
 	}
@@ -20,15 +20,21 @@ package sigjson
 import (
 	"encoding/json"
 	"io/ioutil"
+	"net"
 
 	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/pathpol"
+	"github.com/scionproto/scion/go/lib/pktcls"
 )
 
 // Cfg is a direct Go representation of the JSON file format.
 type Cfg struct {
 	ASes          map[addr.IA]*ASEntry
 	ConfigVersion uint64
+	// Announce configures the announcement of the local IP prefixes to the
+	// SIGs of the remote ASes. If it is nil, no prefixes are announced.
+	Announce *Announce `json:",omitempty"`
 }
 
 // Load a JSON config file from path and parse it into a Cfg struct.
@@ -41,9 +47,205 @@ func LoadFromFile(path string) (*Cfg, error) {
 	if err := json.Unmarshal(b, cfg); err != nil {
 		return nil, common.NewBasicError("Unable to parse SIG config", err)
 	}
+	for ia, entry := range cfg.ASes {
+		if err := entry.Validate(); err != nil {
+			return nil, common.NewBasicError("Invalid SIG config", err, "ia", ia)
+		}
+	}
+	if cfg.Announce != nil {
+		if err := cfg.Announce.Validate(); err != nil {
+			return nil, common.NewBasicError("Invalid SIG config", err)
+		}
+	}
 	return cfg, nil
 }
 
+// MaxTrafficClasses is the maximum number of traffic classes per AS. Each
+// class uses its own session, session 0 is used by the default class.
+const MaxTrafficClasses = 255
+
 type ASEntry struct {
 	Nets []*IPNet
+	// TrafficClasses are the classes of the traffic to the AS. A packet
+	// belongs to the first class, in order, whose condition it matches.
+	// Packets that match no class belong to the default class.
+	TrafficClasses []*TrafficClass `json:",omitempty"`
+	// Multipath enables striping the traffic to the AS across several paths.
+	// If it is nil, each session uses a single path at a time.
+	Multipath *Multipath `json:",omitempty"`
+	// FEC enables forward error correction of the frames sent to the AS. If it
+	// is nil, or the remote SIG does not support it, frames are not protected.
+	FEC *FEC `json:",omitempty"`
+	// AcceptPrefixes filters the IP prefixes announced by the SIGs of the
+	// AS. The accepted prefixes are routed to the AS in addition to Nets. If
+	// it is nil, announced prefixes are ignored.
+	AcceptPrefixes *PrefixFilter `json:",omitempty"`
+}
+
+// Validate checks that the traffic classes are well-formed.
+func (e *ASEntry) Validate() error {
+	if len(e.TrafficClasses) > MaxTrafficClasses {
+		return common.NewBasicError("Too many traffic classes", nil,
+			"classes", len(e.TrafficClasses), "max", MaxTrafficClasses)
+	}
+	names := make(map[string]struct{}, len(e.TrafficClasses))
+	for _, tc := range e.TrafficClasses {
+		if tc.Name == "" {
+			return common.NewBasicError("Traffic class without name", nil)
+		}
+		if _, ok := names[tc.Name]; ok {
+			return common.NewBasicError("Duplicate traffic class", nil, "class", tc.Name)
+		}
+		names[tc.Name] = struct{}{}
+		if tc.Cond == nil || tc.Cond.Cond == nil {
+			return common.NewBasicError("Traffic class without condition", nil,
+				"class", tc.Name)
+		}
+	}
+	if e.Multipath != nil {
+		if err := e.Multipath.Validate(); err != nil {
+			return err
+		}
+	}
+	if e.FEC != nil {
+		return e.FEC.Validate()
+	}
+	return nil
+}
+
+const (
+	// MaxMultipathPaths is the maximum number of paths the traffic of a
+	// session is striped across.
+	MaxMultipathPaths = 16
+	// SchedulerWeighted assigns the flows to the paths in proportion to the
+	// ratio of delivered probes.
+	SchedulerWeighted = "weighted"
+	// SchedulerLatency assigns the flows to the paths in proportion to the
+	// ratio of delivered probes, divided by the RTT.
+	SchedulerLatency = "latency"
+)
+
+// Multipath configures the striping of the traffic to a remote AS. Each
+// session stripes its traffic across up to Paths healthy paths, and keeps
+// the packets of each inner IP flow on the same path.
+type Multipath struct {
+	// Paths is the maximum number of paths used at the same time.
+	Paths int
+	// Scheduler is the algorithm that assigns new flows to paths. If empty,
+	// SchedulerWeighted is used.
+	Scheduler string `json:",omitempty"`
+}
+
+// Validate checks the number of paths and the scheduler.
+func (m *Multipath) Validate() error {
+	if m.Paths < 2 || m.Paths > MaxMultipathPaths {
+		return common.NewBasicError("Invalid number of multipath paths", nil,
+			"paths", m.Paths, "min", 2, "max", MaxMultipathPaths)
+	}
+	switch m.Scheduler {
+	case "", SchedulerWeighted, SchedulerLatency:
+		return nil
+	}
+	return common.NewBasicError("Unknown multipath scheduler", nil, "scheduler", m.Scheduler)
+}
+
+const (
+	// MaxFECDataFrames is the maximum number of data frames per FEC group.
+	MaxFECDataFrames = 64
+	// MaxFECParityFrames is the maximum number of parity frames per FEC group.
+	MaxFECParityFrames = 16
+)
+
+// FEC configures the forward error correction of the frames sent to a remote
+// AS. After every DataFrames data frames, ParityFrames parity frames are sent,
+// such that up to ParityFrames lost frames of the group can be recovered.
+type FEC struct {
+	// DataFrames is the number of data frames per group.
+	DataFrames int
+	// ParityFrames is the number of parity frames per group.
+	ParityFrames int
+}
+
+// Validate checks the group size.
+func (f *FEC) Validate() error {
+	if f.DataFrames < 1 || f.DataFrames > MaxFECDataFrames {
+		return common.NewBasicError("Invalid number of FEC data frames", nil,
+			"frames", f.DataFrames, "min", 1, "max", MaxFECDataFrames)
+	}
+	if f.ParityFrames < 1 || f.ParityFrames > MaxFECParityFrames {
+		return common.NewBasicError("Invalid number of FEC parity frames", nil,
+			"frames", f.ParityFrames, "min", 1, "max", MaxFECParityFrames)
+	}
+	return nil
+}
+
+// MaxAnnouncedPrefixes is the maximum number of IP prefixes a SIG announces.
+const MaxAnnouncedPrefixes = 1024
+
+// Announce configures the announcement of the local IP prefixes.
+type Announce struct {
+	// Prefixes are the IP prefixes served by the local AS.
+	Prefixes []*IPNet
+}
+
+// Validate checks the number of prefixes.
+func (a *Announce) Validate() error {
+	if len(a.Prefixes) > MaxAnnouncedPrefixes {
+		return common.NewBasicError("Too many announced prefixes", nil,
+			"prefixes", len(a.Prefixes), "max", MaxAnnouncedPrefixes)
+	}
+	return nil
+}
+
+// PrefixFilter decides which announced IP prefixes are accepted. A prefix is
+// accepted if it is contained in one of the Allow prefixes, and does not
+// overlap with any of the Deny prefixes.
+type PrefixFilter struct {
+	Allow []*IPNet
+	Deny  []*IPNet `json:",omitempty"`
+}
+
+// Accept returns whether the announced prefix is accepted.
+func (f *PrefixFilter) Accept(n *net.IPNet) bool {
+	for _, d := range f.Deny {
+		if overlaps(d.IPNet(), n) {
+			return false
+		}
+	}
+	for _, a := range f.Allow {
+		if contains(a.IPNet(), n) {
+			return true
+		}
+	}
+	return false
+}
+
+// contains returns whether inner is a subnet of outer.
+func contains(outer, inner *net.IPNet) bool {
+	outerOnes, outerBits := outer.Mask.Size()
+	innerOnes, innerBits := inner.Mask.Size()
+	return outerBits == innerBits && outerOnes <= innerOnes && outer.Contains(inner.IP)
+}
+
+func overlaps(a, b *net.IPNet) bool {
+	return contains(a, b) || contains(b, a)
+}
+
+// TrafficClass is a class of IP traffic to a remote AS. The traffic of each
+// class is sent over its own session, on the paths allowed by the policy of
+// the class.
+type TrafficClass struct {
+	// Name is the name of the class, it is unique within the AS.
+	Name string
+	// Cond is the condition on the IP header the packets of the class match,
+	// in the format of package pktcls.
+	Cond *pktcls.Class
+	// Policy is the path policy of the class. If it is nil, all paths are
+	// used.
+	Policy *pathpol.Policy `json:",omitempty"`
+}
+
+// Class returns the packet class of the traffic class.
+func (tc *TrafficClass) Class() *pktcls.Class {
+	return pktcls.NewClass(tc.Name, tc.Cond.Cond)
 }
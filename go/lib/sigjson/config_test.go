@@ -21,8 +21,10 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/pktcls"
 	"github.com/scionproto/scion/go/lib/xtest"
 )
 
@@ -86,6 +88,136 @@ func TestLoadFromFile(t *testing.T) {
 	}
 }
 
+func TestLoadTrafficClasses(t *testing.T) {
+	cfg, err := LoadFromFile(filepath.Join("testdata", "02-trafficclasses.json"))
+	require.NoError(t, err)
+	entry := cfg.ASes[xtest.MustParseIA("1-ff00:0:1")]
+	require.NotNil(t, entry)
+	require.Len(t, entry.TrafficClasses, 2)
+
+	voice, bulk := entry.TrafficClasses[0], entry.TrafficClasses[1]
+	assert.Equal(t, "voice", voice.Name)
+	assert.Equal(t, "voice", voice.Class().GetName())
+	assert.Equal(t, pktcls.NewCondIPv4(&pktcls.IPv4MatchDSCP{DSCP: 0x2e}), voice.Cond.Cond)
+	require.NotNil(t, voice.Policy)
+	assert.Equal(t, "1-ff00:0:110#0 1-ff00:0:1#0", voice.Policy.Sequence.String())
+	assert.Equal(t, "bulk", bulk.Name)
+	require.NotNil(t, bulk.Policy)
+	assert.Len(t, bulk.Policy.ACL.Entries, 2)
+	assert.Equal(t, &Multipath{Paths: 3, Scheduler: SchedulerLatency}, entry.Multipath)
+	assert.Equal(t, &FEC{DataFrames: 16, ParityFrames: 2}, entry.FEC)
+}
+
+func TestLoadPrefixes(t *testing.T) {
+	cfg, err := LoadFromFile(filepath.Join("testdata", "03-prefixes.json"))
+	require.NoError(t, err)
+	require.NotNil(t, cfg.Announce)
+	require.Len(t, cfg.Announce.Prefixes, 2)
+	assert.Equal(t, "192.0.2.0/24", cfg.Announce.Prefixes[0].String())
+	assert.Equal(t, "2001:db8::/32", cfg.Announce.Prefixes[1].String())
+	entry := cfg.ASes[xtest.MustParseIA("1-ff00:0:1")]
+	require.NotNil(t, entry)
+	require.NotNil(t, entry.AcceptPrefixes)
+	assert.Len(t, entry.AcceptPrefixes.Allow, 1)
+	assert.Len(t, entry.AcceptPrefixes.Deny, 1)
+}
+
+func TestPrefixFilterAccept(t *testing.T) {
+	f := &PrefixFilter{
+		Allow: []*IPNet{mustParseIPNet(t, "10.0.0.0/8"), mustParseIPNet(t, "2001:db8::/32")},
+		Deny:  []*IPNet{mustParseIPNet(t, "10.1.0.0/16")},
+	}
+	tests := map[string]bool{
+		"10.2.0.0/16":     true,
+		"2001:db8:1::/48": true,
+		"0.0.0.0/0":       false,
+		"10.1.2.0/24":     false,
+		// Prefixes that overlap a denied prefix are rejected, even if they
+		// are allowed.
+		"10.0.0.0/8":          false,
+		"10.0.0.0/7":          false,
+		"192.0.2.0/24":        false,
+		"2001:db9::/32":       false,
+		"::ffff:10.0.0.0/104": false,
+	}
+	for prefix, accepted := range tests {
+		t.Run(prefix, func(t *testing.T) {
+			assert.Equal(t, accepted, f.Accept(mustParseIPNet(t, prefix).IPNet()))
+		})
+	}
+}
+
+func mustParseIPNet(t *testing.T, s string) *IPNet {
+	_, n, err := net.ParseCIDR(s)
+	require.NoError(t, err)
+	return (*IPNet)(n)
+}
+
+func TestASEntryValidate(t *testing.T) {
+	cond := pktcls.NewClass("", pktcls.CondTrue)
+	tests := map[string]struct {
+		Classes   []*TrafficClass
+		Multipath *Multipath
+		FEC       *FEC
+		Error     assert.ErrorAssertionFunc
+	}{
+		"no classes": {
+			Error: assert.NoError,
+		},
+		"valid": {
+			Classes: []*TrafficClass{{Name: "a", Cond: cond}, {Name: "b", Cond: cond}},
+			Error:   assert.NoError,
+		},
+		"no name": {
+			Classes: []*TrafficClass{{Cond: cond}},
+			Error:   assert.Error,
+		},
+		"duplicate name": {
+			Classes: []*TrafficClass{{Name: "a", Cond: cond}, {Name: "a", Cond: cond}},
+			Error:   assert.Error,
+		},
+		"no condition": {
+			Classes: []*TrafficClass{{Name: "a"}},
+			Error:   assert.Error,
+		},
+		"multipath": {
+			Multipath: &Multipath{Paths: 4, Scheduler: SchedulerWeighted},
+			Error:     assert.NoError,
+		},
+		"multipath single path": {
+			Multipath: &Multipath{Paths: 1},
+			Error:     assert.Error,
+		},
+		"multipath too many paths": {
+			Multipath: &Multipath{Paths: MaxMultipathPaths + 1},
+			Error:     assert.Error,
+		},
+		"multipath unknown scheduler": {
+			Multipath: &Multipath{Paths: 2, Scheduler: "fastest"},
+			Error:     assert.Error,
+		},
+		"fec": {
+			FEC:   &FEC{DataFrames: 8, ParityFrames: 2},
+			Error: assert.NoError,
+		},
+		"fec without parity": {
+			FEC:   &FEC{DataFrames: 8},
+			Error: assert.Error,
+		},
+		"fec too many data frames": {
+			FEC:   &FEC{DataFrames: MaxFECDataFrames + 1, ParityFrames: 1},
+			Error: assert.Error,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			e := &ASEntry{TrafficClasses: test.Classes, Multipath: test.Multipath,
+				FEC: test.FEC}
+			test.Error(t, e.Validate())
+		})
+	}
+}
+
 func TestIPNetUnmarshalJSON(t *testing.T) {
 	tests := []struct {
 		Name  string
@@ -0,0 +1,88 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snet
+
+import (
+	"context"
+	"math/rand"
+	"net"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/sock/reliable"
+	"github.com/scionproto/scion/go/lib/topology/overlay"
+)
+
+var _ reliable.Dispatcher = (*DirectDispatcher)(nil)
+
+// DirectDispatcher registers applications without the SCION Dispatcher. Each
+// registration opens its own UDP socket on an overlay port in Ports, with
+// the same number as the SCION/UDP port. Packets are sent directly to the
+// border routers, which deliver the packets to ports in the range directly
+// to the application, provided they are configured with the same range.
+//
+// SCMP messages and SVC addresses are routed by the dispatcher only, hence
+// they are not supported by direct registrations.
+type DirectDispatcher struct {
+	// Ports is the range of ports to bind to. If empty,
+	// overlay.DefaultDirectPorts is used.
+	Ports overlay.PortRange
+}
+
+// Register opens a UDP socket on address. If the port of address is 0, a
+// free port in the range is chosen.
+func (d *DirectDispatcher) Register(ctx context.Context, ia addr.IA, address *net.UDPAddr,
+	svc addr.HostSVC) (net.PacketConn, uint16, error) {
+
+	if svc != addr.SvcNone {
+		return nil, 0, serrors.New("SVC registrations require the dispatcher", "svc", svc)
+	}
+	if address == nil || address.IP == nil {
+		return nil, 0, serrors.New("nil listen address not supported")
+	}
+	ports := d.ports()
+	if address.Port != 0 {
+		if !ports.Contains(address.Port) {
+			return nil, 0, serrors.New("port not in direct port range", "port", address.Port,
+				"range", ports)
+		}
+		conn, err := net.ListenUDP("udp", address)
+		if err != nil {
+			return nil, 0, serrors.WrapStr("unable to open socket", err, "addr", address)
+		}
+		return conn, uint16(address.Port), nil
+	}
+	// Start at a random port, such that concurrently started applications
+	// rarely race for the same ports.
+	offset := rand.Intn(ports.Len())
+	for i := 0; i < ports.Len(); i++ {
+		if err := ctx.Err(); err != nil {
+			return nil, 0, err
+		}
+		port := int(ports.Min) + (offset+i)%ports.Len()
+		conn, err := net.ListenUDP("udp", &net.UDPAddr{IP: address.IP, Port: port})
+		if err == nil {
+			return conn, uint16(port), nil
+		}
+	}
+	return nil, 0, serrors.New("no free port in direct port range", "range", ports)
+}
+
+func (d *DirectDispatcher) ports() overlay.PortRange {
+	if d.Ports.Empty() {
+		return overlay.DefaultDirectPorts
+	}
+	return d.Ports
+}
@@ -0,0 +1,82 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snet_test
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/lib/topology/overlay"
+	"github.com/scionproto/scion/go/lib/xtest"
+)
+
+var testDirectPorts = overlay.PortRange{Min: 41000, Max: 41099}
+
+func TestDirectDispatcherRegister(t *testing.T) {
+	d := &snet.DirectDispatcher{Ports: testDirectPorts}
+	ia := xtest.MustParseIA("1-ff00:0:110")
+	localhost := net.IP{127, 0, 0, 1}
+	ctx := context.Background()
+
+	t.Run("free port", func(t *testing.T) {
+		conn, port, err := d.Register(ctx, ia, &net.UDPAddr{IP: localhost}, addr.SvcNone)
+		require.NoError(t, err)
+		defer conn.Close()
+		assert.True(t, testDirectPorts.Contains(int(port)))
+		assert.Equal(t, int(port), conn.LocalAddr().(*net.UDPAddr).Port)
+	})
+	t.Run("port out of range", func(t *testing.T) {
+		_, _, err := d.Register(ctx, ia, &net.UDPAddr{IP: localhost, Port: 40000},
+			addr.SvcNone)
+		assert.Error(t, err)
+	})
+	t.Run("SVC", func(t *testing.T) {
+		_, _, err := d.Register(ctx, ia, &net.UDPAddr{IP: localhost}, addr.SvcCS)
+		assert.Error(t, err)
+	})
+}
+
+func TestDirectNetworkLocalAS(t *testing.T) {
+	ia := xtest.MustParseIA("1-ff00:0:110")
+	localhost := net.IP{127, 0, 0, 1}
+	n := snet.NewDirectNetworkWithPR(ia, testDirectPorts, nil, nil)
+	ctx := context.Background()
+	server, err := n.Listen(ctx, "udp", &net.UDPAddr{IP: localhost}, addr.SvcNone)
+	require.NoError(t, err)
+	defer server.Close()
+	client, err := n.Listen(ctx, "udp", &net.UDPAddr{IP: localhost}, addr.SvcNone)
+	require.NoError(t, err)
+	defer client.Close()
+
+	// Packets to a host in the local AS are sent to the port of the
+	// application, without a dispatcher in between.
+	serverAddr := &snet.UDPAddr{IA: ia, Host: server.LocalAddr().(*net.UDPAddr)}
+	_, err = client.WriteTo([]byte("hello"), serverAddr)
+	require.NoError(t, err)
+	require.NoError(t, server.SetReadDeadline(time.Now().Add(time.Second)))
+	buf := make([]byte, 16)
+	nr, from, err := server.ReadFrom(buf)
+	require.NoError(t, err)
+	assert.Equal(t, "hello", string(buf[:nr]))
+	assert.Equal(t, client.LocalAddr().(*net.UDPAddr).Port,
+		from.(*snet.UDPAddr).Host.Port)
+}
@@ -44,6 +44,9 @@ type DefaultPacketDispatcherService struct {
 	// handler is nil, errors are returned back to applications every time an
 	// SCMP message is received.
 	SCMPHandler SCMPHandler
+	// TelemetryHandler is invoked for data packets that carry in-band network
+	// telemetry. It is optional.
+	TelemetryHandler TelemetryHandler
 }
 
 func (s *DefaultPacketDispatcherService) Register(ctx context.Context, ia addr.IA,
@@ -53,7 +56,11 @@ func (s *DefaultPacketDispatcherService) Register(ctx context.Context, ia addr.I
 	if err != nil {
 		return nil, 0, err
 	}
-	return &SCIONPacketConn{conn: rconn, scmpHandler: s.SCMPHandler}, port, nil
+	return &SCIONPacketConn{
+		conn:             rconn,
+		scmpHandler:      s.SCMPHandler,
+		telemetryHandler: s.TelemetryHandler,
+	}, port, nil
 }
 
 // RevocationHandler is called by the default SCMP Handler whenever revocations are encountered.
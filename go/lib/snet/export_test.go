@@ -28,3 +28,9 @@ func NewScionConnBase(localIA addr.IA, listen *net.UDPAddr) *scionConnBase {
 		scionNet: &SCIONNetwork{localIA: localIA},
 	}
 }
+
+func NewSCIONPacketConnWithTelemetry(conn net.PacketConn,
+	handler TelemetryHandler) *SCIONPacketConn {
+
+	return &SCIONPacketConn{conn: conn, telemetryHandler: handler}
+}
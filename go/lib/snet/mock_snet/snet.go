@@ -8,6 +8,7 @@ import (
 	context "context"
 	gomock "github.com/golang/mock/gomock"
 	addr "github.com/scionproto/scion/go/lib/addr"
+	seg "github.com/scionproto/scion/go/lib/ctrl/seg"
 	snet "github.com/scionproto/scion/go/lib/snet"
 	spath "github.com/scionproto/scion/go/lib/spath"
 	net "net"
@@ -489,6 +490,20 @@ func (mr *MockPathMockRecorder) Interfaces() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Interfaces", reflect.TypeOf((*MockPath)(nil).Interfaces))
 }
 
+// Load mocks base method
+func (m *MockPath) Load() []seg.InterfaceLoad {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Load")
+	ret0, _ := ret[0].([]seg.InterfaceLoad)
+	return ret0
+}
+
+// Load indicates an expected call of Load
+func (mr *MockPathMockRecorder) Load() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Load", reflect.TypeOf((*MockPath)(nil).Load))
+}
+
 // MTU mocks base method
 func (m *MockPath) MTU() uint16 {
 	m.ctrl.T.Helper()
@@ -531,6 +546,20 @@ func (mr *MockPathMockRecorder) Path() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Path", reflect.TypeOf((*MockPath)(nil).Path))
 }
 
+// StaticInfo mocks base method
+func (m *MockPath) StaticInfo() []seg.InterfaceStaticInfo {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StaticInfo")
+	ret0, _ := ret[0].([]seg.InterfaceStaticInfo)
+	return ret0
+}
+
+// StaticInfo indicates an expected call of StaticInfo
+func (mr *MockPathMockRecorder) StaticInfo() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StaticInfo", reflect.TypeOf((*MockPath)(nil).StaticInfo))
+}
+
 // MockPathQuerier is a mock of PathQuerier interface
 type MockPathQuerier struct {
 	ctrl     *gomock.Controller
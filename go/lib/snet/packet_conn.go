@@ -126,6 +126,9 @@ type SCIONPacketConn struct {
 	// handler is nil, errors are returned back to applications every time an
 	// SCMP message is received.
 	scmpHandler SCMPHandler
+	// telemetryHandler is invoked for data packets that carry the INT
+	// extension. It is optional.
+	telemetryHandler TelemetryHandler
 }
 
 // NewSCIONPacketConn creates a new conn with packet serialization/decoding
@@ -205,6 +208,11 @@ func (c *SCIONPacketConn) ReadFrom(pkt *Packet, ov *net.UDPAddr) error {
 		} else {
 			// non-SCMP L4s are assumed to be data and get passed back to the
 			// app.
+			if c.telemetryHandler != nil {
+				if t := pkt.Telemetry(); t != nil {
+					c.telemetryHandler.HandleTelemetry(pkt.Source, t)
+				}
+			}
 			return nil
 		}
 	}
@@ -20,6 +20,7 @@ import (
 
 	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/ctrl/seg"
 	"github.com/scionproto/scion/go/lib/spath"
 )
 
@@ -60,6 +61,14 @@ type Path interface {
 	// Expiry returns the expiration time of the path. If the result is a zero
 	// value expiration time is unknown.
 	Expiry() time.Time
+	// Load returns the load of the interfaces on the path, as announced in the
+	// path segments. It is aligned with Interfaces. If the load is not
+	// available the result is nil.
+	Load() []seg.InterfaceLoad
+	// StaticInfo returns the static metadata of the interfaces on the path, as
+	// announced in the path segments. It is aligned with Interfaces. If the
+	// static metadata is not available the result is nil.
+	StaticInfo() []seg.InterfaceStaticInfo
 	// Copy create a copy of the path.
 	Copy() Path
 }
@@ -114,6 +123,14 @@ func (p *partialPath) Expiry() time.Time {
 	return time.Time{}
 }
 
+func (p *partialPath) Load() []seg.InterfaceLoad {
+	return nil
+}
+
+func (p *partialPath) StaticInfo() []seg.InterfaceStaticInfo {
+	return nil
+}
+
 func (p *partialPath) Copy() Path {
 	if p == nil {
 		return nil
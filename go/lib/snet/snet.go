@@ -30,6 +30,10 @@
 //
 // Multiple networking contexts can share the same SCIOND and/or dispatcher.
 //
+// Networking contexts created with NewDirectNetworkWithPR do not use the
+// dispatcher. Their connections bind to their own UDP socket on a port in the
+// direct port range, and exchange packets with the border routers directly.
+//
 // Write calls never return SCMP errors directly. If a write call caused an
 // SCMP message to be received by the Conn, it can be inspected by calling
 // Read. In this case, the error value is non-nil and can be type asserted to
@@ -52,6 +56,7 @@ import (
 	"github.com/scionproto/scion/go/lib/serrors"
 	"github.com/scionproto/scion/go/lib/snet/internal/metrics"
 	"github.com/scionproto/scion/go/lib/sock/reliable"
+	"github.com/scionproto/scion/go/lib/topology/overlay"
 )
 
 var _ Network = (*SCIONNetwork)(nil)
@@ -64,6 +69,11 @@ type SCIONNetwork struct {
 	// is set to nil when operating on a SCIOND-less Network.
 	querier PathQuerier
 	localIA addr.IA
+	// directPorts is the range of ports of the applications that do not use
+	// the dispatcher. Packets to a host in the local AS on a port in the range
+	// are sent to the application directly. It is empty if all packets are
+	// sent to the dispatcher.
+	directPorts overlay.PortRange
 }
 
 // NewNetworkWithPR creates a new networking context with path resolver pr. A
@@ -83,6 +93,22 @@ func NewNetworkWithPR(ia addr.IA, dispatcher reliable.Dispatcher,
 	}
 }
 
+// NewDirectNetworkWithPR creates a new networking context that does not use
+// the dispatcher. The connections bind to ports in ports, which must match the
+// direct port range of the border routers. If ports is empty,
+// overlay.DefaultDirectPorts is used. A nil path resolver means the Network
+// will run without SCIOND.
+func NewDirectNetworkWithPR(ia addr.IA, ports overlay.PortRange,
+	querier PathQuerier, revHandler RevocationHandler) *SCIONNetwork {
+
+	if ports.Empty() {
+		ports = overlay.DefaultDirectPorts
+	}
+	n := NewNetworkWithPR(ia, &DirectDispatcher{Ports: ports}, querier, revHandler)
+	n.directPorts = ports
+	return n
+}
+
 // NewCustomNetworkWithPR is similar to NewNetworkWithPR, while giving control
 // over packet processing via pktDispatcher.
 func NewCustomNetworkWithPR(ia addr.IA, pktDispatcher PacketDispatcherService) *SCIONNetwork {
@@ -162,6 +188,7 @@ func (n *SCIONNetwork) Listen(ctx context.Context, network string, listen *net.U
 		// Update port
 		conn.listen.Port = int(port)
 	}
-	log.Debug("Registered with dispatcher", "addr", &UDPAddr{IA: n.localIA, Host: conn.listen})
+	log.Debug("Registered", "direct", !n.directPorts.Empty(),
+		"addr", &UDPAddr{IA: n.localIA, Host: conn.listen})
 	return newConn(conn, n.querier, packetConn), nil
 }
@@ -0,0 +1,39 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snet
+
+import (
+	"github.com/scionproto/scion/go/lib/layers"
+)
+
+// TelemetryHandler receives the in-band network telemetry collected by the
+// border routers on the path of received packets.
+type TelemetryHandler interface {
+	// HandleTelemetry is called for every received data packet that carries
+	// the INT extension, before the packet is returned to the caller. The
+	// handler must not keep references to the packet.
+	HandleTelemetry(src SCIONAddress, telemetry *layers.ExtnINT)
+}
+
+// Telemetry returns the in-band network telemetry extension of the packet, or
+// nil if the packet does not carry one.
+func (p *PacketInfo) Telemetry() *layers.ExtnINT {
+	for _, e := range p.Extensions {
+		if t, ok := e.(*layers.ExtnINT); ok {
+			return t
+		}
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snet_test
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/l4"
+	"github.com/scionproto/scion/go/lib/layers"
+	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/lib/xtest"
+)
+
+// loopbackConn returns the last written packet on read.
+type loopbackConn struct {
+	net.PacketConn
+	last []byte
+}
+
+func (c *loopbackConn) WriteTo(b []byte, _ net.Addr) (int, error) {
+	c.last = append([]byte(nil), b...)
+	return len(b), nil
+}
+
+func (c *loopbackConn) ReadFrom(b []byte) (int, net.Addr, error) {
+	return copy(b, c.last), &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 30041}, nil
+}
+
+type telemetryRecorder struct {
+	src       snet.SCIONAddress
+	telemetry *layers.ExtnINT
+}
+
+func (r *telemetryRecorder) HandleTelemetry(src snet.SCIONAddress, t *layers.ExtnINT) {
+	r.src, r.telemetry = src, t
+}
+
+func TestTelemetry(t *testing.T) {
+	ia := xtest.MustParseIA("1-ff00:0:110")
+	extn, err := layers.NewExtnINT(2)
+	require.NoError(t, err)
+	extn.Records = []layers.INTRecord{
+		{IA: ia, Timestamp: time.Unix(0, 42), Egress: 1, QueueLength: 7},
+	}
+	pkt := &snet.Packet{
+		Bytes: make(snet.Bytes, common.MaxMTU),
+		PacketInfo: snet.PacketInfo{
+			Source:      snet.SCIONAddress{IA: ia, Host: addr.HostFromIPStr("127.0.0.1")},
+			Destination: snet.SCIONAddress{IA: ia, Host: addr.HostFromIPStr("127.0.0.2")},
+			Extensions:  []common.Extension{extn},
+			L4Header:    &l4.UDP{SrcPort: 1, DstPort: 2},
+			Payload:     common.RawBytes{1, 2, 3},
+		},
+	}
+	assert.Equal(t, extn, pkt.Telemetry())
+
+	recorder := &telemetryRecorder{}
+	conn := snet.NewSCIONPacketConnWithTelemetry(&loopbackConn{}, recorder)
+	require.NoError(t, conn.WriteTo(pkt, &net.UDPAddr{}))
+	recv := &snet.Packet{}
+	require.NoError(t, conn.ReadFrom(recv, nil))
+	require.NotNil(t, recv.Telemetry())
+	assert.Equal(t, recv.Telemetry(), recorder.telemetry)
+	assert.Equal(t, pkt.Source.IA, recorder.src.IA)
+	assert.Equal(t, 2, recorder.telemetry.Capacity)
+	require.Len(t, recorder.telemetry.Records, 1)
+	assert.Equal(t, uint16(7), recorder.telemetry.Records[0].QueueLength)
+
+	// Packets without the extension are not reported.
+	recorder.telemetry = nil
+	pkt.Extensions = nil
+	require.NoError(t, conn.WriteTo(pkt, &net.UDPAddr{}))
+	recv = &snet.Packet{}
+	require.NoError(t, conn.ReadFrom(recv, nil))
+	assert.Nil(t, recv.Telemetry())
+	assert.Nil(t, recorder.telemetry)
+}
@@ -64,6 +64,9 @@ func (c *scionConnWriter) WriteTo(b []byte, raddr net.Addr) (int, error) {
 		nextHop = a.NextHop
 		if nextHop == nil && c.base.scionNet.localIA.Equal(a.IA) {
 			nextHop = &net.UDPAddr{IP: a.Host.IP, Port: overlay.EndhostPort}
+			if c.base.scionNet.directPorts.Contains(a.Host.Port) {
+				nextHop.Port = a.Host.Port
+			}
 		}
 	case *SVCAddr:
 		dst, port, path = SCIONAddress{IA: a.IA, Host: a.SVC}, 0, a.Path
@@ -24,6 +24,7 @@ import (
 
 	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/ctrl/seg"
 	"github.com/scionproto/scion/go/lib/l4"
 	"github.com/scionproto/scion/go/lib/snet"
 	"github.com/scionproto/scion/go/lib/spath"
@@ -201,6 +202,14 @@ func (p *path) Expiry() time.Time {
 	return time.Time{}
 }
 
+func (p *path) Load() []seg.InterfaceLoad {
+	return nil
+}
+
+func (p *path) StaticInfo() []seg.InterfaceStaticInfo {
+	return nil
+}
+
 func (p *path) Copy() snet.Path {
 	if p == nil {
 		return nil
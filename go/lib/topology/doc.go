@@ -77,12 +77,22 @@ The full JSON format for a BR data-plane AS-external underlay socket looks like
     },
     "LinkTo": "CORE",
     "MTU": 1472,
+    "Metadata": {
+      "Latency": "10ms",
+      "InternalLatency": "500us",
+      "Geo": {
+        "Latitude": 47.3769,
+        "Longitude": 8.5417,
+        "Address": "Zurich",
+      },
+      "LinkType": "direct",
+    },
   }
 
 To construct a BR data-plane AS-external underlay socket address out of the above, the following
 rules are used:
-  - Properties "ISD_AS", "Bandwidth", "LinkTo", and "MTU" are ignored. All unknown properties not
-    included in the above example are silently ignored.
+  - Properties "ISD_AS", "Bandwidth", "LinkTo", "MTU", and "Metadata" are ignored. All unknown
+    properties not included in the above example are silently ignored.
   - Properties "PublicOverlay" and "RemoteOverlay" must exist. An error is returned if one of them
     is missing.
   - Property "BindOverlay" is optional. If it is not present, then the address checks below do not
@@ -98,6 +108,12 @@ rules are used:
     however it sees fit.
 
 
+The optional "Metadata" property contains static information about the interface that is
+disseminated in the beacons. All of its properties are optional. "Latency" is the latency of the
+inter-AS link and "InternalLatency" the latency between the interface and the internal network of
+the AS. "LinkType" is the physical type of the link, one of "direct", "multihop", and "opennet".
+Parsing returns an error if a latency or the link type cannot be parsed.
+
 The full JSON format for a BR data-plane AS-internal underlay socket address looks like the
 following:
   {
@@ -110,6 +110,30 @@ type BRInterface struct {
 	IA             string           `json:"ISD_AS"`
 	LinkTo         string           `json:"LinkTo"`
 	MTU            int              `json:"MTU"`
+	// Metadata is the static metadata of the interface, which is
+	// disseminated in the beacons. It is optional.
+	Metadata *InterfaceMetadata `json:"Metadata,omitempty"`
+}
+
+// InterfaceMetadata contains the static metadata of an interface.
+type InterfaceMetadata struct {
+	// Latency is the latency of the inter-AS link, e.g., "5ms".
+	Latency string `json:"Latency,omitempty"`
+	// InternalLatency is the latency between the interface and the internal
+	// network of the AS.
+	InternalLatency string `json:"InternalLatency,omitempty"`
+	// Geo is the location of the interface.
+	Geo *GeoCoordinates `json:"Geo,omitempty"`
+	// LinkType is the physical type of the inter-AS link, one of "direct",
+	// "multihop" and "opennet".
+	LinkType string `json:"LinkType,omitempty"`
+}
+
+// GeoCoordinates is a geographical location.
+type GeoCoordinates struct {
+	Latitude  float32 `json:"Latitude"`
+	Longitude float32 `json:"Longitude"`
+	Address   string  `json:"Address,omitempty"`
 }
 
 // UnderlayAddress is a standard layer 3 + layer 4 address. This is identical to the other basic
@@ -145,6 +145,16 @@ func TestLoadRawFromFile(t *testing.T) {
 						IA:        "6-ff00:0:364",
 						LinkTo:    "CHILD",
 						MTU:       4430,
+						Metadata: &jsontopo.InterfaceMetadata{
+							Latency:         "10ms",
+							InternalLatency: "500us",
+							Geo: &jsontopo.GeoCoordinates{
+								Latitude:  47.3769,
+								Longitude: 8.5417,
+								Address:   "Zurich",
+							},
+							LinkType: "direct",
+						},
 					},
 				},
 			},
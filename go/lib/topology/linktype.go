@@ -49,3 +49,28 @@ func (lt LinkType) String() string {
 func LinkTypeFromString(s string) LinkType {
 	return LinkType(proto.LinkTypeFromString(strings.ToLower(s)))
 }
+
+// PhysLinkType describes the physical layer of inter-AS links.
+type PhysLinkType int
+
+const (
+	// PhysUnset is used for unknown physical link types.
+	PhysUnset PhysLinkType = 0
+	// Direct links are direct physical connections.
+	Direct PhysLinkType = 1
+	// Multihop links are connections with local routing/switching.
+	Multihop PhysLinkType = 2
+	// OpenNet links are overlayed over the public Internet.
+	OpenNet PhysLinkType = 3
+)
+
+func (lt PhysLinkType) String() string {
+	return proto.PhysicalLinkType(lt).String()
+}
+
+// PhysLinkTypeFromString returns the numerical physical link type associated with a string
+// description. If the string is not recognized, PhysUnset is returned. The matching is
+// case-insensitive.
+func PhysLinkTypeFromString(s string) PhysLinkType {
+	return PhysLinkType(proto.PhysicalLinkTypeFromString(strings.ToLower(s)))
+}
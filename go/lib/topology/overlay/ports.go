@@ -0,0 +1,96 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package overlay
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/scionproto/scion/go/lib/serrors"
+)
+
+// DefaultDirectPorts is the default range of overlay ports that applications
+// bind to when they do not use the dispatcher.
+var DefaultDirectPorts = PortRange{Min: 31000, Max: 32767}
+
+// PortRange is an inclusive range of overlay ports. Packets to a SCION/UDP
+// port in the range are delivered to the overlay port of the same number,
+// instead of the dispatcher on EndhostPort. The zero value is the empty range.
+type PortRange struct {
+	Min uint16
+	Max uint16
+}
+
+// PortRangeFromString parses a range of the form min-max. The empty string is
+// the empty range.
+func PortRangeFromString(s string) (PortRange, error) {
+	if s == "" {
+		return PortRange{}, nil
+	}
+	parts := strings.Split(s, "-")
+	if len(parts) != 2 {
+		return PortRange{}, serrors.New("port range must be of the form min-max", "range", s)
+	}
+	min, err := strconv.ParseUint(parts[0], 10, 16)
+	if err != nil {
+		return PortRange{}, serrors.WrapStr("invalid minimum port", err, "range", s)
+	}
+	max, err := strconv.ParseUint(parts[1], 10, 16)
+	if err != nil {
+		return PortRange{}, serrors.WrapStr("invalid maximum port", err, "range", s)
+	}
+	if min == 0 || min > max {
+		return PortRange{}, serrors.New("invalid port range", "range", s)
+	}
+	return PortRange{Min: uint16(min), Max: uint16(max)}, nil
+}
+
+// Empty returns whether the range contains no ports.
+func (r PortRange) Empty() bool {
+	return r.Min == 0 || r.Min > r.Max
+}
+
+// Contains returns whether port is in the range.
+func (r PortRange) Contains(port int) bool {
+	return !r.Empty() && port >= int(r.Min) && port <= int(r.Max)
+}
+
+// Len returns the number of ports in the range.
+func (r PortRange) Len() int {
+	if r.Empty() {
+		return 0
+	}
+	return int(r.Max) - int(r.Min) + 1
+}
+
+func (r PortRange) String() string {
+	if r.Empty() {
+		return ""
+	}
+	return fmt.Sprintf("%d-%d", r.Min, r.Max)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (r PortRange) MarshalText() ([]byte, error) {
+	return []byte(r.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (r *PortRange) UnmarshalText(text []byte) error {
+	var err error
+	*r, err = PortRangeFromString(string(text))
+	return err
+}
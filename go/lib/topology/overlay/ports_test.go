@@ -0,0 +1,61 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package overlay
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPortRangeFromString(t *testing.T) {
+	tests := map[string]struct {
+		Input    string
+		Expected PortRange
+		Valid    bool
+	}{
+		"empty":       {Input: "", Valid: true},
+		"range":       {Input: "31000-32767", Expected: DefaultDirectPorts, Valid: true},
+		"single port": {Input: "40000-40000", Expected: PortRange{40000, 40000}, Valid: true},
+		"no dash":     {Input: "31000"},
+		"reversed":    {Input: "32767-31000"},
+		"zero":        {Input: "0-100"},
+		"too large":   {Input: "31000-70000"},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			r, err := PortRangeFromString(test.Input)
+			if !test.Valid {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.Expected, r)
+			assert.Equal(t, test.Input, r.String())
+		})
+	}
+}
+
+func TestPortRangeContains(t *testing.T) {
+	r := PortRange{Min: 31000, Max: 31001}
+	assert.False(t, r.Contains(30999))
+	assert.True(t, r.Contains(31000))
+	assert.True(t, r.Contains(31001))
+	assert.False(t, r.Contains(31002))
+	assert.Equal(t, 2, r.Len())
+	assert.False(t, PortRange{}.Contains(0))
+	assert.Zero(t, PortRange{}.Len())
+}
@@ -30,6 +30,7 @@ import (
 	"github.com/scionproto/scion/go/lib/serrors"
 	jsontopo "github.com/scionproto/scion/go/lib/topology/json"
 	"github.com/scionproto/scion/go/lib/topology/overlay"
+	"github.com/scionproto/scion/go/lib/util"
 	"github.com/scionproto/scion/go/proto"
 )
 
@@ -107,6 +108,22 @@ type (
 		IA           addr.IA
 		LinkType     LinkType
 		MTU          int
+		// Latency is the latency of the link. It is 0 if unknown.
+		Latency time.Duration
+		// InternalLatency is the latency between the interface and the
+		// internal network of the AS. It is 0 if unknown.
+		InternalLatency time.Duration
+		// Geo is the location of the interface. It is nil if unknown.
+		Geo *GeoCoordinates
+		// PhysLinkType is the physical type of the link.
+		PhysLinkType PhysLinkType
+	}
+
+	// GeoCoordinates is a geographical location.
+	GeoCoordinates struct {
+		Latitude  float32
+		Longitude float32
+		Address   string
 	}
 
 	// IDAddrMap maps process IDs to their topology addresses.
@@ -233,6 +250,10 @@ func (t *RWTopology) populateBR(raw *jsontopo.Topology) error {
 			if err = ifinfo.CheckLinks(t.Attributes.Contains(trc.Core), name); err != nil {
 				return err
 			}
+			if err = ifinfo.setMetadata(rawIntf.Metadata); err != nil {
+				return serrors.WrapStr("unable to extract interface metadata", err,
+					"br", name, "ifid", ifid)
+			}
 			// These fields are only necessary for the border router.
 			// Parsing should not fail if they are missing.
 			if rawIntf.Underlay == "" && rawIntf.BindUnderlay == nil &&
@@ -388,6 +409,37 @@ func (i IFInfo) CheckLinks(isCore bool, brName string) error {
 	return nil
 }
 
+func (i *IFInfo) setMetadata(raw *jsontopo.InterfaceMetadata) error {
+	if raw == nil {
+		return nil
+	}
+	var err error
+	if raw.Latency != "" {
+		if i.Latency, err = util.ParseDuration(raw.Latency); err != nil {
+			return err
+		}
+	}
+	if raw.InternalLatency != "" {
+		if i.InternalLatency, err = util.ParseDuration(raw.InternalLatency); err != nil {
+			return err
+		}
+	}
+	if raw.Geo != nil {
+		i.Geo = &GeoCoordinates{
+			Latitude:  raw.Geo.Latitude,
+			Longitude: raw.Geo.Longitude,
+			Address:   raw.Geo.Address,
+		}
+	}
+	if raw.LinkType != "" {
+		if i.PhysLinkType = PhysLinkTypeFromString(raw.LinkType); i.PhysLinkType == PhysUnset {
+			return common.NewBasicError("Unknown physical link type", nil,
+				"type", raw.LinkType)
+		}
+	}
+	return nil
+}
+
 func (i IFInfo) String() string {
 	return fmt.Sprintf("IFinfo: Name[%s] IntAddr[%+v] CtrlAddr[%+v] Overlay:%s Local:%+v "+
 		"Remote:%+v Bw:%d IA:%s Type:%v MTU:%d", i.BRName, i.InternalAddr, i.CtrlAddrs, i.Underlay,
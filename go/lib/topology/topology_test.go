@@ -175,10 +175,18 @@ func TestIFInfoMap(t *testing.T) {
 				IP:   net.ParseIP("2001:db8:a0b:12f0::2"),
 				Port: 44998,
 			},
-			Bandwidth: 5000,
-			IA:        xtest.MustParseIA("1-ff00:0:314"),
-			LinkType:  Child,
-			MTU:       4430,
+			Bandwidth:       5000,
+			IA:              xtest.MustParseIA("1-ff00:0:314"),
+			LinkType:        Child,
+			MTU:             4430,
+			Latency:         10 * time.Millisecond,
+			InternalLatency: 500 * time.Microsecond,
+			Geo: &GeoCoordinates{
+				Latitude:  47.3769,
+				Longitude: 8.5417,
+				Address:   "Zurich",
+			},
+			PhysLinkType: Direct,
 		},
 		8: IFInfo{
 			ID:     8,
@@ -0,0 +1,95 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"flag"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+
+	"github.com/scionproto/scion/go/lib/common"
+)
+
+var _ flag.Value = (*Bandwidth)(nil)
+
+var bandwidthRE = regexp.MustCompile(`^([0-9]+(?:\.[0-9]+)?)(|k|M|G|T)bps$`)
+
+var bandwidthUnits = []struct {
+	prefix string
+	factor uint64
+}{
+	{"T", 1e12},
+	{"G", 1e9},
+	{"M", 1e6},
+	{"k", 1e3},
+	{"", 1},
+}
+
+// Bandwidth is a data rate in bits per second. It is marshalled to and
+// unmarshalled from strings like "500kbps" or "1.5Gbps".
+type Bandwidth uint64
+
+// ParseBandwidth parses a string into a Bandwidth. The string must consist of
+// a non-negative decimal number followed by one of the units "bps", "kbps",
+// "Mbps", "Gbps" or "Tbps". The prefixes are decimal, i.e. 1kbps is 1000 bits
+// per second.
+func ParseBandwidth(s string) (Bandwidth, error) {
+	matches := bandwidthRE.FindStringSubmatch(s)
+	if len(matches) != 3 {
+		return 0, common.NewBasicError("Invalid bandwidth string", nil, "val", s)
+	}
+	n, err := strconv.ParseFloat(matches[1], 64)
+	if err != nil {
+		return 0, common.NewBasicError("Invalid bandwidth value", err, "val", s)
+	}
+	for _, u := range bandwidthUnits {
+		if u.prefix == matches[2] {
+			return Bandwidth(math.Round(n * float64(u.factor))), nil
+		}
+	}
+	return 0, common.NewBasicError("Invalid unit in bandwidth string", nil, "val", s)
+}
+
+// BytesPerSecond returns the bandwidth in bytes per second.
+func (b Bandwidth) BytesPerSecond() float64 {
+	return float64(b) / 8
+}
+
+func (b *Bandwidth) UnmarshalText(text []byte) error {
+	return b.Set(string(text))
+}
+
+func (b Bandwidth) MarshalText() ([]byte, error) {
+	return []byte(b.String()), nil
+}
+
+func (b *Bandwidth) Set(s string) error {
+	var err error
+	*b, err = ParseBandwidth(s)
+	return err
+}
+
+// String returns the bandwidth with the largest unit that represents it
+// without loss of precision.
+func (b Bandwidth) String() string {
+	for _, u := range bandwidthUnits {
+		if b != 0 && uint64(b)%u.factor == 0 {
+			return fmt.Sprintf("%d%sbps", uint64(b)/u.factor, u.prefix)
+		}
+	}
+	return "0bps"
+}
@@ -0,0 +1,70 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseBandwidth(t *testing.T) {
+	tests := []struct {
+		input          string
+		output         Bandwidth
+		errorAssertion assert.ErrorAssertionFunc
+	}{
+		{"", 0, assert.Error},
+		{"10", 0, assert.Error},
+		{"10mbps", 0, assert.Error},
+		{"-1Mbps", 0, assert.Error},
+		{"0bps", 0, assert.NoError},
+		{"800bps", 800, assert.NoError},
+		{"500kbps", 500000, assert.NoError},
+		{"1.5Mbps", 1500000, assert.NoError},
+		{"50Mbps", 50000000, assert.NoError},
+		{"2Gbps", 2000000000, assert.NoError},
+		{"1Tbps", 1000000000000, assert.NoError},
+	}
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("Input: %q", test.input), func(t *testing.T) {
+			ret, err := ParseBandwidth(test.input)
+			test.errorAssertion(t, err)
+			assert.Equal(t, test.output, ret)
+		})
+	}
+}
+
+func TestBandwidthString(t *testing.T) {
+	tests := []struct {
+		input  Bandwidth
+		output string
+	}{
+		{0, "0bps"},
+		{999, "999bps"},
+		{1500000, "1500kbps"},
+		{50000000, "50Mbps"},
+		{3000000000, "3Gbps"},
+	}
+	for _, test := range tests {
+		t.Run(test.output, func(t *testing.T) {
+			assert.Equal(t, test.output, test.input.String())
+			parsed, err := ParseBandwidth(test.output)
+			assert.NoError(t, err)
+			assert.Equal(t, test.input, parsed)
+		})
+	}
+}
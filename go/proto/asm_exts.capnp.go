@@ -3,6 +3,7 @@
 package proto
 
 import (
+	math "math"
 	capnp "zombiezen.com/go/capnproto2"
 	text "zombiezen.com/go/capnproto2/encoding/text"
 	schemas "zombiezen.com/go/capnproto2/schemas"
@@ -236,32 +237,602 @@ func (p HiddenPathSegExtn_Promise) Struct() (HiddenPathSegExtn, error) {
 	return HiddenPathSegExtn{s}, err
 }
 
-const schema_e6c88f91b6a1209e = "x\xda\x8c\xce?\x8b\x13A\x1c\xc6\xf1\xe7\x99\xd9d\x8d" +
-	"h\x925\xfb\x02\xc4RD\x08\"\x88\x8d\x7fH\xc0t" +
-	";\xc6F\x10t\xc9\x8e\xc9Bvva'\xb0[\x05" +
-	"A\xad\x04I\xa1b#\xe45\x08bk\xa1\xf8\x1a\xae" +
-	";\xb8?/\xe0\xaa\xab\xf6\xd8\x14w\x1c\x97\xe2\xda\x1f" +
-	"\x9fy\xe6\xdb\xfd\xf6X\xf4\x1b\x1d\x02\xeaZ\xa3Y\x1d" +
-	"\xef\xdd\x7f\xf0{\xe7\xcfW\xa8\x0eE\xf5\xe3\xe6\xfa\xd7" +
-	"\xea\xf3\xff\x034\xe8\x02\xfdD\xd0+]\xc0[\x1c\x82" +
-	"\xd5\xfa\xdf\x9dwm\xfd\xf1oMyF\x1d\x17\xb8\xf7" +
-	"\x927\xd8\x8b\xebG=\xcdG`u\xf4i\x7f\xf7\xfb" +
-	"\xcf\xb2\xda\x86?\xf0*{_6x\xb5\xc1a\x9e\xbc" +
-	"\xd6\x85\xcd\xe5\xddI\x98\x99\xec\xe1\xf3tac3\x0d" +
-	"\xd2y<)\x87\x85E@\xaa\xaet\x00\x87\x80\x17\xde" +
-	"\x02\xd4+I5\x13\xf4H\x9f\xf5Q?\x05\xd4\x1bI" +
-	"5\x17\xf4\x04}\x0a\xc0\x8bo\x03*\x92T\x99 \xa5" +
-	"O\x09xI\x0dg\x92\xea\xbd\xa0\x9bkKB\x90\xe0" +
-	"2K\xe7/\xcaL\xb3\x09\xc1&\xd8\x89\xdf\x8e\x06l" +
-	"A\xb0\x05.\xe3<\x0as\x9d\xb3\x0d\x06\x92\x9bs{" +
-	"K\xf9h<xbL\xba0\x13\x9dhc\x87\x05m" +
-	"\xdd\xee\x9c\xb6_\xaf\xdb\xafH*\xff\xfc\xef\x17\x96\x9e" +
-	"\xc5Q\xa4M\x10\xda\xd9XO\x87\x855\xc0\xe5\x96N" +
-	"\x02\x00\x00\xff\xff#\x1dpa"
+type LoadInfoExtn struct{ capnp.Struct }
+
+// LoadInfoExtn_TypeID is the unique identifier for the type LoadInfoExtn.
+const LoadInfoExtn_TypeID = 0xc9ceacfca4d03b88
+
+func NewLoadInfoExtn(s *capnp.Segment) (LoadInfoExtn, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 1})
+	return LoadInfoExtn{st}, err
+}
+
+func NewRootLoadInfoExtn(s *capnp.Segment) (LoadInfoExtn, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 1})
+	return LoadInfoExtn{st}, err
+}
+
+func ReadRootLoadInfoExtn(msg *capnp.Message) (LoadInfoExtn, error) {
+	root, err := msg.RootPtr()
+	return LoadInfoExtn{root.Struct()}, err
+}
+
+func (s LoadInfoExtn) String() string {
+	str, _ := text.Marshal(0xc9ceacfca4d03b88, s.Struct)
+	return str
+}
+
+func (s LoadInfoExtn) Set() bool {
+	return s.Struct.Bit(0)
+}
+
+func (s LoadInfoExtn) SetSet(v bool) {
+	s.Struct.SetBit(0, v)
+}
+
+func (s LoadInfoExtn) Interfaces() (InterfaceLoad_List, error) {
+	p, err := s.Struct.Ptr(0)
+	return InterfaceLoad_List{List: p.List()}, err
+}
+
+func (s LoadInfoExtn) HasInterfaces() bool {
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s LoadInfoExtn) SetInterfaces(v InterfaceLoad_List) error {
+	return s.Struct.SetPtr(0, v.List.ToPtr())
+}
+
+// NewInterfaces sets the interfaces field to a newly
+// allocated InterfaceLoad_List, preferring placement in s's segment.
+func (s LoadInfoExtn) NewInterfaces(n int32) (InterfaceLoad_List, error) {
+	l, err := NewInterfaceLoad_List(s.Struct.Segment(), n)
+	if err != nil {
+		return InterfaceLoad_List{}, err
+	}
+	err = s.Struct.SetPtr(0, l.List.ToPtr())
+	return l, err
+}
+
+// LoadInfoExtn_List is a list of LoadInfoExtn.
+type LoadInfoExtn_List struct{ capnp.List }
+
+// NewLoadInfoExtn creates a new list of LoadInfoExtn.
+func NewLoadInfoExtn_List(s *capnp.Segment, sz int32) (LoadInfoExtn_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 8, PointerCount: 1}, sz)
+	return LoadInfoExtn_List{l}, err
+}
+
+func (s LoadInfoExtn_List) At(i int) LoadInfoExtn { return LoadInfoExtn{s.List.Struct(i)} }
+
+func (s LoadInfoExtn_List) Set(i int, v LoadInfoExtn) error { return s.List.SetStruct(i, v.Struct) }
+
+func (s LoadInfoExtn_List) String() string {
+	str, _ := text.MarshalList(0xc9ceacfca4d03b88, s.List)
+	return str
+}
+
+// LoadInfoExtn_Promise is a wrapper for a LoadInfoExtn promised by a client call.
+type LoadInfoExtn_Promise struct{ *capnp.Pipeline }
+
+func (p LoadInfoExtn_Promise) Struct() (LoadInfoExtn, error) {
+	s, err := p.Pipeline.Struct()
+	return LoadInfoExtn{s}, err
+}
+
+type InterfaceLoad struct{ capnp.Struct }
+
+// InterfaceLoad_TypeID is the unique identifier for the type InterfaceLoad.
+const InterfaceLoad_TypeID = 0xa0114092393e4a29
+
+func NewInterfaceLoad(s *capnp.Segment) (InterfaceLoad, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 24, PointerCount: 0})
+	return InterfaceLoad{st}, err
+}
+
+func NewRootInterfaceLoad(s *capnp.Segment) (InterfaceLoad, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 24, PointerCount: 0})
+	return InterfaceLoad{st}, err
+}
+
+func ReadRootInterfaceLoad(msg *capnp.Message) (InterfaceLoad, error) {
+	root, err := msg.RootPtr()
+	return InterfaceLoad{root.Struct()}, err
+}
+
+func (s InterfaceLoad) String() string {
+	str, _ := text.Marshal(0xa0114092393e4a29, s.Struct)
+	return str
+}
+
+func (s InterfaceLoad) IfID() uint64 {
+	return s.Struct.Uint64(0)
+}
+
+func (s InterfaceLoad) SetIfID(v uint64) {
+	s.Struct.SetUint64(0, v)
+}
+
+func (s InterfaceLoad) Rate() uint64 {
+	return s.Struct.Uint64(8)
+}
+
+func (s InterfaceLoad) SetRate(v uint64) {
+	s.Struct.SetUint64(8, v)
+}
+
+func (s InterfaceLoad) Utilization() uint8 {
+	return s.Struct.Uint8(16)
+}
+
+func (s InterfaceLoad) SetUtilization(v uint8) {
+	s.Struct.SetUint8(16, v)
+}
+
+func (s InterfaceLoad) RouterQueueFill() uint8 {
+	return s.Struct.Uint8(17)
+}
+
+func (s InterfaceLoad) SetRouterQueueFill(v uint8) {
+	s.Struct.SetUint8(17, v)
+}
+
+// InterfaceLoad_List is a list of InterfaceLoad.
+type InterfaceLoad_List struct{ capnp.List }
+
+// NewInterfaceLoad creates a new list of InterfaceLoad.
+func NewInterfaceLoad_List(s *capnp.Segment, sz int32) (InterfaceLoad_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 24, PointerCount: 0}, sz)
+	return InterfaceLoad_List{l}, err
+}
+
+func (s InterfaceLoad_List) At(i int) InterfaceLoad { return InterfaceLoad{s.List.Struct(i)} }
+
+func (s InterfaceLoad_List) Set(i int, v InterfaceLoad) error { return s.List.SetStruct(i, v.Struct) }
+
+func (s InterfaceLoad_List) String() string {
+	str, _ := text.MarshalList(0xa0114092393e4a29, s.List)
+	return str
+}
+
+// InterfaceLoad_Promise is a wrapper for a InterfaceLoad promised by a client call.
+type InterfaceLoad_Promise struct{ *capnp.Pipeline }
+
+func (p InterfaceLoad_Promise) Struct() (InterfaceLoad, error) {
+	s, err := p.Pipeline.Struct()
+	return InterfaceLoad{s}, err
+}
+
+type StaticInfoExtn struct{ capnp.Struct }
+
+// StaticInfoExtn_TypeID is the unique identifier for the type StaticInfoExtn.
+const StaticInfoExtn_TypeID = 0xdb505e3694652d57
+
+func NewStaticInfoExtn(s *capnp.Segment) (StaticInfoExtn, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 1})
+	return StaticInfoExtn{st}, err
+}
+
+func NewRootStaticInfoExtn(s *capnp.Segment) (StaticInfoExtn, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 1})
+	return StaticInfoExtn{st}, err
+}
+
+func ReadRootStaticInfoExtn(msg *capnp.Message) (StaticInfoExtn, error) {
+	root, err := msg.RootPtr()
+	return StaticInfoExtn{root.Struct()}, err
+}
+
+func (s StaticInfoExtn) String() string {
+	str, _ := text.Marshal(0xdb505e3694652d57, s.Struct)
+	return str
+}
+
+func (s StaticInfoExtn) Set() bool {
+	return s.Struct.Bit(0)
+}
+
+func (s StaticInfoExtn) SetSet(v bool) {
+	s.Struct.SetBit(0, v)
+}
+
+func (s StaticInfoExtn) Interfaces() (InterfaceStaticInfo_List, error) {
+	p, err := s.Struct.Ptr(0)
+	return InterfaceStaticInfo_List{List: p.List()}, err
+}
+
+func (s StaticInfoExtn) HasInterfaces() bool {
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s StaticInfoExtn) SetInterfaces(v InterfaceStaticInfo_List) error {
+	return s.Struct.SetPtr(0, v.List.ToPtr())
+}
+
+// NewInterfaces sets the interfaces field to a newly
+// allocated InterfaceStaticInfo_List, preferring placement in s's segment.
+func (s StaticInfoExtn) NewInterfaces(n int32) (InterfaceStaticInfo_List, error) {
+	l, err := NewInterfaceStaticInfo_List(s.Struct.Segment(), n)
+	if err != nil {
+		return InterfaceStaticInfo_List{}, err
+	}
+	err = s.Struct.SetPtr(0, l.List.ToPtr())
+	return l, err
+}
+
+// StaticInfoExtn_List is a list of StaticInfoExtn.
+type StaticInfoExtn_List struct{ capnp.List }
+
+// NewStaticInfoExtn creates a new list of StaticInfoExtn.
+func NewStaticInfoExtn_List(s *capnp.Segment, sz int32) (StaticInfoExtn_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 8, PointerCount: 1}, sz)
+	return StaticInfoExtn_List{l}, err
+}
+
+func (s StaticInfoExtn_List) At(i int) StaticInfoExtn { return StaticInfoExtn{s.List.Struct(i)} }
+
+func (s StaticInfoExtn_List) Set(i int, v StaticInfoExtn) error { return s.List.SetStruct(i, v.Struct) }
+
+func (s StaticInfoExtn_List) String() string {
+	str, _ := text.MarshalList(0xdb505e3694652d57, s.List)
+	return str
+}
+
+// StaticInfoExtn_Promise is a wrapper for a StaticInfoExtn promised by a client call.
+type StaticInfoExtn_Promise struct{ *capnp.Pipeline }
+
+func (p StaticInfoExtn_Promise) Struct() (StaticInfoExtn, error) {
+	s, err := p.Pipeline.Struct()
+	return StaticInfoExtn{s}, err
+}
+
+type InterfaceStaticInfo struct{ capnp.Struct }
+
+// InterfaceStaticInfo_TypeID is the unique identifier for the type InterfaceStaticInfo.
+const InterfaceStaticInfo_TypeID = 0xcffadada0c3546e6
+
+func NewInterfaceStaticInfo(s *capnp.Segment) (InterfaceStaticInfo, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 32, PointerCount: 1})
+	return InterfaceStaticInfo{st}, err
+}
+
+func NewRootInterfaceStaticInfo(s *capnp.Segment) (InterfaceStaticInfo, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 32, PointerCount: 1})
+	return InterfaceStaticInfo{st}, err
+}
+
+func ReadRootInterfaceStaticInfo(msg *capnp.Message) (InterfaceStaticInfo, error) {
+	root, err := msg.RootPtr()
+	return InterfaceStaticInfo{root.Struct()}, err
+}
+
+func (s InterfaceStaticInfo) String() string {
+	str, _ := text.Marshal(0xcffadada0c3546e6, s.Struct)
+	return str
+}
+
+func (s InterfaceStaticInfo) IfID() uint64 {
+	return s.Struct.Uint64(0)
+}
+
+func (s InterfaceStaticInfo) SetIfID(v uint64) {
+	s.Struct.SetUint64(0, v)
+}
+
+func (s InterfaceStaticInfo) Latency() uint32 {
+	return s.Struct.Uint32(8)
+}
+
+func (s InterfaceStaticInfo) SetLatency(v uint32) {
+	s.Struct.SetUint32(8, v)
+}
+
+func (s InterfaceStaticInfo) InternalLatency() uint32 {
+	return s.Struct.Uint32(12)
+}
+
+func (s InterfaceStaticInfo) SetInternalLatency(v uint32) {
+	s.Struct.SetUint32(12, v)
+}
+
+func (s InterfaceStaticInfo) Bandwidth() uint64 {
+	return s.Struct.Uint64(16)
+}
+
+func (s InterfaceStaticInfo) SetBandwidth(v uint64) {
+	s.Struct.SetUint64(16, v)
+}
+
+func (s InterfaceStaticInfo) LinkType() PhysicalLinkType {
+	return PhysicalLinkType(s.Struct.Uint16(24))
+}
+
+func (s InterfaceStaticInfo) SetLinkType(v PhysicalLinkType) {
+	s.Struct.SetUint16(24, uint16(v))
+}
+
+func (s InterfaceStaticInfo) Geo() (GeoCoordinates, error) {
+	p, err := s.Struct.Ptr(0)
+	return GeoCoordinates{Struct: p.Struct()}, err
+}
+
+func (s InterfaceStaticInfo) HasGeo() bool {
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s InterfaceStaticInfo) SetGeo(v GeoCoordinates) error {
+	return s.Struct.SetPtr(0, v.Struct.ToPtr())
+}
+
+// NewGeo sets the geo field to a newly
+// allocated GeoCoordinates struct, preferring placement in s's segment.
+func (s InterfaceStaticInfo) NewGeo() (GeoCoordinates, error) {
+	ss, err := NewGeoCoordinates(s.Struct.Segment())
+	if err != nil {
+		return GeoCoordinates{}, err
+	}
+	err = s.Struct.SetPtr(0, ss.Struct.ToPtr())
+	return ss, err
+}
+
+// InterfaceStaticInfo_List is a list of InterfaceStaticInfo.
+type InterfaceStaticInfo_List struct{ capnp.List }
+
+// NewInterfaceStaticInfo creates a new list of InterfaceStaticInfo.
+func NewInterfaceStaticInfo_List(s *capnp.Segment, sz int32) (InterfaceStaticInfo_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 32, PointerCount: 1}, sz)
+	return InterfaceStaticInfo_List{l}, err
+}
+
+func (s InterfaceStaticInfo_List) At(i int) InterfaceStaticInfo {
+	return InterfaceStaticInfo{s.List.Struct(i)}
+}
+
+func (s InterfaceStaticInfo_List) Set(i int, v InterfaceStaticInfo) error {
+	return s.List.SetStruct(i, v.Struct)
+}
+
+func (s InterfaceStaticInfo_List) String() string {
+	str, _ := text.MarshalList(0xcffadada0c3546e6, s.List)
+	return str
+}
+
+// InterfaceStaticInfo_Promise is a wrapper for a InterfaceStaticInfo promised by a client call.
+type InterfaceStaticInfo_Promise struct{ *capnp.Pipeline }
+
+func (p InterfaceStaticInfo_Promise) Struct() (InterfaceStaticInfo, error) {
+	s, err := p.Pipeline.Struct()
+	return InterfaceStaticInfo{s}, err
+}
+
+func (p InterfaceStaticInfo_Promise) Geo() GeoCoordinates_Promise {
+	return GeoCoordinates_Promise{Pipeline: p.Pipeline.GetPipeline(0)}
+}
+
+type GeoCoordinates struct{ capnp.Struct }
+
+// GeoCoordinates_TypeID is the unique identifier for the type GeoCoordinates.
+const GeoCoordinates_TypeID = 0xf4b200634d562552
+
+func NewGeoCoordinates(s *capnp.Segment) (GeoCoordinates, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 1})
+	return GeoCoordinates{st}, err
+}
+
+func NewRootGeoCoordinates(s *capnp.Segment) (GeoCoordinates, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 1})
+	return GeoCoordinates{st}, err
+}
+
+func ReadRootGeoCoordinates(msg *capnp.Message) (GeoCoordinates, error) {
+	root, err := msg.RootPtr()
+	return GeoCoordinates{root.Struct()}, err
+}
+
+func (s GeoCoordinates) String() string {
+	str, _ := text.Marshal(0xf4b200634d562552, s.Struct)
+	return str
+}
+
+func (s GeoCoordinates) Latitude() float32 {
+	return math.Float32frombits(s.Struct.Uint32(0))
+}
+
+func (s GeoCoordinates) SetLatitude(v float32) {
+	s.Struct.SetUint32(0, math.Float32bits(v))
+}
+
+func (s GeoCoordinates) Longitude() float32 {
+	return math.Float32frombits(s.Struct.Uint32(4))
+}
+
+func (s GeoCoordinates) SetLongitude(v float32) {
+	s.Struct.SetUint32(4, math.Float32bits(v))
+}
+
+func (s GeoCoordinates) Address() (string, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.Text(), err
+}
+
+func (s GeoCoordinates) HasAddress() bool {
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s GeoCoordinates) AddressBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.TextBytes(), err
+}
+
+func (s GeoCoordinates) SetAddress(v string) error {
+	return s.Struct.SetText(0, v)
+}
+
+// GeoCoordinates_List is a list of GeoCoordinates.
+type GeoCoordinates_List struct{ capnp.List }
+
+// NewGeoCoordinates creates a new list of GeoCoordinates.
+func NewGeoCoordinates_List(s *capnp.Segment, sz int32) (GeoCoordinates_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 8, PointerCount: 1}, sz)
+	return GeoCoordinates_List{l}, err
+}
+
+func (s GeoCoordinates_List) At(i int) GeoCoordinates { return GeoCoordinates{s.List.Struct(i)} }
+
+func (s GeoCoordinates_List) Set(i int, v GeoCoordinates) error { return s.List.SetStruct(i, v.Struct) }
+
+func (s GeoCoordinates_List) String() string {
+	str, _ := text.MarshalList(0xf4b200634d562552, s.List)
+	return str
+}
+
+// GeoCoordinates_Promise is a wrapper for a GeoCoordinates promised by a client call.
+type GeoCoordinates_Promise struct{ *capnp.Pipeline }
+
+func (p GeoCoordinates_Promise) Struct() (GeoCoordinates, error) {
+	s, err := p.Pipeline.Struct()
+	return GeoCoordinates{s}, err
+}
+
+type PhysicalLinkType uint16
+
+// PhysicalLinkType_TypeID is the unique identifier for the type PhysicalLinkType.
+const PhysicalLinkType_TypeID = 0xd65c514e1a428cbc
+
+// Values of PhysicalLinkType.
+const (
+	PhysicalLinkType_unset    PhysicalLinkType = 0
+	PhysicalLinkType_direct   PhysicalLinkType = 1
+	PhysicalLinkType_multihop PhysicalLinkType = 2
+	PhysicalLinkType_opennet  PhysicalLinkType = 3
+)
+
+// String returns the enum's constant name.
+func (c PhysicalLinkType) String() string {
+	switch c {
+	case PhysicalLinkType_unset:
+		return "unset"
+	case PhysicalLinkType_direct:
+		return "direct"
+	case PhysicalLinkType_multihop:
+		return "multihop"
+	case PhysicalLinkType_opennet:
+		return "opennet"
+
+	default:
+		return ""
+	}
+}
+
+// PhysicalLinkTypeFromString returns the enum value with a name,
+// or the zero value if there's no such value.
+func PhysicalLinkTypeFromString(c string) PhysicalLinkType {
+	switch c {
+	case "unset":
+		return PhysicalLinkType_unset
+	case "direct":
+		return PhysicalLinkType_direct
+	case "multihop":
+		return PhysicalLinkType_multihop
+	case "opennet":
+		return PhysicalLinkType_opennet
+
+	default:
+		return 0
+	}
+}
+
+type PhysicalLinkType_List struct{ capnp.List }
+
+func NewPhysicalLinkType_List(s *capnp.Segment, sz int32) (PhysicalLinkType_List, error) {
+	l, err := capnp.NewUInt16List(s, sz)
+	return PhysicalLinkType_List{l.List}, err
+}
+
+func (l PhysicalLinkType_List) At(i int) PhysicalLinkType {
+	ul := capnp.UInt16List{List: l.List}
+	return PhysicalLinkType(ul.At(i))
+}
+
+func (l PhysicalLinkType_List) Set(i int, v PhysicalLinkType) {
+	ul := capnp.UInt16List{List: l.List}
+	ul.Set(i, uint16(v))
+}
+
+const schema_e6c88f91b6a1209e = "x\xda\xb4TOh\\\xd5\x1b\xfd\xce\xbd\xf3&i\xc8" +
+	"/\x93\xf7{#h7Q\xa9\x8b\x84V\xaa\xa1\xa2\x15" +
+	"l\x8d\x99\xda\x84D\xe6&\xc6\xaa\x04\xf5u\xdeM\xe6" +
+	"\xe2\xcb}\xd3y\xf7\x91\x8c\x18BEE\xa1 J\x11" +
+	"\xbb\xa8$\xd0\x85\x85\x8a\xff\x8a(t\xa1\x0bE7\xea" +
+	"\xcaE\\\x88\xa2\x15]V\xbb\xa8(O\xee$o\x92" +
+	"Lg\xe1\xc6\xdd}\xdf=|\xf7|\xe7\x9c\xef\xed\x1f" +
+	"`\x87\xd9\x1d\xce\x00'\x12{\x9c|z\xed\xa7\x03w" +
+	"\x7f\xb4\xfe\xe9\xeb$\x0a`\xe9\x9b7\xaf}\xf8\xea+" +
+	"_\\&\x07]D\xc3\x09\x18\xbc\x93\xf6\xe8-\xe3\x17" +
+	"B:8~\xdf=\xaf\x1dvW-\x9ao\xa1s\x16" +
+	"\xe1\xb3\xdf\xbc\x05fO\x8aY\xec\xda\xe7{O\xf6\xc9" +
+	"\x17?\xb3X\xec\xc4\x0e\x97\xf8\xff\xe1\xcdp\x0b\x16\xfc" +
+	"\x10!}\xe9\xdeo\xce\xfdu\xe1\xab/\xdb\xc0M\x1a" +
+	"\xde\x09\xfe\xa3\xb7\xdc\x047\xf8\"!\xbd|\xe4@\xef" +
+	"\xfa\xfa\x9f_[p\xae\x9d\xf3\xaf|7\xbck\x16=" +
+	"|\x95\x1f\x03!\xbdtjd\xf7Cb\xf6[r\x0b" +
+	"\xdb&$\x0cO:\x0c\xdec\x8em<\xe3\xcc\x13\xd2" +
+	"c\xfb\xe4\xe9\xbb\x9e(\x7f\xd7\x91\xc5\xb2s\xc5{\xb9" +
+	"\x09~\xc1Y$|?u\xdb#\x93\x95\xf7\xff\xe8\x04" +
+	"\xbd\xea\\\xf1\x90\xb7\xa7\xbf\x9dw\x08\xe9\xef\xa7~\xfe" +
+	"\xe1\xcc\x07\x8d\xb4\x93\x14g\xf3=\xf0\xden\x82\xcf\xe7" +
+	"\xad\x14~\xbc\xf0\xa4\\21\xbf\xbd\xe2\xd7t\xed\xe0" +
+	"T\x94\x18\xa5\xe7\xcbQ\xa8*\x8d\xd2\x92\xa12 \xfa" +
+	"y\x8e(\x07\"\xd7\xbf\x95H\xccr\x88*\x83\x0b\x14" +
+	"a\x8br\x84H<\xc5!B\x06\x97\xa1\x08F\xe4\xaa" +
+	"!\"\x11p\x88\x1a\x03x\x11\x9c\xc8]\xb0\xc0*\x87" +
+	"x\x9e\xa1+\x96\x06 \x06\x10VjQ\xf8p\xa3&" +
+	"\x91'\x86<\xa1\xa0\xe6\xc6F\xb1\x8b\x18v\x11VT" +
+	"\x1c\xf8\xb1\x8c\xd1G(s4\xcb}\xdb\x98\xb3M\xe6" +
+	"c\xda\xc8\xfa\x9c_\x91\x85\x89\xc8\x0f\xdah\x0fu\xa2" +
+	"=\xb4\x9dv\xff&\xed\xe3\x9b\x14\x0d\x83\xcb\xdd\x0d\xde" +
+	"'\x9e#\x125\x0e\xf1,\xdb\xc9\xadP\xf7\x8d\xcc>" +
+	"\xd2\xc4\xa8P=\xe3\x1b\xeaR\x91\xcefI\xebQb" +
+	"d]$\x90\x89<\xa2\xc2\x90Z7\xed\xda\x8fM\x8f" +
+	"\xde\xafu\x94\xe8\x8a\\\x90\xda\x94\x96`\xec\x18\xb9\xd6" +
+	"\x18\xff\xb3\xeaws\x88\xe2N\xfd\xae\xd3\xc2*0\xa6" +
+	"\xe7\xa2R\xd7\x92\xd1\xb6Gw\xab\xc7\xa0\xed\xb1\x87C" +
+	"\xecg\xc8\x94\xd8\xf78\x91\xd8\xcb!\x8e\xb6\xf5U\x9b" +
+	"\x9a\x12\xdf\xd2\xbf\x7fk/\x09\xe8\xeb4G\xe6\xc4\xb4" +
+	"\xf1\x8d\xaa\x8ci>\x17Y\x127\xb6H\x9c\xb1\xd2\x9f" +
+	"\xe6\x10\xab\xd6\x0f\xb6\xc1\xe2\xacM\xc7\x1b\x1c\xe2\x9c\xf5" +
+	"\x83o\xf8\xb1f\xa5_\xe5\x10\x17\xac\x1fl\xc3\x8f\xf3" +
+	"SD\xe2-\x0eq\x91\xc1\xcd\xf5\x16\x91#r\xdf\x1b" +
+	"'\x12\xefr\x88K\x0cp\x8ap\x88\xdc\x8f\xed\xb0\x17" +
+	"9\xc4'm\xc6\xad\x84\xbe\x91\xba\xd2@71tg" +
+	"\x93j?\xc4\xc4\xc6\x05\xb5n\x8e\xfb:XT\x81!" +
+	"T[N\x87J?m\x03KD(l\xad<\x01\x05" +
+	"B\xd7\xbc\x8c\xd0\x9fm,\x01\xfd\x1d4*W\x1b\xb1" +
+	"\xaa\xf8\xe1D\xd6\xa9\x19\xd8\xe6\xc8\x83w\x12\x01\xee-" +
+	"\x07\x89\xc0\xdc\x9b\xc6\x89\xc0\xdd\x1bF\x88\x06\x12\x1dK" +
+	"s(PuY1\xe9B\x12\x1aU\x8djD\xb4\x12" +
+	"\xd5\xa4\xd6\xd2\\\x97\x83\xcc\x80\xb9\x81\xa8\xf4_$\xa1" +
+	"\xf5olKB\xf6\xfe\x832z \x8a\xea\x81\x1a\xd0" +
+	"\xbe\x91\xb1}\xbf\xb7\xf5~\xc9\x1a6\xca!\xca\xdb\x96" +
+	"r\xd2Z;\xc1!\x1ee\xb0\xc1\xb0\x82\xcc\xd8`\x94" +
+	"9\xc4,C\x1a\xfaF\x99$hJ\xdfC\x0c=\xd6" +
+	"\x8eH\xcf\xdb\"Af\xb5\x15?\x08\xea2\x8e\xd1K" +
+	"\x0c\xbd\x1d\x0c8\xaa\x82@\xea\xb2o\xaa\xd3r\xde\x8a" +
+	"C\xf4\xef\x96\xed\x9f\x01\x00=\x98\xa2."
 
 func init() {
 	schemas.Register(schema_e6c88f91b6a1209e,
 		0x96c1dab83835e4f9,
+		0xa0114092393e4a29,
 		0xc586650e812cc6a1,
+		0xc9ceacfca4d03b88,
+		0xcffadada0c3546e6,
+		0xd65c514e1a428cbc,
+		0xdb505e3694652d57,
+		0xf4b200634d562552,
 		0xff79b399e1e58cf3)
 }
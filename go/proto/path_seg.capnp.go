@@ -174,12 +174,12 @@ type ASEntry_exts ASEntry
 const ASEntry_TypeID = 0xd4a209e8e78874ff
 
 func NewASEntry(s *capnp.Segment) (ASEntry, error) {
-	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 32, PointerCount: 4})
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 32, PointerCount: 6})
 	return ASEntry{st}, err
 }
 
 func NewRootASEntry(s *capnp.Segment) (ASEntry, error) {
-	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 32, PointerCount: 4})
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 32, PointerCount: 6})
 	return ASEntry{st}, err
 }
 
@@ -335,12 +335,62 @@ func (s ASEntry_exts) NewHiddenPathSeg() (HiddenPathSegExtn, error) {
 	return ss, err
 }
 
+func (s ASEntry_exts) LoadInfo() (LoadInfoExtn, error) {
+	p, err := s.Struct.Ptr(4)
+	return LoadInfoExtn{Struct: p.Struct()}, err
+}
+
+func (s ASEntry_exts) HasLoadInfo() bool {
+	p, err := s.Struct.Ptr(4)
+	return p.IsValid() || err != nil
+}
+
+func (s ASEntry_exts) SetLoadInfo(v LoadInfoExtn) error {
+	return s.Struct.SetPtr(4, v.Struct.ToPtr())
+}
+
+// NewLoadInfo sets the loadInfo field to a newly
+// allocated LoadInfoExtn struct, preferring placement in s's segment.
+func (s ASEntry_exts) NewLoadInfo() (LoadInfoExtn, error) {
+	ss, err := NewLoadInfoExtn(s.Struct.Segment())
+	if err != nil {
+		return LoadInfoExtn{}, err
+	}
+	err = s.Struct.SetPtr(4, ss.Struct.ToPtr())
+	return ss, err
+}
+
+func (s ASEntry_exts) StaticInfo() (StaticInfoExtn, error) {
+	p, err := s.Struct.Ptr(5)
+	return StaticInfoExtn{Struct: p.Struct()}, err
+}
+
+func (s ASEntry_exts) HasStaticInfo() bool {
+	p, err := s.Struct.Ptr(5)
+	return p.IsValid() || err != nil
+}
+
+func (s ASEntry_exts) SetStaticInfo(v StaticInfoExtn) error {
+	return s.Struct.SetPtr(5, v.Struct.ToPtr())
+}
+
+// NewStaticInfo sets the staticInfo field to a newly
+// allocated StaticInfoExtn struct, preferring placement in s's segment.
+func (s ASEntry_exts) NewStaticInfo() (StaticInfoExtn, error) {
+	ss, err := NewStaticInfoExtn(s.Struct.Segment())
+	if err != nil {
+		return StaticInfoExtn{}, err
+	}
+	err = s.Struct.SetPtr(5, ss.Struct.ToPtr())
+	return ss, err
+}
+
 // ASEntry_List is a list of ASEntry.
 type ASEntry_List struct{ capnp.List }
 
 // NewASEntry creates a new list of ASEntry.
 func NewASEntry_List(s *capnp.Segment, sz int32) (ASEntry_List, error) {
-	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 32, PointerCount: 4}, sz)
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 32, PointerCount: 6}, sz)
 	return ASEntry_List{l}, err
 }
 
@@ -383,6 +433,14 @@ func (p ASEntry_exts_Promise) HiddenPathSeg() HiddenPathSegExtn_Promise {
 	return HiddenPathSegExtn_Promise{Pipeline: p.Pipeline.GetPipeline(3)}
 }
 
+func (p ASEntry_exts_Promise) LoadInfo() LoadInfoExtn_Promise {
+	return LoadInfoExtn_Promise{Pipeline: p.Pipeline.GetPipeline(4)}
+}
+
+func (p ASEntry_exts_Promise) StaticInfo() StaticInfoExtn_Promise {
+	return StaticInfoExtn_Promise{Pipeline: p.Pipeline.GetPipeline(5)}
+}
+
 type HopEntry struct{ capnp.Struct }
 
 // HopEntry_TypeID is the unique identifier for the type HopEntry.
@@ -729,66 +787,70 @@ func (l PathSegType_List) Set(i int, v PathSegType) {
 	ul.Set(i, uint16(v))
 }
 
-const schema_fb8053d9fb34b837 = "x\xda\xacT_h\x1c\xd5\x17>\xdf=\xb3\xbbI~" +
-	"\xbf8{\xd9\x95\xa6\"\xac\x15\x05]\xb5\xd4\xb4\x92R" +
-	"\x84\xd8\x9a\xb6\x89\x10\xdc\xdb\xc4JE\xd1\xe9\xee4\x19" +
-	"0\xb3\xc3\xce]\xda\x8d\x94\xd6\x87\xf8\x07\x15\x1f\x14\xb1" +
-	"\xa5%mQ0\xd0\xd0\x8a-\xa4A!-\x85\"\x08" +
-	"\xbe\x88h\xa0`\xb5E\x0b\xbe\xf9 m\xa9#w7" +
-	"\xb3\xbb$\xed\x9bow\x0e\xe7~\xe7|\xdf\xfd\xbeY" +
-	"w\x03\xcf\x88'\x13\x97\x04\x91z \x91\x8c\xfe\xf7\xd8" +
-	"\xdbgN]\xfe\xf2=R6\x12Q\xdf\xdc\x86[?" +
-	"\x8f\x1c\xbcE\x09\xa4\x882C\xb8\x90Q\xe6\xb4~\x18" +
-	"/\x82\x10\xdd\x0c\xe6\xdf:\xbcp\xe4#\x926\xda\x9a" +
-	"\x85i>.\x163\xb3\xf5\xd3\x8c\xd8K\x88\xec\xf9\xae" +
-	"\xa7\xdf\xd8\xf9\xe9\xb4A\xc6r\xe4\xd5\xbc\x98y\x98\xcd" +
-	"i\x0d\x9b\xe6\x83\x1bOt\xb93\x7f\x1f'i\x8bV" +
-	"/!\xb3\x9f\x173\xef\xd6\x1b\xa7x\x8c\x10E\xd3S" +
-	"G\xff\xe9\xd2\x17H\xad\x82\x15E\xfa\x9d\xdf\xff\xe8<" +
-	"\xf1\x03\xddk\xa5`&\xf3\xaf\x84\xcc,\x9f\"D\xf9" +
-	"?'W\xf5L~\x7fi\xd9\xae\x0d:V\x1e\x99W" +
-	",\x03\xbb\xcb\xea7\xb01\x90\xb2a\xb5u\xd7[j" +
-	"\xd6|\xe6MsZ\xbf\xdf\xfa\xd0\xc8pM\xd5\xfa\x8a" +
-	"\xdb\x17~\xbc#\xb5\xdb\x89\xc3\x99D\xd2\x9c\x904\xd4" +
-	"\x02G\x8f\xbf\x1a\xbacbm\xd1\x09\xfc`\xd3`9" +
-	"\xd8\xea\xebJ\x8d\x0a\x80\xeaa\x8b\xc8\x02\x91<\x94'" +
-	"R\x1f3\xd41\x01\x09da\x8aG_\"RG\x18" +
-	"\xea\x0b\x01):\xb2\x10D\xf2\xf3^\"u\x8c\xa1N" +
-	"\x0aH\xe6,\x98H\xce\x98\xe2g\x0cuZ@ZV" +
-	"\x16\x16\x91\x9c\xddM\xa4N2\xd4\x9c\x00\x12Y$\x88" +
-	"\xe4Y3\xe74C}-`{\xfe\xd0ft\x92@" +
-	"'!\xaa\xb8\x13e\xed\x0e\xf9\xc4C\xdb\xe2b\xce\xf3" +
-	"\x87G_@\x8a\x04R\x84\\\xb9\xaaW\\x\xbeJ" +
-	")\xdd\xbaa\x8f\x97\x83m\xe8&\x81\xee;\x90/8" +
-	"z|\xc4\x1d\x9bp\xd9\xd7\x86\x7fG\x93\xff\xa3\x86\xc0" +
-	"C\x0c\xb5\xae\x8d\xff\x13;\x88\xd4\xe3\x0c5(\x90\x0b" +
-	"K\x8ev\x9a\xc8NhT\xf4\\B\x88{\x08\x05\x06" +
-	"\xd2\xd1\x8e\xdfn\xf6Mm\xef\x9d&\x82)\xdem\xfc" +
-	"\xb0\xcb\xdaY6~Kk|s\xba\x91\xea\x11\x86\xda" +
-	" p h\\E\xba\x15\x02\x02\xd2\x04[\xd7\x02\x17" +
-	"v\xcb\xc2\x04\xd8w\x9f=Z\xe3\xc05\xb3\xd3\xf5\xc7" +
-	"4\xb4\x01\xb9\xe6>\"\x08\xb9:O\x04\x962O\x94" +
-	"\xab\xfa\xa1\xab\xb9\x1a\xd8\xa5\xf2^\xdf.\x96+\xee\x0a" +
-	"\xc8\xcd#u'\xaduS\xfbt\xa8\xfe\xcfV\x1aY" +
-	"$\x89\xe4\xd6\x0a\x91\x1a`\xa8\x82\x11Sd\x8d1\xe5" +
-	"\xb0Qx\x90\xa1F\x8d\x998\x8b\x0e\"\xa9Lg\x81" +
-	"\xa1^\x16\x88*\xe5\xaa\xf6\xfc\xb1\x02\xe5\xca\xaf{\xc5" +
-	"\x1a\xd2\xd1\x8d\xabOm\x9c[<\xff\xc9\x12\xd7\\\xe8" +
-	"\xed\xae8HG\x1f\xbcv}\xd7\xb9\xeb\xb7\xe7\x96\xea" +
-	"\xd1\xb8W*\xb9~\xc1\xa1\\\xac\xd2_\xef_\xbbr" +
-	"\xe8\xabZ\x14w\xc4\xbb\xf3r'\xf8z\xc4\x1b\xf3\xdd" +
-	"\x92=\xe04\x1e\xc5j>J\xb7\xd9\xb8\x83\xa1\xb2\xc2" +
-	"8qOy\xa5\xb1\x10K\xd1\xdf\xd0\xc2\x00\xdc\xdf\x04" +
-	"8\xdb\xdb2{\xd3T\xe76\x11\xa93\x0c\xb5`t" +
-	"\x10\x8dP}c\xde\x7f\x8e\xa1.\x9aP\xf54Bu" +
-	"\xfe9\"\xb5\xc0P\xdf\x09`)S\xdf\x1aS\\d" +
-	"\xa8_\x04d\xa2\xbb\x11\xaa\xcb\x0f\x12\xa9\x9f\x18\xea\xaa" +
-	"\x00\x92h\xfbM\xc9+y\x129/,9a\x9c\x90" +
-	"~])\xeet+\xf1\xe7\x81\xa2[\xd1m\xdf\x91\xb7" +
-	"gh`\xc4\x9bt\x89\x08I\x12H6B\xd5f\xf3" +
-	"\xe6O\xbba\xf3\xd4\x84\xae\xc6\x11\xb5\xdd}:\\\xa1" +
-	"N\x81\x9f\xdd\xf2\x9f\xf9\xdd\xec\x17/\xfbo\x00\x00\x00" +
-	"\xff\xff*<\x8e\xd4"
+const schema_fb8053d9fb34b837 = "x\xda\xacU]h\x1cU\x18\xfd\xce\xbd3\xbbI$" +
+	"\x9d\x1dv\xa4i\x11\xd6\x8a\x82\xae\xb6\xd4\xb4\x9aR\x85" +
+	"\xd8Z\xdb\xae\x10\xdc\xdb\xd4\x16}P\xa7\xbb\x93d\xa0" +
+	"\x99Yv\xee\xd2n\xb44>D\x13\xac\xa8\x18\xa4-" +
+	")iK\x04\x03)\xb6\xd8@\x1a\x14\xd2R(\x05\xf1" +
+	"\x07D4\x120\xda\xa2\x05\xdf|\x10[\xeb\xc8\xcdf" +
+	"v\x97\xa4}\xf3\xed\xce\x9d\xf3\x9d\xef~g\xce\xb9\xb3" +
+	"~5{\x86=\xae_aD\xe2~=\x16\xde\xf3\xe8" +
+	"\xdb\xe7?\x9d;\xf7\x0e\x09\x03z\xd86\xb5\xf1\xd6\x8f" +
+	"\x9d\xfd\xb7HG\x9c(\x99\xc1\xa5\xa4P\xab\x0d\x1d\xd8" +
+	"\x0bBx\xb30\xfd\xd6\xf1\x99\x91\x0f\xc94P\x07f" +
+	"\x0a|\x8a\xcd&\xcf,\xac\xc6\xd9\x01BhL7=" +
+	"\xfd\xfa\x9e\xa3\xa3\x8a\x19K\x99W\xf1\xd9\xe4C\\\xad" +
+	"\xd6p\x05\xee\xdft\xba\xc9\x19\xff\xeb\x14\x99\x06\xaba" +
+	"\x09\xc9C|69\xb4\x00\x1c\xe0\xdd\x840\x1c\x1d8" +
+	"\xf1o\x93\xbcDb%\xb40\x94\x83\xbf\xfd\xdex\xfa" +
+	";\xba7\x16\x87\xea\xcc\x7fIN*\xf4\x86s<\xa5" +
+	"\x0e\x9c\xfe\xa3oeK\xdf\xd7W\x96\x1cxa\xa6o" +
+	"\xb54\x92\xf3\x9a\xe2\x9e\xd3\xda\x0956a@\xabC" +
+	"\xc7\x14\xe4\xb66\x9d\xd4uU\x07\xfd=E}]\x94" +
+	"\xdbr;f\xbe\xbf\xe3|o\xc6\x8e'\x87\x16\xea\x06" +
+	"bj\xbe\x82-{^\x0d\x9cn\xb6.g\x17\xbc\xc2" +
+	"\xe6\x9d~\xe19O\x16\xcb\x94\x05D\x0b\xd7\x884\x10" +
+	"\x99\xc7\xd2Db\x98C\x9cd0\x01\x0bj\xf3\xc4\xcb" +
+	"Db\x84C|\xc2`\xb2\x06\x0b\x8c\xc8\xfc\xb8\x95H" +
+	"\x9c\xe4\x10\x13\x0c&\xe7\x168\x919\xae6\xc78\xc4" +
+	"Y\x06S\xd3,hD\xe6\x99}Db\x82CL1" +
+	"@\xb7\xa0\x13\x99\x93\xaa\xcfY\x0e\xf19\x83\xe1z\x99" +
+	"-h$\x86FBXtz}\xe9d<\xe2\x99\xed" +
+	"\xd1f\xca\xf5:v\xbf\x8881\xc4\x09)\xbf$\x97" +
+	"\x15\xbcP\xa2\xb8\xacU\x18=~a;\x9a\x89\xa1\xf9" +
+	"\x0e\xc3gm\xd9\xd3\xe9t\xf7:\xdc\x93j\xfe\x86\xea" +
+	"\xfc\x8f\xa8\x01\x1e\xe4\x10\xeb\xeb\xe6_\xbb\x8bH<\xc6" +
+	"!v2\xa4\x82\xbc-\xed*\xb3\x1d(\x15]\x87\x10" +
+	"`\x05!\xcb\x81D\xb8\xeb\xd7\x9bm\x03;ZG\x89" +
+	"\x80\x15wo\xdf\xe1pi/i\xbf\xb5\xd6\xbe\xda]" +
+	"I\xf50\x87\xd8\xc8p\xb8P)E\xa2\x96\x04\x02\x12" +
+	"\x04C\x96\x0b\x0e\x8c\x9a\x8f\x090\xee\xde{w\x99\x17" +
+	"\x1c\xd5;\x01\xb686`\xaeYM\x04f\xaeJ\x13" +
+	"\x81\x9bf\x9a(U\xf2\x02G\xf2R\xc1\xc8\xfb\x07<" +
+	"#\xe7\x17\x9de\x94[:\x17\x9c\xb4\xce\x89\x1f\x94\x81" +
+	"\xe2\xb4\xb8\x96\x80\x85\x18\x91y\xa8H$\xde\xe0\x10\x83" +
+	"JOf!Nd\x0e(\x91\xfb9\xc4\x11\xe5'n" +
+	"\xa1\x81\xc8\x1cR\xc8A\x0e1\xac\xfc\xa4Yh$2" +
+	"?x\x9eH\xbc\xcf!F\x94\x9ft\x0bM\xca\xa3\xca" +
+	"\x8eG9\xc4\x18CX\xf4K\xd2\xf5\xba\xb3\x94\xf2\xf7" +
+	"\xbb\xb92\x12\xe1\xdf\xd7\x9e\xd845{\xf1\xa3Ea" +
+	"R\x81\xbb\xafh#\x11\xbe\xfb\xda\x8d\x97.\xdc\xb8=" +
+	"\xb5\xb8\x1f\xf6\xb8\xf9\xbc\xe3emJE\x92\xfey\xe4" +
+	"\xfa\xfc\xb1\xcf\xcaa\x84\xd8\xef\xdb\xf9\x8c\xd7\xe5\x13\x11" +
+	"\x12\xe1\xe0S\xdf\x8c\xfd3\xf1\xd5\xd5\xe8m m\xe9" +
+	"\xe6\x94Q\xbb|$\xc2\xbdk\x9d\xe1'_\xc9\xfe\x14" +
+	"\xbd\x8eT\xe2K=\xe7\xc9N\xb7\xdbs\xf2\xc66\xbb" +
+	"\xf2\xf9\xb5\xea\xe7oV\xc24p\x08\x8b)\xcfw\xf9" +
+	"\xcb-\x8cH\xf4\xf6\x8a\xea\x8a\xe0\xbe*\xc1dk-" +
+	"VU\xfb^\xd8L$\xces\x88\x19%7\xab\xc4\xf7" +
+	"\x0b\xe5\xb4)\x0eqY\xc9\xddR\x89\xefE%\xf7\x0c" +
+	"\x87\xf8\x92\x01\x8b\xe9\xbd\xaa\xecw\x99C\xfc\xcc`\xea" +
+	"\xcd\x95\xf8\xce=@$~\xe0\x10\xd7\x18\x10C\xdd\xad" +
+	"h\xce\xa7\x89\xa5\xdc o\x07Q\x16\xdbe1\xb7\xc7" +
+	")F\x8f\x87sNQ\xd6=\x87nWf[\xa7\xdb" +
+	"\xe7\x10\x11b\xc4\x10\xab\xc4\xb7.P\xd5\x7fD%P" +
+	"\xf1^Y\x8a.\x03\xc39(\x83e\xead\xf9\xb3[" +
+	"\xff\xb7d\xa9\xf3E\x87\xfdo\x00gr\xac\xab"
 
 func init() {
 	schemas.Register(schema_fb8053d9fb34b837,
@@ -3,6 +3,7 @@
 package proto
 
 import (
+	math "math"
 	strconv "strconv"
 	capnp "zombiezen.com/go/capnproto2"
 	text "zombiezen.com/go/capnproto2/encoding/text"
@@ -26,10 +27,12 @@ const (
 	SCIONDMsg_Which_revReply           SCIONDMsg_Which = 10
 	SCIONDMsg_Which_segTypeHopReq      SCIONDMsg_Which = 11
 	SCIONDMsg_Which_segTypeHopReply    SCIONDMsg_Which = 12
+	SCIONDMsg_Which_pathSubReq         SCIONDMsg_Which = 13
+	SCIONDMsg_Which_pathUpdate         SCIONDMsg_Which = 14
 )
 
 func (w SCIONDMsg_Which) String() string {
-	const s = "unsetpathReqpathReplyasInfoReqasInfoReplyrevNotificationifInfoRequestifInfoReplyserviceInfoRequestserviceInfoReplyrevReplysegTypeHopReqsegTypeHopReply"
+	const s = "unsetpathReqpathReplyasInfoReqasInfoReplyrevNotificationifInfoRequestifInfoReplyserviceInfoRequestserviceInfoReplyrevReplysegTypeHopReqsegTypeHopReplypathSubReqpathUpdate"
 	switch w {
 	case SCIONDMsg_Which_unset:
 		return s[0:5]
@@ -57,6 +60,10 @@ func (w SCIONDMsg_Which) String() string {
 		return s[122:135]
 	case SCIONDMsg_Which_segTypeHopReply:
 		return s[135:150]
+	case SCIONDMsg_Which_pathSubReq:
+		return s[150:160]
+	case SCIONDMsg_Which_pathUpdate:
+		return s[160:170]
 
 	}
 	return "SCIONDMsg_Which(" + strconv.FormatUint(uint64(w), 10) + ")"
@@ -497,6 +504,72 @@ func (s SCIONDMsg) NewSegTypeHopReply() (SegTypeHopReply, error) {
 	return ss, err
 }
 
+func (s SCIONDMsg) PathSubReq() (PathReq, error) {
+	if s.Struct.Uint16(8) != 13 {
+		panic("Which() != pathSubReq")
+	}
+	p, err := s.Struct.Ptr(0)
+	return PathReq{Struct: p.Struct()}, err
+}
+
+func (s SCIONDMsg) HasPathSubReq() bool {
+	if s.Struct.Uint16(8) != 13 {
+		return false
+	}
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s SCIONDMsg) SetPathSubReq(v PathReq) error {
+	s.Struct.SetUint16(8, 13)
+	return s.Struct.SetPtr(0, v.Struct.ToPtr())
+}
+
+// NewPathSubReq sets the pathSubReq field to a newly
+// allocated PathReq struct, preferring placement in s's segment.
+func (s SCIONDMsg) NewPathSubReq() (PathReq, error) {
+	s.Struct.SetUint16(8, 13)
+	ss, err := NewPathReq(s.Struct.Segment())
+	if err != nil {
+		return PathReq{}, err
+	}
+	err = s.Struct.SetPtr(0, ss.Struct.ToPtr())
+	return ss, err
+}
+
+func (s SCIONDMsg) PathUpdate() (PathReply, error) {
+	if s.Struct.Uint16(8) != 14 {
+		panic("Which() != pathUpdate")
+	}
+	p, err := s.Struct.Ptr(0)
+	return PathReply{Struct: p.Struct()}, err
+}
+
+func (s SCIONDMsg) HasPathUpdate() bool {
+	if s.Struct.Uint16(8) != 14 {
+		return false
+	}
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s SCIONDMsg) SetPathUpdate(v PathReply) error {
+	s.Struct.SetUint16(8, 14)
+	return s.Struct.SetPtr(0, v.Struct.ToPtr())
+}
+
+// NewPathUpdate sets the pathUpdate field to a newly
+// allocated PathReply struct, preferring placement in s's segment.
+func (s SCIONDMsg) NewPathUpdate() (PathReply, error) {
+	s.Struct.SetUint16(8, 14)
+	ss, err := NewPathReply(s.Struct.Segment())
+	if err != nil {
+		return PathReply{}, err
+	}
+	err = s.Struct.SetPtr(0, ss.Struct.ToPtr())
+	return ss, err
+}
+
 func (s SCIONDMsg) TraceId() ([]byte, error) {
 	p, err := s.Struct.Ptr(1)
 	return []byte(p.Data()), err
@@ -585,6 +658,14 @@ func (p SCIONDMsg_Promise) SegTypeHopReply() SegTypeHopReply_Promise {
 	return SegTypeHopReply_Promise{Pipeline: p.Pipeline.GetPipeline(0)}
 }
 
+func (p SCIONDMsg_Promise) PathSubReq() PathReq_Promise {
+	return PathReq_Promise{Pipeline: p.Pipeline.GetPipeline(0)}
+}
+
+func (p SCIONDMsg_Promise) PathUpdate() PathReply_Promise {
+	return PathReply_Promise{Pipeline: p.Pipeline.GetPipeline(0)}
+}
+
 type PathReq struct{ capnp.Struct }
 type PathReq_flags PathReq
 
@@ -1013,12 +1094,12 @@ type FwdPathMeta struct{ capnp.Struct }
 const FwdPathMeta_TypeID = 0x8adfcabe5ff9daf4
 
 func NewFwdPathMeta(s *capnp.Segment) (FwdPathMeta, error) {
-	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 2})
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 5})
 	return FwdPathMeta{st}, err
 }
 
 func NewRootFwdPathMeta(s *capnp.Segment) (FwdPathMeta, error) {
-	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 2})
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 5})
 	return FwdPathMeta{st}, err
 }
 
@@ -1087,12 +1168,87 @@ func (s FwdPathMeta) SetExpTime(v uint32) {
 	s.Struct.SetUint32(4, v)
 }
 
+func (s FwdPathMeta) Load() (InterfaceLoad_List, error) {
+	p, err := s.Struct.Ptr(2)
+	return InterfaceLoad_List{List: p.List()}, err
+}
+
+func (s FwdPathMeta) HasLoad() bool {
+	p, err := s.Struct.Ptr(2)
+	return p.IsValid() || err != nil
+}
+
+func (s FwdPathMeta) SetLoad(v InterfaceLoad_List) error {
+	return s.Struct.SetPtr(2, v.List.ToPtr())
+}
+
+// NewLoad sets the load field to a newly
+// allocated InterfaceLoad_List, preferring placement in s's segment.
+func (s FwdPathMeta) NewLoad(n int32) (InterfaceLoad_List, error) {
+	l, err := NewInterfaceLoad_List(s.Struct.Segment(), n)
+	if err != nil {
+		return InterfaceLoad_List{}, err
+	}
+	err = s.Struct.SetPtr(2, l.List.ToPtr())
+	return l, err
+}
+
+func (s FwdPathMeta) StaticInfo() (InterfaceStaticInfo_List, error) {
+	p, err := s.Struct.Ptr(3)
+	return InterfaceStaticInfo_List{List: p.List()}, err
+}
+
+func (s FwdPathMeta) HasStaticInfo() bool {
+	p, err := s.Struct.Ptr(3)
+	return p.IsValid() || err != nil
+}
+
+func (s FwdPathMeta) SetStaticInfo(v InterfaceStaticInfo_List) error {
+	return s.Struct.SetPtr(3, v.List.ToPtr())
+}
+
+// NewStaticInfo sets the staticInfo field to a newly
+// allocated InterfaceStaticInfo_List, preferring placement in s's segment.
+func (s FwdPathMeta) NewStaticInfo(n int32) (InterfaceStaticInfo_List, error) {
+	l, err := NewInterfaceStaticInfo_List(s.Struct.Segment(), n)
+	if err != nil {
+		return InterfaceStaticInfo_List{}, err
+	}
+	err = s.Struct.SetPtr(3, l.List.ToPtr())
+	return l, err
+}
+
+func (s FwdPathMeta) Quality() (PathQuality, error) {
+	p, err := s.Struct.Ptr(4)
+	return PathQuality{Struct: p.Struct()}, err
+}
+
+func (s FwdPathMeta) HasQuality() bool {
+	p, err := s.Struct.Ptr(4)
+	return p.IsValid() || err != nil
+}
+
+func (s FwdPathMeta) SetQuality(v PathQuality) error {
+	return s.Struct.SetPtr(4, v.Struct.ToPtr())
+}
+
+// NewQuality sets the quality field to a newly
+// allocated PathQuality struct, preferring placement in s's segment.
+func (s FwdPathMeta) NewQuality() (PathQuality, error) {
+	ss, err := NewPathQuality(s.Struct.Segment())
+	if err != nil {
+		return PathQuality{}, err
+	}
+	err = s.Struct.SetPtr(4, ss.Struct.ToPtr())
+	return ss, err
+}
+
 // FwdPathMeta_List is a list of FwdPathMeta.
 type FwdPathMeta_List struct{ capnp.List }
 
 // NewFwdPathMeta creates a new list of FwdPathMeta.
 func NewFwdPathMeta_List(s *capnp.Segment, sz int32) (FwdPathMeta_List, error) {
-	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 8, PointerCount: 2}, sz)
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 8, PointerCount: 5}, sz)
 	return FwdPathMeta_List{l}, err
 }
 
@@ -1113,6 +1269,101 @@ func (p FwdPathMeta_Promise) Struct() (FwdPathMeta, error) {
 	return FwdPathMeta{s}, err
 }
 
+func (p FwdPathMeta_Promise) Quality() PathQuality_Promise {
+	return PathQuality_Promise{Pipeline: p.Pipeline.GetPipeline(4)}
+}
+
+type PathQuality struct{ capnp.Struct }
+
+// PathQuality_TypeID is the unique identifier for the type PathQuality.
+const PathQuality_TypeID = 0xd1ad24b613ac14cf
+
+func NewPathQuality(s *capnp.Segment) (PathQuality, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 24, PointerCount: 0})
+	return PathQuality{st}, err
+}
+
+func NewRootPathQuality(s *capnp.Segment) (PathQuality, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 24, PointerCount: 0})
+	return PathQuality{st}, err
+}
+
+func ReadRootPathQuality(msg *capnp.Message) (PathQuality, error) {
+	root, err := msg.RootPtr()
+	return PathQuality{root.Struct()}, err
+}
+
+func (s PathQuality) String() string {
+	str, _ := text.Marshal(0xd1ad24b613ac14cf, s.Struct)
+	return str
+}
+
+func (s PathQuality) Alive() bool {
+	return s.Struct.Bit(0)
+}
+
+func (s PathQuality) SetAlive(v bool) {
+	s.Struct.SetBit(0, v)
+}
+
+func (s PathQuality) Rtt() uint32 {
+	return s.Struct.Uint32(4)
+}
+
+func (s PathQuality) SetRtt(v uint32) {
+	s.Struct.SetUint32(4, v)
+}
+
+func (s PathQuality) Loss() float32 {
+	return math.Float32frombits(s.Struct.Uint32(8))
+}
+
+func (s PathQuality) SetLoss(v float32) {
+	s.Struct.SetUint32(8, math.Float32bits(v))
+}
+
+func (s PathQuality) Probes() uint32 {
+	return s.Struct.Uint32(12)
+}
+
+func (s PathQuality) SetProbes(v uint32) {
+	s.Struct.SetUint32(12, v)
+}
+
+func (s PathQuality) LastProbe() uint32 {
+	return s.Struct.Uint32(16)
+}
+
+func (s PathQuality) SetLastProbe(v uint32) {
+	s.Struct.SetUint32(16, v)
+}
+
+// PathQuality_List is a list of PathQuality.
+type PathQuality_List struct{ capnp.List }
+
+// NewPathQuality creates a new list of PathQuality.
+func NewPathQuality_List(s *capnp.Segment, sz int32) (PathQuality_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 24, PointerCount: 0}, sz)
+	return PathQuality_List{l}, err
+}
+
+func (s PathQuality_List) At(i int) PathQuality { return PathQuality{s.List.Struct(i)} }
+
+func (s PathQuality_List) Set(i int, v PathQuality) error { return s.List.SetStruct(i, v.Struct) }
+
+func (s PathQuality_List) String() string {
+	str, _ := text.MarshalList(0xd1ad24b613ac14cf, s.List)
+	return str
+}
+
+// PathQuality_Promise is a wrapper for a PathQuality promised by a client call.
+type PathQuality_Promise struct{ *capnp.Pipeline }
+
+func (p PathQuality_Promise) Struct() (PathQuality, error) {
+	s, err := p.Pipeline.Struct()
+	return PathQuality{s}, err
+}
+
 type PathInterface struct{ capnp.Struct }
 
 // PathInterface_TypeID is the unique identifier for the type PathInterface.
@@ -2262,131 +2513,149 @@ func (p SegTypeHopReplyEntry_Promise) Struct() (SegTypeHopReplyEntry, error) {
 	return SegTypeHopReplyEntry{s}, err
 }
 
-const schema_8f4bd412642c9517 = "x\xda\x94W}l\x1bg\x19\x7f\x9e\xf7\xec\xd8Nl" +
-	"\x9f\xddsF\x09\x82\xd0\xaaU\x9bj\xa9\x96t\x85\xae" +
-	"\x82\xa5M\xdb,\x1et\xcd\xd9\x11\x7fLC\xcc\x8b_" +
-	"'FN\xec\xdc]\xd2z\xa2\x84\xa2\x06XaZ\xab" +
-	"-\x82\xd1N\xb4\x1b\x94\x06\x86\xd8J5\xb1\x82&\xd0" +
-	":>\xa2\x09\x98T4-\x1a\xac-\xeb\xdat\xabX" +
-	"\xba\x8e~\xd0r\xe8\xb9;\xdf]/\xd7\x8f\xe5\xaf7" +
-	"\xfe=\xfe\xbd\xcf\xd7\xefy^\xdf1/\xb8\x86\xb5\x05" +
-	"\xff\x1d\x02\x90\x1f\x08\xd6\xe9\x1f<\xf7\x8b\xfd\xef\x9e\x7f" +
-	"\xf8\xdb\x90\x8c\xa1\xfe\xb1\x89\xdb\xf3\xf3\x8e~\xe11\x08" +
-	"b\x08@\xfa\x870-\xcd\x08t:)t\x00\xea\xe7" +
-	"\xa7/}\xe5\xa5\xa9\xb7v\x80\x1cC\xb71#\x93\xc6" +
-	"\xc0\x94\xb4 @\xa7O\x06N\x01\xeaM\xc9=]o" +
-	"+\xdb\x1e\xf3\x18\x1b\x16\xc1\xe0A)\x16\xa4S$H" +
-	"\xc4]/w\x8d\x1d\xda}f\x17\xd92\xc7v\x03\x0b" +
-	"\xc50 \xb5\x04\x0fKmd\xbd\xa25\xb8I\x00\xd4" +
-	"\x9f:\x9d:\xb1t\xfe\xd7\x9f\xf0\xf3\xf9\xcf\xe1)\xe9" +
-	"\xefa:\xbd\x16&\xea}[\x1b\x0e\xac\\S\x9d\xf0" +
-	"P\x1bn`dZ\x8aE\x0c7\"\x9b\x01\xf5\x99\xce" +
-	"\xb7\xc6\x7f:^\xb7\xdb\x8f\x97G\xceH\xc3\x86\xed`" +
-	"\x84x\xa7\xdf\xd8q\xfax\xf0/\xbbAnDA\x7f" +
-	"\xf7\x99#o\xb65\xfe\xe1\x084b\x08\x01\xa4]\x91" +
-	"i@i\xc2`\x9d\xd7\xb6\xb7\xed\x81\xf0\xa6I\x1f\xd6" +
-	"\x15\xb3\x11\x86\xd2U\x83\xf6\x92A{hvR\xbe\x7f" +
-	"\xfe\xc5g\xbd)6\xac[\xeb\xe7\xa1\xf4\xf9z\xb2\xbe" +
-	"\xab\xfe\x97\x80\xfa\xa7\x17?\xbe9\xb8\xa4\xe9\xa0oA" +
-	"f\xea\x0fJ\xb3\x86\xf1\xd9z\xf2\xe3\xf4\xb9\xdbFO" +
-	"\x9e]\xf3\xb2_t\xad\x0dg\xa4\xbb\x1a\xe8\xb4\xb2\x81" +
-	"\xdc\xb0\xe3\x91c(\xccIE\xc3\xcf\xa4A2^Q" +
-	"lhF@\xfd\xbd\xd1\xefWz\x97\xeb\xafx\x98\x0d" +
-	"/\x86\xa3'\xa4\xadQ:U\xa3\xe4\x85\xc8\xff\xba\xb6" +
-	"s\xfb\xa7\xa6\xfc\xda\xe2\x8d\xe8\xb4t\xd2\xb0=\x1e%" +
-	"/~\xf2\xce\xa2=\x07\x9e\xe6\xaf\xfa\xd9b\xec\xb0\x14" +
-	"\x89\x19\xcd\x14#\xdb7\x8f\xfff\xff#\x8f/9\xe5" +
-	"\x9b\xb8\xc5\xb1&\x94V\x1a\xd6m1J\\\xe9X\xe6" +
-	"KM\xaf]8\xe5\x97\x8b\xe3\xb1)\xe9\xaca;c" +
-	"0\xafZ\xf2\xfa\xb7\xfa\x1b_y\xdf\x8fY\xfax\xfc" +
-	"\x9c\xb48N\xa7\x05q\x0a\xaf\xe3\x9d\xbb[^\x98\x11" +
-	"g}\x8d\xb7\xc5\x0fK\x8f\x18\xc6\xe3\x86\xf1\x8b/m" +
-	"\x99\xfc\xee\xeb\xfb/\xf8y\xf1a\xfc\x9c\x84\"\x9d\xae" +
-	"\xc6\xc9\x8bh\xd3?\x7f\xde\xbf\xf8\xe4%\x90oCW" +
-	"\xe1\x1b\x99\xd1o\xad\xe2\x09@\xa9M$\xd6_\xbd\xf0" +
-	"\xf0=\x87\x9ey\xfe\xb2_\xc7?*\x9e\x93\x9e4X" +
-	"'D\xca\x83\xdaW,\x0f\xe5\x97\xf7\xb1\\e\xa8\xb2" +
-	":\xdd\x95\x1e*\x943|x\x84\x0b\xaa\xd6\x83(\x07" +
-	"\x84\x00@\x00\x01\x92\xb1v\x009,\xa0\xbc\x88as" +
-	"\xb1\x90^\xafb\x1c\xb0G@\x8c\x00\xa3\xa3\x87\xabk" +
-	"s\xbe'\xa7\x0dl\xe4Z\x0e\x80\xa8\x126U\xae\x93" +
-	"f\x8f\x80\xf2\x00C\xc4\x14\xd2g|!\x80\xfc\xa0\x80" +
-	"r\x89a\x92a\x0a\x19@\xb2x?\x80< \xa0\xbc" +
-	"\x9daR\xc0\x14\x0a\x00\xc9m\xf4\xed\xaf\x09(\x7f\x87" +
-	"\xe1X\xc1\xbc\x05c\xc00\x06\x18\x1a\xd4F0\x04\x0c" +
-	"C\x80zqH\xe3J!\xd7\x07\x02\xb7}M8\xf3" +
-	"\x00\x90>\x1c\xe3[*\xbd\xc5A\x8ea`\x18vE" +
-	"\x81F\x14\x19>\xda\x9c\xe1\x95R\xd5\x93\x8c\xd5V2" +
-	"R\x0c;\x14\xae\x8e\x944\xfb\xdak\x09\xb2\xeb\xd2\x1d" +
-	"\x9b\xee[\xbfQ\xed'\x86\xcf\xd5\x18\xa4\x09l\x02\xc8" +
-	"\xeeD\x01\xb3{\x90a\x0cu\xddH\x84\xf4$\xb6\x03" +
-	"d\x9f `/\x01\xec\x7f\xba\x91\x0c\xe9)\xec\x04\xc8" +
-	"\xfe\x80\x80\x1f\x13 \\\xd5\x8d\x84H\xfb0\x03\x90\xdd" +
-	"K\xc0\xb3\x04\x04\xae\xe8)\x0c\x00H\x93\x06p\x80\x80" +
-	"C\x04\x04\xff\xab\xa70\x08 =\x8f\x0f\x01d\x9f#" +
-	"\xe0\xb7\x04\xd4]\xd6SX\x07 \xbd\x88\xdf\x04\xc8\xfe" +
-	"\x9a\x80#\x04\x84.\xe9)\xa3\x1b\x7f\x8f\x0a@\xf6w" +
-	"\x04\xbcJ@\xf8\xa2\x9e\xc20\x8d[\x83\xeaO\x04\x1c" +
-	"% rAOa\x84\xa6/\xfe\x10 {\x94\x80c" +
-	"\x04\xd4\xffGOa=-\x15\xdc\x01\x90=F\xc0{" +
-	"\x044|\xa8\xa7\xb0\x81\xd4\x86\xf7\x02dO\x13p\x9e" +
-	"\x80\xe8y=\x85Q\x00i\xd6\xb8\xfc}\x02\xae\x10\x10" +
-	"\xfb@Oa\x8cF\xa6\xe1\xeeE\x02\x02\x8ca2\x8e" +
-	")\x8c\xd3l`\x94\xaa+\xf4y\x981\x14\x8ay\xa3" +
-	"I#\x80\xcd#C*\xd7\xa0n\xac\x92\xd3\x062|" +
-	"\x18\x13\xce\xa8\x03\xc4\x04\xa0n\"\x95\x12`\x15\x13\x8e" +
-	"\x9e-4\xa7\x9a\x12\x01\xa4\xef\xda\x03\xca\x8b\x86*%" +
-	"\xfa\xb6\xbd\xa8,\\\xe1\xa3\xf7\x95\xb5b\x01\x8b}9" +
-	"\xadX\x1e\x02L8K\xc7\xb2)\x16,\x8e\xe6\xe1\x11" +
-	"\xaej\x98pV\xb4\xd7\xc2\xba\xc5\x1ef\x16\xaere" +
-	"\xb4\xd8\xc7\xd3\xe8\x123&\x9c=\xe4kV)U\x81" +
-	"\xdc\xb1g\x92\xe3\xb2\x05\x12j/u\x9b\xa3\xbf\xb7Z" +
-	"\xe1\xdd\xd0\\\xae\x98\xe9\xb4\xe7\xbb\xc7\x02\xc9\x80x0" +
-	"\xe1l\"\xd3fLSr}<\x9d\xaf\xa9\xd83I" +
-	"\xd6f\xd3\x8e\x87\x1e\x1dv:Ci\x8c\x0fiJ\xd1" +
-	"-u{\x10\x9aR\xf7\xd0\xd2\xdcH\x9b#B\xe8\xe3" +
-	"\xc4\x1b\xb6y[h\xd8-\x12P\xbe\x83a\xb26\xa2" +
-	"Z\x97\x01\xc8K\x05\x94\xef\xa4\x09\xa8\xe6sj\xad\xab" +
-	"D\x9a\x87\xb5\x7f<\xd7d\xac\x92\x17\xfbr\"\x95\xdc" +
-	"\x13\xc0\xbd\x00rT@y>C]\xcd\xf0Q\x0a\xd5" +
-	"Lu\xe6_\x97?;~O\xfb\x8f\xecD\xce\xf1>" +
-	"\xc3\x87\x97\x17J9\xa1_%\xd7\x13;\xcd\xf9\xd8\xd2" +
-	"\xe9\xf6}\x971\x0a\x92\xad\xab\x1d\xdf\xc7\x14^P\xb8" +
-	":\x80\x08\x0c\x11\xb0c\xa0\x98\xcf\xf3\xa1\xda\xbf\xf6E" +
-	"\x829\xc0\xac.\xa9\xf5\x92\xaayk\xf0U+\x84\xa5" +
-	"\xcc\xee\xa9^\x10\xab\x15\xa7\x14\xa2\xae\xf5\xff\xed\x13-" +
-	"\xad\x99\x13\xdeR\xd4\xee0{\xc4j\x91\x0dC\x9a\x82" +
-	"\xc6\xc4\x8d\xda\xb7l\xa0U\xb0^@\xf9Agg|" +
-	"9\xe3\xec\x11{g\xf0Ng\x91\xdc\xda\x0a\xd0\xb5\xe2" +
-	" W\xb5\xdc `\xa5\xb6\x06n\xb2\x16\xba\xcbj\xb3" +
-	"F)\xf1\xb4\xcd2'\xf5\xf4\xe7,\xeddk;0" +
-	"\xb1RV\xec=\xd1\x9c\xcb\xe7\x15\xd5SUW\"D" +
-	"\x9f\xads\xc3n\xb7_\x8e\x9e\x14c\xad_Dj\x18" +
-	"bL\xd9\x8c[i\xebn\xb1\x16l-\xad\xdb\x16:" +
-	"\x0b6\xc9\xc2fZ\xc7\xa9Q\xb7\x0b(\xefd\x88\x02" +
-	"\xba\xde\xbf\xc9G\xdb\x81a\xc0\xd8,\xc9\x11\xea\xb3\x8a" +
-	"\x80\xf2\xf7\x18\x86\xf2\xaaV\x13EHU\xfal\x81\x0c" +
-	"\xe6\xb6P\xfb\xaa\xd4\xe7\xb5l\x14J\xb9~\xb5c\xa0" +
-	"\xb2\xae\xd0\xef\x8ai\xfe\x86\xb7\xef\x96\xfe\xb8\xe0\xf0\xf5" +
-	"\x15l5LHS\xaa\xd7\xaf\x85#a\x8a\xe2v\x01" +
-	"\xe5U\x0cE\x1a\xf4\x98p~\xd4X:\x1b(\xab\x9a" +
-	"\xa3B\xfb\xa1\xe5\xabBW\xbd\x043\xb7\xaej-s" +
-	"\xde\x08\xa2V\xadp\x14\xf5o\xacz\xba\x9eO^\xd8" +
-	"Gl\xe2\x9c\x1a\xad\xcd\xa6;L\x99]\xe7\xe9\x95\xf2" +
-	"\x0e\x9e\x1bi\xd5\x14\x92\xa0x\x85\xf4\x90%\xa4\x1eW" +
-	"^6R\xc9\xbb\x05\x94{\x19\"3+.\x93\xbaz" +
-	",!\xd9\xba\x0e\x99\x81\xb8\xf5,\x02\x864\xaddK" +
-	"\xc5N \xba*\xe9\xcec\xfc\xba\x0f\xcf\x8f<\xe2\xed" +
-	"\xb7\xf9\xcdh\x9bi\xa8To$W\x9f\x0e\xb9f\xac" +
-	"\xdfZ_\xd8j\xeb\x18\xb0\xdf\x8d\xae\x1b3\xce\x18\xae" +
-	"\xdd\xd8\xd6i\xdd\xd8\xcdP\xe7\x8aRV\xd6\x95\xf3\x80" +
-	"\xbc&\x8d\xb9A\xdb?\xb7|\x83v5\x81\xef\xd3\xf5" +
-	"\x86\xf9\xb4\x7fE\xf9Rw[)X\x9e\xcb\x87\xf2\x8a" +
-	"j\x06fF1Gn\xcc\xb31\xc5be\xf4\xce\xda" +
-	"\x86\xa7\x7f>s\xf3u\xef\x14\xcd\xd5\xbf$\x865\x02" +
-	"\xca_\xa4\x8b\x02\xe6\xed\xe9\x85\xae\xa6f=\xe6\xed\x1b" +
-	"W;M}\xadl\xdc?\x13:\x8a\xea\xba\xb2\xc2k" +
-	"\xdb\xef\xff\x01\x00\x00\xff\xff\x18V:\xa1"
+const schema_8f4bd412642c9517 = "x\xda\x94W}l\x14\xd7\x11\x9fy\xef\xce\xe7\x8f\xfb" +
+	"Z\xef9\xb5\xdc\x0f\x87\x08\x04F\x18a\x03-A)" +
+	"6\x06\x8c\x8f\xd6\x89\xf7\x8eT*JU\xce\xbe\xb5}" +
+	"\xd5\xd9w\xde]\x1b\x1c5u\xa8p[h\x10\xa1i" +
+	"\xd4\x0fR5$\x0a\x0dmPS\x0aH\xd0\x906J" +
+	"h\x1a+- \x81\x02\x16m\x02%\x04\x08H\x98@" +
+	"\xf9h\xda\xadfwo\xf7X\xd6$\xbd\xbf\xf6v~" +
+	";o\xde\xcco~\xf3\xde\x9c7\xfd\xcd\xac\xc1\xbf\xbc" +
+	"\x14@Z\xed/\xd1?zy\xe7\xf6\x0f\xaf>\xfa}" +
+	"\x10B\xa8\x7f\xe6\xe9Y\xe9\xca\xa3_\xd9\x0c~\x0c\x00" +
+	"\x88e\xbeq\xb1\xcaGO\x82\xaf\x09P\xbf:~\xf3" +
+	"\x9b\xaf\x8e\xbd\xbb\x11\xa4\x10\x16\x83\xfd\x04Y\xec\x1b\x13" +
+	"\xdb\x09<7\xee\xdb\x8c\x80z\x8d\xf0L\xeb\x19e\xdd" +
+	"f\x17\xda\xf0WV\xb2K\x14J\xe8)TB\x9e[" +
+	"_o\x1d\xd9\xbd\xf5\xc2\x16\xc22\x07\xbb\x8c\x05\"\xe8" +
+	"\x13\xebK\xf6\x8b\xf3\x09=\xb7\xa1\xe4\x8f\x1cP\xff\xc5" +
+	"\xb9\xd8\xe9\x19\xd5\xdf\xf9\xb1W\xd0\xed\xe5c\xe2\xd7\xcb" +
+	"\xe9\xe9\xe1rr\xbd\xed\xb1\x8a\x17\xe77\x0f?\xedr" +
+	"m\x841Z>.n1\xb0\x9b\xca\xd7\x00\xea\xe7[" +
+	"\xde\x1d\xfd\xd5h\xc9V/\xbf\xe7\xcb/\x88\xd7\x0c\xec" +
+	"\x84\xe1w\xfc\xc4\xc6s\xa7\xfc\x7f\xdb\x0aR\x15r\xfd" +
+	"\xc3\xe7\xdf8\xd9P\xf5\xe77\xa0\x0a\x03\x08 VU" +
+	"\x8c\x8bS*\x08\xfd\xf9\x0a\xf2\\\xd9\xf0l\xc3#\xa5" +
+	"\x0f\xed\xf0\xf0<w\xb8\x82\xa18j\x80\xd7U\x90\xeb" +
+	"\xdd\x13;\xa4U\xd57^r\xe7\xd9@\xef\xa9\xa8D" +
+	"\xf1\xa0\x81~\xad\xe2\xb7\x80\xfa\xbd\xd3\x9eZ\xe3\x9f^" +
+	"\xb3\xcb\x8df\x04\x19\x08\xee\x12\x87\x83\xf44\x18\xa48" +
+	"\xce]\xb9g\xe8\xfdK\xcd\xaf{\xedpO\xf0\x82\xf8" +
+	"\x9a\x81=\x10\xa40\xec=I!\xe4n\xf0\xa5\xe0\xaf" +
+	"\xc5k\x04\x9e;\x11\xacE@\xfd\xe2\xd0O\xf2+g" +
+	"\xeb\x07]\x9e\x8d(n\x86N\x8b\xfe0=a\x98\xa2" +
+	"\x88\xc8\x87\x16\xb7\xac\xff\xc2\x98\x175R\xe1q\xb1\xcf" +
+	"\xc0f\xc2\x14\xc5\x0bg\xa7>\xf3\xe2s\xf2\xdb^\xd8" +
+	"\x0d\xe1\xfd\xe2\x16\x03\xbb\xc9\xc0\x1e\x8a\xbd$\xee\x9d\xba" +
+	"\xf3\x88+b\x03\xbb3<&\xee\x0b\x1b\x19\x0c\x1b\x01" +
+	"\x9f<\xf5\x87\xed\x1b\x9e\x9a\xfe\x81g\x96\xdf\x8a\xd4\xa0" +
+	"x\"B\x1f\x1e\x8bP\x96\xb3\xef%\xbeVs\xe4\xfa" +
+	"\x07^\x89\x1b\x8d\x8e\x89[\xa2F\x18Q\x0ac\xc1\xf4" +
+	"w\xbe\xd7Su\xf0\xb2\x97g\xf1@\xf4\x8a\xf8\x96\x01" +
+	">\x18\xa5\\4\x9d]T\xb7\xf7|d\xc2\x13<M" +
+	"\xd8/\xd6\x0b\xf4T'\x10x\xdf\xabkw\xfc\xf0\x9d" +
+	"\xed\xd7\xbd\xa2\xf8\x99pE|\xc1\xc0n\x13(\x8a`" +
+	"\xcd?~\xd33\xed\xfd\x9b \xdd\x83E,\xa9b\x06" +
+	"A\x8f\x08\xa7\xc5\xbf\x1b\xe8\x13\x86\xe7\xdf\xef}t\xf9" +
+	"\xee\xe7\x7fw\xcb\xabM\xe6W^\x11\x17W\xd2\xd3\x97" +
+	"+)\x17jW&\xd7\x9f\x9e\xdd\xc5R\xf9\xfe\xfc\xc2" +
+	"xk\xbc\xbf;\x97\x90\x07\x06e\xaej\x1d\x88\x92\x8f" +
+	"\xfb\x00|\x08 \x84\x1a\x01\xa4R\x8e\xd2T\x86\xb5\x99" +
+	"\xee\xf8R\x15\xc3\x80\x1d\x1c\xb1\x0c\x18\x86\xef\xf0\xd5\xba" +
+	"&\xdd\x91\xd2z\xdbe-\x05@\xae>g\xbb\xda\xd3" +
+	"\x02 \xbd\xccQz\x85!b\x0c\xe9\xdd\xbe\xfb\x00\xa4" +
+	"\xdd\x1c\xa5?1\x14\x18\xc6\x90\x01\x08\x07V\x01H\xaf" +
+	"p\x94\x8e3\x148\xc6\x90\x03\x08\xc7\xe8\xeb\xc3\x1c\xa5" +
+	"\x93\x0c\x05\x1f\x8b\xa1\x0f@81\x13@:\xcaQ\xba" +
+	"\xccP\xf0\xf3\x18\xfa\x01\x84K\xf4\xf9E\x8e\xc9Rd" +
+	"(\x94\xf8bX\x02 \xfa\xb1\x05 \x81\x1c\x93Ad" +
+	"8\xd2m\xc6\x89!`\x18\x02\x0c\xf4i\x83\x18\x00\x86" +
+	"\x01@=\xd3\xaf\xc9Jw\xaa\x0b\xb8l\xef6\xea\xc8" +
+	"\x10 \xbd\x1c\x91\xd7\xe6Wf\xfad,\x05\x86\xa5\x80" +
+	"\x91l.\x95v\xd0u+\x16\xdd\xff\xa3f\xe1Y\x0b" +
+	"\xad\xabZJ\xcbt\xc5\xfb\x81w\xe7\x1c\xd4\xd9\xd6\xf9" +
+	"\xc1\xf1\xf1[\x87\x0a>\x07\x06S\xd9\x8c6\x8cQ\xa7" +
+	"\x11\x001Z\x94e4\xb2\x9c\x90\x87j\x13r>;" +
+	"\xec*\xd6B\xabX1\x86M\x8a\xac\x0ef5{S" +
+	"\xb7;H.\x897=\xf4\xe0\xd2v\xb5\x87<,-" +
+	"x\x10\xff\x8a5\x00\xc9\xbfP\x9a\x8e\"\xc3\x10\xea\xba" +
+	"Q(\xf1\x086\x02$\xdf&\xc3q2\xb0\xff\xeaF" +
+	"\xb1\xc4c\x94\xd7\xe4a2\x9c$\x03\xff\x8fn\x14L" +
+	"<\x81\x09\x80\xe4q2\x9c!\x83\xefc\xdd(\x9ax" +
+	"\xca0\xbcG\x86\x8bd\xf0\xff[7\x0a'\x9e\xc7N" +
+	"\x80\xe492\\%C\xc9-\xdd\xac\xdd\x04~\x17 " +
+	"y\x99\x0c\x1f\x93!pS\x8f\x19\x1ds\x13\x15\x80\xe4" +
+	"\x0d2\xf8\x18\xc3P\xe9\x0d=\x86\xa5\x00\"\xb2N\x80" +
+	"\x04\xa3j\xd3\xfb\xb2\xebz\x0c\xcbhl\xb1\x9f\x03$" +
+	"\x83d\xa8&C\xf9\xbf\xf4\x18\x96\x93\xdc\xb3\x8d\x00\xc9" +
+	"j2L%C\xc55=\x86\x15\x00\xe2\x14\xb6\x02 " +
+	"y/\x19f\x91!xU\x8fa\x90\xfa\x99\xd1\xda3" +
+	"\xc80\x8f\x0c\xa1\x8f\xf4\x18\x86\x00\xc4\x06F\xd1\xce!" +
+	"\xc3\x03\x8c\xa1\x10\xc5\x18\x86\x01\xc4\xfb\x19ej\x1e\xbd" +
+	"o\xa6\x0f\xc2W\xf4\x18F\xa8'\xd9*\x80\xe4\x03d" +
+	"h#CdB\x8fa\x14@\\f\x18\x96\x92\xa1\x83" +
+	"1\xe4\x99\xb4\xd1ue\x80\xb5\x83\xfd\xaa\xacA\xc9H" +
+	">\xa5\xf5&\xe4\x01\x8c:bo1\xc6\xb4\xe4\xb3\x80" +
+	"\xc4'[\xa4,kJ5{\x1e\x90\xbe\xb5%\xdam" +
+	"\x0d\xe4\xb3\xf4\xb5=\xae-\xbb\"\x0f=\x98\xd32\xdd" +
+	"\x98\xe9Ji\x99\\?`\xd4\x19\xbd\x16&\xd3m\xf9" +
+	"\xa8\x1d\x18\x94U\x0d\xa3\xceI\xc5\x8d\xb0V\xb1\x15\xba" +
+	"\xc0yY\x19\xcat\xc9q,R'\x8c:\x93\xd8\x13" +
+	"\x96\xcf\x0e\x03\x85c\x0b\xad\x13\xb2e$\xab}\xb4\xb1" +
+	"}\xf4\xac\x1c\xce\xcbmP\x9b\xcb\x9b\xe9\xb4'\x9c\x0b" +
+	"\x81\xb9\xbc\xe9\x07\xa3\xce,61#\x9a\x92\xea\x92\xe3" +
+	"\xe9\x82\xa8\x18%H\x0ev&\x80OZ\xa1\x87\xf3\xe9" +
+	"\x14pM\xf6(\xd1\xed\xc2\xba8\x19w\xf6\xe7j\xfb" +
+	"\x16G\xa3G\xe4~M\xc9\x14\xeb\x96=\x17\x0aJt" +
+	"\x9b[\x12\xc1\xb8\xa9w\xbcK&\xbf\xa5\xb6\xdf:\xd2" +
+	"\xfe\xa9\x1c\xa59\x0c\x85\x82b\xd7\x93\xe4\xce\xe0(\xcd" +
+	"\xa3\x81\xa0\xa6Sj\x81\x93\x11\x1a\x0f\x85?\xaee\x12" +
+	"\x16a2]\xa9\x08\x11\xc6\xb5\x81\x15\x00R\x90\xa3T" +
+	"\xcdPW\x13\xf2\x10m\xd5,T\xe2\x9f\xb7\xbe4\xba" +
+	"\xbc\xf1\x97\xdeI\xe90\xd9?\xbb;\x9b\xe2=\xaa\x15" +
+	"}\xf4Isb\xd4\xb5\x14\x87\xbf\xc5\x9c\x18\xf5\x0b\x9d" +
+	"\xf0G\x14\xb9[\x91\xd5^D`\x88\x80M\xbd\x99t" +
+	"Z\xee/\xfc\xb5\xd7\xe2\xa6dZ4+\x90Q\xd5\xdc" +
+	"e\xf8\x96\xb5\x8b\x19\xcc&\xe5J\x88\x0c\xe7\x9djD" +
+	"t\xad\xe7\xf0g\xeb\xea\x13\xa7\xdd\xd5(\xaca\x92\xcc" +
+	"\xe2\xd8\xb2~MAC\xe3\x83\xf6*\xcbh\xba-\xe5" +
+	"(\xadv\xa6\xe87\x12\x00\xd2#\x1c\xa5\xde\xa2)*" +
+	"\xd3\xf6Ws\x94\xb2\xecS\x8e4]\xcb\xf4\xc9\xaa\x96" +
+	"\xea\x03\xcc\x17\xc6\x9a{\xcc\xb9\xe6H[N\xad\xd5(" +
+	"%.\xe6\xcctRO?\xe7(#\xd47\x02\x8b\xe4" +
+	"s\x8a=\x99jS\xe9\xb4\xa2\xba\x0a[\x94\x88\x88\xc7" +
+	"\x9c\xbb+\xe1\xed\xc3\xb7+\xc5X\xa0L\x848C\x1e" +
+	"c\xb6\xc7\xc7\xe8\x1c\xb2\x96\xa3\xb4\xbe\x88\xea\xeb\xe8\xe5" +
+	"\xb79J?\xa0\xb4\x96\x9ai\x1d%\xae\xae\xe7(=" +
+	"\xc9\x109\x16]#\x84M\x8d\xc0\xd0g\x1eB\x06\x89" +
+	"gy\x8e\xd2\x13\x0c\x03iU+\xf4E@U\xba\x0a" +
+	"\xcfz_j-1X\x05\x00;\x1b\xdd\xd9T\x8f\xda" +
+	"\xd4\x9b_\xd2\xddS\xb4\xa7\xeaeg\x16\x89oN\xd9" +
+	"?y\x13[\x84\x09h\xca\xf0\xe4\xb5p\xba\x98v1" +
+	"\x8b\xa3\xb4\x80a\x84t\x08\xa3\xce\xe5\xd0j\xb5\xde\x9c" +
+	"\xaa9\x8dh\x1f?=\x1b\xb1\xa8^\\\x1epUk" +
+	"\xa6s*\x89h\xc3y\x19#\xfa\xe3\x0b\x9e+\x97w" +
+	"\\\xdf\x06\x80\x18\xb9\xa3F\x8b\x93\xf1&\xb3\xcd&9" +
+	"\x8c\xc6\xdc\xda\xe3\x91\x10\xc9<K\x01\xb8*\xdd\xf8\x89" +
+	"\x95fV\xa5)\xee\xc7\xcd\x12\x0a\x9c\x9b\xa2\xb2a\xa1" +
+	"S~\xc1\xe73Ee\x13\xf5\xdf\x13\x1c\xa5\x9f2\xac" +
+	"Me3CrAC\x02\x8a\xa6\x15\x9d\x10U\x15\xcb" +
+	"\x81a9`S^\xc9u\xcaj\xc1\xa6gS\xaa\xd6" +
+	"\xa1\xe4:\x01\xefl5\x0f\xfd1\xc5\x81+nq\xe8" +
+	"\xb4\xc4\xa1\xa3hs\xed\xb4\xb96\x8e\xd2J\x86h\xed" +
+	"M\xa2\x88;,q\xb0\xb5*`\x16\xa7X\xa3\"\x80" +
+	"\x01M\xcb\xda1\xd9\xa4\xc0\"v\x16s#<\xe9\xf5" +
+	"\xe2\xff\x9e\\\xf6-\xec\x93\xdc\xd6\x92P\x0e\xdfM\x82" +
+	"<X\x7f\xdb\xb4\xfat\\\xb7\x15\xa4\xa9\xd7>}\x17" +
+	"\xad\x98pFKa\xc5\x86\x16k\xc56\x86\xba\xac(" +
+	"9eI.M5\xb6\xda\xfd\xceM\xdb\xb7p\xcfM" +
+	"\x17\x91\xc0\xf3\x02p\xd7|\xda\xf7eO\xd7mV\x0a" +
+	"f\xa7\xd2\x81\xb4\xa2\xda{\x8b\xa1;\x9d\x06\xb3\x98\xeb" +
+	",\x10\xc9\xe4\x87\xe6\x15N>\xf4\xe7\x8b\x85?\x93\x1f" +
+	"d\x9c\xba\x15Q\x98\xfa\xb3\x99\xa3\xf4UZ\xc8g\xae" +
+	"\x1e\xbf\xaf\x88\xd7\xac\xc3\\\xbd}\xa1\xc3\xeb\xdb\xd5\xa0" +
+	"\xf86\xd7\x94Q\x97\xe4\x14\xbb!\xff7\x00\x0ah\xac" +
+	"\xf9"
 
 func init() {
 	schemas.Register(schema_8f4bd412642c9517,
@@ -2406,6 +2675,7 @@ func init() {
 		0xc5ff2e54709776ec,
 		0xca1e844241cf650f,
 		0xcc65a2a89c24e6a5,
+		0xd1ad24b613ac14cf,
 		0xe7279389a6bbe1dc,
 		0xe7f7d11a5652e06c,
 		0xf0c5156786d72738,
@@ -13,13 +13,14 @@ type SIGCtrl struct{ capnp.Struct }
 type SIGCtrl_Which uint16
 
 const (
-	SIGCtrl_Which_unset   SIGCtrl_Which = 0
-	SIGCtrl_Which_pollReq SIGCtrl_Which = 1
-	SIGCtrl_Which_pollRep SIGCtrl_Which = 2
+	SIGCtrl_Which_unset     SIGCtrl_Which = 0
+	SIGCtrl_Which_pollReq   SIGCtrl_Which = 1
+	SIGCtrl_Which_pollRep   SIGCtrl_Which = 2
+	SIGCtrl_Which_prefixAnn SIGCtrl_Which = 3
 )
 
 func (w SIGCtrl_Which) String() string {
-	const s = "unsetpollReqpollRep"
+	const s = "unsetpollReqpollRepprefixAnn"
 	switch w {
 	case SIGCtrl_Which_unset:
 		return s[0:5]
@@ -27,6 +28,8 @@ func (w SIGCtrl_Which) String() string {
 		return s[5:12]
 	case SIGCtrl_Which_pollRep:
 		return s[12:19]
+	case SIGCtrl_Which_prefixAnn:
+		return s[19:28]
 
 	}
 	return "SIGCtrl_Which(" + strconv.FormatUint(uint64(w), 10) + ")"
@@ -137,6 +140,39 @@ func (s SIGCtrl) NewPollRep() (SIGPoll, error) {
 	return ss, err
 }
 
+func (s SIGCtrl) PrefixAnn() (SIGPrefixAnn, error) {
+	if s.Struct.Uint16(8) != 3 {
+		panic("Which() != prefixAnn")
+	}
+	p, err := s.Struct.Ptr(0)
+	return SIGPrefixAnn{Struct: p.Struct()}, err
+}
+
+func (s SIGCtrl) HasPrefixAnn() bool {
+	if s.Struct.Uint16(8) != 3 {
+		return false
+	}
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s SIGCtrl) SetPrefixAnn(v SIGPrefixAnn) error {
+	s.Struct.SetUint16(8, 3)
+	return s.Struct.SetPtr(0, v.Struct.ToPtr())
+}
+
+// NewPrefixAnn sets the prefixAnn field to a newly
+// allocated SIGPrefixAnn struct, preferring placement in s's segment.
+func (s SIGCtrl) NewPrefixAnn() (SIGPrefixAnn, error) {
+	s.Struct.SetUint16(8, 3)
+	ss, err := NewSIGPrefixAnn(s.Struct.Segment())
+	if err != nil {
+		return SIGPrefixAnn{}, err
+	}
+	err = s.Struct.SetPtr(0, ss.Struct.ToPtr())
+	return ss, err
+}
+
 // SIGCtrl_List is a list of SIGCtrl.
 type SIGCtrl_List struct{ capnp.List }
 
@@ -171,18 +207,22 @@ func (p SIGCtrl_Promise) PollRep() SIGPoll_Promise {
 	return SIGPoll_Promise{Pipeline: p.Pipeline.GetPipeline(0)}
 }
 
+func (p SIGCtrl_Promise) PrefixAnn() SIGPrefixAnn_Promise {
+	return SIGPrefixAnn_Promise{Pipeline: p.Pipeline.GetPipeline(0)}
+}
+
 type SIGPoll struct{ capnp.Struct }
 
 // SIGPoll_TypeID is the unique identifier for the type SIGPoll.
 const SIGPoll_TypeID = 0x9ad73a0235a46141
 
 func NewSIGPoll(s *capnp.Segment) (SIGPoll, error) {
-	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 1})
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 2})
 	return SIGPoll{st}, err
 }
 
 func NewRootSIGPoll(s *capnp.Segment) (SIGPoll, error) {
-	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 1})
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 2})
 	return SIGPoll{st}, err
 }
 
@@ -229,12 +269,37 @@ func (s SIGPoll) SetSession(v uint8) {
 	s.Struct.SetUint8(0, v)
 }
 
+func (s SIGPoll) Fec() (SIGFEC, error) {
+	p, err := s.Struct.Ptr(1)
+	return SIGFEC{Struct: p.Struct()}, err
+}
+
+func (s SIGPoll) HasFec() bool {
+	p, err := s.Struct.Ptr(1)
+	return p.IsValid() || err != nil
+}
+
+func (s SIGPoll) SetFec(v SIGFEC) error {
+	return s.Struct.SetPtr(1, v.Struct.ToPtr())
+}
+
+// NewFec sets the fec field to a newly
+// allocated SIGFEC struct, preferring placement in s's segment.
+func (s SIGPoll) NewFec() (SIGFEC, error) {
+	ss, err := NewSIGFEC(s.Struct.Segment())
+	if err != nil {
+		return SIGFEC{}, err
+	}
+	err = s.Struct.SetPtr(1, ss.Struct.ToPtr())
+	return ss, err
+}
+
 // SIGPoll_List is a list of SIGPoll.
 type SIGPoll_List struct{ capnp.List }
 
 // NewSIGPoll creates a new list of SIGPoll.
 func NewSIGPoll_List(s *capnp.Segment, sz int32) (SIGPoll_List, error) {
-	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 8, PointerCount: 1}, sz)
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 8, PointerCount: 2}, sz)
 	return SIGPoll_List{l}, err
 }
 
@@ -259,6 +324,248 @@ func (p SIGPoll_Promise) Addr() SIGAddr_Promise {
 	return SIGAddr_Promise{Pipeline: p.Pipeline.GetPipeline(0)}
 }
 
+func (p SIGPoll_Promise) Fec() SIGFEC_Promise {
+	return SIGFEC_Promise{Pipeline: p.Pipeline.GetPipeline(1)}
+}
+
+type SIGFEC struct{ capnp.Struct }
+
+// SIGFEC_TypeID is the unique identifier for the type SIGFEC.
+const SIGFEC_TypeID = 0x90a34fc042905cf4
+
+func NewSIGFEC(s *capnp.Segment) (SIGFEC, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 0})
+	return SIGFEC{st}, err
+}
+
+func NewRootSIGFEC(s *capnp.Segment) (SIGFEC, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 0})
+	return SIGFEC{st}, err
+}
+
+func ReadRootSIGFEC(msg *capnp.Message) (SIGFEC, error) {
+	root, err := msg.RootPtr()
+	return SIGFEC{root.Struct()}, err
+}
+
+func (s SIGFEC) String() string {
+	str, _ := text.Marshal(0x90a34fc042905cf4, s.Struct)
+	return str
+}
+
+func (s SIGFEC) DataFrames() uint8 {
+	return s.Struct.Uint8(0)
+}
+
+func (s SIGFEC) SetDataFrames(v uint8) {
+	s.Struct.SetUint8(0, v)
+}
+
+func (s SIGFEC) ParityFrames() uint8 {
+	return s.Struct.Uint8(1)
+}
+
+func (s SIGFEC) SetParityFrames(v uint8) {
+	s.Struct.SetUint8(1, v)
+}
+
+// SIGFEC_List is a list of SIGFEC.
+type SIGFEC_List struct{ capnp.List }
+
+// NewSIGFEC creates a new list of SIGFEC.
+func NewSIGFEC_List(s *capnp.Segment, sz int32) (SIGFEC_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 8, PointerCount: 0}, sz)
+	return SIGFEC_List{l}, err
+}
+
+func (s SIGFEC_List) At(i int) SIGFEC { return SIGFEC{s.List.Struct(i)} }
+
+func (s SIGFEC_List) Set(i int, v SIGFEC) error { return s.List.SetStruct(i, v.Struct) }
+
+func (s SIGFEC_List) String() string {
+	str, _ := text.MarshalList(0x90a34fc042905cf4, s.List)
+	return str
+}
+
+// SIGFEC_Promise is a wrapper for a SIGFEC promised by a client call.
+type SIGFEC_Promise struct{ *capnp.Pipeline }
+
+func (p SIGFEC_Promise) Struct() (SIGFEC, error) {
+	s, err := p.Pipeline.Struct()
+	return SIGFEC{s}, err
+}
+
+type SIGPrefixAnn struct{ capnp.Struct }
+
+// SIGPrefixAnn_TypeID is the unique identifier for the type SIGPrefixAnn.
+const SIGPrefixAnn_TypeID = 0xe6d125300269dd3e
+
+func NewSIGPrefixAnn(s *capnp.Segment) (SIGPrefixAnn, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 2})
+	return SIGPrefixAnn{st}, err
+}
+
+func NewRootSIGPrefixAnn(s *capnp.Segment) (SIGPrefixAnn, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 2})
+	return SIGPrefixAnn{st}, err
+}
+
+func ReadRootSIGPrefixAnn(msg *capnp.Message) (SIGPrefixAnn, error) {
+	root, err := msg.RootPtr()
+	return SIGPrefixAnn{root.Struct()}, err
+}
+
+func (s SIGPrefixAnn) String() string {
+	str, _ := text.Marshal(0xe6d125300269dd3e, s.Struct)
+	return str
+}
+
+func (s SIGPrefixAnn) Version() uint64 {
+	return s.Struct.Uint64(0)
+}
+
+func (s SIGPrefixAnn) SetVersion(v uint64) {
+	s.Struct.SetUint64(0, v)
+}
+
+func (s SIGPrefixAnn) Prefixes() (SIGPrefix_List, error) {
+	p, err := s.Struct.Ptr(0)
+	return SIGPrefix_List{List: p.List()}, err
+}
+
+func (s SIGPrefixAnn) HasPrefixes() bool {
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s SIGPrefixAnn) SetPrefixes(v SIGPrefix_List) error {
+	return s.Struct.SetPtr(0, v.List.ToPtr())
+}
+
+// NewPrefixes sets the prefixes field to a newly
+// allocated SIGPrefix_List, preferring placement in s's segment.
+func (s SIGPrefixAnn) NewPrefixes(n int32) (SIGPrefix_List, error) {
+	l, err := NewSIGPrefix_List(s.Struct.Segment(), n)
+	if err != nil {
+		return SIGPrefix_List{}, err
+	}
+	err = s.Struct.SetPtr(0, l.List.ToPtr())
+	return l, err
+}
+
+func (s SIGPrefixAnn) Chain() ([]byte, error) {
+	p, err := s.Struct.Ptr(1)
+	return []byte(p.Data()), err
+}
+
+func (s SIGPrefixAnn) HasChain() bool {
+	p, err := s.Struct.Ptr(1)
+	return p.IsValid() || err != nil
+}
+
+func (s SIGPrefixAnn) SetChain(v []byte) error {
+	return s.Struct.SetData(1, v)
+}
+
+// SIGPrefixAnn_List is a list of SIGPrefixAnn.
+type SIGPrefixAnn_List struct{ capnp.List }
+
+// NewSIGPrefixAnn creates a new list of SIGPrefixAnn.
+func NewSIGPrefixAnn_List(s *capnp.Segment, sz int32) (SIGPrefixAnn_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 8, PointerCount: 2}, sz)
+	return SIGPrefixAnn_List{l}, err
+}
+
+func (s SIGPrefixAnn_List) At(i int) SIGPrefixAnn { return SIGPrefixAnn{s.List.Struct(i)} }
+
+func (s SIGPrefixAnn_List) Set(i int, v SIGPrefixAnn) error { return s.List.SetStruct(i, v.Struct) }
+
+func (s SIGPrefixAnn_List) String() string {
+	str, _ := text.MarshalList(0xe6d125300269dd3e, s.List)
+	return str
+}
+
+// SIGPrefixAnn_Promise is a wrapper for a SIGPrefixAnn promised by a client call.
+type SIGPrefixAnn_Promise struct{ *capnp.Pipeline }
+
+func (p SIGPrefixAnn_Promise) Struct() (SIGPrefixAnn, error) {
+	s, err := p.Pipeline.Struct()
+	return SIGPrefixAnn{s}, err
+}
+
+type SIGPrefix struct{ capnp.Struct }
+
+// SIGPrefix_TypeID is the unique identifier for the type SIGPrefix.
+const SIGPrefix_TypeID = 0xf7b4413c3b5cec08
+
+func NewSIGPrefix(s *capnp.Segment) (SIGPrefix, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 1})
+	return SIGPrefix{st}, err
+}
+
+func NewRootSIGPrefix(s *capnp.Segment) (SIGPrefix, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 1})
+	return SIGPrefix{st}, err
+}
+
+func ReadRootSIGPrefix(msg *capnp.Message) (SIGPrefix, error) {
+	root, err := msg.RootPtr()
+	return SIGPrefix{root.Struct()}, err
+}
+
+func (s SIGPrefix) String() string {
+	str, _ := text.Marshal(0xf7b4413c3b5cec08, s.Struct)
+	return str
+}
+
+func (s SIGPrefix) Ip() ([]byte, error) {
+	p, err := s.Struct.Ptr(0)
+	return []byte(p.Data()), err
+}
+
+func (s SIGPrefix) HasIp() bool {
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s SIGPrefix) SetIp(v []byte) error {
+	return s.Struct.SetData(0, v)
+}
+
+func (s SIGPrefix) Length() uint8 {
+	return s.Struct.Uint8(0)
+}
+
+func (s SIGPrefix) SetLength(v uint8) {
+	s.Struct.SetUint8(0, v)
+}
+
+// SIGPrefix_List is a list of SIGPrefix.
+type SIGPrefix_List struct{ capnp.List }
+
+// NewSIGPrefix creates a new list of SIGPrefix.
+func NewSIGPrefix_List(s *capnp.Segment, sz int32) (SIGPrefix_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 8, PointerCount: 1}, sz)
+	return SIGPrefix_List{l}, err
+}
+
+func (s SIGPrefix_List) At(i int) SIGPrefix { return SIGPrefix{s.List.Struct(i)} }
+
+func (s SIGPrefix_List) Set(i int, v SIGPrefix) error { return s.List.SetStruct(i, v.Struct) }
+
+func (s SIGPrefix_List) String() string {
+	str, _ := text.MarshalList(0xf7b4413c3b5cec08, s.List)
+	return str
+}
+
+// SIGPrefix_Promise is a wrapper for a SIGPrefix promised by a client call.
+type SIGPrefix_Promise struct{ *capnp.Pipeline }
+
+func (p SIGPrefix_Promise) Struct() (SIGPrefix, error) {
+	s, err := p.Pipeline.Struct()
+	return SIGPrefix{s}, err
+}
+
 type SIGAddr struct{ capnp.Struct }
 
 // SIGAddr_TypeID is the unique identifier for the type SIGAddr.
@@ -368,33 +675,54 @@ func (p SIGAddr_Promise) Data() HostInfo_Promise {
 	return HostInfo_Promise{Pipeline: p.Pipeline.GetPipeline(1)}
 }
 
-const schema_8273379c3e06a721 = "x\xdat\x91Ok\x1aQ\x14\xc5\xefy\xcf\x19\xffP" +
-	"q\x06]\x09\xa5-X\xaa\x82\xa5J\xa5 \xb4\xd4\x96" +
-	"R\xb2\xf3%\xdb\x1028C\x1c\x98\xe883\xe2R" +
-	"\xc8G0\xbb$\xcb@6Y\xe5{d\x91E\xc8*" +
-	"\x0bWYg\x1f\xf3\xc2c\x12\x95HV\x17\xce\xb9\xdc" +
-	"\xdf;\xef\x18\xe7\xbfY]\xcb\x81H\xbc\xd3t\xd9\xb6" +
-	"N\x9b\xacusD\"\x03\xc8Og\xfa\xaf\x93\x1f\xe1" +
-	"\x01iH\x12\x99\xfbSs\xa4\xe6pL\x98\x97\x8f\xdf" +
-	"\xcf\x1e\xeeo\xcd\xcc\xea\x1aS\xf6\xe5\xd4\xbcV\xf3j" +
-	"L\x90\xa9\x9f\x8d\xb0R\xda\x99\xa9\x83l\xb9\xf9\x0fI" +
-	"\x8eD\xbe\x86i\xbe\xa9n\xe7\xeb\xb8#\xc8\xd0\xdd\xfb" +
-	"\xda\xb5\xfc>\xfc\xd6\xd6\xc6\xff\xce\xc0\x83\xd7\x01D\x8a" +
-	"'\x88\x12 2+U\"Q\xe2\x10\xdf\x18\x80\x02\x94" +
-	"V\xfbC$\xca\x1c\xe2;C\xce\xb2\xed\x00\xc6\xcb\xeb" +
-	"\x080\x08\x93\xd0\x09Cw\xd0\x87N\x0c\xfa\x1a\xa6m" +
-	"\xdb\x08\xde\xc6\x98\x0bNu\x85\xd3\x8d\x02\x0f\x86\xfc\xf8" +
-	"\xf9p\xac})^PL\xca\xd9Vd\xad\xcb\xaf\x80" +
-	"\x7f\xa3 \xcee,\x80V\x91Hls\x88\x1eC\x16" +
-	"R\xc6D\xa7A$v9\x84\xc7\x90e\x8f\xb2\x00F" +
-	"d\xba*\xaf\xcd!|\x86,\x9f\xcb\x02\xb8*G\xa9" +
-	"=\x0e\x111p\xd7F\x9a\x18\xd2\x84\x0f\xa3~\xe8D" +
-	"\xa4O\xfc\x81\xe7m:C\x18\xcb\x8e\x9f\x7f'v\xfc" +
-	"u\xe7)\x00\x00\xff\xff\x12\xacx\x8f"
+const schema_8273379c3e06a721 = "x\xdalS\xdfK\x14]\x18~\x9fsfwU\xbe" +
+	"\xfdv\x87Y(\x041CI\x85b\xb5\"0SW" +
+	"\xd32\x8a\xf6X]\x18\x1a\x0c;\xa3N\xad\xe38\xb3" +
+	"\xa6Aa\x85A\x85\x81\xd8MTW\x15t)DW" +
+	"]\xf5/t\xd5\x95\x17\xdet\xd5\x8d\x90\x84\xa0M\x9c" +
+	"\xfd\xe1\xd8\xb8Wsx\xcf3\xef\xf3\xbc\xcf\xf3\x9e\xf4" +
+	"\x1d\xf4\xb1\x8e\xc8!F$\x0eG\xa2\xfe\xaf\xb1\x95\xfe" +
+	"\xafW\xdf\xaf\x90\xa8\x03\xfc\xa6\x8f\xd1\x9e\xb7g\xbc\xc7" +
+	"\xa4\xc4\x88\xd4\xcd\xe7\xea\xb6\xfcn\xcd\x13\xfc\x8c\xfe\xe1" +
+	"4\xeb\xfa\xfe:\x04\x8c\xb0\x18\x916\x8aUM\x87<" +
+	"\x8dc\x8d\xb0\xdb\xfa\xa6acgs]\xad;\x80\xdc" +
+	"\xc2\xaa\xb6[DnC\xb6\xad9\xd7\xe9\xb55\xdf\xda" +
+	"\x90mY\x00\x1eDL\x81\xa2\xdd`\xab\xda\xb8\xfc\xef" +
+	"\xe4(k\x04\xc1\xefY\xb7X\xba\xe5\xdb\x8f\xaa*\xa6" +
+	"\xf9\x17m\x8e\xcb\xd3,_\x93\xbd\x7f\x8e\x9d\xed\xce|" +
+	"\xfe\x1d\x06\x17\xe9\x1b\x94wZ\x8b\x1cSkR\xa4\x10" +
+	"\xcf\x9a<\x91\xd3\x1d\x1bN\xd7\xb5\xe1\x0bC\x83\x03D" +
+	"Y@\xd4p\x85H\x01\x91\xdav\x93H\xb4r\x88S" +
+	"\x0c*\x90\x82,v\xdc&\x12i\x0e\xd1\xcd\xe0\x1bz" +
+	"A\x1fr\xf5i\xe2\xa6\x87(1D\x09\xbe\xa3\xbbV" +
+	"\xe1\xde\x90K\x09}z_\xf9_\xba\xecL\x1eyI" +
+	"\xf7\xdf\x1e\xdd`;\x91\xe8\xe3\x10\x97\x19*l\xc3\xfd" +
+	"D\xe2<\x87\xc82\xa8\x0c)0\"\xf5\xcaQ\"q" +
+	"\x91C\\gH\xe8\x86\xe1\"Y\xf1\x9f\x80$a\xd1" +
+	"3=\xcf\x9a\xb1+\xdc\xb1\x093\x87d\x90|\x09\x15" +
+	"R\x941\x0c\xb8!\x03\xa4\xa2f\x0e\x91\xdeg\xc0\xf1" +
+	"\xf6\xc0\x95D\xae\xe0\xe6\x91\xf4\x8f\xb4\xbc\x9c\x8f\x1c\xab" +
+	"\xffT\xee\x9c\x90\xbe\x1c,\x87\x08\x07\x0an\xc9\x82\xd4" +
+	"\x1e\xe1\x83z\"\xb1\xc0!\x96\x18\xe2\xf0\xfd\x12\xe3\xa3" +
+	"N\"q\x9fC<e\x88\xb3?~\xc9\x85'\xd2\x9a" +
+	"\x87\x1cb\x99!\xcew\xfd\x148\x91\xfaLV\x978" +
+	"\xc4\x0aC\\\xd9\xf1SP\x88\xd4\x17#Db\x99C" +
+	"\xbcb\xe0\x96\x81Zb\xa8%4\xce\xd9\x9eY\xa0\xe8" +
+	"\xa23\x93\xcf\x8f\x98\xb3H\x06K_v\xb2t\xe3\x1c" +
+	"\xbc\xf1\x1d\xd7\x9c\xb0\x1626\xc1F2X\xd3\xaa\xa3" +
+	"f]\xb3\xb7\x08\xb6C\x91\xf7W\x89\xfcR9]c" +
+	"_\xe4\xba\xb4`\x8cCL1,\xde5\xddb\xba\xe5" +
+	")\xcaJL\x8f\x88\xf0?!\xcb\x81d\xf0\x14\x08\xb2" +
+	"\xd8\x98\x9b\xd2-\x1bqb\x88W\x93\x17\x9b\xb0\x16B" +
+	"\xe1\xd7\x07\xe1\xefe\xdf\x15d\xcf-\xa7\xd2\xae7o" +
+	"\xda\x93\x85\xa9\xca\xba\xfd\x1d\x00_\x06\xfb\x15"
 
 func init() {
 	schemas.Register(schema_8273379c3e06a721,
+		0x90a34fc042905cf4,
 		0x9ad73a0235a46141,
 		0xddf1fce11d9b0028,
-		0xe15e242973323d08)
+		0xe15e242973323d08,
+		0xe6d125300269dd3e,
+		0xf7b4413c3b5cec08)
 }
@@ -21,6 +21,7 @@ import (
 
 	"github.com/scionproto/scion/go/lib/config"
 	"github.com/scionproto/scion/go/lib/env"
+	"github.com/scionproto/scion/go/lib/infra/modules/combinator"
 	"github.com/scionproto/scion/go/lib/log"
 	"github.com/scionproto/scion/go/lib/pathstorage"
 	"github.com/scionproto/scion/go/lib/sciond"
@@ -98,6 +99,16 @@ type SDConfig struct {
 	// QueryInterval specifies after how much time segments
 	// for a destination should be refetched.
 	QueryInterval util.DurWrap `toml:"query_interval,omitempty"`
+	// ProbeInterval specifies how often the paths handed out to applications
+	// are probed to measure their quality. If zero, paths are not probed.
+	ProbeInterval util.DurWrap `toml:"probe_interval,omitempty"`
+	// PathRanking lists the scorers used to rank the paths handed out to
+	// applications, in order of precedence. See combinator.ParseScorer for the
+	// names. If empty, the paths are not ranked.
+	PathRanking []string `toml:"path_ranking,omitempty"`
+	// MaxPathsPerInterfaceSet is the maximum number of paths handed out that
+	// traverse the same set of interfaces. Zero means no cap.
+	MaxPathsPerInterfaceSet int `toml:"max_paths_per_interface_set,omitempty"`
 }
 
 func (cfg *SDConfig) InitDefaults() {
@@ -113,9 +124,35 @@ func (cfg *SDConfig) Validate() error {
 	if cfg.QueryInterval.Duration == 0 {
 		return serrors.New("QueryInterval must not be zero")
 	}
+	if cfg.ProbeInterval.Duration < 0 {
+		return serrors.New("ProbeInterval must not be negative")
+	}
+	if _, err := cfg.RankOptions(); err != nil {
+		return err
+	}
+	if cfg.MaxPathsPerInterfaceSet < 0 {
+		return serrors.New("MaxPathsPerInterfaceSet must not be negative")
+	}
 	return nil
 }
 
+// RankOptions returns the options to rank the paths handed out to
+// applications. It returns nil if the paths are not ranked.
+func (cfg *SDConfig) RankOptions() (*combinator.RankOptions, error) {
+	if len(cfg.PathRanking) == 0 && cfg.MaxPathsPerInterfaceSet == 0 {
+		return nil, nil
+	}
+	opts := &combinator.RankOptions{MaxPerInterfaceSet: cfg.MaxPathsPerInterfaceSet}
+	for _, name := range cfg.PathRanking {
+		scorer, err := combinator.ParseScorer(name)
+		if err != nil {
+			return nil, serrors.WrapStr("invalid path_ranking", err)
+		}
+		opts.Scorers = append(opts.Scorers, scorer)
+	}
+	return opts, nil
+}
+
 func (cfg *SDConfig) Sample(dst io.Writer, path config.Path, ctx config.CtxMap) {
 	config.WriteString(dst, sdSample)
 }
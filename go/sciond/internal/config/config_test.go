@@ -62,4 +62,7 @@ func CheckTestConfig(t *testing.T, cfg *Config, id string) {
 func CheckTestSDConfig(t *testing.T, cfg *SDConfig, id string) {
 	assert.Equal(t, sciond.DefaultSCIONDAddress, cfg.Address)
 	assert.Equal(t, DefaultQueryInterval, cfg.QueryInterval.Duration)
+	assert.Zero(t, cfg.ProbeInterval.Duration)
+	assert.Empty(t, cfg.PathRanking)
+	assert.Zero(t, cfg.MaxPathsPerInterfaceSet)
 }
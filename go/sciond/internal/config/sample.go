@@ -23,4 +23,19 @@ address = "127.0.0.1:30255"
 
 # The time after which segments for a destination are refetched. (default 5m)
 query_interval = "5m"
+
+# The interval at which the paths handed out to applications are probed to
+# measure their latency, loss and liveness. Paths are not probed if zero.
+# (default 0s)
+probe_interval = "0s"
+
+# The scorers used to rank the paths handed out to applications, in order of
+# precedence. Valid scorers are "hops", "latency", "expiration" and
+# "diversity". If empty, the paths are not ranked. (default [])
+path_ranking = []
+
+# The maximum number of paths handed out that traverse the same set of
+# interfaces. If set without path_ranking, the paths are ranked by hop count.
+# Zero means no cap. (default 0)
+max_paths_per_interface_set = 0
 `
@@ -28,6 +28,7 @@ import (
 	"github.com/scionproto/scion/go/lib/infra"
 	"github.com/scionproto/scion/go/lib/infra/modules/combinator"
 	"github.com/scionproto/scion/go/lib/infra/modules/segfetcher"
+	"github.com/scionproto/scion/go/lib/infra/modules/seghandler"
 	"github.com/scionproto/scion/go/lib/log"
 	"github.com/scionproto/scion/go/lib/pathdb"
 	"github.com/scionproto/scion/go/lib/revcache"
@@ -58,36 +59,56 @@ type Fetcher interface {
 type fetcher struct {
 	pather segfetcher.Pather
 	config config.SDConfig
+	// rankOpts are the options to rank the paths, nil if the paths are not
+	// ranked.
+	rankOpts *combinator.RankOptions
 }
 
+// Notifier is notified when path segments are inserted or updated.
+type Notifier interface {
+	Notify()
+}
+
+// NewFetcher creates a new fetcher. The notifier is notified whenever a
+// segment reply inserts or updates path segments, it can be nil.
 func NewFetcher(requestAPI segfetcher.RequestAPI, pathDB pathdb.PathDB, inspector infra.ASInspector,
 	verificationFactory infra.VerificationFactory, revCache revcache.RevCache, cfg config.SDConfig,
-	topoProvider topology.Provider) Fetcher {
+	topoProvider topology.Provider, notifier Notifier) Fetcher {
 
 	localIA := topoProvider.Get().IA()
+	// The configuration is validated on startup, the error can be ignored.
+	rankOpts, _ := cfg.RankOptions()
+	segFetcher := segfetcher.FetcherConfig{
+		QueryInterval:       cfg.QueryInterval.Duration,
+		LocalIA:             localIA,
+		VerificationFactory: verificationFactory,
+		PathDB:              pathDB,
+		RevCache:            revCache,
+		RequestAPI:          requestAPI,
+		DstProvider:         &dstProvider{IA: localIA},
+		Splitter: &segfetcher.MultiSegmentSplitter{
+			Local:     localIA,
+			Inspector: inspector,
+		},
+		SciondMode:       true,
+		MetricsNamespace: metrics.Namespace,
+		LocalInfo:        neverLocal{},
+	}.New()
+	if notifier != nil {
+		segFetcher.ReplyHandler = notifyingHandler{
+			ReplyHandler: segFetcher.ReplyHandler,
+			notifier:     notifier,
+		}
+	}
 	return &fetcher{
 		pather: segfetcher.Pather{
 			PathDB:       pathDB,
 			RevCache:     revCache,
 			TopoProvider: topoProvider,
-			Fetcher: segfetcher.FetcherConfig{
-				QueryInterval:       cfg.QueryInterval.Duration,
-				LocalIA:             localIA,
-				VerificationFactory: verificationFactory,
-				PathDB:              pathDB,
-				RevCache:            revCache,
-				RequestAPI:          requestAPI,
-				DstProvider:         &dstProvider{IA: localIA},
-				Splitter: &segfetcher.MultiSegmentSplitter{
-					Local:     localIA,
-					Inspector: inspector,
-				},
-				SciondMode:       true,
-				MetricsNamespace: metrics.Namespace,
-				LocalInfo:        neverLocal{},
-			}.New(),
+			Fetcher:      segFetcher,
 		},
-		config: cfg,
+		config:   cfg,
+		rankOpts: rankOpts,
 	}
 }
 
@@ -122,6 +143,9 @@ func (f *fetcher) GetPaths(ctx context.Context, req *sciond.PathReq,
 	default:
 		return &sciond.PathReply{ErrorCode: sciond.ErrorInternal}, err
 	}
+	if f.rankOpts != nil {
+		cPaths = combinator.Rank(cPaths, *f.rankOpts)
+	}
 	var paths []sciond.PathReplyEntry
 	var errs serrors.List
 	for _, path := range cPaths {
@@ -179,6 +203,8 @@ func (f *fetcher) translate(path *combinator.Path) (sciond.PathReplyEntry, error
 			Mtu:        path.Mtu,
 			Interfaces: path.Interfaces,
 			ExpTime:    uint32(path.ComputeExpTime().Unix()),
+			Load:       path.Load,
+			StaticInfo: path.StaticInfo,
 		},
 		HostInfo: hostinfo.FromUDPAddr(*nextHop),
 	}
@@ -196,3 +222,24 @@ func (r *dstProvider) Dst(_ context.Context, _ segfetcher.Request) (net.Addr, er
 type neverLocal struct{}
 
 func (neverLocal) IsSegLocal(_ context.Context, _, _ addr.IA) (bool, error) { return false, nil }
+
+// notifyingHandler notifies once a segment reply is processed, if it inserted
+// or updated path segments.
+type notifyingHandler struct {
+	segfetcher.ReplyHandler
+	notifier Notifier
+}
+
+func (h notifyingHandler) Handle(ctx context.Context, recs seghandler.Segments,
+	server net.Addr, earlyTrigger <-chan struct{}) *seghandler.ProcessedResult {
+
+	result := h.ReplyHandler.Handle(ctx, recs, server, earlyTrigger)
+	go func() {
+		defer log.HandlePanic()
+		<-result.FullReplyProcessed()
+		if stats := result.Stats(); stats.SegsInserted()+stats.SegsUpdated() > 0 {
+			h.notifier.Notify()
+		}
+	}()
+	return result
+}
@@ -56,6 +56,10 @@ const (
 var (
 	// PathRequests contains metrics for path requests.
 	PathRequests = newPathRequest()
+	// PathSubscriptions contains metrics for path subscriptions.
+	PathSubscriptions = newPathSubscription()
+	// PathProbes contains metrics for the probing of paths.
+	PathProbes = newPathProbe()
 	// Revocations contains metrics for revocations.
 	Revocations = newRevocation()
 	// ASInfos contains metrics for AS info requests.
@@ -150,6 +154,57 @@ func (r PathRequest) Start() func(PathRequestLabels) {
 	}
 }
 
+// PathSubscription contains the metrics for path subscriptions.
+type PathSubscription struct {
+	active  prometheus.Gauge
+	updates *prometheus.CounterVec
+}
+
+func newPathSubscription() PathSubscription {
+	return PathSubscription{
+		active: prom.NewGauge(Namespace, subsystemPath, "subscriptions_active",
+			"The number of active path subscriptions."),
+		updates: prom.NewCounterVecWithLabels(Namespace, subsystemPath,
+			"subscription_updates_total", "The amount of path updates pushed to subscribers.",
+			resultLabel{}),
+	}
+}
+
+// Active returns the gauge for the number of active subscriptions.
+func (s PathSubscription) Active() prometheus.Gauge {
+	return s.active
+}
+
+// Updates returns the counter for pushed path updates.
+func (s PathSubscription) Updates(result string) prometheus.Counter {
+	return s.updates.WithLabelValues(result)
+}
+
+// PathProbe contains the metrics for the probing of paths.
+type PathProbe struct {
+	paths  prometheus.Gauge
+	probes *prometheus.CounterVec
+}
+
+func newPathProbe() PathProbe {
+	return PathProbe{
+		paths: prom.NewGauge(Namespace, subsystemPath, "probed_paths",
+			"The number of paths that are probed."),
+		probes: prom.NewCounterVecWithLabels(Namespace, subsystemPath, "probes_total",
+			"The amount of probes sent on paths.", resultLabel{}),
+	}
+}
+
+// Paths returns the gauge for the number of probed paths.
+func (p PathProbe) Paths() prometheus.Gauge {
+	return p.paths
+}
+
+// Probes returns the counter for probes.
+func (p PathProbe) Probes(result string) prometheus.Counter {
+	return p.probes.WithLabelValues(result)
+}
+
 // Revocation contains the metrics for revocation processing.
 type Revocation struct {
 	count   *prometheus.CounterVec
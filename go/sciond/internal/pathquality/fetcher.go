@@ -0,0 +1,62 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pathquality
+
+import (
+	"context"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/sciond"
+	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/sciond/internal/fetcher"
+)
+
+var _ fetcher.Fetcher = (*Fetcher)(nil)
+
+// Fetcher wraps a fetcher. It annotates the returned paths with their quality
+// and tracks them for probing.
+type Fetcher struct {
+	Fetcher fetcher.Fetcher
+	Prober  *Prober
+}
+
+// GetPaths returns the paths of the wrapped fetcher, annotated with their
+// quality.
+func (f *Fetcher) GetPaths(ctx context.Context, req *sciond.PathReq,
+	earlyReplyInterval time.Duration) (*sciond.PathReply, error) {
+
+	reply, err := f.Fetcher.GetPaths(ctx, req, earlyReplyInterval)
+	if reply == nil || reply.ErrorCode != sciond.ErrorOk {
+		return reply, err
+	}
+	dst := req.Dst.IA()
+	paths := make([]snet.Path, 0, len(reply.Entries))
+	for _, entry := range reply.Entries {
+		if entry.Path == nil {
+			continue
+		}
+		entry.Path.Quality = f.Prober.Store.Quality(entry.Path.Fingerprint())
+		path, convErr := sciond.PathReplyEntryToPath(entry, dst)
+		if convErr != nil {
+			log.FromCtx(ctx).Info("Unable to track path for probing", "path", entry.Path,
+				"err", convErr)
+			continue
+		}
+		paths = append(paths, path)
+	}
+	f.Prober.Track(dst, paths)
+	return reply, err
+}
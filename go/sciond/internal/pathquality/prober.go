@@ -0,0 +1,168 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pathquality
+
+import (
+	"context"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/periodic"
+	"github.com/scionproto/scion/go/lib/sciond/pathprobe"
+	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/sciond/internal/metrics"
+)
+
+// StatusProber probes paths to a destination.
+type StatusProber interface {
+	// GetStatuses probes the paths to dst and returns their statuses, keyed by
+	// pathprobe.PathKey.
+	GetStatuses(ctx context.Context, dst addr.IA,
+		paths []snet.Path) (map[string]pathprobe.Status, error)
+}
+
+// SCMPProber probes paths with pathprobe.Prober, i.e., with packets that the
+// border router of the destination AS answers with an SCMP error.
+type SCMPProber struct {
+	LocalIA addr.IA
+	LocalIP net.IP
+}
+
+// GetStatuses probes the paths to dst. ctx must have a deadline.
+func (p SCMPProber) GetStatuses(ctx context.Context, dst addr.IA,
+	paths []snet.Path) (map[string]pathprobe.Status, error) {
+
+	prober := pathprobe.Prober{
+		DstIA:   dst,
+		LocalIA: p.LocalIA,
+		LocalIP: p.LocalIP,
+	}
+	return prober.GetStatuses(ctx, paths)
+}
+
+var _ periodic.Task = (*Prober)(nil)
+
+// Prober is a periodic task that probes the tracked paths and records the
+// outcome in the store. Paths are tracked until they expire. The paths to
+// different destinations are probed concurrently.
+type Prober struct {
+	Store  *Store
+	Prober StatusProber
+
+	mu    sync.Mutex
+	paths map[addr.IA]map[snet.PathFingerprint]snet.Path
+}
+
+// Track registers the paths to dst for probing. Empty paths are ignored.
+func (p *Prober) Track(dst addr.IA, paths []snet.Path) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.paths == nil {
+		p.paths = make(map[addr.IA]map[snet.PathFingerprint]snet.Path)
+	}
+	for _, path := range pathprobe.FilterEmptyPaths(paths) {
+		fp := path.Fingerprint()
+		if fp == "" {
+			continue
+		}
+		if p.paths[dst] == nil {
+			p.paths[dst] = make(map[snet.PathFingerprint]snet.Path)
+		}
+		// Keep the path that expires last, the path over the same interfaces
+		// might have been built from different segments.
+		if old, ok := p.paths[dst][fp]; ok && old.Expiry().After(path.Expiry()) {
+			continue
+		}
+		p.paths[dst][fp] = path
+	}
+}
+
+// Name returns the task name.
+func (p *Prober) Name() string {
+	return "sd_path_prober"
+}
+
+// Run probes all tracked paths that did not expire yet.
+func (p *Prober) Run(ctx context.Context) {
+	probes := p.untrackExpired(time.Now())
+	var wg sync.WaitGroup
+	for dst, paths := range probes {
+		wg.Add(1)
+		go func(dst addr.IA, paths []snet.Path) {
+			defer log.HandlePanic()
+			defer wg.Done()
+			p.probe(ctx, dst, paths)
+		}(dst, paths)
+	}
+	wg.Wait()
+}
+
+// untrackExpired removes the paths that expired before now, together with
+// their history, and returns the remaining paths per destination.
+func (p *Prober) untrackExpired(now time.Time) map[addr.IA][]snet.Path {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	probes := make(map[addr.IA][]snet.Path, len(p.paths))
+	var count int
+	for dst, paths := range p.paths {
+		for fp, path := range paths {
+			if !path.Expiry().After(now) {
+				delete(paths, fp)
+				p.Store.Delete(fp)
+				continue
+			}
+			probes[dst] = append(probes[dst], path)
+			count++
+		}
+		if len(paths) == 0 {
+			delete(p.paths, dst)
+		}
+	}
+	metrics.PathProbes.Paths().Set(float64(count))
+	return probes
+}
+
+func (p *Prober) probe(ctx context.Context, dst addr.IA, paths []snet.Path) {
+	logger := log.FromCtx(ctx)
+	now := time.Now()
+	statuses, err := p.Prober.GetStatuses(ctx, dst, paths)
+	if err != nil {
+		logger.Info("Unable to probe paths", "dst", dst, "err", err)
+		metrics.PathProbes.Probes(metrics.ErrNetwork).Add(float64(len(paths)))
+		return
+	}
+	for _, path := range paths {
+		status, ok := statuses[pathprobe.PathKey(path)]
+		if !ok {
+			continue
+		}
+		sample := Sample{Time: now}
+		switch status.Status {
+		case pathprobe.StatusAlive:
+			sample.Alive = true
+			sample.Latency = status.Latency
+			metrics.PathProbes.Probes(metrics.OkSuccess).Inc()
+		case pathprobe.StatusTimeout:
+			metrics.PathProbes.Probes(metrics.ErrTimeout).Inc()
+		default:
+			logger.Debug("Unexpected probe status", "path", path, "status", status)
+			metrics.PathProbes.Probes(metrics.ErrNotClassified).Inc()
+		}
+		p.Store.Record(path.Fingerprint(), sample)
+	}
+}
@@ -0,0 +1,134 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pathquality_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/hostinfo"
+	"github.com/scionproto/scion/go/lib/sciond"
+	"github.com/scionproto/scion/go/lib/sciond/pathprobe"
+	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/lib/spath"
+	"github.com/scionproto/scion/go/lib/util"
+	"github.com/scionproto/scion/go/lib/xtest"
+	"github.com/scionproto/scion/go/sciond/internal/pathquality"
+)
+
+func TestProber(t *testing.T) {
+	src, dst := xtest.MustParseIA("1-ff00:0:111"), xtest.MustParseIA("1-ff00:0:110")
+	now := time.Now()
+	alive := newEntry(src, dst, 1, now.Add(time.Hour))
+	down := newEntry(src, dst, 2, now.Add(time.Hour))
+	expired := newEntry(src, dst, 3, now.Add(-time.Second))
+
+	statusProber := &testStatusProber{statuses: map[common.IFIDType]pathprobe.Status{
+		1: {Status: pathprobe.StatusAlive, Latency: 5 * time.Millisecond},
+		2: {Status: pathprobe.StatusTimeout},
+	}}
+	prober := &pathquality.Prober{Store: &pathquality.Store{}, Prober: statusProber}
+	f := &pathquality.Fetcher{
+		Fetcher: testFetcher(func() *sciond.PathReply {
+			return &sciond.PathReply{Entries: []sciond.PathReplyEntry{
+				*alive.Copy(), *down.Copy(), *expired.Copy(),
+			}}
+		}),
+		Prober: prober,
+	}
+	req := &sciond.PathReq{Dst: dst.IAInt(), Src: src.IAInt()}
+
+	reply, err := f.GetPaths(context.Background(), req, 0)
+	require.NoError(t, err)
+	for _, entry := range reply.Entries {
+		assert.Nil(t, entry.Path.Quality, "not probed yet")
+	}
+
+	ctx, cancelF := context.WithTimeout(context.Background(), time.Second)
+	defer cancelF()
+	prober.Run(ctx)
+	assert.Equal(t, dst, statusProber.dst)
+	assert.ElementsMatch(t, []common.IFIDType{1, 2}, statusProber.probed,
+		"expired paths are not probed")
+
+	reply, err = f.GetPaths(context.Background(), req, 0)
+	require.NoError(t, err)
+	require.Len(t, reply.Entries, 3)
+	lastProbe := reply.Entries[0].Path.Quality.LastProbe
+	assert.InDelta(t, util.TimeToSecs(now), lastProbe, 1)
+	assert.Equal(t, &sciond.PathQuality{Alive: true, RTT: 5000, Probes: 1,
+		LastProbe: lastProbe}, reply.Entries[0].Path.Quality)
+	assert.Equal(t, &sciond.PathQuality{Loss: 1, Probes: 1, LastProbe: lastProbe},
+		reply.Entries[1].Path.Quality)
+	assert.Nil(t, reply.Entries[2].Path.Quality)
+}
+
+// newEntry creates a path reply entry for a one-hop path over interface ifID.
+func newEntry(src, dst addr.IA, ifID common.IFIDType,
+	expiry time.Time) *sciond.PathReplyEntry {
+
+	raw := spath.NewOneHop(src.I, ifID, time.Now(), spath.DefaultHopFExpiry, sha256.New()).Raw
+	return &sciond.PathReplyEntry{
+		Path: &sciond.FwdPathMeta{
+			FwdPath: raw,
+			Interfaces: []sciond.PathInterface{
+				{RawIsdas: src.IAInt(), IfID: ifID},
+				{RawIsdas: dst.IAInt(), IfID: ifID},
+			},
+			ExpTime: util.TimeToSecs(expiry),
+		},
+		HostInfo: hostinfo.FromUDPAddr(net.UDPAddr{IP: net.IP{127, 0, 0, 1}, Port: 30041}),
+	}
+}
+
+type testFetcher func() *sciond.PathReply
+
+func (f testFetcher) GetPaths(_ context.Context, _ *sciond.PathReq,
+	_ time.Duration) (*sciond.PathReply, error) {
+
+	return f(), nil
+}
+
+// testStatusProber returns the status for the first interface of each path.
+type testStatusProber struct {
+	mu       sync.Mutex
+	statuses map[common.IFIDType]pathprobe.Status
+	dst      addr.IA
+	probed   []common.IFIDType
+}
+
+func (p *testStatusProber) GetStatuses(_ context.Context, dst addr.IA,
+	paths []snet.Path) (map[string]pathprobe.Status, error) {
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.dst = dst
+	statuses := make(map[string]pathprobe.Status, len(paths))
+	for _, path := range paths {
+		ifID := path.Interfaces()[0].ID()
+		p.probed = append(p.probed, ifID)
+		statuses[pathprobe.PathKey(path)] = p.statuses[ifID]
+	}
+	return statuses, nil
+}
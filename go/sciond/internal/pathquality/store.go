@@ -0,0 +1,115 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package pathquality measures the quality of the paths SCIOND hands out.
+//
+// The Prober periodically probes the tracked paths with SCMP and records the
+// outcome in the Store, which keeps a bounded history of probes per path
+// fingerprint. The Fetcher wraps the path fetcher of SCIOND: it tracks every
+// path it returns and annotates it with the quality derived from the
+// history.
+package pathquality
+
+import (
+	"sync"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/sciond"
+	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/lib/util"
+)
+
+// DefaultHistorySize is the default number of probes that are kept per path.
+const DefaultHistorySize = 20
+
+// Sample is the outcome of a single probe.
+type Sample struct {
+	// Time is the time the probe was sent.
+	Time time.Time
+	// Alive indicates whether the probe was answered.
+	Alive bool
+	// Latency is the round-trip time of the probe. It is only set if the probe
+	// was answered.
+	Latency time.Duration
+}
+
+// Store keeps the recent probe history per path fingerprint. The zero value is
+// ready to use. It is safe for concurrent use.
+type Store struct {
+	// HistorySize is the number of probes that are kept per path. If zero,
+	// DefaultHistorySize is used.
+	HistorySize int
+
+	mu        sync.Mutex
+	histories map[snet.PathFingerprint][]Sample
+}
+
+// Record appends the sample to the history of the path with fingerprint fp.
+// The oldest sample is dropped if the history is full.
+func (s *Store) Record(fp snet.PathFingerprint, sample Sample) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.histories == nil {
+		s.histories = make(map[snet.PathFingerprint][]Sample)
+	}
+	history := append(s.histories[fp], sample)
+	if size := s.historySize(); len(history) > size {
+		history = append(history[:0:0], history[len(history)-size:]...)
+	}
+	s.histories[fp] = history
+}
+
+// Delete removes the history of the path with fingerprint fp.
+func (s *Store) Delete(fp snet.PathFingerprint) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.histories, fp)
+}
+
+// Quality returns the quality of the path with fingerprint fp, derived from its
+// history. If the path was never probed, the result is nil.
+func (s *Store) Quality(fp snet.PathFingerprint) *sciond.PathQuality {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	history := s.histories[fp]
+	if len(history) == 0 {
+		return nil
+	}
+	last := history[len(history)-1]
+	q := &sciond.PathQuality{
+		Alive:     last.Alive,
+		Probes:    uint32(len(history)),
+		LastProbe: util.TimeToSecs(last.Time),
+	}
+	var answered int
+	var total time.Duration
+	for _, sample := range history {
+		if sample.Alive {
+			answered++
+			total += sample.Latency
+		}
+	}
+	if answered > 0 {
+		q.RTT = uint32((total / time.Duration(answered)) / time.Microsecond)
+	}
+	q.Loss = float32(len(history)-answered) / float32(len(history))
+	return q
+}
+
+func (s *Store) historySize() int {
+	if s.HistorySize == 0 {
+		return DefaultHistorySize
+	}
+	return s.HistorySize
+}
@@ -0,0 +1,58 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pathquality_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/scionproto/scion/go/lib/sciond"
+	"github.com/scionproto/scion/go/lib/util"
+	"github.com/scionproto/scion/go/sciond/internal/pathquality"
+)
+
+func TestStoreQuality(t *testing.T) {
+	now := time.Now()
+	s := &pathquality.Store{HistorySize: 4}
+	assert.Nil(t, s.Quality("a"))
+
+	s.Record("a", pathquality.Sample{Time: now, Alive: true, Latency: 10 * time.Millisecond})
+	s.Record("a", pathquality.Sample{Time: now})
+	s.Record("a", pathquality.Sample{Time: now, Alive: true, Latency: 20 * time.Millisecond})
+	assert.Equal(t, &sciond.PathQuality{
+		Alive:     true,
+		RTT:       15000,
+		Loss:      float32(1) / 3,
+		Probes:    3,
+		LastProbe: util.TimeToSecs(now),
+	}, s.Quality("a"))
+
+	// The oldest samples are dropped once the history is full.
+	later := now.Add(time.Minute)
+	s.Record("a", pathquality.Sample{Time: later})
+	s.Record("a", pathquality.Sample{Time: later})
+	assert.Equal(t, &sciond.PathQuality{
+		RTT:       20000,
+		Loss:      0.75,
+		Probes:    4,
+		LastProbe: util.TimeToSecs(later),
+	}, s.Quality("a"))
+	assert.Nil(t, s.Quality("b"))
+
+	s.Delete("a")
+	assert.Nil(t, s.Quality("a"))
+}
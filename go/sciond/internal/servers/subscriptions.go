@@ -0,0 +1,239 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servers
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/ctrl/path_mgmt"
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/revcache"
+	"github.com/scionproto/scion/go/lib/sciond"
+	"github.com/scionproto/scion/go/proto"
+	"github.com/scionproto/scion/go/sciond/internal/fetcher"
+	"github.com/scionproto/scion/go/sciond/internal/metrics"
+)
+
+const (
+	// DefaultSubscriptionRefresh is the maximum interval after which the
+	// paths of a subscription are looked up again.
+	DefaultSubscriptionRefresh = 10 * time.Second
+	// expiryMargin is added to the expiration time of the first expiring path
+	// before the paths are looked up again, such that the expired path is no
+	// longer returned.
+	expiryMargin = time.Second
+	// minLookupInterval is the minimum interval between path lookups of a
+	// subscription that is not notified.
+	minLookupInterval = time.Second
+)
+
+// PathSubscriptions keeps track of the active path subscriptions. The zero
+// value is ready to use, and all methods are safe to call on a nil object.
+type PathSubscriptions struct {
+	mu   sync.Mutex
+	subs map[chan struct{}]struct{}
+}
+
+// Notify triggers all active subscriptions to look up their paths again, e.g.,
+// because a revocation was received or new segments were fetched.
+func (s *PathSubscriptions) Notify() {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for c := range s.subs {
+		select {
+		case c <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// RevCache wraps the revocation cache, such that the subscriptions are
+// notified whenever a revocation is inserted. This covers revocations from
+// notifications as well as the ones contained in segment replies.
+func (s *PathSubscriptions) RevCache(revCache revcache.RevCache) revcache.RevCache {
+	return notifyingRevCache{RevCache: revCache, subs: s}
+}
+
+type notifyingRevCache struct {
+	revcache.RevCache
+	subs *PathSubscriptions
+}
+
+func (c notifyingRevCache) Insert(ctx context.Context,
+	rev *path_mgmt.SignedRevInfo) (bool, error) {
+
+	inserted, err := c.RevCache.Insert(ctx, rev)
+	if inserted {
+		c.subs.Notify()
+	}
+	return inserted, err
+}
+
+// add registers a subscription. It returns the channel that is written to on
+// Notify, and a function that removes the subscription again.
+func (s *PathSubscriptions) add() (<-chan struct{}, func()) {
+	c := make(chan struct{}, 1)
+	if s == nil {
+		return c, func() {}
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.subs == nil {
+		s.subs = make(map[chan struct{}]struct{})
+	}
+	s.subs[c] = struct{}{}
+	return c, func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		delete(s.subs, c)
+	}
+}
+
+// PathSubscriptionHandler handles path subscriptions. The SCIOND API spawns a
+// goroutine with method Handle for each subscription it receives. The
+// goroutine pushes the paths to the client, and looks them up again when the
+// first path expires, when Subscriptions is notified, and at least every
+// RefreshInterval. A new set of paths is only pushed if it differs from the
+// previous one, or if the liveness of a path as measured by probing changed.
+// The subscription ends when the client closes the connection.
+type PathSubscriptionHandler struct {
+	Fetcher       fetcher.Fetcher
+	Subscriptions *PathSubscriptions
+	// RefreshInterval is the maximum interval between path lookups. If zero,
+	// DefaultSubscriptionRefresh is used.
+	RefreshInterval time.Duration
+}
+
+func (h *PathSubscriptionHandler) Handle(ctx context.Context, conn net.Conn, src net.Addr,
+	pld *sciond.Pld) {
+
+	defer conn.Close()
+	logger := log.FromCtx(ctx)
+	logger.Debug("[PathSubscriptionHandler] Received subscription", "req", pld.PathSubReq)
+	metrics.PathSubscriptions.Active().Inc()
+	defer metrics.PathSubscriptions.Active().Dec()
+	// The client does not send anything after subscribing, reading from the
+	// connection only detects that the client closed it.
+	closed := make(chan struct{})
+	go func() {
+		defer log.HandlePanic()
+		defer close(closed)
+		io.Copy(ioutil.Discard, conn)
+	}()
+	notify, remove := h.Subscriptions.add()
+	defer remove()
+	refresh := h.RefreshInterval
+	if refresh == 0 {
+		refresh = DefaultSubscriptionRefresh
+	}
+	req := pld.PathSubReq.Copy()
+	var last string
+	for first := true; ; first = false {
+		reply := h.lookup(ctx, req)
+		// Only the initial lookup honors the refresh flag, subsequent lookups
+		// use the cached segments unless they expire.
+		req.Flags.Refresh = false
+		if key := replyKey(reply); first || key != last {
+			last = key
+			update := &sciond.Pld{
+				Id:         pld.Id,
+				Which:      proto.SCIONDMsg_Which_pathUpdate,
+				PathUpdate: reply,
+			}
+			conn.SetWriteDeadline(time.Now().Add(DefaultReplyTimeout))
+			if err := sciond.Send(update, conn); err != nil {
+				logger.Warn("Unable to push paths to client", "client", src, "err", err)
+				metrics.PathSubscriptions.Updates(metrics.ErrNetwork).Inc()
+				return
+			}
+			logger.Debug("Pushed paths",
+				"num_paths", len(reply.Entries),
+				"err_code", reply.ErrorCode)
+			metrics.PathSubscriptions.Updates(metrics.OkSuccess).Inc()
+		}
+		timer := time.NewTimer(nextLookup(reply, refresh))
+		select {
+		case <-closed:
+			timer.Stop()
+			logger.Debug("[PathSubscriptionHandler] Client closed subscription")
+			return
+		case <-notify:
+			timer.Stop()
+		case <-timer.C:
+		}
+	}
+}
+
+func (h *PathSubscriptionHandler) lookup(ctx context.Context,
+	req *sciond.PathReq) *sciond.PathReply {
+
+	workCtx, workCancelF := context.WithTimeout(ctx, DefaultWorkTimeout)
+	defer workCancelF()
+	reply, err := h.Fetcher.GetPaths(workCtx, req, DefaultEarlyReply)
+	if err != nil {
+		log.FromCtx(ctx).Error("Unable to get paths", "err", err)
+	}
+	if reply == nil {
+		reply = &sciond.PathReply{ErrorCode: sciond.ErrorInternal}
+	}
+	return reply
+}
+
+// nextLookup returns the time until the paths should be looked up again.
+func nextLookup(reply *sciond.PathReply, refresh time.Duration) time.Duration {
+	wait := refresh
+	for _, entry := range reply.Entries {
+		if entry.Path == nil {
+			continue
+		}
+		untilExpiry := time.Until(entry.Path.Expiry()) + expiryMargin
+		if untilExpiry < wait {
+			wait = untilExpiry
+		}
+	}
+	if wait < minLookupInterval {
+		return minLookupInterval
+	}
+	return wait
+}
+
+// replyKey returns a key that is equal for replies that contain the same
+// paths with the same liveness, regardless of the order.
+func replyKey(reply *sciond.PathReply) string {
+	keys := make([]string, 0, len(reply.Entries))
+	for _, entry := range reply.Entries {
+		if entry.Path == nil {
+			continue
+		}
+		key := fmt.Sprintf("%x/%d", entry.Path.FwdPath, entry.Path.ExpTime)
+		if q := entry.Path.Quality; q != nil && !q.Alive {
+			key += "/down"
+		}
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return fmt.Sprintf("%d:%s", reply.ErrorCode, strings.Join(keys, ","))
+}
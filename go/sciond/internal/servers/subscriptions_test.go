@@ -0,0 +1,183 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servers_test
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	capnp "zombiezen.com/go/capnproto2"
+
+	"github.com/scionproto/scion/go/lib/ctrl/path_mgmt"
+	"github.com/scionproto/scion/go/lib/revcache/mock_revcache"
+	"github.com/scionproto/scion/go/lib/sciond"
+	"github.com/scionproto/scion/go/lib/xtest"
+	"github.com/scionproto/scion/go/proto"
+	"github.com/scionproto/scion/go/sciond/internal/servers"
+)
+
+func TestPathSubscriptionHandler(t *testing.T) {
+	exp := uint32(time.Now().Add(time.Hour).Unix())
+	pathA := sciond.PathReplyEntry{Path: &sciond.FwdPathMeta{FwdPath: []byte{1}, ExpTime: exp}}
+	pathB := sciond.PathReplyEntry{Path: &sciond.FwdPathMeta{FwdPath: []byte{2}, ExpTime: exp}}
+	f := &testFetcher{reply: &sciond.PathReply{Entries: []sciond.PathReplyEntry{pathA}}}
+	subs := &servers.PathSubscriptions{}
+	h := &servers.PathSubscriptionHandler{
+		Fetcher:         f,
+		Subscriptions:   subs,
+		RefreshInterval: time.Hour,
+	}
+	client, server := net.Pipe()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		h.Handle(context.Background(), server, nil, &sciond.Pld{
+			Which: proto.SCIONDMsg_Which_pathSubReq,
+			PathSubReq: &sciond.PathReq{
+				Dst: xtest.MustParseIA("1-ff00:0:110").IAInt(),
+			},
+		})
+	}()
+
+	update := receiveUpdate(t, client)
+	assert.Equal(t, []sciond.PathReplyEntry{pathA}, update.Entries)
+
+	// Unchanged paths are not pushed.
+	subs.Notify()
+	f.waitCalls(t, 2)
+	f.set(&sciond.PathReply{Entries: []sciond.PathReplyEntry{pathB, pathA}})
+	subs.Notify()
+	update = receiveUpdate(t, client)
+	assert.Equal(t, []sciond.PathReplyEntry{pathB, pathA}, update.Entries)
+
+	// A path that goes down is pushed, even if the paths are unchanged.
+	downA := sciond.PathReplyEntry{Path: pathA.Path.Copy()}
+	downA.Path.Quality = &sciond.PathQuality{Loss: 1, Probes: 1, LastProbe: exp}
+	f.set(&sciond.PathReply{Entries: []sciond.PathReplyEntry{pathB, downA}})
+	subs.Notify()
+	update = receiveUpdate(t, client)
+	assert.Equal(t, []sciond.PathReplyEntry{pathB, downA}, update.Entries)
+
+	f.set(&sciond.PathReply{ErrorCode: sciond.ErrorNoPaths})
+	subs.Notify()
+	update = receiveUpdate(t, client)
+	assert.Equal(t, sciond.ErrorNoPaths, update.ErrorCode)
+	assert.Empty(t, update.Entries)
+
+	require.NoError(t, client.Close())
+	xtest.AssertReadReturnsBefore(t, done, time.Second)
+}
+
+func TestPathSubscriptionsRevCache(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	rev := &path_mgmt.SignedRevInfo{}
+	revCache := mock_revcache.NewMockRevCache(ctrl)
+	gomock.InOrder(
+		revCache.EXPECT().Insert(gomock.Any(), rev).Return(false, nil),
+		revCache.EXPECT().Insert(gomock.Any(), rev).Return(true, nil),
+	)
+	exp := uint32(time.Now().Add(time.Hour).Unix())
+	pathA := sciond.PathReplyEntry{Path: &sciond.FwdPathMeta{FwdPath: []byte{1}, ExpTime: exp}}
+	f := &testFetcher{reply: &sciond.PathReply{Entries: []sciond.PathReplyEntry{pathA}}}
+	subs := &servers.PathSubscriptions{}
+	h := &servers.PathSubscriptionHandler{
+		Fetcher:         f,
+		Subscriptions:   subs,
+		RefreshInterval: time.Hour,
+	}
+	client, server := net.Pipe()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		h.Handle(context.Background(), server, nil, &sciond.Pld{
+			Which:      proto.SCIONDMsg_Which_pathSubReq,
+			PathSubReq: &sciond.PathReq{},
+		})
+	}()
+	receiveUpdate(t, client)
+	wrapped := subs.RevCache(revCache)
+
+	// A known revocation does not trigger a lookup.
+	inserted, err := wrapped.Insert(context.Background(), rev)
+	require.NoError(t, err)
+	assert.False(t, inserted)
+	time.Sleep(50 * time.Millisecond)
+	f.mu.Lock()
+	assert.Equal(t, 1, f.calls)
+	f.mu.Unlock()
+
+	inserted, err = wrapped.Insert(context.Background(), rev)
+	require.NoError(t, err)
+	assert.True(t, inserted)
+	f.waitCalls(t, 2)
+
+	require.NoError(t, client.Close())
+	xtest.AssertReadReturnsBefore(t, done, time.Second)
+}
+
+func receiveUpdate(t *testing.T, conn net.Conn) *sciond.PathReply {
+	t.Helper()
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(time.Second)))
+	msg, err := proto.SafeDecode(capnp.NewDecoder(conn))
+	require.NoError(t, err)
+	root, err := msg.RootPtr()
+	require.NoError(t, err)
+	pld := &sciond.Pld{}
+	require.NoError(t, proto.SafeExtract(pld, proto.SCIONDMsg_TypeID, root.Struct()))
+	require.Equal(t, proto.SCIONDMsg_Which_pathUpdate, pld.Which)
+	return pld.PathUpdate
+}
+
+type testFetcher struct {
+	mu    sync.Mutex
+	reply *sciond.PathReply
+	calls int
+}
+
+func (f *testFetcher) GetPaths(_ context.Context, _ *sciond.PathReq,
+	_ time.Duration) (*sciond.PathReply, error) {
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls++
+	return f.reply, nil
+}
+
+func (f *testFetcher) set(reply *sciond.PathReply) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.reply = reply
+}
+
+func (f *testFetcher) waitCalls(t *testing.T, calls int) {
+	t.Helper()
+	for start := time.Now(); time.Since(start) < time.Second; {
+		f.mu.Lock()
+		n := f.calls
+		f.mu.Unlock()
+		if n >= calls {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("fetcher not called %d times", calls)
+}
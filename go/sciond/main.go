@@ -50,6 +50,7 @@ import (
 	"github.com/scionproto/scion/go/proto"
 	"github.com/scionproto/scion/go/sciond/internal/config"
 	"github.com/scionproto/scion/go/sciond/internal/fetcher"
+	"github.com/scionproto/scion/go/sciond/internal/pathquality"
 	"github.com/scionproto/scion/go/sciond/internal/servers"
 )
 
@@ -145,17 +146,40 @@ func realMain() int {
 		return 1
 	}
 
+	// The subscriptions look up their paths again whenever a revocation is
+	// inserted or new segments are fetched.
+	subscriptions := &servers.PathSubscriptions{}
+	revCache = subscriptions.RevCache(revCache)
+	pathFetcher := fetcher.NewFetcher(
+		msger,
+		pathDB,
+		trustStore,
+		verificationFactory{Provider: trustStore},
+		revCache,
+		cfg.SD,
+		itopo.Provider(),
+		subscriptions,
+	)
+	if cfg.SD.ProbeInterval.Duration > 0 {
+		prober := &pathquality.Prober{
+			Store: &pathquality.Store{},
+			Prober: pathquality.SCMPProber{
+				LocalIA: itopo.Get().IA(),
+				LocalIP: publicIP.IP,
+			},
+		}
+		pathFetcher = &pathquality.Fetcher{Fetcher: pathFetcher, Prober: prober}
+		probeRunner := periodic.Start(prober, cfg.SD.ProbeInterval.Duration,
+			cfg.SD.ProbeInterval.Duration)
+		defer probeRunner.Stop()
+	}
 	handlers := servers.HandlerMap{
 		proto.SCIONDMsg_Which_pathReq: &servers.PathRequestHandler{
-			Fetcher: fetcher.NewFetcher(
-				msger,
-				pathDB,
-				trustStore,
-				verificationFactory{Provider: trustStore},
-				revCache,
-				cfg.SD,
-				itopo.Provider(),
-			),
+			Fetcher: pathFetcher,
+		},
+		proto.SCIONDMsg_Which_pathSubReq: &servers.PathSubscriptionHandler{
+			Fetcher:       pathFetcher,
+			Subscriptions: subscriptions,
 		},
 		proto.SCIONDMsg_Which_asInfoReq: &servers.ASInfoRequestHandler{
 			ASInspector: trustStore,
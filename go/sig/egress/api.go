@@ -17,6 +17,7 @@ package egress
 
 import (
 	"io"
+	"net"
 
 	"github.com/scionproto/scion/go/lib/fatal"
 	"github.com/scionproto/scion/go/lib/log"
@@ -24,6 +25,7 @@ import (
 	"github.com/scionproto/scion/go/sig/egress/asmap"
 	"github.com/scionproto/scion/go/sig/egress/iface"
 	"github.com/scionproto/scion/go/sig/egress/reader"
+	"github.com/scionproto/scion/go/sig/internal/announce"
 )
 
 func Init(tunIO io.ReadWriteCloser) {
@@ -34,10 +36,25 @@ func Init(tunIO io.ReadWriteCloser) {
 		defer log.HandlePanic()
 		reader.NewReader(tunIO).Run()
 	}()
+	// Apply the prefixes announced by remote SIGs.
+	go func() {
+		defer log.HandlePanic()
+		asmap.PrefixAnnHdlr()
+	}()
 }
 
 func ReloadConfig(cfg *sigjson.Cfg) bool {
 	res := asmap.Map.ReloadConfig(cfg)
+	var nets []*net.IPNet
+	if cfg.Announce != nil {
+		nets = make([]*net.IPNet, 0, len(cfg.Announce.Prefixes))
+		for _, ipnet := range cfg.Announce.Prefixes {
+			nets = append(nets, ipnet.IPNet())
+		}
+	}
+	if announce.Local.Set(nets) {
+		log.Info("Announced prefixes updated", "prefixes", nets)
+	}
 	log.Info("Config reloaded")
 	return res
 }
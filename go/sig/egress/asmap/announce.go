@@ -0,0 +1,65 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package asmap
+
+import (
+	"context"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/sigdisp"
+	"github.com/scionproto/scion/go/lib/sigjson"
+	"github.com/scionproto/scion/go/sig/internal/announce"
+	"github.com/scionproto/scion/go/sig/internal/metrics"
+)
+
+const verifyTimeout = 5 * time.Second
+
+// PrefixAnnHdlr applies the prefix announcements of remote SIGs to the
+// corresponding AS entries.
+func PrefixAnnHdlr() {
+	log.Info("PrefixAnnHdlr: starting")
+	for rpld := range sigdisp.Dispatcher.PrefixAnnC {
+		result := handlePrefixAnn(rpld)
+		metrics.PrefixAnnsRecv.WithLabelValues(rpld.Addr.IA.String(), result).Inc()
+	}
+	log.Info("PrefixAnnHdlr: stopped")
+}
+
+func handlePrefixAnn(rpld *sigdisp.RegPld) string {
+	ae := Map.ASEntry(rpld.Addr.IA)
+	if ae == nil {
+		log.Debug("PrefixAnnHdlr: Announcement from unknown AS", "src", rpld.Addr)
+		return "unknown_as"
+	}
+	ctx, cancelF := context.WithTimeout(context.Background(), verifyTimeout)
+	defer cancelF()
+	ann, err := announce.Verify(ctx, rpld)
+	if err != nil {
+		ae.logger.Error("PrefixAnnHdlr: Invalid announcement", "src", rpld.Addr, "err", err)
+		return "invalid"
+	}
+	nets, err := ann.Nets()
+	if err == nil && len(nets) > sigjson.MaxAnnouncedPrefixes {
+		err = common.NewBasicError("Too many announced prefixes", nil,
+			"prefixes", len(nets), "max", sigjson.MaxAnnouncedPrefixes)
+	}
+	if err != nil {
+		ae.logger.Error("PrefixAnnHdlr: Invalid announcement", "src", rpld.Addr, "err", err)
+		return "invalid"
+	}
+	return ae.UpdateAnnounced(ann.Version, nets)
+}
@@ -16,6 +16,8 @@
 package asmap
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"net"
 	"sync"
@@ -23,15 +25,20 @@ import (
 
 	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/ctrl/sig_mgmt"
 	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/pathmgr"
 	"github.com/scionproto/scion/go/lib/ringbuf"
 	"github.com/scionproto/scion/go/lib/sigjson"
 	"github.com/scionproto/scion/go/sig/egress/dispatcher"
 	"github.com/scionproto/scion/go/sig/egress/iface"
+	"github.com/scionproto/scion/go/sig/egress/multipath"
 	"github.com/scionproto/scion/go/sig/egress/router"
 	"github.com/scionproto/scion/go/sig/egress/selector"
 	"github.com/scionproto/scion/go/sig/egress/session"
 	"github.com/scionproto/scion/go/sig/internal/base"
+	"github.com/scionproto/scion/go/sig/internal/fec"
+	"github.com/scionproto/scion/go/sig/internal/metrics"
 )
 
 const (
@@ -49,7 +56,31 @@ type ASEntry struct {
 	version           uint64 // used to track certain changes made to ASEntry
 	logger            log.Logger
 
+	// Session is the session of the default traffic class.
 	Session *session.Session
+	// classes are the sessions of the configured traffic classes, selector
+	// chooses among them.
+	classes  []*classSession
+	selector *selector.ClassSelector
+	// multipath is the striping configuration of all sessions, nil if they use
+	// a single path at a time.
+	multipath *multipath.Config
+	// fec are the FEC parameters of all sessions, nil if their frames are not
+	// protected.
+	fec *fec.Params
+	// cfgNets are the configured networks, announced the networks most
+	// recently announced by the remote SIG, and accept decides which of them
+	// are used. Nets contains the union of both.
+	cfgNets    []*net.IPNet
+	announced  []*net.IPNet
+	annVersion uint64
+	accept     *sigjson.PrefixFilter
+}
+
+// classSession is the session of a traffic class.
+type classSession struct {
+	cfg     *sigjson.TrafficClass
+	session *session.Session
 }
 
 func newASEntry(ia addr.IA) (*ASEntry, error) {
@@ -69,6 +100,7 @@ func newASEntry(ia addr.IA) (*ASEntry, error) {
 	if err != nil {
 		return nil, err
 	}
+	ae.selector = selector.NewClassSelector(ae.Session)
 	return ae, nil
 }
 
@@ -76,15 +108,192 @@ func (ae *ASEntry) ReloadConfig(cfg *sigjson.Cfg, cfgEntry *sigjson.ASEntry) boo
 	ae.Lock()
 	defer ae.Unlock()
 	// Method calls first to prevent skips due to logical short-circuit
-	s := ae.addNewNets(cfgEntry.Nets)
-	return ae.delOldNets(cfgEntry.Nets) && s
+	ae.cfgNets = make([]*net.IPNet, 0, len(cfgEntry.Nets))
+	for _, ipnet := range cfgEntry.Nets {
+		ae.cfgNets = append(ae.cfgNets, ipnet.IPNet())
+	}
+	ae.accept = cfgEntry.AcceptPrefixes
+	s := ae.updateNets()
+	s = ae.setMultipath(cfgEntry.Multipath) && s
+	s = ae.setFEC(cfgEntry.FEC) && s
+	return ae.reloadClasses(cfgEntry.TrafficClasses) && s
+}
+
+// setMultipath applies the striping configuration to the sessions. Sessions
+// created later use it as well.
+func (ae *ASEntry) setMultipath(cfg *sigjson.Multipath) bool {
+	ae.multipath = nil
+	if cfg != nil {
+		sched, err := multipath.NewScheduler(cfg.Scheduler)
+		if err != nil {
+			ae.logger.Error("Unable to configure multipath", "err", err)
+			return false
+		}
+		ae.multipath = &multipath.Config{Paths: cfg.Paths, Scheduler: sched}
+	}
+	ae.Session.SetMultipath(ae.multipath)
+	for _, c := range ae.classes {
+		c.session.SetMultipath(ae.multipath)
+	}
+	return true
+}
+
+// setFEC applies the FEC configuration to the sessions. Sessions created later
+// use it as well.
+func (ae *ASEntry) setFEC(cfg *sigjson.FEC) bool {
+	ae.fec = nil
+	if cfg != nil {
+		p := &fec.Params{DataFrames: cfg.DataFrames, ParityFrames: cfg.ParityFrames}
+		if err := p.Validate(); err != nil {
+			ae.logger.Error("Unable to configure FEC", "err", err)
+			return false
+		}
+		ae.fec = p
+	}
+	ae.Session.SetFEC(ae.fec)
+	for _, c := range ae.classes {
+		c.session.SetFEC(ae.fec)
+	}
+	return true
+}
+
+// reloadClasses replaces the sessions of the traffic classes, if the classes
+// changed. The class at index i uses session i+1, session 0 is used by the
+// default class.
+func (ae *ASEntry) reloadClasses(cfgClasses []*sigjson.TrafficClass) bool {
+	if classesEqual(ae.classes, cfgClasses) {
+		return true
+	}
+	classes := make([]*classSession, 0, len(cfgClasses))
+	for i, cfgClass := range cfgClasses {
+		sess, err := ae.newClassSession(sig_mgmt.SessionType(i+1), cfgClass)
+		if err != nil {
+			ae.logger.Error("Unable to create traffic class session", "class", cfgClass.Name,
+				"err", err)
+			for _, c := range classes {
+				ae.cleanSession(c.session)
+			}
+			return false
+		}
+		classes = append(classes, &classSession{cfg: cfgClass, session: sess})
+	}
+	selected := make([]selector.ClassSession, 0, len(classes))
+	for _, c := range classes {
+		if ae.egressRing != nil {
+			c.session.Start()
+		}
+		selected = append(selected, selector.ClassSession{
+			Class:   c.cfg.Class(),
+			Session: c.session,
+		})
+	}
+	// Switch the traffic to the new sessions before the old ones are removed.
+	ae.selector.SetClasses(selected)
+	for _, c := range ae.classes {
+		ae.cleanSession(c.session)
+	}
+	ae.classes = classes
+	ae.logger.Info("Traffic classes updated", "classes", len(classes))
+	return true
+}
+
+func (ae *ASEntry) newClassSession(id sig_mgmt.SessionType,
+	cfgClass *sigjson.TrafficClass) (*session.Session, error) {
+
+	var policy pathmgr.Policy
+	if cfgClass.Policy != nil {
+		policy = cfgClass.Policy
+	}
+	pool, err := session.NewPathPoolWithPolicy(ae.IA, policy)
+	if err != nil {
+		return nil, err
+	}
+	logger := ae.logger.New("class", cfgClass.Name)
+	sess, err := session.NewSession(ae.IA, id, logger, pool)
+	if err != nil {
+		pool.Destroy()
+		return nil, err
+	}
+	sess.SetMultipath(ae.multipath)
+	sess.SetFEC(ae.fec)
+	return sess, nil
+}
+
+// classesEqual returns whether the sessions were created for the configured
+// traffic classes.
+func classesEqual(classes []*classSession, cfgClasses []*sigjson.TrafficClass) bool {
+	if len(classes) != len(cfgClasses) {
+		return false
+	}
+	for i, c := range classes {
+		old, err := json.Marshal(c.cfg)
+		if err != nil {
+			return false
+		}
+		cur, err := json.Marshal(cfgClasses[i])
+		if err != nil || !bytes.Equal(old, cur) {
+			return false
+		}
+	}
+	return true
+}
+
+// UpdateAnnounced sets the networks announced by the remote SIG, unless the
+// announcement is older than the current one. It returns the result of the
+// update for the metrics.
+func (ae *ASEntry) UpdateAnnounced(version uint64, nets []*net.IPNet) string {
+	ae.Lock()
+	defer ae.Unlock()
+	if version < ae.annVersion {
+		return "stale"
+	}
+	// The announcement is stored even if announcements are not accepted, such
+	// that it is applied as soon as the config accepts them.
+	ae.annVersion = version
+	ae.announced = nets
+	if ae.accept == nil {
+		return "ignored"
+	}
+	ae.updateNets()
+	return "accepted"
+}
+
+// updateNets sets the networks to the configured ones and the accepted
+// announced ones.
+func (ae *ASEntry) updateNets() bool {
+	nets := make([]*net.IPNet, 0, len(ae.cfgNets))
+	seen := make(map[string]struct{})
+	add := func(ipnet *net.IPNet) {
+		if _, ok := seen[ipnet.String()]; !ok {
+			seen[ipnet.String()] = struct{}{}
+			nets = append(nets, ipnet)
+		}
+	}
+	for _, ipnet := range ae.cfgNets {
+		add(ipnet)
+	}
+	accepted := 0
+	if ae.accept != nil {
+		for _, ipnet := range ae.announced {
+			if !ae.accept.Accept(ipnet) {
+				ae.logger.Debug("Announced network rejected", "net", ipnet)
+				continue
+			}
+			add(ipnet)
+			accepted++
+		}
+	}
+	metrics.AnnouncedNets.WithLabelValues(ae.IAString).Set(float64(accepted))
+	// Method calls first to prevent skips due to logical short-circuit
+	s := ae.addNewNets(nets)
+	return ae.delOldNets(nets) && s
 }
 
 // addNewNets adds the networks in ipnets that are not currently configured.
-func (ae *ASEntry) addNewNets(ipnets []*sigjson.IPNet) bool {
+func (ae *ASEntry) addNewNets(ipnets []*net.IPNet) bool {
 	s := true
 	for _, ipnet := range ipnets {
-		err := ae.addNet(ipnet.IPNet())
+		err := ae.addNet(ipnet)
 		if err != nil {
 			ae.logger.Error("Unable to add network", "net", ipnet, "err", err)
 			s = false
@@ -94,12 +303,12 @@ func (ae *ASEntry) addNewNets(ipnets []*sigjson.IPNet) bool {
 }
 
 // delOldNets deletes currently configured networks that are not in ipnets.
-func (ae *ASEntry) delOldNets(ipnets []*sigjson.IPNet) bool {
+func (ae *ASEntry) delOldNets(ipnets []*net.IPNet) bool {
 	s := true
 Top:
 	for k, v := range ae.Nets {
 		for _, ipnet := range ipnets {
-			if k == ipnet.IPNet().String() {
+			if k == ipnet.String() {
 				continue Top
 			}
 		}
@@ -218,6 +427,7 @@ func (ae *ASEntry) Cleanup() error {
 			ae.logger.Error("Error removing networks during cleanup", "err", err)
 		}
 	}
+	metrics.AnnouncedNets.DeleteLabelValues(ae.IAString)
 	ae.egressRing.Close()
 	// Clean up sessions, and associated workers.
 	ae.cleanSessions()
@@ -225,8 +435,17 @@ func (ae *ASEntry) Cleanup() error {
 }
 
 func (ae *ASEntry) cleanSessions() {
-	if err := ae.Session.Cleanup(); err != nil {
-		ae.Session.Logger().Error("Error cleaning up session", "err", err)
+	ae.selector.SetClasses(nil)
+	for _, c := range ae.classes {
+		ae.cleanSession(c.session)
+	}
+	ae.classes = nil
+	ae.cleanSession(ae.Session)
+}
+
+func (ae *ASEntry) cleanSession(sess *session.Session) {
+	if err := sess.Cleanup(); err != nil {
+		sess.Logger().Error("Error cleaning up session", "err", err)
 	}
 }
 
@@ -234,13 +453,15 @@ func (ae *ASEntry) setupNet() {
 	ae.egressRing = ringbuf.New(iface.EgressRemotePkts, nil, fmt.Sprintf("egress_%s", ae.IAString))
 	go func() {
 		defer log.HandlePanic()
-		dispatcher.NewDispatcher(ae.IA, ae.egressRing,
-			&selector.SingleSession{Session: ae.Session}).Run()
+		dispatcher.NewDispatcher(ae.IA, ae.egressRing, ae.selector).Run()
 	}()
 	go func() {
 		defer log.HandlePanic()
 		ae.monitorHealth()
 	}()
 	ae.Session.Start()
+	for _, c := range ae.classes {
+		c.session.Start()
+	}
 	ae.logger.Info("Network setup done")
 }
@@ -26,8 +26,9 @@ import (
 	"github.com/scionproto/scion/go/lib/log"
 	"github.com/scionproto/scion/go/lib/ringbuf"
 	"github.com/scionproto/scion/go/lib/snet"
-	"github.com/scionproto/scion/go/lib/spath/spathmeta"
+	"github.com/scionproto/scion/go/sig/egress/multipath"
 	"github.com/scionproto/scion/go/sig/egress/siginfo"
+	"github.com/scionproto/scion/go/sig/internal/fec"
 )
 
 func Init() {
@@ -75,6 +76,13 @@ type Session interface {
 type RemoteInfo struct {
 	Sig      *siginfo.Sig
 	SessPath *SessPath
+	// Multipath contains the healthy paths the traffic is striped across. It
+	// is nil if the session does not stripe its traffic. The set is
+	// immutable, and is shared by the copies.
+	Multipath *multipath.Set
+	// FEC are the parameters of the forward error correction agreed on with
+	// the remote SIG. It is nil if the frames are not protected.
+	FEC *fec.Params
 }
 
 // Copy created a deep copy of the object.
@@ -83,8 +91,10 @@ func (r *RemoteInfo) Copy() *RemoteInfo {
 		return nil
 	}
 	return &RemoteInfo{
-		Sig:      r.Sig.Copy(),
-		SessPath: r.SessPath.Copy(),
+		Sig:       r.Sig.Copy(),
+		SessPath:  r.SessPath.Copy(),
+		Multipath: r.Multipath,
+		FEC:       r.FEC,
 	}
 }
 
@@ -95,8 +105,9 @@ func (r *RemoteInfo) String() string {
 // PathPool is implemented by objects that maintain sets of paths. PathPools
 // must be safe for concurrent use by multiple goroutines.
 type PathPool interface {
-	// Paths returns the paths contained in the pool.
-	Paths() spathmeta.AppPathSet
+	// Paths returns the paths contained in the pool, ordered by preference,
+	// best first.
+	Paths() []snet.Path
 	// Destroy cleans up any resources associated with the PathPool.
 	Destroy() error
 }
@@ -31,22 +31,19 @@ func NewSessPathPool() *SessPathPool {
 }
 
 // Get returns the most suitable path. Excludes a specific path, if possible.
+// Among the paths with the least failures, the most preferred one is returned.
 func (spp SessPathPool) Get(exclude snet.PathFingerprint) *SessPath {
 	var bestSessPath *SessPathStats
-	var minFail uint16 = math.MaxUint16
 	var bestNonExpiringSessPath *SessPathStats
-	var minNonExpiringFail uint16 = math.MaxUint16
 	for k, v := range spp {
 		if k == exclude {
 			continue
 		}
-		if v.failCount < minFail {
+		if v.better(bestSessPath) {
 			bestSessPath = v
-			minFail = v.failCount
 		}
-		if v.failCount < minNonExpiringFail && !v.SessPath.IsCloseToExpiry() {
+		if v.better(bestNonExpiringSessPath) && !v.SessPath.IsCloseToExpiry() {
 			bestNonExpiringSessPath = v
-			minNonExpiringFail = v.failCount
 		}
 	}
 	// Return a non-expiring path with least failures.
@@ -78,22 +75,28 @@ func (spp SessPathPool) PathCount() int {
 	return len(spp)
 }
 
-func (spp SessPathPool) Update(aps spathmeta.AppPathSet) {
+// Update replaces the paths in the pool with paths, which are ordered by
+// preference, best first.
+func (spp SessPathPool) Update(paths []snet.Path) {
+	aps := spathmeta.NewAppPathSet(paths)
 	// Remove any old entries that aren't present in the update.
 	for key := range spp {
 		if _, ok := aps[key]; !ok {
 			delete(spp, key)
 		}
 	}
-	for key, path := range aps {
+	for rank, path := range paths {
+		key := path.Fingerprint()
 		e, ok := spp[key]
 		if !ok {
 			// This is a new path, add an entry.
-			spp[key] = newSessPathStats(key, path)
+			e = newSessPathStats(key, path)
+			spp[key] = e
 		} else {
 			// This path already exists, update it.
 			e.SessPath.path = path
 		}
+		e.rank = rank
 	}
 }
 
@@ -127,6 +130,20 @@ type SessPathStats struct {
 	SessPath  *SessPath
 	lastFail  time.Time
 	failCount uint16
+	// rank is the position of the path in the preference order of the pool.
+	rank int
+}
+
+// better returns whether s has fewer failures than other, or as many failures
+// and a higher preference. Any path is better than a nil other.
+func (s *SessPathStats) better(other *SessPathStats) bool {
+	if other == nil {
+		return true
+	}
+	if s.failCount != other.failCount {
+		return s.failCount < other.failCount
+	}
+	return s.rank < other.rank
 }
 
 func newSessPathStats(key snet.PathFingerprint, path snet.Path) *SessPathStats {
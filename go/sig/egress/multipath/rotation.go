@@ -0,0 +1,316 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package multipath implements the striping of a session's egress traffic
+// across several paths.
+//
+// The session monitor keeps a Rotation of paths, which it health-checks with
+// its own probes. Healthy paths are published in a Set, together with the
+// weights assigned by the Scheduler. The egress worker uses a Striper to
+// assign each inner IP flow to one path of the set, such that the packets of
+// a flow are not reordered.
+//
+// Each path uses its own epoch in the SIG frame header. As the remote SIG
+// reassembles the frames of each epoch separately, striping requires no
+// support from the remote SIG.
+package multipath
+
+import (
+	"sort"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/lib/spath/spathmeta"
+)
+
+const (
+	// MaxPaths is the maximum number of paths in a rotation.
+	MaxPaths = 16
+	// probeTimeout is the time after which a probe without reply is lost.
+	probeTimeout = 1 * time.Second
+	// healthTimeout is the time without replies after which a path is taken
+	// out of the rotation.
+	healthTimeout = 1 * time.Second
+	// evictTimeout is the time without replies after which a path is
+	// replaced by another path.
+	evictTimeout = 5 * time.Second
+	// evictHold is the time before an evicted path is considered again.
+	evictHold = 30 * time.Second
+	// ewmaAlpha is the weight of a new sample in the smoothed RTT and loss.
+	ewmaAlpha = 0.125
+)
+
+// Config configures the striping of a session's traffic.
+type Config struct {
+	// Paths is the maximum number of paths the traffic is striped across.
+	Paths int
+	// Scheduler weighs the paths.
+	Scheduler Scheduler
+}
+
+// PathState is the health of a path in the rotation.
+type PathState struct {
+	Path snet.Path
+	// RTT is the smoothed round trip time of the probes.
+	RTT time.Duration
+	// Loss is the smoothed ratio of probes without reply.
+	Loss float64
+	// Healthy is set if the path received a probe reply recently.
+	Healthy bool
+	// Probes and Replies are the number of probes sent on the path, and the
+	// replies received.
+	Probes  uint64
+	Replies uint64
+
+	added     time.Time
+	lastReply time.Time
+}
+
+func (ps *PathState) lastAlive() time.Time {
+	if ps.lastReply.IsZero() {
+		return ps.added
+	}
+	return ps.lastReply
+}
+
+type probe struct {
+	fp   snet.PathFingerprint
+	sent time.Time
+}
+
+// Rotation keeps the paths the traffic is striped across, and tracks their
+// health from the probes sent on them. A rotation is not safe for concurrent
+// use.
+type Rotation struct {
+	cfg     Config
+	paths   map[snet.PathFingerprint]*PathState
+	probes  map[uint64]probe
+	evicted map[snet.PathFingerprint]time.Time
+}
+
+// NewRotation creates an empty rotation.
+func NewRotation(cfg Config) *Rotation {
+	return &Rotation{
+		cfg:     cfg,
+		paths:   make(map[snet.PathFingerprint]*PathState),
+		probes:  make(map[uint64]probe),
+		evicted: make(map[snet.PathFingerprint]time.Time),
+	}
+}
+
+// SetConfig changes the configuration. It takes effect on the next update.
+func (r *Rotation) SetConfig(cfg Config) {
+	r.cfg = cfg
+}
+
+// Config returns the configuration.
+func (r *Rotation) Config() Config {
+	return r.cfg
+}
+
+// Update updates the rotation with the paths available in the pool, which are
+// ordered by preference, best first. It expires outstanding probes, takes
+// paths without replies out of the rotation and replaces the paths that failed
+// for too long. It returns the paths that were removed from the rotation.
+func (r *Rotation) Update(paths []snet.Path, now time.Time) []snet.PathFingerprint {
+	aps := spathmeta.NewAppPathSet(paths)
+	for id, p := range r.probes {
+		if now.Sub(p.sent) < probeTimeout {
+			continue
+		}
+		delete(r.probes, id)
+		if ps, ok := r.paths[p.fp]; ok {
+			ps.Loss += ewmaAlpha * (1 - ps.Loss)
+		}
+	}
+	for fp, t := range r.evicted {
+		if now.Sub(t) > evictHold {
+			delete(r.evicted, fp)
+		}
+	}
+	var removed []snet.PathFingerprint
+	for fp, ps := range r.paths {
+		path, ok := aps[fp]
+		switch {
+		case !ok:
+			// The path was retired from the pool.
+		case now.Sub(ps.lastAlive()) > evictTimeout:
+			r.evicted[fp] = now
+		default:
+			ps.Path = path
+			ps.Healthy = !ps.lastReply.IsZero() && now.Sub(ps.lastReply) <= healthTimeout
+			continue
+		}
+		delete(r.paths, fp)
+		removed = append(removed, fp)
+	}
+	for len(r.paths) > r.maxPaths() {
+		fp := r.worst()
+		delete(r.paths, fp)
+		removed = append(removed, fp)
+	}
+	for _, path := range r.candidates(paths) {
+		if len(r.paths) >= r.maxPaths() {
+			break
+		}
+		r.paths[path.Fingerprint()] = &PathState{Path: path, added: now}
+	}
+	return removed
+}
+
+func (r *Rotation) maxPaths() int {
+	if r.cfg.Paths > MaxPaths {
+		return MaxPaths
+	}
+	return r.cfg.Paths
+}
+
+// worst returns the path in the rotation that was alive least recently.
+func (r *Rotation) worst() snet.PathFingerprint {
+	var worst snet.PathFingerprint
+	var worstAlive time.Time
+	for fp, ps := range r.paths {
+		if worst == "" || ps.lastAlive().Before(worstAlive) {
+			worst, worstAlive = fp, ps.lastAlive()
+		}
+	}
+	return worst
+}
+
+// candidates returns the paths of the pool that can be added to the rotation,
+// in the order of the pool.
+func (r *Rotation) candidates(paths []snet.Path) []snet.Path {
+	var candidates []snet.Path
+	for _, path := range paths {
+		fp := path.Fingerprint()
+		if _, ok := r.paths[fp]; ok {
+			continue
+		}
+		if _, ok := r.evicted[fp]; ok {
+			continue
+		}
+		candidates = append(candidates, path)
+	}
+	return candidates
+}
+
+// Paths returns the paths in the rotation, healthy or not. All of them are
+// probed.
+func (r *Rotation) Paths() map[snet.PathFingerprint]*PathState {
+	return r.paths
+}
+
+// Probe records that the probe with the id was sent on the path.
+func (r *Rotation) Probe(id uint64, fp snet.PathFingerprint, now time.Time) {
+	ps, ok := r.paths[fp]
+	if !ok {
+		return
+	}
+	ps.Probes++
+	r.probes[id] = probe{fp: fp, sent: now}
+}
+
+// Reply records the reply to the probe with the id. It returns false if the
+// probe was not sent by the rotation.
+func (r *Rotation) Reply(id uint64, now time.Time) bool {
+	p, ok := r.probes[id]
+	if !ok {
+		return false
+	}
+	delete(r.probes, id)
+	ps, ok := r.paths[p.fp]
+	if !ok {
+		return true
+	}
+	rtt := now.Sub(p.sent)
+	if ps.Replies == 0 {
+		ps.RTT = rtt
+	} else {
+		ps.RTT += time.Duration(ewmaAlpha * float64(rtt-ps.RTT))
+	}
+	ps.Loss -= ewmaAlpha * ps.Loss
+	ps.Replies++
+	ps.lastReply = now
+	ps.Healthy = true
+	return true
+}
+
+// Set returns the healthy paths, weighted by the scheduler.
+func (r *Rotation) Set() *Set {
+	set := &Set{}
+	for fp, ps := range r.paths {
+		if !ps.Healthy {
+			continue
+		}
+		set.Paths = append(set.Paths, &SetPath{
+			Fingerprint: fp,
+			Path:        ps.Path,
+			Weight:      r.scheduler().Weight(ps),
+		})
+	}
+	sort.Slice(set.Paths, func(i, j int) bool {
+		return set.Paths[i].Fingerprint < set.Paths[j].Fingerprint
+	})
+	return set
+}
+
+func (r *Rotation) scheduler() Scheduler {
+	if r.cfg.Scheduler == nil {
+		return Weighted{}
+	}
+	return r.cfg.Scheduler
+}
+
+// Set is an immutable snapshot of the healthy paths of a rotation.
+type Set struct {
+	Paths []*SetPath
+}
+
+// Get returns the path with the fingerprint, or nil if it is not in the set.
+func (s *Set) Get(fp snet.PathFingerprint) *SetPath {
+	if s == nil {
+		return nil
+	}
+	for _, p := range s.Paths {
+		if p.Fingerprint == fp {
+			return p
+		}
+	}
+	return nil
+}
+
+// Len returns the number of paths in the set.
+func (s *Set) Len() int {
+	if s == nil {
+		return 0
+	}
+	return len(s.Paths)
+}
+
+// SetPath is a path of a set, and the share of the flows it is assigned.
+type SetPath struct {
+	Fingerprint snet.PathFingerprint
+	Path        snet.Path
+	Weight      float64
+}
+
+// Label returns a short identifier of the path, for metrics and logging.
+func Label(fp snet.PathFingerprint) string {
+	raw := common.RawBytes(fp)
+	if len(raw) > 8 {
+		raw = raw[:8]
+	}
+	return raw.String()
+}
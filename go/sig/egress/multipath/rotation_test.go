@@ -0,0 +1,167 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package multipath
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/ctrl/seg"
+	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/lib/spath"
+)
+
+type testPath struct {
+	fp   snet.PathFingerprint
+	hops int
+}
+
+func (p *testPath) Fingerprint() snet.PathFingerprint { return p.fp }
+func (p *testPath) OverlayNextHop() *net.UDPAddr      { return nil }
+func (p *testPath) Path() *spath.Path                 { return nil }
+func (p *testPath) Interfaces() []snet.PathInterface {
+	return make([]snet.PathInterface, p.hops)
+}
+func (p *testPath) Destination() addr.IA                  { return addr.IA{} }
+func (p *testPath) MTU() uint16                           { return 1472 }
+func (p *testPath) Expiry() time.Time                     { return time.Time{} }
+func (p *testPath) Load() []seg.InterfaceLoad             { return nil }
+func (p *testPath) StaticInfo() []seg.InterfaceStaticInfo { return nil }
+func (p *testPath) Copy() snet.Path {
+	return &testPath{fp: p.fp, hops: p.hops}
+}
+
+func pathList(paths ...*testPath) []snet.Path {
+	res := make([]snet.Path, 0, len(paths))
+	for _, p := range paths {
+		res = append(res, p)
+	}
+	return res
+}
+
+// probeAll sends a probe on every path in the rotation, and replies to the
+// probes of the paths in replied after rtt.
+func probeAll(r *Rotation, id *uint64, now time.Time, rtt time.Duration,
+	replied ...snet.PathFingerprint) {
+
+	ids := make(map[snet.PathFingerprint]uint64)
+	for fp := range r.Paths() {
+		*id++
+		r.Probe(*id, fp, now)
+		ids[fp] = *id
+	}
+	for _, fp := range replied {
+		if pid, ok := ids[fp]; ok {
+			r.Reply(pid, now.Add(rtt))
+		}
+	}
+}
+
+func TestRotationUpdate(t *testing.T) {
+	short := &testPath{fp: "short", hops: 2}
+	mid := &testPath{fp: "mid", hops: 4}
+	long := &testPath{fp: "long", hops: 6}
+	aps := pathList(short, mid, long)
+	now := time.Now()
+
+	t.Run("preferred paths are added first", func(t *testing.T) {
+		r := NewRotation(Config{Paths: 2})
+		assert.Empty(t, r.Update(aps, now))
+		assert.Len(t, r.Paths(), 2)
+		assert.Contains(t, r.Paths(), short.fp)
+		assert.Contains(t, r.Paths(), mid.fp)
+		assert.Equal(t, 0, r.Set().Len(), "paths are unhealthy until probed")
+	})
+	t.Run("pool order is followed over path length", func(t *testing.T) {
+		r := NewRotation(Config{Paths: 2})
+		assert.Empty(t, r.Update(pathList(long, short, mid), now))
+		assert.Len(t, r.Paths(), 2)
+		assert.Contains(t, r.Paths(), long.fp)
+		assert.Contains(t, r.Paths(), short.fp)
+	})
+	t.Run("paths with replies are healthy", func(t *testing.T) {
+		r := NewRotation(Config{Paths: 2})
+		r.Update(aps, now)
+		var id uint64
+		probeAll(r, &id, now, 10*time.Millisecond, short.fp)
+		r.Update(aps, now.Add(500*time.Millisecond))
+		set := r.Set()
+		require.Equal(t, 1, set.Len())
+		assert.Equal(t, short.fp, set.Paths[0].Fingerprint)
+		assert.Equal(t, 10*time.Millisecond, r.Paths()[short.fp].RTT)
+	})
+	t.Run("path without replies is taken out and replaced", func(t *testing.T) {
+		r := NewRotation(Config{Paths: 2})
+		r.Update(aps, now)
+		var id uint64
+		ts := now
+		for i := 0; i < 20; i++ {
+			probeAll(r, &id, ts, 10*time.Millisecond, short.fp)
+			ts = ts.Add(500 * time.Millisecond)
+			r.Update(aps, ts)
+			if i == 4 {
+				assert.False(t, r.Paths()[mid.fp].Healthy)
+				assert.Greater(t, r.Paths()[mid.fp].Loss, 0.0)
+			}
+		}
+		assert.NotContains(t, r.Paths(), mid.fp)
+		assert.Contains(t, r.Paths(), long.fp)
+		assert.Equal(t, 1, r.Set().Len())
+	})
+	t.Run("retired paths are removed", func(t *testing.T) {
+		r := NewRotation(Config{Paths: 2})
+		r.Update(aps, now)
+		removed := r.Update(pathList(mid, long), now)
+		assert.Equal(t, []snet.PathFingerprint{short.fp}, removed)
+		assert.Contains(t, r.Paths(), long.fp)
+	})
+	t.Run("shrinking the rotation", func(t *testing.T) {
+		r := NewRotation(Config{Paths: 3})
+		r.Update(aps, now)
+		r.SetConfig(Config{Paths: 1})
+		assert.Len(t, r.Update(aps, now), 2)
+		assert.Len(t, r.Paths(), 1)
+	})
+	t.Run("unknown replies", func(t *testing.T) {
+		r := NewRotation(Config{Paths: 2})
+		r.Update(aps, now)
+		assert.False(t, r.Reply(42, now))
+	})
+}
+
+func TestSchedulers(t *testing.T) {
+	fast := &PathState{RTT: 10 * time.Millisecond}
+	slow := &PathState{RTT: 40 * time.Millisecond}
+	lossy := &PathState{RTT: 10 * time.Millisecond, Loss: 0.5}
+
+	w := Weighted{}
+	assert.Equal(t, w.Weight(fast), w.Weight(slow))
+	assert.InDelta(t, w.Weight(fast)/2, w.Weight(lossy), 1e-9)
+
+	l := LatencyAware{}
+	assert.InDelta(t, 4*l.Weight(slow), l.Weight(fast), 1e-9)
+	assert.InDelta(t, l.Weight(fast)/2, l.Weight(lossy), 1e-9)
+
+	_, err := NewScheduler("fastest")
+	assert.Error(t, err)
+	s, err := NewScheduler(SchedLatency)
+	require.NoError(t, err)
+	assert.Equal(t, LatencyAware{}, s)
+}
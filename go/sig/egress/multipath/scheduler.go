@@ -0,0 +1,80 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package multipath
+
+import (
+	"time"
+
+	"github.com/scionproto/scion/go/lib/serrors"
+)
+
+const (
+	// SchedWeighted is the name of the Weighted scheduler.
+	SchedWeighted = "weighted"
+	// SchedLatency is the name of the LatencyAware scheduler.
+	SchedLatency = "latency"
+
+	// minWeight is the weight of a path that lost all probes, such that
+	// flows are only assigned to it if there is no other path.
+	minWeight = 0.01
+	// minRTT is the RTT assumed for paths with a smaller or unknown RTT.
+	minRTT = time.Millisecond
+)
+
+// Scheduler weighs the paths of a rotation. New flows are assigned to the
+// paths in proportion to their weights.
+type Scheduler interface {
+	Weight(ps *PathState) float64
+}
+
+// NewScheduler returns the scheduler with the name. The empty name is the
+// Weighted scheduler.
+func NewScheduler(name string) (Scheduler, error) {
+	switch name {
+	case "", SchedWeighted:
+		return Weighted{}, nil
+	case SchedLatency:
+		return LatencyAware{}, nil
+	}
+	return nil, serrors.New("unknown scheduler", "name", name)
+}
+
+// Weighted weighs the paths by the ratio of probes they deliver. Lossless
+// paths carry the same share of the flows.
+type Weighted struct{}
+
+func (Weighted) Weight(ps *PathState) float64 {
+	return deliveryRatio(ps)
+}
+
+// LatencyAware weighs the paths by the ratio of probes they deliver, divided
+// by their RTT. A path with half the RTT of another carries twice the share
+// of the flows.
+type LatencyAware struct{}
+
+func (LatencyAware) Weight(ps *PathState) float64 {
+	rtt := ps.RTT
+	if rtt < minRTT {
+		rtt = minRTT
+	}
+	return deliveryRatio(ps) * float64(minRTT) / float64(rtt)
+}
+
+func deliveryRatio(ps *PathState) float64 {
+	if w := 1 - ps.Loss; w > minWeight {
+		return w
+	}
+	return minWeight
+}
@@ -0,0 +1,167 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package multipath
+
+import (
+	"hash/fnv"
+	"math"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/snet"
+)
+
+const (
+	// flowTimeout is the idle time after which a flow is forgotten, and may
+	// be assigned to another path.
+	flowTimeout = 30 * time.Second
+	// maxFlows is the maximum number of flows pinned to their paths. Further
+	// flows are assigned by their hash only, which keeps them on their path
+	// as long as the weights do not change.
+	maxFlows = 1 << 16
+
+	protoTCP  = 6
+	protoUDP  = 17
+	protoSCTP = 132
+)
+
+type flow struct {
+	fp       snet.PathFingerprint
+	lastSeen time.Time
+}
+
+// Striper assigns flows to the paths of a set. A flow stays on its path as
+// long as the path is in the set. A striper is not safe for concurrent use.
+type Striper struct {
+	flows      map[uint64]*flow
+	lastExpiry time.Time
+}
+
+// NewStriper creates a striper without flows.
+func NewStriper() *Striper {
+	return &Striper{flows: make(map[uint64]*flow)}
+}
+
+// Choose returns the path of the flow with the key. New flows, and flows
+// whose path left the set, are assigned to a path by weighted rendezvous
+// hashing. It returns nil if the set is empty.
+func (s *Striper) Choose(key uint64, set *Set, now time.Time) *SetPath {
+	if set.Len() == 0 {
+		return nil
+	}
+	if now.Sub(s.lastExpiry) > flowTimeout {
+		s.expire(now)
+	}
+	if f, ok := s.flows[key]; ok {
+		if p := set.Get(f.fp); p != nil {
+			f.lastSeen = now
+			return p
+		}
+	}
+	p := rendezvous(key, set)
+	if f, ok := s.flows[key]; ok {
+		f.fp, f.lastSeen = p.Fingerprint, now
+	} else if len(s.flows) < maxFlows {
+		s.flows[key] = &flow{fp: p.Fingerprint, lastSeen: now}
+	}
+	return p
+}
+
+// Flows returns the number of pinned flows.
+func (s *Striper) Flows() int {
+	return len(s.flows)
+}
+
+func (s *Striper) expire(now time.Time) {
+	for key, f := range s.flows {
+		if now.Sub(f.lastSeen) > flowTimeout {
+			delete(s.flows, key)
+		}
+	}
+	s.lastExpiry = now
+}
+
+// rendezvous returns the path with the highest weighted score for the key.
+// Each path is chosen for a share of the keys proportional to its weight.
+func rendezvous(key uint64, set *Set) *SetPath {
+	var best *SetPath
+	bestScore := math.Inf(-1)
+	for _, p := range set.Paths {
+		h := fnv.New64a()
+		h.Write([]byte(p.Fingerprint))
+		// Map the hash to (0, 1).
+		u := (float64(mix(h.Sum64()^key)>>11) + 0.5) / (1 << 53)
+		score := p.Weight / -math.Log(u)
+		if best == nil || score > bestScore {
+			best, bestScore = p, score
+		}
+	}
+	return best
+}
+
+// mix is the finalizer of MurmurHash3, such that similar inputs result in
+// independent outputs.
+func mix(x uint64) uint64 {
+	x ^= x >> 33
+	x *= 0xff51afd7ed558ccd
+	x ^= x >> 33
+	x *= 0xc4ceb9fe1a85ec53
+	x ^= x >> 33
+	return x
+}
+
+// FlowKey returns the hash of the flow of an IP packet. The flow is
+// identified by the addresses, the protocol and, for unfragmented TCP, UDP
+// and SCTP packets, the ports. Packets that are not IP have the key 0.
+func FlowKey(pkt common.RawBytes) uint64 {
+	h := fnv.New64a()
+	if len(pkt) < 1 {
+		return 0
+	}
+	switch pkt[0] >> 4 {
+	case 4:
+		if len(pkt) < 20 {
+			return 0
+		}
+		ihl := int(pkt[0]&0x0f) * 4
+		proto := pkt[9]
+		h.Write(pkt[12:20])
+		h.Write([]byte{proto})
+		// Fragments other than the first carry no ports, hence ports are only
+		// used if the packet is not fragmented at all.
+		unfragmented := common.Order.Uint16(pkt[6:8])&0x3fff == 0
+		if unfragmented && hasPorts(proto) && len(pkt) >= ihl+4 {
+			h.Write(pkt[ihl : ihl+4])
+		}
+	case 6:
+		if len(pkt) < 40 {
+			return 0
+		}
+		next := pkt[6]
+		h.Write(pkt[8:40])
+		h.Write([]byte{next})
+		// Packets with extension headers are identified by their addresses.
+		if hasPorts(next) && len(pkt) >= 44 {
+			h.Write(pkt[40:44])
+		}
+	default:
+		return 0
+	}
+	return h.Sum64()
+}
+
+func hasPorts(proto uint8) bool {
+	return proto == protoTCP || proto == protoUDP || proto == protoSCTP
+}
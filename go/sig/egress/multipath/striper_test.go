@@ -0,0 +1,116 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package multipath
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/snet"
+)
+
+func udpPkt(t *testing.T, src, dst string, sport, dport int) common.RawBytes {
+	ip := &layers.IPv4{
+		Version:  4,
+		TTL:      64,
+		Protocol: layers.IPProtocolUDP,
+		SrcIP:    net.ParseIP(src).To4(),
+		DstIP:    net.ParseIP(dst).To4(),
+	}
+	udp := &layers.UDP{SrcPort: layers.UDPPort(sport), DstPort: layers.UDPPort(dport)}
+	require.NoError(t, udp.SetNetworkLayerForChecksum(ip))
+	buf := gopacket.NewSerializeBuffer()
+	opts := gopacket.SerializeOptions{FixLengths: true, ComputeChecksums: true}
+	require.NoError(t, gopacket.SerializeLayers(buf, opts, ip, udp, gopacket.Payload("x")))
+	return buf.Bytes()
+}
+
+func TestFlowKey(t *testing.T) {
+	a := udpPkt(t, "192.0.2.1", "198.51.100.1", 4000, 53)
+	b := udpPkt(t, "192.0.2.1", "198.51.100.1", 4000, 53)
+	c := udpPkt(t, "192.0.2.1", "198.51.100.1", 4001, 53)
+	assert.Equal(t, FlowKey(a), FlowKey(b))
+	assert.NotEqual(t, FlowKey(a), FlowKey(c))
+	assert.Equal(t, uint64(0), FlowKey(common.RawBytes{0x45, 0}))
+	assert.Equal(t, uint64(0), FlowKey(nil))
+}
+
+func testSet(weights map[snet.PathFingerprint]float64) *Set {
+	set := &Set{}
+	for fp, w := range weights {
+		set.Paths = append(set.Paths, &SetPath{Fingerprint: fp, Weight: w})
+	}
+	return set
+}
+
+func TestStriper(t *testing.T) {
+	now := time.Now()
+
+	t.Run("empty set", func(t *testing.T) {
+		assert.Nil(t, NewStriper().Choose(1, &Set{}, now))
+		assert.Nil(t, NewStriper().Choose(1, nil, now))
+	})
+	t.Run("shares follow the weights", func(t *testing.T) {
+		s := NewStriper()
+		set := testSet(map[snet.PathFingerprint]float64{"a": 3, "b": 1})
+		counts := make(map[snet.PathFingerprint]int)
+		for key := uint64(1); key <= 4000; key++ {
+			counts[s.Choose(key*0x9e3779b97f4a7c15, set, now).Fingerprint]++
+		}
+		assert.InDelta(t, 3000, counts["a"], 200)
+		assert.InDelta(t, 1000, counts["b"], 200)
+	})
+	t.Run("flows stay on their path", func(t *testing.T) {
+		s := NewStriper()
+		set := testSet(map[snet.PathFingerprint]float64{"a": 1, "b": 1})
+		first := s.Choose(7, set, now).Fingerprint
+		// Even if the weights change, the flow keeps its path.
+		reweighted := testSet(map[snet.PathFingerprint]float64{"a": 1, "b": 1})
+		for _, p := range reweighted.Paths {
+			if p.Fingerprint == first {
+				p.Weight = 0.01
+			}
+		}
+		assert.Equal(t, first, s.Choose(7, reweighted, now).Fingerprint)
+	})
+	t.Run("flows move when their path leaves the set", func(t *testing.T) {
+		s := NewStriper()
+		set := testSet(map[snet.PathFingerprint]float64{"a": 1, "b": 1})
+		first := s.Choose(7, set, now).Fingerprint
+		other := snet.PathFingerprint("a")
+		if first == other {
+			other = "b"
+		}
+		remaining := testSet(map[snet.PathFingerprint]float64{other: 1})
+		assert.Equal(t, other, s.Choose(7, remaining, now).Fingerprint)
+		// The flow stays on the new path when the old one comes back.
+		assert.Equal(t, other, s.Choose(7, set, now).Fingerprint)
+	})
+	t.Run("idle flows expire", func(t *testing.T) {
+		s := NewStriper()
+		set := testSet(map[snet.PathFingerprint]float64{"a": 1})
+		s.Choose(7, set, now)
+		assert.Equal(t, 1, s.Flows())
+		s.Choose(8, set, now.Add(2*flowTimeout))
+		assert.Equal(t, 1, s.Flows())
+	})
+}
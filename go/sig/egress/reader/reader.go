@@ -43,6 +43,8 @@ type Reader struct {
 	tunIO io.ReadWriteCloser
 }
 
+// NewReader creates a reader of the IP packets of tunIO. Each Read of tunIO
+// must return a single packet, as for a TUN device or a memtun device.
 func NewReader(tunIO io.ReadWriteCloser) *Reader {
 	return &Reader{log: log.New(), tunIO: tunIO}
 }
@@ -0,0 +1,72 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selector
+
+import (
+	"sync/atomic"
+
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/pktcls"
+	"github.com/scionproto/scion/go/sig/egress/iface"
+)
+
+var _ iface.SessionSelector = (*ClassSelector)(nil)
+
+// ClassSession is the session of a traffic class.
+type ClassSession struct {
+	Class   *pktcls.Class
+	Session iface.Session
+}
+
+// ClassSelector implements iface.SessionSelector, returning the session of
+// the first traffic class that matches the packet. Packets that match no
+// class are sent over the default session. The classes can be replaced while
+// the selector is in use.
+type ClassSelector struct {
+	Default iface.Session
+	// classes holds the []ClassSession.
+	classes atomic.Value
+}
+
+func NewClassSelector(def iface.Session) *ClassSelector {
+	s := &ClassSelector{Default: def}
+	s.classes.Store([]ClassSession(nil))
+	return s
+}
+
+// SetClasses replaces the traffic classes. The order of the classes is the
+// order in which they are matched.
+func (s *ClassSelector) SetClasses(classes []ClassSession) {
+	s.classes.Store(classes)
+}
+
+// Classes returns the current traffic classes.
+func (s *ClassSelector) Classes() []ClassSession {
+	return s.classes.Load().([]ClassSession)
+}
+
+func (s *ClassSelector) ChooseSess(b common.RawBytes) iface.Session {
+	classes := s.Classes()
+	if len(classes) == 0 {
+		return s.Default
+	}
+	pkt := pktcls.NewPacket(b)
+	for _, c := range classes {
+		if c.Class.Eval(pkt) {
+			return c.Session
+		}
+	}
+	return s.Default
+}
@@ -0,0 +1,85 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selector
+
+import (
+	"net"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/pktcls"
+	"github.com/scionproto/scion/go/sig/egress/iface/mock_iface"
+)
+
+func TestClassSelector(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	def := mock_iface.NewMockSession(ctrl)
+	voice := mock_iface.NewMockSession(ctrl)
+	bulk := mock_iface.NewMockSession(ctrl)
+
+	s := NewClassSelector(def)
+	voicePkt := ipv4Packet(t, 0x2e<<2, net.IP{192, 0, 2, 1})
+	bulkPkt := ipv4Packet(t, 0, net.IP{192, 0, 2, 129})
+	otherPkt := ipv4Packet(t, 0, net.IP{192, 0, 2, 1})
+	assert.Equal(t, def, s.ChooseSess(voicePkt))
+
+	_, bulkNet, _ := net.ParseCIDR("192.0.2.128/25")
+	s.SetClasses([]ClassSession{
+		{
+			Class: pktcls.NewClass("voice",
+				pktcls.NewCondIPv4(&pktcls.IPv4MatchDSCP{DSCP: 0x2e})),
+			Session: voice,
+		},
+		{
+			Class: pktcls.NewClass("bulk",
+				pktcls.NewCondIPv4(&pktcls.IPv4MatchDestination{Net: bulkNet})),
+			Session: bulk,
+		},
+	})
+	assert.Equal(t, voice, s.ChooseSess(voicePkt))
+	assert.Equal(t, bulk, s.ChooseSess(bulkPkt))
+	assert.Equal(t, def, s.ChooseSess(otherPkt))
+	// Classes are matched in order.
+	s.SetClasses([]ClassSession{
+		{Class: pktcls.NewClass("all", pktcls.CondTrue), Session: bulk},
+		{Class: pktcls.NewClass("voice", pktcls.CondTrue), Session: voice},
+	})
+	assert.Equal(t, bulk, s.ChooseSess(voicePkt))
+}
+
+func ipv4Packet(t *testing.T, tos uint8, dst net.IP) common.RawBytes {
+	ip := &layers.IPv4{
+		Version:  4,
+		TTL:      64,
+		TOS:      tos,
+		Protocol: layers.IPProtocolUDP,
+		SrcIP:    net.IP{198, 51, 100, 1},
+		DstIP:    dst,
+	}
+	udp := &layers.UDP{SrcPort: 40000, DstPort: 40001}
+	require.NoError(t, udp.SetNetworkLayerForChecksum(ip))
+	buf := gopacket.NewSerializeBuffer()
+	opts := gopacket.SerializeOptions{FixLengths: true, ComputeChecksums: true}
+	require.NoError(t, gopacket.SerializeLayers(buf, opts, ip, udp,
+		gopacket.Payload([]byte("payload"))))
+	return common.RawBytes(buf.Bytes())
+}
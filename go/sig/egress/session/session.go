@@ -32,9 +32,10 @@ import (
 	"github.com/scionproto/scion/go/lib/pktdisp"
 	"github.com/scionproto/scion/go/lib/ringbuf"
 	"github.com/scionproto/scion/go/lib/snet"
-	"github.com/scionproto/scion/go/lib/spath/spathmeta"
 	"github.com/scionproto/scion/go/sig/egress/iface"
+	"github.com/scionproto/scion/go/sig/egress/multipath"
 	"github.com/scionproto/scion/go/sig/egress/worker"
+	"github.com/scionproto/scion/go/sig/internal/fec"
 	"github.com/scionproto/scion/go/sig/internal/sigcmn"
 )
 
@@ -52,7 +53,13 @@ type Session struct {
 	// FIXME: Use AtomicRemoteInfo instead
 	currRemote atomic.Value
 	// FIXME: Use AtomicBool instead.
-	healthy        atomic.Value
+	healthy atomic.Value
+	// multipath is the *multipath.Config of the session, nil if the session
+	// uses a single path at a time.
+	multipath atomic.Value
+	// fec are the *fec.Params the session proposes to the remote SIG, nil if
+	// the session does not protect its frames.
+	fec            atomic.Value
 	ring           *ringbuf.Ring
 	conn           *snet.Conn
 	sessMonStop    chan struct{}
@@ -60,6 +67,8 @@ type Session struct {
 	pktDispStop    chan struct{}
 	pktDispStopped chan struct{}
 	workerStopped  chan struct{}
+	// started is set once the session monitor and worker are started.
+	started bool
 }
 
 func NewSession(dstIA addr.IA, sessId sig_mgmt.SessionType, logger log.Logger,
@@ -74,6 +83,8 @@ func NewSession(dstIA addr.IA, sessId sig_mgmt.SessionType, logger log.Logger,
 	}
 	s.currRemote.Store((*iface.RemoteInfo)(nil))
 	s.healthy.Store(false)
+	s.multipath.Store((*multipath.Config)(nil))
+	s.fec.Store((*fec.Params)(nil))
 	s.ring = ringbuf.New(64, nil, fmt.Sprintf("egress_%s_%s", dstIA, sessId))
 	// Not using a fixed local port, as this is for outgoing data only.
 	s.conn, err = sigcmn.Network.Listen(context.Background(), "udp",
@@ -97,6 +108,7 @@ func (s *Session) Logger() log.Logger {
 }
 
 func (s *Session) Start() {
+	s.started = true
 	go func() {
 		defer log.HandlePanic()
 		newSessMonitor(s).run()
@@ -110,10 +122,12 @@ func (s *Session) Start() {
 func (s *Session) Cleanup() error {
 	s.ring.Close()
 	close(s.sessMonStop)
-	s.logger.Debug("iface.Session Cleanup: wait for worker")
-	<-s.workerStopped
-	s.logger.Debug("iface.Session Cleanup: wait for session monitor")
-	<-s.sessMonStopped
+	if s.started {
+		s.logger.Debug("iface.Session Cleanup: wait for worker")
+		<-s.workerStopped
+		s.logger.Debug("iface.Session Cleanup: wait for session monitor")
+		<-s.sessMonStopped
+	}
 	close(s.pktDispStop)
 	s.logger.Debug("iface.Session Cleanup: wait for pktDisp")
 	s.conn.SetReadDeadline(time.Now())
@@ -157,6 +171,29 @@ func (s *Session) PathPool() iface.PathPool {
 	return s.pool
 }
 
+// SetMultipath configures the session to stripe its traffic across several
+// paths. A nil config makes the session use a single path at a time. It can
+// be changed while the session is running.
+func (s *Session) SetMultipath(cfg *multipath.Config) {
+	s.multipath.Store(cfg)
+}
+
+func (s *Session) multipathConfig() *multipath.Config {
+	return s.multipath.Load().(*multipath.Config)
+}
+
+// SetFEC configures the session to protect its frames with forward error
+// correction. The parameters are proposed to the remote SIG, and are used once
+// it agrees to them. nil disables FEC. It can be changed while the session is
+// running.
+func (s *Session) SetFEC(p *fec.Params) {
+	s.fec.Store(p)
+}
+
+func (s *Session) fecConfig() *fec.Params {
+	return s.fec.Load().(*fec.Params)
+}
+
 func (s *Session) AnnounceWorkerStopped() {
 	close(s.workerStopped)
 }
@@ -169,7 +206,13 @@ type PathPool struct {
 var _ iface.PathPool = (*PathPool)(nil)
 
 func NewPathPool(dst addr.IA) (*PathPool, error) {
-	pool, err := sigcmn.PathMgr.Watch(context.TODO(), sigcmn.IA, dst)
+	return NewPathPoolWithPolicy(dst, nil)
+}
+
+// NewPathPoolWithPolicy creates a pool of the paths to dst that are allowed
+// by policy. A nil policy allows all paths.
+func NewPathPoolWithPolicy(dst addr.IA, policy pathmgr.Policy) (*PathPool, error) {
+	pool, err := sigcmn.PathMgr.WatchFilter(context.TODO(), sigcmn.IA, dst, policy)
 	if err != nil {
 		return nil, common.NewBasicError("Unable to register watch", err)
 	}
@@ -184,6 +227,6 @@ func (pp *PathPool) Destroy() error {
 	return nil
 }
 
-func (pp *PathPool) Paths() spathmeta.AppPathSet {
-	return pp.pool.Load().APS
+func (pp *PathPool) Paths() []snet.Path {
+	return pp.pool.Load().Paths
 }
@@ -25,8 +25,12 @@ import (
 	"github.com/scionproto/scion/go/lib/infra"
 	"github.com/scionproto/scion/go/lib/log"
 	"github.com/scionproto/scion/go/lib/sigdisp"
+	"github.com/scionproto/scion/go/lib/snet"
 	"github.com/scionproto/scion/go/sig/egress/iface"
+	"github.com/scionproto/scion/go/sig/egress/multipath"
 	"github.com/scionproto/scion/go/sig/egress/siginfo"
+	"github.com/scionproto/scion/go/sig/internal/announce"
+	"github.com/scionproto/scion/go/sig/internal/fec"
 	"github.com/scionproto/scion/go/sig/internal/metrics"
 	"github.com/scionproto/scion/go/sig/internal/sigcmn"
 )
@@ -37,6 +41,9 @@ const (
 	tout          = 1 * time.Second
 	writeTout     = 100 * time.Millisecond
 	pathExpiryLen = 10 * time.Second
+	// How often the local prefixes are announced to the remote SIG, if they
+	// did not change.
+	announceInterval = 10 * time.Second
 )
 
 // sessMonitor is responsible for monitoring a session, polling remote SIGs, and switching
@@ -59,6 +66,19 @@ type sessMonitor struct {
 	updateMsgId sig_mgmt.MsgIdType
 	// the last time a PollRep was received.
 	lastReply time.Time
+	// the paths the session's traffic is striped across, nil if the session
+	// uses a single path at a time.
+	rotation *multipath.Rotation
+	// the healthy paths of the rotation, as published in the session's remote.
+	stripe *multipath.Set
+	// the id of the last PollReq sent on a path of the rotation.
+	lastProbeId sig_mgmt.MsgIdType
+	// the FEC parameters agreed on with the remote SIG, nil if the frames are
+	// not protected.
+	fec *fec.Params
+	// the version of the last prefix announcement sent, and when it was sent.
+	annVersion uint64
+	lastAnn    time.Time
 }
 
 func newSessMonitor(sess *Session) *sessMonitor {
@@ -100,8 +120,11 @@ Top:
 			break Top
 		case <-reqTick.C:
 			sm.updatePaths()
+			sm.updateRotation()
 			sm.updateRemote()
 			sm.sendReq()
+			sm.sendRotationReqs()
+			sm.sendAnnouncement()
 		case rpld := <-regc:
 			sm.handleRep(rpld)
 		case <-pathExpiryTick.C:
@@ -113,6 +136,7 @@ Top:
 	if err != nil {
 		log.Error("sessMonitor: unable to unregister from ctrl dispatcher", "err", err)
 	}
+	sm.resetRotation()
 	sm.logger.Info("sessMonitor: stopped")
 }
 
@@ -139,6 +163,66 @@ func (sm *sessMonitor) updatePaths() {
 	}
 }
 
+// updateRotation updates the paths the session's traffic is striped across,
+// and publishes the healthy ones to the session.
+func (sm *sessMonitor) updateRotation() {
+	cfg := sm.sess.multipathConfig()
+	if cfg == nil {
+		if sm.rotation != nil {
+			sm.logger.Info("sessMonitor: Multipath disabled")
+			sm.resetRotation()
+			sm.updateSessSnap()
+		}
+		return
+	}
+	if sm.rotation == nil {
+		sm.logger.Info("sessMonitor: Multipath enabled", "paths", cfg.Paths)
+		sm.rotation = multipath.NewRotation(*cfg)
+	} else {
+		sm.rotation.SetConfig(*cfg)
+	}
+	for _, fp := range sm.rotation.Update(sm.pool.Paths(), time.Now()) {
+		sm.logger.Info("sessMonitor: Path removed from rotation", "path", multipath.Label(fp))
+		sm.deletePathMetrics(fp)
+	}
+	for fp, ps := range sm.rotation.Paths() {
+		labels := sm.pathLabels(fp)
+		metrics.PathRTT.WithLabelValues(labels...).Set(ps.RTT.Seconds())
+		metrics.PathLoss.WithLabelValues(labels...).Set(ps.Loss)
+		var healthVal float64
+		if ps.Healthy {
+			healthVal = 1
+		}
+		metrics.PathHealth.WithLabelValues(labels...).Set(healthVal)
+	}
+	sm.stripe = sm.rotation.Set()
+	sm.updateSessSnap()
+}
+
+// resetRotation stops striping the session's traffic.
+func (sm *sessMonitor) resetRotation() {
+	if sm.rotation == nil {
+		return
+	}
+	for fp := range sm.rotation.Paths() {
+		sm.deletePathMetrics(fp)
+	}
+	sm.rotation, sm.stripe = nil, nil
+}
+
+func (sm *sessMonitor) pathLabels(fp snet.PathFingerprint) []string {
+	return []string{sm.sess.IA().String(), sm.sess.SessId.String(), multipath.Label(fp)}
+}
+
+func (sm *sessMonitor) deletePathMetrics(fp snet.PathFingerprint) {
+	labels := sm.pathLabels(fp)
+	metrics.PathRTT.DeleteLabelValues(labels...)
+	metrics.PathLoss.DeleteLabelValues(labels...)
+	metrics.PathHealth.DeleteLabelValues(labels...)
+	metrics.PathFramesSent.DeleteLabelValues(labels...)
+	metrics.PathFrameBytesSent.DeleteLabelValues(labels...)
+}
+
 func (sm *sessMonitor) updateRemote() {
 	// There were no replies from the remote SIG for some time. We don't know whether
 	// the failure was caused by bad path or bad SIG. Therefore, we choose a different
@@ -223,6 +307,8 @@ func (sm *sessMonitor) updateSessSnap() {
 		}
 		remote.Sig = old.Sig
 	}
+	remote.Multipath = sm.stripe
+	remote.FEC = sm.fec
 	sm.sess.currRemote.Store(remote)
 	if remote.SessPath != nil {
 		mtu := remote.SessPath.Path().MTU()
@@ -257,9 +343,72 @@ func (sm *sessMonitor) sendReq() {
 		return
 	}
 	sm.updateMsgId = sig_mgmt.MsgIdType(time.Now().UnixNano())
+	sm.sendPoll(sm.updateMsgId, sm.smRemote.SessPath.Path())
+}
+
+// sendRotationReqs health-checks the paths of the rotation, by sending a
+// PollReq on each of them.
+func (sm *sessMonitor) sendRotationReqs() {
+	if sm.rotation == nil || sm.smRemote == nil {
+		return
+	}
+	for fp, ps := range sm.rotation.Paths() {
+		// The ids must be unique, as they identify the path of the reply.
+		id := sig_mgmt.MsgIdType(time.Now().UnixNano())
+		if id <= sm.lastProbeId || id == sm.updateMsgId {
+			id = sm.lastProbeId + 1
+		}
+		sm.lastProbeId = id
+		sm.rotation.Probe(uint64(id), fp, time.Now())
+		sm.sendPoll(id, ps.Path)
+	}
+}
+
+// sendAnnouncement announces the local prefixes to the remote SIG, if they
+// changed or were not announced for some time. Only the session of the default
+// traffic class announces them.
+func (sm *sessMonitor) sendAnnouncement() {
+	if sm.sess.SessId != 0 || sm.smRemote == nil || sm.smRemote.SessPath == nil {
+		return
+	}
+	if !announce.Enabled() {
+		return
+	}
+	ann := announce.Local.Get()
+	if ann == nil {
+		return
+	}
+	if ann.Version == sm.annVersion && time.Since(sm.lastAnn) < announceInterval {
+		return
+	}
+	raw, err := announce.Pack(sig_mgmt.MsgIdType(time.Now().UnixNano()), ann)
+	if err != nil {
+		sm.logger.Error("sessMonitor: Error packing prefix announcement", "err", err)
+		return
+	}
+	path := sm.smRemote.SessPath.Path()
+	raddr := sm.smRemote.Sig.CtrlSnetAddr(path.Path(), path.OverlayNextHop())
+	if _, err := sm.sess.conn.WriteTo(raw, raddr); err != nil {
+		sm.logger.Error("sessMonitor: Error sending prefix announcement", "err", err)
+		return
+	}
+	if ann.Version != sm.annVersion {
+		sm.logger.Info("sessMonitor: Announced prefixes", "ann", ann)
+	}
+	sm.annVersion, sm.lastAnn = ann.Version, time.Now()
+	metrics.PrefixAnnsSent.WithLabelValues(sm.sess.IA().String()).Inc()
+}
+
+func (sm *sessMonitor) sendPoll(id sig_mgmt.MsgIdType, path snet.Path) {
 	mgmtAddr := sigcmn.GetMgmtAddr()
-	spld, err := sig_mgmt.NewPld(sm.updateMsgId, sig_mgmt.NewPollReq(&mgmtAddr,
-		sm.sess.SessId))
+	req := sig_mgmt.NewPollReq(&mgmtAddr, sm.sess.SessId)
+	if p := sm.sess.fecConfig(); p != nil {
+		req.FEC = &sig_mgmt.FEC{
+			DataFrames:   uint8(p.DataFrames),
+			ParityFrames: uint8(p.ParityFrames),
+		}
+	}
+	spld, err := sig_mgmt.NewPld(id, req)
 	if err != nil {
 		sm.logger.Error("sessMonitor: Error creating SIGCtrl payload", "err", err)
 		return
@@ -279,10 +428,7 @@ func (sm *sessMonitor) sendReq() {
 		sm.logger.Error("sessMonitor: Error packing signed Ctrl payload", "err", err)
 		return
 	}
-	raddr := sm.smRemote.Sig.CtrlSnetAddr(
-		sm.smRemote.SessPath.Path().Path(),
-		sm.smRemote.SessPath.Path().OverlayNextHop(),
-	)
+	raddr := sm.smRemote.Sig.CtrlSnetAddr(path.Path(), path.OverlayNextHop())
 	// XXX(kormat): if this blocks, both the sessMon and egress worker
 	// goroutines will block. Can't just use SetWriteDeadline, as both
 	// goroutines write to it.
@@ -308,6 +454,11 @@ func (sm *sessMonitor) handleRep(rpld *sigdisp.RegPld) {
 	metrics.SessionProbeReplies.WithLabelValues(sm.sess.IA().String(),
 		sm.sess.SessId.String()).Inc()
 
+	// Replies to the health checks of the rotation only update the path.
+	if sm.rotation != nil && sm.rotation.Reply(uint64(rpld.Id), time.Now()) {
+		return
+	}
+
 	// Inform SessPathPool that a reply has arrived.
 	if sm.smRemote.SessPath != nil {
 		sm.sessPathPool.Reply(sm.smRemote.SessPath, rpld.Id.Time())
@@ -324,6 +475,7 @@ func (sm *sessMonitor) handleRep(rpld *sigdisp.RegPld) {
 			CtrlL4Port:  int(pollRep.Addr.Ctrl.Port),
 			EncapL4Port: int(pollRep.Addr.Data.Port),
 		}
+		fecChanged := sm.updateFEC(pollRep.FEC)
 		// Update session's remote, if needed.
 		sessRemote := sm.sess.Remote()
 		if sessRemote == nil || !sm.smRemote.Sig.Equal(sessRemote.Sig) {
@@ -332,6 +484,8 @@ func (sm *sessMonitor) handleRep(rpld *sigdisp.RegPld) {
 				"msgId", rpld.Id, "remote", sm.smRemote)
 			metrics.SessionRemoteSwitched.WithLabelValues(sm.sess.IA().String(),
 				sm.sess.SessId.String()).Inc()
+		} else if fecChanged {
+			sm.updateSessSnap()
 		}
 		sm.setHealth(true)
 
@@ -348,6 +502,28 @@ func (sm *sessMonitor) handleRep(rpld *sigdisp.RegPld) {
 	}
 }
 
+// updateFEC updates the FEC parameters agreed on with the remote SIG. The
+// proposed parameters are agreed on if the remote SIG echoes them in its
+// reply. It returns true if the parameters changed.
+func (sm *sessMonitor) updateFEC(rep *sig_mgmt.FEC) bool {
+	var agreed *fec.Params
+	proposed := sm.sess.fecConfig()
+	if proposed != nil && rep != nil && int(rep.DataFrames) == proposed.DataFrames &&
+		int(rep.ParityFrames) == proposed.ParityFrames {
+		agreed = proposed
+	}
+	if agreed == sm.fec || (agreed != nil && sm.fec != nil && *agreed == *sm.fec) {
+		return false
+	}
+	if agreed != nil {
+		sm.logger.Info("sessMonitor: FEC enabled", "params", agreed)
+	} else {
+		sm.logger.Info("sessMonitor: FEC disabled", "proposed", proposed)
+	}
+	sm.fec = agreed
+	return true
+}
+
 func (sm *sessMonitor) setHealth(healthy bool) {
 	sm.sess.healthy.Store(healthy)
 	var healthVal float64
@@ -30,7 +30,9 @@ import (
 	"github.com/scionproto/scion/go/lib/spkt"
 	"github.com/scionproto/scion/go/lib/util"
 	"github.com/scionproto/scion/go/sig/egress/iface"
+	"github.com/scionproto/scion/go/sig/egress/multipath"
 	"github.com/scionproto/scion/go/sig/egress/siginfo"
+	"github.com/scionproto/scion/go/sig/internal/fec"
 	"github.com/scionproto/scion/go/sig/internal/metrics"
 	"github.com/scionproto/scion/go/sig/internal/sigcmn"
 )
@@ -63,22 +65,48 @@ type SCIONWriter interface {
 
 type worker struct {
 	log.Logger
-	iaString      string
-	sess          iface.Session
-	writer        SCIONWriter
-	currSig       *siginfo.Sig
-	currPathEntry snet.Path
-	frameSentCtrs metrics.CtrPair
+	iaString       string
+	sess           iface.Session
+	writer         SCIONWriter
+	currSig        *siginfo.Sig
+	frameSentCtrs  metrics.CtrPair
+	paritySentCtrs metrics.CtrPair
 
-	epoch uint16
-	seq   uint32
-	pkts  ringbuf.EntryList
+	// lane sends the frames over the session's current path.
+	lane *lane
+	// lanes send the frames over the paths of a multipath session.
+	lanes map[snet.PathFingerprint]*lane
+	// stripe contains the paths of a multipath session. It is empty if the
+	// session does not stripe its traffic, or none of its paths is healthy.
+	stripe  *multipath.Set
+	striper *multipath.Striper
+	// lastEpoch is the epoch of the most recently started lane.
+	lastEpoch uint16
+	epochSet  bool
+
+	pkts ringbuf.EntryList
 
 	// TODO(sustrik): This is used for testing only. The code should be refactored
 	// in such a way that it's not needed.
 	ignoreAddress bool
 }
 
+// lane is a sequence of frames sent over one path. Each lane has its own
+// epoch and sequence numbers, such that the remote SIG reassembles the frames
+// of each lane separately.
+type lane struct {
+	fp    snet.PathFingerprint
+	path  snet.Path
+	frame *frame
+	epoch uint16
+	seq   uint32
+	// enc computes the parity frames of the lane if the session's frames are
+	// protected by FEC.
+	enc *fec.Encoder
+	// ctrs count the frames sent on a path of a multipath session.
+	ctrs *metrics.CtrPair
+}
+
 // NewWorker creates a new worker object.
 // ignoreAddress is set to true only in tests. Elsewhere is should be set to false.
 func NewWorker(sess iface.Session, writer SCIONWriter, ignoreAddress bool,
@@ -94,40 +122,51 @@ func NewWorker(sess iface.Session, writer SCIONWriter, ignoreAddress bool,
 			Pkts:  metrics.FramesSent.WithLabelValues(sess.IA().String(), sess.ID().String()),
 			Bytes: metrics.FrameBytesSent.WithLabelValues(sess.IA().String(), sess.ID().String()),
 		},
-		pkts: make(ringbuf.EntryList, 0, iface.EgressBufPkts),
+		paritySentCtrs: metrics.CtrPair{
+			Pkts: metrics.FECParityFramesSent.WithLabelValues(sess.IA().String(),
+				sess.ID().String()),
+			Bytes: metrics.FECParityBytesSent.WithLabelValues(sess.IA().String(),
+				sess.ID().String()),
+		},
+		lane:    &lane{frame: newFrame()},
+		lanes:   make(map[snet.PathFingerprint]*lane),
+		striper: multipath.NewStriper(),
+		pkts:    make(ringbuf.EntryList, 0, iface.EgressBufPkts),
 	}
 }
 
 func (w *worker) Run() {
 	defer log.HandlePanic()
 	w.Info("EgressWorker: starting")
-	f := newFrame()
 
 TopLoop:
 	for {
-		// If the frame is empty, block indefinitely for more packets.
-		fEmpty := f.offset == sigcmn.SIGHdrSize
-		if !w.read(fEmpty) {
+		// If the frames are empty, block indefinitely for more packets.
+		empty := w.empty()
+		if !w.read(empty) {
 			break TopLoop
 		}
-		if fEmpty {
+		if empty {
 			// Cover the case where no packets have arrived in a while, and the
-			// current path is stale.
-			w.resetFrame(f)
-		} else if len(w.pkts) == 0 {
-			// Didn't read any new packets, send partial frame.
-			if err := w.write(f); err != nil {
-				w.Error("Error sending frame", "err", err)
+			// current paths are stale.
+			w.resetLane(w.lane)
+			for _, l := range w.lanes {
+				w.resetLane(l)
 			}
+		} else if len(w.pkts) == 0 {
+			// Didn't read any new packets, send partial frames.
+			w.flush()
 			continue TopLoop
 		}
 		// Process buffered packets.
+		now := time.Now()
 		for i := range w.pkts {
 			pkt := w.pkts[i].(common.RawBytes)
-			if err := w.processPkt(f, pkt); err != nil {
+			if err := w.processPkt(w.laneFor(pkt, now), pkt); err != nil {
 				w.Error("Error sending frame", "err", err)
 			}
 		}
+		w.retireLanes()
 		// Return processed pkts to the free pool, and remove references.
 		iface.EgressFreePkts.Write(w.pkts, true)
 		for i := range w.pkts {
@@ -138,7 +177,91 @@ TopLoop:
 	w.sess.AnnounceWorkerStopped()
 }
 
-func (w *worker) processPkt(f *frame, pkt common.RawBytes) error {
+// laneFor returns the lane of the packet. If the session stripes its traffic,
+// this is the lane of the path of the packet's flow.
+func (w *worker) laneFor(pkt common.RawBytes, now time.Time) *lane {
+	p := w.striper.Choose(multipath.FlowKey(pkt), w.stripe, now)
+	if p == nil {
+		return w.lane
+	}
+	l, ok := w.lanes[p.Fingerprint]
+	if !ok {
+		label := multipath.Label(p.Fingerprint)
+		l = &lane{
+			fp:    p.Fingerprint,
+			path:  p.Path,
+			frame: newFrame(),
+			ctrs: &metrics.CtrPair{
+				Pkts: metrics.PathFramesSent.WithLabelValues(w.iaString,
+					w.sess.ID().String(), label),
+				Bytes: metrics.PathFrameBytesSent.WithLabelValues(w.iaString,
+					w.sess.ID().String(), label),
+			},
+		}
+		w.resetLane(l)
+		w.lanes[p.Fingerprint] = l
+	}
+	return l
+}
+
+// retireLanes sends the partial frames of the lanes whose path is no longer
+// in the stripe, and removes the lanes. If the session started to stripe its
+// traffic, the partial frame of the session's current path is sent as well.
+func (w *worker) retireLanes() {
+	if w.stripe.Len() > 0 && !w.lane.frame.empty() {
+		if err := w.write(w.lane); err != nil {
+			w.Error("Error sending frame", "err", err)
+		}
+	}
+	for fp, l := range w.lanes {
+		if w.stripe.Get(fp) != nil {
+			continue
+		}
+		if !l.frame.empty() {
+			if err := w.write(l); err != nil {
+				w.Error("Error sending frame", "err", err)
+			}
+		}
+		if err := w.writeParity(l); err != nil {
+			w.Error("Error sending parity frames", "err", err)
+		}
+		delete(w.lanes, fp)
+	}
+}
+
+func (w *worker) empty() bool {
+	if !w.lane.empty() {
+		return false
+	}
+	for _, l := range w.lanes {
+		if !l.empty() {
+			return false
+		}
+	}
+	return true
+}
+
+// flush sends the partial frames, and the parity frames of the partial groups.
+func (w *worker) flush() {
+	w.flushLane(w.lane)
+	for _, l := range w.lanes {
+		w.flushLane(l)
+	}
+}
+
+func (w *worker) flushLane(l *lane) {
+	if !l.frame.empty() {
+		if err := w.write(l); err != nil {
+			w.Error("Error sending frame", "err", err)
+		}
+	}
+	if err := w.writeParity(l); err != nil {
+		w.Error("Error sending parity frames", "err", err)
+	}
+}
+
+func (w *worker) processPkt(l *lane, pkt common.RawBytes) error {
+	f := l.frame
 	f.startPkt(uint16(len(pkt)))
 	pktOff := 0
 	// Write chunks of the packet to frames, sending off frames as they fill up.
@@ -146,7 +269,7 @@ func (w *worker) processPkt(f *frame, pkt common.RawBytes) error {
 		pktOff += f.readFrom(pkt[pktOff:])
 		if f.isFull() {
 			// There's no point in trying to fit another packet into this frame.
-			if err := w.write(f); err != nil {
+			if err := w.write(l); err != nil {
 				// Skip the rest of this packet.
 				return err
 			}
@@ -171,70 +294,177 @@ func (w *worker) read(block bool) bool {
 	return true
 }
 
-func (w *worker) write(f *frame) error {
+func (w *worker) write(l *lane) error {
 	// TODO(kormat): consider looking for an updated path here, and switching
 	// to it if the mtu isn't smaller than the current one.
-	defer w.resetFrame(f)
-	if w.seq == 0 {
-		w.epoch = uint16(time.Now().Unix() & 0xFFFF)
+	defer w.resetLane(l)
+	if l.seq == 0 {
+		// The group of the previous epoch ends with it.
+		if err := w.writeParity(l); err != nil {
+			w.Error("Error sending parity frames", "err", err)
+		}
+		l.epoch = w.newEpoch()
 	}
 
 	// Update the sequence number.
 	// We want to do this even if the function fails. Otherwise, if writing fails
 	// in the middle of sending a packet the peer wouldn't recognize that there's
 	// a frame missing and would try to parse an inconsistent sequence of frames.
-	seq := w.seq
-	w.seq += 1
-	if w.seq > MaxSeq {
-		w.seq = 0
+	seq := l.seq
+	l.seq += 1
+	if l.seq > MaxSeq {
+		l.seq = 0
 	}
 
-	var snetAddr *snet.UDPAddr
-	if !w.ignoreAddress {
-		if w.currPathEntry == nil {
-			// FIXME(kormat): add some metrics to track this.
-			return nil
-		}
-		if w.currSig == nil {
-			// FIXME(kormat): add some metrics to track this.
-			return nil
+	snetAddr, ok := w.dstAddr(l)
+	if !ok {
+		// The frames of a group must have consecutive sequence numbers.
+		if l.enc != nil {
+			l.enc.Reset()
 		}
-		snetAddr = w.currSig.EncapSnetAddr()
-		snetAddr.Path = w.currPathEntry.Path()
-		snetAddr.NextHop = w.currPathEntry.OverlayNextHop()
+		return nil
 	}
 
-	f.writeHdr(w.sess.ID(), w.epoch, seq)
-	bytesWritten, err := w.writer.WriteTo(f.raw(), snetAddr)
+	l.frame.writeHdr(w.sess.ID(), l.epoch, seq, l.enc != nil)
+	// The frame is added to the group even if sending fails, such that the
+	// remote SIG can recover it.
+	groupDone := l.enc != nil && l.enc.Add(l.frame.raw())
+	bytesWritten, err := w.writer.WriteTo(l.frame.raw(), snetAddr)
 	if err != nil {
-		return common.NewBasicError("Egress write error", err)
+		err = common.NewBasicError("Egress write error", err)
+	} else {
+		w.frameSentCtrs.Pkts.Inc()
+		w.frameSentCtrs.Bytes.Add(float64(bytesWritten))
+		if l.ctrs != nil {
+			l.ctrs.Pkts.Inc()
+			l.ctrs.Bytes.Add(float64(bytesWritten))
+		}
+	}
+	if groupDone {
+		if perr := w.writeParity(l); perr != nil && err == nil {
+			err = perr
+		}
+	}
+	return err
+}
+
+// writeParity sends the parity frames of the lane's current group, if any.
+func (w *worker) writeParity(l *lane) error {
+	if l.enc == nil || l.enc.Len() == 0 {
+		return nil
+	}
+	parity := l.enc.Parity()
+	snetAddr, ok := w.dstAddr(l)
+	if !ok {
+		return nil
+	}
+	for _, p := range parity {
+		bytesWritten, err := w.writer.WriteTo(p, snetAddr)
+		if err != nil {
+			return common.NewBasicError("Egress parity write error", err)
+		}
+		w.paritySentCtrs.Pkts.Inc()
+		w.paritySentCtrs.Bytes.Add(float64(bytesWritten))
+		if l.ctrs != nil {
+			l.ctrs.Pkts.Inc()
+			l.ctrs.Bytes.Add(float64(bytesWritten))
+		}
 	}
-	w.frameSentCtrs.Pkts.Inc()
-	w.frameSentCtrs.Bytes.Add(float64(bytesWritten))
 	return nil
 }
 
-func (w *worker) resetFrame(f *frame) {
+// dstAddr returns the address of the remote SIG, with the path of the lane. It
+// returns false if the remote SIG or the path is unknown.
+func (w *worker) dstAddr(l *lane) (*snet.UDPAddr, bool) {
+	if w.ignoreAddress {
+		return nil, true
+	}
+	if l.path == nil {
+		// FIXME(kormat): add some metrics to track this.
+		return nil, false
+	}
+	if w.currSig == nil {
+		// FIXME(kormat): add some metrics to track this.
+		return nil, false
+	}
+	snetAddr := w.currSig.EncapSnetAddr()
+	snetAddr.Path = l.path.Path()
+	snetAddr.NextHop = l.path.OverlayNextHop()
+	return snetAddr, true
+}
+
+// newEpoch returns the epoch of a lane that starts, or restarts, its
+// sequence numbers. Epochs are based on the current time, but never repeat the
+// epochs of recently started lanes, which may still be in use.
+func (w *worker) newEpoch() uint16 {
+	epoch := uint16(time.Now().Unix() & 0xFFFF)
+	if w.epochSet && int16(epoch-w.lastEpoch) <= 0 {
+		epoch = w.lastEpoch + 1
+	}
+	w.lastEpoch, w.epochSet = epoch, true
+	return epoch
+}
+
+// resetLane empties the frame of the lane, and updates the remote SIG and the
+// path of the lane.
+func (w *worker) resetLane(l *lane) {
 	var mtu uint16 = common.MinMTU
-	var addrLen, pathLen uint16
+	var addrLen, pathLen, fecLen uint16
+	var fecParams *fec.Params
 	remote := w.sess.Remote()
 	if remote != nil {
+		fecParams = remote.FEC
 		w.currSig = remote.Sig
 		if w.currSig != nil {
 			addrLen = uint16(spkt.AddrHdrLen(w.currSig.Host,
 				addr.HostFromIP(sigcmn.DataAddr)))
 		}
-		w.currPathEntry = nil
-		if remote.SessPath != nil {
-			w.currPathEntry = remote.SessPath.Path()
+		w.stripe = remote.Multipath
+		if l == w.lane {
+			l.path = nil
+			if remote.SessPath != nil {
+				l.path = remote.SessPath.Path()
+			}
+		} else if p := w.stripe.Get(l.fp); p != nil {
+			l.path = p.Path
 		}
-		if w.currPathEntry != nil {
-			mtu = w.currPathEntry.MTU()
-			pathLen = uint16(len(w.currPathEntry.Path().Raw))
+		if l.path != nil {
+			mtu = l.path.MTU()
+			pathLen = uint16(len(l.path.Path().Raw))
 		}
 	}
+	w.setFEC(l, fecParams)
+	if l.enc != nil {
+		fecLen = fec.Overhead
+	}
 	// FIXME(kormat): to do this properly, need to account for any ext headers.
-	f.reset(mtu - spkt.CmnHdrLen - addrLen - pathLen - l4.UDPLen)
+	l.frame.reset(mtu - spkt.CmnHdrLen - addrLen - pathLen - l4.UDPLen - fecLen)
+}
+
+// setFEC updates the FEC parameters of the lane. If they change, the lane
+// restarts its sequence numbers, such that the remote SIG does not mix
+// protected and unprotected frames in one epoch.
+func (w *worker) setFEC(l *lane, p *fec.Params) {
+	var curr *fec.Params
+	if l.enc != nil {
+		params := l.enc.Params()
+		curr = &params
+	}
+	if (curr == nil && p == nil) || (curr != nil && p != nil && *curr == *p) {
+		return
+	}
+	if err := w.writeParity(l); err != nil {
+		w.Error("Error sending parity frames", "err", err)
+	}
+	l.enc = nil
+	if p != nil {
+		l.enc = fec.NewEncoder(*p)
+	}
+	l.seq = 0
+}
+
+func (l *lane) empty() bool {
+	return l.frame.empty() && (l.enc == nil || l.enc.Len() == 0)
 }
 
 type frame struct {
@@ -253,6 +483,10 @@ func (f *frame) reset(mtu uint16) {
 	f.offset = sigcmn.SIGHdrSize
 }
 
+func (f *frame) empty() bool {
+	return f.offset == sigcmn.SIGHdrSize
+}
+
 func (f *frame) raw() common.RawBytes {
 	return f.b[:f.offset]
 }
@@ -278,9 +512,15 @@ func (f *frame) startPkt(pktLen uint16) {
 	f.offset += PktLenSize
 }
 
-func (f *frame) writeHdr(sessId sig_mgmt.SessionType, epoch uint16, seq uint32) {
+func (f *frame) writeHdr(sessId sig_mgmt.SessionType, epoch uint16, seq uint32,
+	protected bool) {
+
+	idx := f.idx
+	if protected {
+		idx |= fec.ProtectedFlag
+	}
 	f.b[0] = uint8(sessId)
 	common.Order.PutUint16(f.b[1:3], epoch)
 	common.Order.PutUintN(f.b[3:6], uint64(seq), 3)
-	common.Order.PutUint16(f.b[6:8], f.idx)
+	common.Order.PutUint16(f.b[6:8], idx)
 }
@@ -21,6 +21,7 @@ import (
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/scionproto/scion/go/lib/addr"
 	"github.com/scionproto/scion/go/lib/common"
@@ -30,7 +31,9 @@ import (
 	"github.com/scionproto/scion/go/lib/snet"
 	"github.com/scionproto/scion/go/sig/egress/iface"
 	"github.com/scionproto/scion/go/sig/egress/iface/mock_iface"
+	"github.com/scionproto/scion/go/sig/egress/multipath"
 	"github.com/scionproto/scion/go/sig/egress/worker/mock_worker"
+	"github.com/scionproto/scion/go/sig/internal/fec"
 )
 
 func TestMain(m *testing.M) {
@@ -173,3 +176,106 @@ func TestParsing(t *testing.T) {
 		tester.Run()
 	})
 }
+
+func TestMultipath(t *testing.T) {
+	iface.Init()
+	mctrl := gomock.NewController(t)
+	defer mctrl.Finish()
+	ring := ringbuf.New(64, nil, "egress")
+	writer := mock_worker.NewMockSCIONWriter(mctrl)
+	set := &multipath.Set{Paths: []*multipath.SetPath{
+		{Fingerprint: "a", Weight: 1},
+		{Fingerprint: "b", Weight: 1},
+	}}
+	ia, _ := addr.IAFromString("1-ff00:0:300")
+	s := mock_iface.NewMockSession(mctrl)
+	s.EXPECT().IA().AnyTimes().Return(ia)
+	s.EXPECT().ID().AnyTimes().Return(sig_mgmt.SessionType(0))
+	s.EXPECT().Ring().AnyTimes().Return(ring)
+	s.EXPECT().Remote().AnyTimes().Return(&iface.RemoteInfo{Multipath: set})
+	s.EXPECT().AnnounceWorkerStopped().AnyTimes()
+
+	// Each path is a lane with its own epoch, and sequence numbers starting
+	// at 0.
+	var frames [][]byte
+	writer.EXPECT().WriteTo(gomock.Any(), gomock.Any()).Times(2).DoAndReturn(
+		func(frame []byte, address *snet.UDPAddr) (int, error) {
+			frames = append(frames, append([]byte(nil), frame...))
+			if len(frames) == 2 {
+				ring.Close()
+			}
+			return len(frame), nil
+		})
+	for i := 0; i < 16; i++ {
+		// Minimal IPv4 headers of different flows.
+		pkt := make(common.RawBytes, 20)
+		pkt[0] = 0x45
+		pkt[19] = byte(i)
+		bufs := make(ringbuf.EntryList, 1)
+		n, _ := iface.EgressFreePkts.Read(bufs, true)
+		require.Equal(t, 1, n)
+		buf := bufs[0].(common.RawBytes)[:len(pkt)]
+		copy(buf, pkt)
+		n, _ = ring.Write(ringbuf.EntryList{buf}, true)
+		require.Equal(t, 1, n)
+	}
+	NewWorker(s, writer, true, log.New()).Run()
+
+	require.Len(t, frames, 2)
+	assert.NotEqual(t, frames[0][1:3], frames[1][1:3], "epochs must differ")
+	pkts := 0
+	for _, f := range frames {
+		assert.Equal(t, []byte{0, 0, 0}, f[3:6], "sequence number")
+		// Each packet takes 2B length and 20B header, padded to 24B except
+		// for the last one.
+		pkts += (len(f) - SigHdrLen + 2) / 24
+	}
+	assert.Equal(t, 16, pkts)
+}
+
+func TestFEC(t *testing.T) {
+	iface.Init()
+	mctrl := gomock.NewController(t)
+	defer mctrl.Finish()
+	ring := ringbuf.New(64, nil, "egress")
+	writer := mock_worker.NewMockSCIONWriter(mctrl)
+	ia, _ := addr.IAFromString("1-ff00:0:300")
+	s := mock_iface.NewMockSession(mctrl)
+	s.EXPECT().IA().AnyTimes().Return(ia)
+	s.EXPECT().ID().AnyTimes().Return(sig_mgmt.SessionType(0))
+	s.EXPECT().Ring().AnyTimes().Return(ring)
+	s.EXPECT().Remote().AnyTimes().Return(
+		&iface.RemoteInfo{FEC: &fec.Params{DataFrames: 2, ParityFrames: 1}})
+	s.EXPECT().AnnounceWorkerStopped().AnyTimes()
+
+	var frames []common.RawBytes
+	writer.EXPECT().WriteTo(gomock.Any(), gomock.Any()).Times(3).DoAndReturn(
+		func(frame []byte, address *snet.UDPAddr) (int, error) {
+			frames = append(frames, append(common.RawBytes(nil), frame...))
+			if len(frames) == 3 {
+				ring.Close()
+			}
+			return len(frame), nil
+		})
+	bufs := make(ringbuf.EntryList, 1)
+	n, _ := iface.EgressFreePkts.Read(bufs, true)
+	require.Equal(t, 1, n)
+	buf := bufs[0].(common.RawBytes)[:2000]
+	n, _ = ring.Write(ringbuf.EntryList{buf}, true)
+	require.Equal(t, 1, n)
+	NewWorker(s, writer, true, log.New()).Run()
+
+	// The packet is split into two protected frames, which form a group with
+	// one parity frame. The frames are shorter to make room for the parity
+	// overhead.
+	require.Len(t, frames, 3)
+	assert.Equal(t, []byte{0x80, 1}, []byte(frames[0][6:8]))
+	assert.Equal(t, []byte{0x80, 0}, []byte(frames[1][6:8]))
+	assert.Equal(t, 1264-fec.Overhead, len(frames[0]))
+	assert.True(t, fec.IsParity(frames[2]))
+	dec := fec.NewDecoder()
+	dec.AddData(frames[1])
+	recovered, err := dec.AddParity(frames[2])
+	require.NoError(t, err)
+	assert.Equal(t, []common.RawBytes{frames[0]}, recovered)
+}
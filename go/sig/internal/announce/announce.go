@@ -0,0 +1,223 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package announce implements the announcement of IP prefixes between SIGs.
+//
+// Each SIG periodically sends the prefixes served by its AS to the SIGs of the
+// remote ASes, over the sig_mgmt control channel. The announcements are
+// versioned: the version increases whenever the prefixes change, and
+// receivers ignore announcements older than the last one they accepted.
+package announce
+
+import (
+	"context"
+	"net"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/ctrl"
+	"github.com/scionproto/scion/go/lib/ctrl/sig_mgmt"
+	"github.com/scionproto/scion/go/lib/infra"
+	"github.com/scionproto/scion/go/lib/sigdisp"
+	"github.com/scionproto/scion/go/proto"
+)
+
+var (
+	// Signer signs the announcements of the local prefixes. No signer is set
+	// by default, see LoadCrypto.
+	Signer ctrl.Signer = infra.NullSigner
+	// Chain is the raw certificate chain of the Signer. It is attached to the
+	// announcements, such that receivers can verify them.
+	Chain common.RawBytes
+	// Verifier verifies the signatures of the announcements of remote SIGs.
+	// It is bound to the IA of the sender of each announcement. No verifier is
+	// set by default, see LoadCrypto.
+	Verifier infra.Verifier = infra.NullSigVerifier
+	// Chains stores the certificate chains attached to received announcements,
+	// after verifying them. If nil, the chains are ignored.
+	Chains ChainInserter
+	// Insecure allows to send and accept unsigned announcements, if no Signer
+	// or Verifier is set. Any host on the path can then announce prefixes on
+	// behalf of a remote AS, so it must only be set in trusted networks.
+	Insecure bool
+)
+
+// ChainInserter verifies raw certificate chains and makes them available to
+// the Verifier.
+type ChainInserter interface {
+	InsertChain(ctx context.Context, raw []byte) error
+}
+
+// Enabled returns whether announcements can be sent, i.e., whether a Signer is
+// set or unsigned announcements are allowed.
+func Enabled() bool {
+	return Insecure || Signer != infra.NullSigner
+}
+
+// Local are the prefixes announced by this SIG.
+var Local = &Prefixes{}
+
+// Prefixes is a versioned set of prefixes. It is safe for concurrent use.
+type Prefixes struct {
+	mu  sync.Mutex
+	ann *sig_mgmt.PrefixAnn
+}
+
+// Set sets the prefixes. The version is increased if they changed. If nets is
+// nil, nothing is announced. It returns true if the announcement changed.
+func (p *Prefixes) Set(nets []*net.IPNet) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if nets == nil {
+		changed := p.ann != nil
+		p.ann = nil
+		return changed
+	}
+	if p.ann != nil && Equal(p.ann, nets) {
+		return false
+	}
+	// The version is based on the current time, such that it also increases
+	// when the SIG restarts.
+	version := uint64(time.Now().UnixNano())
+	if p.ann != nil && version <= p.ann.Version {
+		version = p.ann.Version + 1
+	}
+	p.ann = sig_mgmt.NewPrefixAnn(version, nets)
+	return true
+}
+
+// Get returns the current announcement, nil if nothing is announced. The
+// announcement must not be modified.
+func (p *Prefixes) Get() *sig_mgmt.PrefixAnn {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.ann
+}
+
+// Equal returns whether the announcement contains exactly the networks, in
+// any order.
+func Equal(ann *sig_mgmt.PrefixAnn, nets []*net.IPNet) bool {
+	if len(ann.Prefixes) != len(nets) {
+		return false
+	}
+	a := make([]string, 0, len(nets))
+	for _, p := range ann.Prefixes {
+		n, err := p.IPNet()
+		if err != nil {
+			return false
+		}
+		a = append(a, n.String())
+	}
+	b := make([]string, 0, len(nets))
+	for _, n := range nets {
+		b = append(b, n.String())
+	}
+	sort.Strings(a)
+	sort.Strings(b)
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Pack returns the signed control payload of the announcement. It fails if
+// announcements are not enabled.
+func Pack(id sig_mgmt.MsgIdType, ann *sig_mgmt.PrefixAnn) (common.RawBytes, error) {
+	if !Enabled() {
+		return nil, common.NewBasicError("No signer for announcements", nil)
+	}
+	if len(Chain) > 0 {
+		withChain := *ann
+		withChain.Chain = Chain
+		ann = &withChain
+	}
+	spld, err := sig_mgmt.NewPld(id, ann)
+	if err != nil {
+		return nil, common.NewBasicError("Error creating SIGCtrl payload", err)
+	}
+	cpld, err := ctrl.NewPld(spld, nil)
+	if err != nil {
+		return nil, common.NewBasicError("Error creating Ctrl payload", err)
+	}
+	scpld, err := cpld.SignedPld(Signer)
+	if err != nil {
+		return nil, common.NewBasicError("Error creating signed Ctrl payload", err)
+	}
+	return scpld.PackPld()
+}
+
+// Verify verifies the signature of a received announcement, and returns the
+// announcement. Unless Insecure is set, unsigned announcements are rejected,
+// and so are all announcements if no Verifier is set.
+func Verify(ctx context.Context, rpld *sigdisp.RegPld) (*sig_mgmt.PrefixAnn, error) {
+	if rpld.Signed == nil {
+		return nil, common.NewBasicError("Announcement without signed payload", nil)
+	}
+	sign := rpld.Signed.Sign
+	signed := sign != nil && sign.Type != proto.SignType_none
+	if !Insecure {
+		if !signed {
+			return nil, common.NewBasicError("Unsigned announcement", nil)
+		}
+		if Verifier == infra.NullSigVerifier {
+			return nil, common.NewBasicError("No verifier for announcements", nil)
+		}
+	}
+	ann, err := parse(rpld.Signed.Blob)
+	if err != nil {
+		return nil, err
+	}
+	if !signed {
+		return ann, nil
+	}
+	if len(ann.Chain) > 0 && Chains != nil {
+		if err := Chains.InsertChain(ctx, ann.Chain); err != nil {
+			return nil, common.NewBasicError("Unable to verify certificate chain", err)
+		}
+	}
+	if err := Verifier.WithIA(rpld.Addr.IA).Verify(ctx, rpld.Signed.Blob, sign); err != nil {
+		return nil, common.NewBasicError("Unable to verify announcement", err)
+	}
+	return ann, nil
+}
+
+func parse(blob common.RawBytes) (*sig_mgmt.PrefixAnn, error) {
+	cpld, err := ctrl.NewPldFromRaw(blob)
+	if err != nil {
+		return nil, common.NewBasicError("Unable to parse announcement", err)
+	}
+	u, err := cpld.Union()
+	if err != nil {
+		return nil, err
+	}
+	spld, ok := u.(*sig_mgmt.Pld)
+	if !ok {
+		return nil, common.NewBasicError("Unexpected Ctrl payload type", nil,
+			"type", common.TypeOf(u))
+	}
+	u, err = spld.Union()
+	if err != nil {
+		return nil, err
+	}
+	ann, ok := u.(*sig_mgmt.PrefixAnn)
+	if !ok {
+		return nil, common.NewBasicError("Unexpected SIGCtrl payload type", nil,
+			"type", common.TypeOf(u))
+	}
+	return ann, nil
+}
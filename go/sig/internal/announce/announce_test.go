@@ -0,0 +1,159 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package announce
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/ctrl"
+	"github.com/scionproto/scion/go/lib/infra"
+	"github.com/scionproto/scion/go/lib/infra/mock_infra"
+	"github.com/scionproto/scion/go/lib/sigdisp"
+	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/lib/xtest"
+	"github.com/scionproto/scion/go/proto"
+)
+
+func mustParseCIDR(t *testing.T, s string) *net.IPNet {
+	_, n, err := net.ParseCIDR(s)
+	require.NoError(t, err)
+	return n
+}
+
+func TestPrefixesSet(t *testing.T) {
+	a := mustParseCIDR(t, "192.0.2.0/24")
+	b := mustParseCIDR(t, "2001:db8::/32")
+	p := &Prefixes{}
+	assert.Nil(t, p.Get())
+
+	assert.True(t, p.Set([]*net.IPNet{a, b}))
+	first := p.Get()
+	require.NotNil(t, first)
+	assert.False(t, p.Set([]*net.IPNet{b, a}), "order does not matter")
+	assert.Equal(t, first.Version, p.Get().Version)
+
+	assert.True(t, p.Set([]*net.IPNet{a}))
+	assert.Greater(t, p.Get().Version, first.Version)
+	assert.True(t, Equal(p.Get(), []*net.IPNet{a}))
+
+	// Announcing no prefixes withdraws them, which is different from not
+	// announcing at all.
+	assert.True(t, p.Set([]*net.IPNet{}))
+	require.NotNil(t, p.Get())
+	assert.Empty(t, p.Get().Prefixes)
+	assert.True(t, p.Set(nil))
+	assert.Nil(t, p.Get())
+}
+
+func TestPackVerify(t *testing.T) {
+	p := &Prefixes{}
+	p.Set([]*net.IPNet{mustParseCIDR(t, "192.0.2.0/24")})
+	_, err := Pack(42, p.Get())
+	assert.Error(t, err, "unsigned announcements are not sent by default")
+
+	Insecure = true
+	defer func() { Insecure = false }()
+	raw, err := Pack(42, p.Get())
+	require.NoError(t, err)
+
+	signed, err := ctrl.NewSignedPldFromRaw(raw)
+	require.NoError(t, err)
+	rpld := &sigdisp.RegPld{
+		Id:     42,
+		Addr:   &snet.UDPAddr{IA: xtest.MustParseIA("1-ff00:0:110")},
+		Signed: signed,
+	}
+	ann, err := Verify(context.Background(), rpld)
+	require.NoError(t, err)
+	assert.Equal(t, p.Get().Version, ann.Version)
+	assert.True(t, Equal(ann, []*net.IPNet{mustParseCIDR(t, "192.0.2.0/24")}))
+
+	_, err = Verify(context.Background(), &sigdisp.RegPld{Addr: rpld.Addr})
+	assert.Error(t, err)
+
+	Insecure = false
+	_, err = Verify(context.Background(), rpld)
+	assert.Error(t, err, "unsigned announcements are rejected by default")
+}
+
+type testSigner struct{}
+
+func (testSigner) Sign(msg []byte) (*proto.SignS, error) {
+	sign := proto.NewSignS(proto.SignType_ed25519, common.RawBytes("src"))
+	sign.Signature = common.RawBytes("signature")
+	return sign, nil
+}
+
+type chainFunc func(ctx context.Context, raw []byte) error
+
+func (f chainFunc) InsertChain(ctx context.Context, raw []byte) error {
+	return f(ctx, raw)
+}
+
+func TestPackVerifySigned(t *testing.T) {
+	mctrl := gomock.NewController(t)
+	defer mctrl.Finish()
+	ia := xtest.MustParseIA("1-ff00:0:110")
+	verifier := mock_infra.NewMockVerifier(mctrl)
+	Signer, Chain, Verifier = testSigner{}, common.RawBytes("chain"), verifier
+	defer func() {
+		Signer, Chain, Verifier, Chains = infra.NullSigner, nil, infra.NullSigVerifier, nil
+	}()
+
+	p := &Prefixes{}
+	p.Set([]*net.IPNet{mustParseCIDR(t, "192.0.2.0/24")})
+	raw, err := Pack(42, p.Get())
+	require.NoError(t, err)
+	assert.Empty(t, p.Get().Chain, "the local announcement is not modified")
+	signed, err := ctrl.NewSignedPldFromRaw(raw)
+	require.NoError(t, err)
+	rpld := &sigdisp.RegPld{
+		Id:     42,
+		Addr:   &snet.UDPAddr{IA: ia},
+		Signed: signed,
+	}
+
+	var inserted []byte
+	Chains = chainFunc(func(_ context.Context, raw []byte) error {
+		inserted = raw
+		return nil
+	})
+	verifier.EXPECT().WithIA(ia).Return(verifier)
+	verifier.EXPECT().Verify(gomock.Any(), []byte(signed.Blob), gomock.Any()).Return(nil)
+	ann, err := Verify(context.Background(), rpld)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("chain"), inserted)
+	assert.Equal(t, p.Get().Version, ann.Version)
+
+	Chains = chainFunc(func(context.Context, []byte) error {
+		return common.NewBasicError("untrusted chain", nil)
+	})
+	_, err = Verify(context.Background(), rpld)
+	assert.Error(t, err, "announcements with an invalid chain are rejected")
+
+	Chains = nil
+	verifier.EXPECT().WithIA(ia).Return(verifier)
+	verifier.EXPECT().Verify(gomock.Any(), []byte(signed.Blob), gomock.Any()).Return(
+		common.NewBasicError("invalid signature", nil))
+	_, err = Verify(context.Background(), rpld)
+	assert.Error(t, err, "announcements with an invalid signature are rejected")
+}
@@ -0,0 +1,86 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package announce
+
+import (
+	"context"
+	"net"
+	"path/filepath"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/infra"
+	"github.com/scionproto/scion/go/lib/infra/modules/trust"
+	"github.com/scionproto/scion/go/lib/infra/modules/trust/trustdbsqlite"
+	"github.com/scionproto/scion/go/lib/keyconf"
+)
+
+// LoadCrypto sets up the Signer, Verifier and Chains from the crypto material
+// in dir. The certs sub-directory must contain the TRCs of all ISDs the SIG
+// accepts announcements from, and the certificate chain of the local AS. The
+// keys sub-directory must contain the signing key of the local AS.
+//
+// The SIG does not fetch crypto material over the network. Instead, the
+// certificate chain of the sender is attached to each announcement, and
+// verified against the local TRCs.
+func LoadCrypto(ctx context.Context, ia addr.IA, dir string) error {
+	db, err := trustdbsqlite.New(":memory:")
+	if err != nil {
+		return common.NewBasicError("Unable to create trust database", err)
+	}
+	provider := trust.Provider{
+		DB:       db,
+		Recurser: trust.LocalOnlyRecurser{},
+		Router:   localRouter{},
+	}
+	store := trust.Store{
+		Inspector:      trust.DefaultInspector{Provider: provider},
+		CryptoProvider: provider,
+		Inserter:       trust.DefaultInserter{BaseInserter: trust.BaseInserter{DB: db}},
+		DB:             db,
+	}
+	if err := store.LoadCryptoMaterial(ctx, filepath.Join(dir, "certs")); err != nil {
+		return common.NewBasicError("Unable to load crypto material", err, "dir", dir)
+	}
+	gen := trust.SignerGen{
+		IA: ia,
+		KeyRing: keyconf.LoadingRing{
+			Dir: filepath.Join(dir, "keys"),
+			IA:  ia,
+		},
+		Provider: store,
+	}
+	signer, err := gen.Signer(ctx)
+	if err != nil {
+		return common.NewBasicError("Unable to create signer", err)
+	}
+	id := trust.ChainID{IA: ia, Version: signer.Meta().Src.ChainVer}
+	chain, err := provider.GetRawChain(ctx, id, infra.ChainOpts{})
+	if err != nil {
+		return common.NewBasicError("Unable to get certificate chain", err)
+	}
+	Signer, Chain, Chains = signer, chain, store
+	Verifier = trust.NewVerifier(provider)
+	return nil
+}
+
+// localRouter fails all requests for remote crypto material, such that the
+// trust provider only uses the local database.
+type localRouter struct{}
+
+func (localRouter) ChooseServer(_ context.Context, isd addr.ISD) (net.Addr, error) {
+	return nil, common.NewBasicError("Crypto material not available locally", nil,
+		"isd", isd)
+}
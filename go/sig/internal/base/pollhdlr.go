@@ -24,6 +24,7 @@ import (
 	"github.com/scionproto/scion/go/lib/log"
 	"github.com/scionproto/scion/go/lib/sigdisp"
 	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/sig/internal/fec"
 	"github.com/scionproto/scion/go/sig/internal/sigcmn"
 )
 
@@ -38,7 +39,13 @@ func PollReqHdlr() {
 		}
 		addr := sig_mgmt.NewAddr(addr.HostFromIP(sigcmn.CtrlAddr), uint16(sigcmn.CtrlPort),
 			addr.HostFromIP(sigcmn.DataAddr), uint16(sigcmn.DataPort))
-		spld, err := sig_mgmt.NewPld(rpld.Id, sig_mgmt.NewPollRep(addr, req.Session))
+		rep := sig_mgmt.NewPollRep(addr, req.Session)
+		// The ingress decodes FEC with any valid parameters, hence they are
+		// accepted as requested.
+		if req.FEC != nil && fecParams(req.FEC).Validate() == nil {
+			rep.FEC = req.FEC
+		}
+		spld, err := sig_mgmt.NewPld(rpld.Id, rep)
 		if err != nil {
 			log.Error("PollReqHdlr: Error creating SIGCtrl payload", "err", err)
 			break
@@ -71,3 +78,7 @@ func PollReqHdlr() {
 	}
 	log.Info("PollReqHdlr: stopped")
 }
+
+func fecParams(f *sig_mgmt.FEC) fec.Params {
+	return fec.Params{DataFrames: int(f.DataFrames), ParityFrames: int(f.ParityFrames)}
+}
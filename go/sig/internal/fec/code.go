@@ -0,0 +1,181 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fec
+
+import (
+	"github.com/scionproto/scion/go/lib/serrors"
+)
+
+// gfExp and gfLog are the exponent and logarithm tables of GF(2^8) with the
+// primitive polynomial x^8 + x^4 + x^3 + x^2 + 1 and generator 2.
+var (
+	gfExp [510]byte
+	gfLog [256]int
+)
+
+func init() {
+	x := 1
+	for i := 0; i < 255; i++ {
+		gfExp[i] = byte(x)
+		gfExp[i+255] = byte(x)
+		gfLog[x] = i
+		x <<= 1
+		if x&0x100 != 0 {
+			x ^= 0x11d
+		}
+	}
+}
+
+func gfMul(a, b byte) byte {
+	if a == 0 || b == 0 {
+		return 0
+	}
+	return gfExp[gfLog[a]+gfLog[b]]
+}
+
+func gfInv(a byte) byte {
+	return gfExp[255-gfLog[a]]
+}
+
+// mulAdd adds c times src to dst.
+func mulAdd(dst, src []byte, c byte) {
+	if c == 0 {
+		return
+	}
+	logC := gfLog[c]
+	for i, s := range src {
+		if s != 0 {
+			dst[i] ^= gfExp[logC+gfLog[s]]
+		}
+	}
+}
+
+// code is a systematic Reed-Solomon erasure code over GF(2^8). The parity
+// shards are computed with a Cauchy matrix, such that the data shards can be
+// recovered from any k of the k+m shards.
+type code struct {
+	k, m   int
+	cauchy [][]byte
+}
+
+func newCode(k, m int) *code {
+	c := &code{k: k, m: m, cauchy: make([][]byte, m)}
+	for j := 0; j < m; j++ {
+		c.cauchy[j] = make([]byte, k)
+		for i := 0; i < k; i++ {
+			// The elements x_j = k+j and y_i = i are distinct, hence
+			// x_j + y_i is never 0.
+			c.cauchy[j][i] = gfInv(byte(k+j) ^ byte(i))
+		}
+	}
+	return c
+}
+
+// encode returns the m parity shards of the k data shards, which must have
+// the same length.
+func (c *code) encode(data [][]byte) [][]byte {
+	parity := make([][]byte, c.m)
+	for j := range parity {
+		parity[j] = make([]byte, len(data[0]))
+		for i, d := range data {
+			mulAdd(parity[j], d, c.cauchy[j][i])
+		}
+	}
+	return parity
+}
+
+// reconstruct recovers the missing data shards, which are nil. Missing
+// parity shards are nil as well. It returns an error if less than k shards
+// are present.
+func (c *code) reconstruct(data, parity [][]byte) error {
+	var rows [][]byte
+	var shards [][]byte
+	missing := false
+	for i, d := range data {
+		if d == nil {
+			missing = true
+			continue
+		}
+		row := make([]byte, c.k)
+		row[i] = 1
+		rows, shards = append(rows, row), append(shards, d)
+	}
+	if !missing {
+		return nil
+	}
+	for j, p := range parity {
+		if len(rows) == c.k {
+			break
+		}
+		if p != nil {
+			rows, shards = append(rows, c.cauchy[j]), append(shards, p)
+		}
+	}
+	if len(rows) < c.k {
+		return serrors.New("not enough shards", "have", len(rows), "need", c.k)
+	}
+	inv, err := invert(rows)
+	if err != nil {
+		return err
+	}
+	for i := range data {
+		if data[i] != nil {
+			continue
+		}
+		data[i] = make([]byte, len(shards[0]))
+		for r, s := range shards {
+			mulAdd(data[i], s, inv[i][r])
+		}
+	}
+	return nil
+}
+
+// invert returns the inverse of the square matrix, by Gauss-Jordan
+// elimination.
+func invert(matrix [][]byte) ([][]byte, error) {
+	n := len(matrix)
+	a := make([][]byte, n)
+	inv := make([][]byte, n)
+	for i := range matrix {
+		a[i] = append([]byte(nil), matrix[i]...)
+		inv[i] = make([]byte, n)
+		inv[i][i] = 1
+	}
+	for col := 0; col < n; col++ {
+		pivot := col
+		for pivot < n && a[pivot][col] == 0 {
+			pivot++
+		}
+		if pivot == n {
+			return nil, serrors.New("singular matrix")
+		}
+		a[col], a[pivot] = a[pivot], a[col]
+		inv[col], inv[pivot] = inv[pivot], inv[col]
+		scale := gfInv(a[col][col])
+		for i := 0; i < n; i++ {
+			a[col][i] = gfMul(a[col][i], scale)
+			inv[col][i] = gfMul(inv[col][i], scale)
+		}
+		for row := 0; row < n; row++ {
+			if row == col || a[row][col] == 0 {
+				continue
+			}
+			f := a[row][col]
+			mulAdd(a[row], a[col], f)
+			mulAdd(inv[row], inv[col], f)
+		}
+	}
+	return inv, nil
+}
@@ -0,0 +1,358 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package fec implements forward error correction for SIG frames.
+//
+// The data frames of an epoch are protected in groups of consecutive frames.
+// After each group, the sender sends parity frames computed with a
+// Reed-Solomon code. The receiver recovers the lost data frames of a group
+// if the number of received parity frames is at least the number of lost data
+// frames.
+//
+// Parity frames have the SIG frame header of the first data frame of their
+// group, with the index set to ParityIndex, followed by the FEC header:
+//
+//   0B       1        2        3
+//   +--------+--------+--------+--------+
+//   |  Data  | Parity | P. Idx |  Rsvd  |
+//   +--------+--------+--------+--------+
+//
+// Data is the number of data frames in the group, Parity the number of parity
+// frames, and P. Idx the index of the parity frame in the group. The header is
+// followed by the parity of the data frames' symbols. The symbol of a data
+// frame is its index and payload length (2B each), followed by the payload.
+//
+// Data frames protected by FEC have the ProtectedFlag set in their index.
+package fec
+
+import (
+	"fmt"
+
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/sig/internal/sigcmn"
+)
+
+const (
+	// MaxDataFrames is the maximum number of data frames per group.
+	MaxDataFrames = 64
+	// MaxParityFrames is the maximum number of parity frames per group.
+	MaxParityFrames = 16
+	// ParityIndex is the index of parity frames in the SIG frame header.
+	ParityIndex = 0xffff
+	// ProtectedFlag is set in the index of data frames protected by FEC.
+	ProtectedFlag = 0x8000
+	// HdrLen is the length of the FEC header of parity frames.
+	HdrLen = 4
+	// Overhead is the number of bytes by which data frames must be shorter
+	// than the path MTU allows, such that their parity frames fit.
+	Overhead = HdrLen + symbolHdrLen
+
+	symbolHdrLen = 4
+	// window is the number of data frames kept for recovery.
+	window = 2 * MaxDataFrames
+)
+
+// Params are the FEC parameters of a session.
+type Params struct {
+	// DataFrames is the number of data frames per group.
+	DataFrames int
+	// ParityFrames is the number of parity frames per group.
+	ParityFrames int
+}
+
+// Validate checks that the group size is supported.
+func (p Params) Validate() error {
+	if p.DataFrames < 1 || p.DataFrames > MaxDataFrames {
+		return serrors.New("invalid number of data frames", "data", p.DataFrames,
+			"max", MaxDataFrames)
+	}
+	if p.ParityFrames < 1 || p.ParityFrames > MaxParityFrames {
+		return serrors.New("invalid number of parity frames", "parity", p.ParityFrames,
+			"max", MaxParityFrames)
+	}
+	return nil
+}
+
+func (p Params) String() string {
+	return fmt.Sprintf("%d+%d", p.DataFrames, p.ParityFrames)
+}
+
+// IsParity returns whether the SIG frame is a parity frame.
+func IsParity(frame common.RawBytes) bool {
+	return len(frame) >= sigcmn.SIGHdrSize && common.Order.Uint16(frame[6:8]) == ParityIndex
+}
+
+// ParityHdr is the group information of a parity frame.
+type ParityHdr struct {
+	// Start is the sequence number of the first data frame of the group.
+	Start int
+	// DataFrames and ParityFrames are the number of frames in the group.
+	DataFrames   int
+	ParityFrames int
+	// Index is the index of the parity frame in the group.
+	Index int
+}
+
+// ParseParity parses the headers of a parity frame.
+func ParseParity(frame common.RawBytes) (ParityHdr, error) {
+	if len(frame) < sigcmn.SIGHdrSize+HdrLen {
+		return ParityHdr{}, serrors.New("parity frame too short", "len", len(frame))
+	}
+	h := ParityHdr{
+		Start:        seq(frame),
+		DataFrames:   int(frame[sigcmn.SIGHdrSize]),
+		ParityFrames: int(frame[sigcmn.SIGHdrSize+1]),
+		Index:        int(frame[sigcmn.SIGHdrSize+2]),
+	}
+	p := Params{DataFrames: h.DataFrames, ParityFrames: h.ParityFrames}
+	if err := p.Validate(); err != nil {
+		return ParityHdr{}, err
+	}
+	if h.Index >= h.ParityFrames {
+		return ParityHdr{}, serrors.New("invalid parity index", "index", h.Index,
+			"parity", h.ParityFrames)
+	}
+	return h, nil
+}
+
+func seq(frame common.RawBytes) int {
+	return int(common.Order.UintN(frame[3:6], 3))
+}
+
+// symbol returns the symbol of a data frame.
+func symbol(frame common.RawBytes) []byte {
+	payload := frame[sigcmn.SIGHdrSize:]
+	s := make([]byte, symbolHdrLen+len(payload))
+	copy(s[0:2], frame[6:8])
+	common.Order.PutUint16(s[2:4], uint16(len(payload)))
+	copy(s[symbolHdrLen:], payload)
+	return s
+}
+
+// frameFromSymbol returns the data frame with the symbol, and the header
+// fields from hdr.
+func frameFromSymbol(s []byte, hdr common.RawBytes, seq int) (common.RawBytes, error) {
+	l := int(common.Order.Uint16(s[2:4]))
+	if symbolHdrLen+l > len(s) {
+		return nil, serrors.New("invalid recovered symbol", "len", l, "size", len(s))
+	}
+	frame := make(common.RawBytes, sigcmn.SIGHdrSize+l)
+	copy(frame[0:3], hdr[0:3])
+	common.Order.PutUintN(frame[3:6], uint64(seq), 3)
+	copy(frame[6:8], s[0:2])
+	copy(frame[sigcmn.SIGHdrSize:], s[symbolHdrLen:symbolHdrLen+l])
+	return frame, nil
+}
+
+// Encoder computes the parity frames of the groups of data frames sent in
+// one epoch. An encoder is not safe for concurrent use.
+type Encoder struct {
+	params  Params
+	code    *code
+	hdr     [sigcmn.SIGHdrSize]byte
+	symbols [][]byte
+}
+
+// NewEncoder creates an encoder for valid parameters.
+func NewEncoder(p Params) *Encoder {
+	return &Encoder{params: p, code: newCode(p.DataFrames, p.ParityFrames)}
+}
+
+// Params returns the parameters of the encoder.
+func (e *Encoder) Params() Params {
+	return e.params
+}
+
+// Len returns the number of data frames in the current group.
+func (e *Encoder) Len() int {
+	return len(e.symbols)
+}
+
+// Add adds a data frame, with its SIG frame header, to the current group. It
+// returns true if the group is complete.
+func (e *Encoder) Add(frame common.RawBytes) bool {
+	if len(e.symbols) == 0 {
+		copy(e.hdr[:], frame[:sigcmn.SIGHdrSize])
+	}
+	e.symbols = append(e.symbols, symbol(frame))
+	return len(e.symbols) >= e.params.DataFrames
+}
+
+// Reset drops the data frames of the current group, and starts a new group.
+func (e *Encoder) Reset() {
+	e.symbols = e.symbols[:0]
+}
+
+// Parity returns the parity frames of the current group, and starts a new
+// group. A partial group has fewer data frames, but as many parity frames as
+// a complete group.
+func (e *Encoder) Parity() []common.RawBytes {
+	k := len(e.symbols)
+	if k == 0 {
+		return nil
+	}
+	size := 0
+	for _, s := range e.symbols {
+		if len(s) > size {
+			size = len(s)
+		}
+	}
+	shards := make([][]byte, k)
+	for i, s := range e.symbols {
+		shards[i] = make([]byte, size)
+		copy(shards[i], s)
+	}
+	c := e.code
+	if k != e.params.DataFrames {
+		c = newCode(k, e.params.ParityFrames)
+	}
+	parity := c.encode(shards)
+	frames := make([]common.RawBytes, len(parity))
+	for j, p := range parity {
+		f := make(common.RawBytes, sigcmn.SIGHdrSize+HdrLen+len(p))
+		copy(f, e.hdr[:])
+		common.Order.PutUint16(f[6:8], ParityIndex)
+		f[sigcmn.SIGHdrSize] = byte(k)
+		f[sigcmn.SIGHdrSize+1] = byte(e.params.ParityFrames)
+		f[sigcmn.SIGHdrSize+2] = byte(j)
+		copy(f[sigcmn.SIGHdrSize+HdrLen:], p)
+		frames[j] = f
+	}
+	e.Reset()
+	return frames
+}
+
+type group struct {
+	hdr    ParityHdr
+	parity [][]byte
+	done   bool
+}
+
+// Decoder recovers the lost data frames of one epoch. A decoder is not safe
+// for concurrent use.
+type Decoder struct {
+	symbols map[int][]byte
+	groups  map[int]*group
+	newest  int
+}
+
+// NewDecoder creates a decoder without frames.
+func NewDecoder() *Decoder {
+	return &Decoder{
+		symbols: make(map[int][]byte),
+		groups:  make(map[int]*group),
+	}
+}
+
+// AddData adds a received data frame, with its SIG frame header. It is kept
+// for the recovery of the other frames of its group.
+func (d *Decoder) AddData(frame common.RawBytes) {
+	s := seq(frame)
+	d.symbols[s] = symbol(frame)
+	d.advance(s)
+}
+
+func (d *Decoder) advance(s int) {
+	if s <= d.newest {
+		return
+	}
+	d.newest = s
+	if len(d.symbols) <= 2*window {
+		return
+	}
+	for s := range d.symbols {
+		if s < d.newest-window {
+			delete(d.symbols, s)
+		}
+	}
+	for start := range d.groups {
+		if start < d.newest-window {
+			delete(d.groups, start)
+		}
+	}
+}
+
+// AddParity adds a received parity frame. It returns the data frames of the
+// group recovered with it, if any. Frames can be recovered once the number of
+// received parity frames of the group is at least the number of lost data
+// frames.
+func (d *Decoder) AddParity(frame common.RawBytes) ([]common.RawBytes, error) {
+	h, err := ParseParity(frame)
+	if err != nil {
+		return nil, err
+	}
+	g, ok := d.groups[h.Start]
+	if !ok || g.hdr.DataFrames != h.DataFrames || g.hdr.ParityFrames != h.ParityFrames {
+		g = &group{hdr: h, parity: make([][]byte, h.ParityFrames)}
+		d.groups[h.Start] = g
+	}
+	d.advance(h.Start)
+	g.parity[h.Index] = append([]byte(nil), frame[sigcmn.SIGHdrSize+HdrLen:]...)
+	if g.done {
+		return nil, nil
+	}
+	size := len(g.parity[h.Index])
+	data := make([][]byte, h.DataFrames)
+	missing := 0
+	for i := range data {
+		s, ok := d.symbols[h.Start+i]
+		if !ok {
+			missing++
+			continue
+		}
+		if len(s) > size {
+			return nil, serrors.New("data frame longer than parity", "seq", h.Start+i,
+				"len", len(s), "parity", size)
+		}
+		data[i] = make([]byte, size)
+		copy(data[i], s)
+	}
+	if missing == 0 {
+		g.done = true
+		return nil, nil
+	}
+	parity := make([][]byte, len(g.parity))
+	received := 0
+	for j, p := range g.parity {
+		if len(p) == size {
+			parity[j] = p
+			received++
+		}
+	}
+	if received < missing {
+		return nil, nil
+	}
+	lost := make([]bool, len(data))
+	for i := range data {
+		lost[i] = data[i] == nil
+	}
+	if err := newCode(h.DataFrames, h.ParityFrames).reconstruct(data, parity); err != nil {
+		return nil, err
+	}
+	g.done = true
+	var recovered []common.RawBytes
+	for i, s := range data {
+		if !lost[i] {
+			continue
+		}
+		f, err := frameFromSymbol(s, frame, h.Start+i)
+		if err != nil {
+			return recovered, err
+		}
+		d.symbols[h.Start+i] = symbol(f)
+		recovered = append(recovered, f)
+	}
+	return recovered, nil
+}
@@ -0,0 +1,138 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fec
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/common"
+)
+
+func TestCodeReconstruct(t *testing.T) {
+	for _, p := range []Params{{1, 1}, {4, 2}, {10, 4}, {MaxDataFrames, MaxParityFrames}} {
+		t.Run(p.String(), func(t *testing.T) {
+			c := newCode(p.DataFrames, p.ParityFrames)
+			data := make([][]byte, p.DataFrames)
+			for i := range data {
+				data[i] = make([]byte, 32)
+				rand.Read(data[i])
+			}
+			parity := c.encode(data)
+			require.Len(t, parity, p.ParityFrames)
+			// Lose as many data shards as there are parity shards.
+			lossy := make([][]byte, len(data))
+			copy(lossy, data)
+			for _, i := range rand.Perm(len(data))[:min(p.ParityFrames, len(data))] {
+				lossy[i] = nil
+			}
+			require.NoError(t, c.reconstruct(lossy, parity))
+			assert.Equal(t, data, lossy)
+		})
+	}
+	t.Run("not enough shards", func(t *testing.T) {
+		c := newCode(4, 1)
+		data := [][]byte{{1}, {2}, {3}, {4}}
+		parity := c.encode(data)
+		assert.Error(t, c.reconstruct([][]byte{nil, nil, {3}, {4}}, parity))
+	})
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// dataFrame returns a data frame with the sequence number and a payload of
+// length l.
+func dataFrame(seq, l int) common.RawBytes {
+	f := make(common.RawBytes, 8+l)
+	f[0] = 3
+	common.Order.PutUint16(f[1:3], 0xbeef)
+	common.Order.PutUintN(f[3:6], uint64(seq), 3)
+	common.Order.PutUint16(f[6:8], ProtectedFlag|1)
+	rand.Read(f[8:])
+	return f
+}
+
+func TestEncoderDecoder(t *testing.T) {
+	p := Params{DataFrames: 4, ParityFrames: 2}
+
+	t.Run("recover lost frames", func(t *testing.T) {
+		enc := NewEncoder(p)
+		frames := []common.RawBytes{dataFrame(10, 100), dataFrame(11, 1200),
+			dataFrame(12, 7), dataFrame(13, 500)}
+		for i, f := range frames {
+			assert.Equal(t, i == len(frames)-1, enc.Add(f))
+		}
+		parity := enc.Parity()
+		require.Len(t, parity, 2)
+		assert.Equal(t, 0, enc.Len())
+		for _, pf := range parity {
+			assert.True(t, IsParity(pf))
+			assert.Equal(t, 1200+8+Overhead, len(pf))
+		}
+		h, err := ParseParity(parity[1])
+		require.NoError(t, err)
+		assert.Equal(t, ParityHdr{Start: 10, DataFrames: 4, ParityFrames: 2, Index: 1}, h)
+
+		dec := NewDecoder()
+		dec.AddData(frames[0])
+		dec.AddData(frames[3])
+		recovered, err := dec.AddParity(parity[0])
+		require.NoError(t, err)
+		assert.Empty(t, recovered, "one parity frame cannot recover two frames")
+		recovered, err = dec.AddParity(parity[1])
+		require.NoError(t, err)
+		assert.Equal(t, []common.RawBytes{frames[1], frames[2]}, recovered)
+	})
+	t.Run("partial group", func(t *testing.T) {
+		enc := NewEncoder(p)
+		frames := []common.RawBytes{dataFrame(0, 50), dataFrame(1, 60)}
+		for _, f := range frames {
+			assert.False(t, enc.Add(f))
+		}
+		parity := enc.Parity()
+		require.Len(t, parity, 2)
+		dec := NewDecoder()
+		dec.AddData(frames[1])
+		recovered, err := dec.AddParity(parity[1])
+		require.NoError(t, err)
+		assert.Equal(t, []common.RawBytes{frames[0]}, recovered)
+	})
+	t.Run("no loss", func(t *testing.T) {
+		enc := NewEncoder(p)
+		dec := NewDecoder()
+		for i := 0; i < 4; i++ {
+			f := dataFrame(i, 20)
+			enc.Add(f)
+			dec.AddData(f)
+		}
+		for _, pf := range enc.Parity() {
+			recovered, err := dec.AddParity(pf)
+			assert.NoError(t, err)
+			assert.Empty(t, recovered)
+		}
+	})
+	t.Run("invalid parity", func(t *testing.T) {
+		_, err := NewDecoder().AddParity(common.RawBytes{0, 0, 0, 0, 0, 0, 0xff, 0xff})
+		assert.Error(t, err)
+	})
+}
@@ -26,6 +26,8 @@ import (
 	"github.com/scionproto/scion/go/sig/internal/sigcmn"
 )
 
+// Init starts the ingress. The decapsulated IP packets are written to tunIO,
+// one packet per Write.
 func Init(tunIO io.ReadWriteCloser) {
 	fatal.Check()
 	conn, err := sigcmn.Network.Listen(context.Background(), "udp",
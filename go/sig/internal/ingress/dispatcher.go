@@ -18,6 +18,7 @@ package ingress
 import (
 	"fmt"
 	"io"
+	"net"
 	"time"
 
 	"github.com/scionproto/scion/go/lib/addr"
@@ -37,17 +38,23 @@ const (
 	workerCleanupInterval = 60 * time.Second
 )
 
+// FrameReader reads encapsulated frames from the SCION network. It is
+// implemented by *snet.Conn.
+type FrameReader interface {
+	ReadFrom(b []byte) (int, net.Addr, error)
+}
+
 // Dispatcher reads new encapsulated packets, classifies the packet by
 // source ISD-AS -> source host Addr -> Sess Id and hands it off to the
 // appropriate Worker, starting a new one if none currently exists.
 type Dispatcher struct {
 	workers            map[string]*Worker
-	extConn            *snet.Conn
+	extConn            FrameReader
 	tunIO              io.ReadWriteCloser
 	framesRecvCounters map[metrics.CtrPairKey]metrics.CtrPair
 }
 
-func NewDispatcher(tio io.ReadWriteCloser, conn *snet.Conn) *Dispatcher {
+func NewDispatcher(tio io.ReadWriteCloser, conn FrameReader) *Dispatcher {
 	return &Dispatcher{
 		tunIO:              tio,
 		extConn:            conn,
@@ -0,0 +1,198 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ingress
+
+import (
+	"sort"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/ctrl/sig_mgmt"
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/ringbuf"
+	"github.com/scionproto/scion/go/sig/internal/fec"
+)
+
+const (
+	// fecHoldCap is the maximum number of frames held back while waiting for
+	// the recovery of a lost frame.
+	fecHoldCap = 2 * fec.MaxDataFrames
+	// fecHoldTimeout is the maximum time frames are held back while waiting
+	// for the recovery of a lost frame.
+	fecHoldTimeout = 100 * time.Millisecond
+)
+
+// fecCtrs are the FEC counters of a worker.
+type fecCtrs struct {
+	parityRecv    prometheus.Counter
+	recovered     prometheus.Counter
+	unrecoverable prometheus.Counter
+}
+
+// fecBuffer recovers the lost frames of one epoch of FEC protected frames.
+// Data frames are passed on to the reassembly list in order. Frames received
+// after a lost frame are held back, until the lost frame is recovered or the
+// recovery is given up.
+type fecBuffer struct {
+	dec       *fec.Decoder
+	rlist     *ReassemblyList
+	ctrs      *fecCtrs
+	next      int
+	held      map[int]*FrameBuf
+	heldSince time.Time
+}
+
+func newFECBuffer(rlist *ReassemblyList, ctrs *fecCtrs) *fecBuffer {
+	return &fecBuffer{
+		dec:   fec.NewDecoder(),
+		rlist: rlist,
+		ctrs:  ctrs,
+		next:  -1,
+		held:  make(map[int]*FrameBuf),
+	}
+}
+
+// insertData adds a protected data frame.
+func (b *fecBuffer) insertData(frame *FrameBuf) {
+	b.dec.AddData(frame.raw[:frame.frameLen])
+	b.deliver(frame)
+	b.checkTimeout()
+}
+
+// insertParity adds a parity frame, and releases it.
+func (b *fecBuffer) insertParity(frame *FrameBuf) {
+	defer frame.Release()
+	b.ctrs.parityRecv.Inc()
+	raw := frame.raw[:frame.frameLen]
+	hdr, err := fec.ParseParity(raw)
+	if err != nil {
+		log.Error("Invalid FEC parity frame", "err", err)
+		return
+	}
+	recovered, err := b.dec.AddParity(raw)
+	if err != nil {
+		log.Error("Unable to recover frames", "err", err)
+	}
+	for _, r := range recovered {
+		b.ctrs.recovered.Inc()
+		if fb := b.newFrameBuf(r); fb != nil {
+			b.deliver(fb)
+		}
+	}
+	if len(b.held) == 0 {
+		return
+	}
+	// The frames of the gap cannot be recovered anymore once the last parity
+	// frame of their group, or a parity frame of a later group, has arrived.
+	lastOfGroup := hdr.Index == hdr.ParityFrames-1 &&
+		b.next >= hdr.Start && b.next < hdr.Start+hdr.DataFrames
+	if lastOfGroup || hdr.Start > b.next {
+		b.giveUp()
+		return
+	}
+	b.checkTimeout()
+}
+
+// deliver passes the frame on to the reassembly list if it is the next one,
+// or holds it back otherwise.
+func (b *fecBuffer) deliver(frame *FrameBuf) {
+	switch {
+	case b.next == -1 || frame.seqNr == b.next:
+		b.rlist.Insert(frame)
+		b.next = frame.seqNr + 1
+		b.drain()
+	case frame.seqNr < b.next:
+		// Already delivered, e.g., a frame that was recovered and then
+		// arrived late.
+		frame.Release()
+	default:
+		if _, ok := b.held[frame.seqNr]; ok {
+			frame.Release()
+			return
+		}
+		if len(b.held) == 0 {
+			b.heldSince = time.Now()
+		}
+		b.held[frame.seqNr] = frame
+		if len(b.held) > fecHoldCap {
+			b.giveUp()
+		}
+	}
+}
+
+// drain delivers the held frames that directly follow the delivered ones.
+func (b *fecBuffer) drain() {
+	for {
+		frame, ok := b.held[b.next]
+		if !ok {
+			break
+		}
+		delete(b.held, b.next)
+		b.rlist.Insert(frame)
+		b.next++
+	}
+	if len(b.held) != 0 {
+		// The remaining frames wait for the next gap to be recovered.
+		b.heldSince = time.Now()
+	}
+}
+
+// giveUp passes all held frames on to the reassembly list, which discards the
+// incomplete packets.
+func (b *fecBuffer) giveUp() {
+	if len(b.held) == 0 {
+		return
+	}
+	b.ctrs.unrecoverable.Inc()
+	seqs := make([]int, 0, len(b.held))
+	for seq := range b.held {
+		seqs = append(seqs, seq)
+	}
+	sort.Ints(seqs)
+	for _, seq := range seqs {
+		b.rlist.Insert(b.held[seq])
+		delete(b.held, seq)
+	}
+	b.next = seqs[len(seqs)-1] + 1
+}
+
+func (b *fecBuffer) checkTimeout() {
+	if len(b.held) != 0 && time.Since(b.heldSince) > fecHoldTimeout {
+		b.giveUp()
+	}
+}
+
+// releaseAll releases all held frames.
+func (b *fecBuffer) releaseAll() {
+	for seq, frame := range b.held {
+		frame.Release()
+		delete(b.held, seq)
+	}
+}
+
+// newFrameBuf copies a recovered frame into a frame buffer.
+func (b *fecBuffer) newFrameBuf(raw common.RawBytes) *FrameBuf {
+	frames := make(ringbuf.EntryList, 1)
+	if n := NewFrameBufs(frames); n != 1 {
+		return nil
+	}
+	fb := frames[0].(*FrameBuf)
+	fb.frameLen = copy(fb.raw, raw)
+	fb.sessId = sig_mgmt.SessionType(fb.raw[0])
+	initFrame(fb, b.rlist.snd)
+	return fb
+}
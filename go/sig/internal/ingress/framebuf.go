@@ -17,6 +17,7 @@ package ingress
 
 import (
 	"fmt"
+	"sync"
 
 	"github.com/scionproto/scion/go/lib/common"
 	"github.com/scionproto/scion/go/lib/ctrl/sig_mgmt"
@@ -34,13 +35,12 @@ const (
 
 var (
 	// Cache of the frame buffers free to be used.
-	freeFrames *ringbuf.Ring
+	freeFrames     *ringbuf.Ring
+	freeFramesOnce sync.Once
 )
 
 func NewFrameBufs(frames ringbuf.EntryList) int {
-	if freeFrames == nil {
-		initFreeFrames()
-	}
+	freeFramesOnce.Do(initFreeFrames)
 	n, _ := freeFrames.Read(frames, true)
 	return n
 }
@@ -25,6 +25,7 @@ import (
 	"github.com/scionproto/scion/go/lib/log"
 	"github.com/scionproto/scion/go/lib/ringbuf"
 	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/sig/internal/fec"
 	"github.com/scionproto/scion/go/sig/internal/metrics"
 )
 
@@ -46,6 +47,8 @@ type Worker struct {
 	SessId           sig_mgmt.SessionType
 	Ring             *ringbuf.Ring
 	rlists           map[int]*ReassemblyList
+	fecBufs          map[int]*fecBuffer
+	fecCtrs          fecCtrs
 	markedForCleanup bool
 	sentCtrs         metrics.CtrPair
 	tunIO            io.ReadWriteCloser
@@ -55,17 +58,26 @@ func NewWorker(remote *snet.UDPAddr, sessId sig_mgmt.SessionType,
 	tunIO io.ReadWriteCloser) *Worker {
 
 	worker := &Worker{
-		Logger: log.New("ingress", remote.String(), "sessId", sessId),
-		Remote: remote,
-		SessId: sessId,
-		Ring:   ringbuf.New(64, nil, fmt.Sprintf("ingress_%s_%s", remote.IA, sessId)),
-		rlists: make(map[int]*ReassemblyList),
+		Logger:  log.New("ingress", remote.String(), "sessId", sessId),
+		Remote:  remote,
+		SessId:  sessId,
+		Ring:    ringbuf.New(64, nil, fmt.Sprintf("ingress_%s_%s", remote.IA, sessId)),
+		rlists:  make(map[int]*ReassemblyList),
+		fecBufs: make(map[int]*fecBuffer),
 		sentCtrs: metrics.CtrPair{
 			Pkts: metrics.PktsSent.WithLabelValues(remote.IA.String(),
 				sessId.String()),
 			Bytes: metrics.PktBytesSent.WithLabelValues(remote.IA.String(),
 				sessId.String()),
 		},
+		fecCtrs: fecCtrs{
+			parityRecv: metrics.FECParityFramesRecv.WithLabelValues(remote.IA.String(),
+				sessId.String()),
+			recovered: metrics.FECFramesRecovered.WithLabelValues(remote.IA.String(),
+				sessId.String()),
+			unrecoverable: metrics.FECUnrecoverable.WithLabelValues(remote.IA.String(),
+				sessId.String()),
+		},
 		tunIO: tunIO,
 	}
 	return worker
@@ -102,25 +114,43 @@ func (w *Worker) Run() {
 
 // processFrame processes a SIG frame by first writing all completely contained
 // packets to the wire and then adding the frame to the corresponding reassembly
-// list if needed.
+// list if needed. Frames protected by FEC are passed through the FEC buffer of
+// the epoch, which recovers lost frames.
 func (w *Worker) processFrame(frame *FrameBuf) {
 	epoch := int(common.Order.Uint16(frame.raw[1:3]))
+	if fec.IsParity(frame.raw[:frame.frameLen]) {
+		w.getFECBuf(epoch).insertParity(frame)
+		return
+	}
+	protected := initFrame(frame, w)
+	//w.Debug("Received Frame", "seqNr", frame.seqNr, "index", frame.index, "epoch", epoch,
+	//	"len", frame.frameLen)
+	if protected {
+		w.getFECBuf(epoch).insertData(frame)
+		return
+	}
+	// Add to frame buf reassembly list.
+	rlist := w.getRlist(epoch)
+	rlist.Insert(frame)
+}
+
+// initFrame initializes the frame buffer from the header of the data frame. It
+// returns whether the frame is protected by FEC.
+func initFrame(frame *FrameBuf, snd sender) bool {
 	seqNr := int(common.Order.UintN(frame.raw[3:6], 3))
 	index := int(common.Order.Uint16(frame.raw[6:8]))
+	protected := index&fec.ProtectedFlag != 0
+	index &^= fec.ProtectedFlag
 	frame.seqNr = seqNr
 	frame.index = index
-	frame.snd = w
-	//w.Debug("Received Frame", "seqNr", seqNr, "index", index, "epoch", epoch,
-	//	"len", frame.frameLen)
+	frame.snd = snd
 	// If index == 1 then we can be sure that there is no fragment at the beginning
 	// of the frame.
 	frame.fragNProcessed = index == 1
 	// If index == 0 then we can be sure that there are no complete packets in this
 	// frame.
 	frame.completePktsProcessed = index == 0
-	// Add to frame buf reassembly list.
-	rlist := w.getRlist(epoch)
-	rlist.Insert(frame)
+	return protected
 }
 
 func (w *Worker) getRlist(epoch int) *ReassemblyList {
@@ -133,6 +163,16 @@ func (w *Worker) getRlist(epoch int) *ReassemblyList {
 	return rlist
 }
 
+func (w *Worker) getFECBuf(epoch int) *fecBuffer {
+	buf, ok := w.fecBufs[epoch]
+	if !ok {
+		buf = newFECBuffer(w.getRlist(epoch), &w.fecCtrs)
+		w.fecBufs[epoch] = buf
+	}
+	buf.rlist.markedForDeletion = false
+	return buf
+}
+
 func (w *Worker) cleanup() {
 	for epoch := range w.rlists {
 		rlist := w.rlists[epoch]
@@ -141,11 +181,19 @@ func (w *Worker) cleanup() {
 			// Remove the reassembly list from the map and then release all frames
 			// back to the bufpool.
 			delete(w.rlists, epoch)
+			buf := w.fecBufs[epoch]
+			delete(w.fecBufs, epoch)
 			go func() {
 				defer log.HandlePanic()
 				rlist.removeAll()
+				if buf != nil {
+					buf.releaseAll()
+				}
 			}()
 		} else {
+			if buf, ok := w.fecBufs[epoch]; ok {
+				buf.checkTimeout()
+			}
 			// Mark the reassembly list for deletion. If it is not accessed between now
 			// and the next cleanup interval, it will be removed.
 			rlist.markedForDeletion = true
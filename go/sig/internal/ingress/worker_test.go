@@ -20,9 +20,12 @@ import (
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/scionproto/scion/go/lib/common"
 	"github.com/scionproto/scion/go/lib/ringbuf"
 	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/lib/util"
 	"github.com/scionproto/scion/go/lib/xtest"
+	"github.com/scionproto/scion/go/sig/internal/fec"
 )
 
 type MockTun struct {
@@ -125,3 +128,56 @@ func TestParsing(t *testing.T) {
 	mt.AssertPacket(t, []byte{201, 202, 203})
 	mt.AssertDone(t)
 }
+
+// protectedFrame returns an FEC protected frame with a single packet.
+func protectedFrame(seq int, pkt ...byte) common.RawBytes {
+	f := common.RawBytes{1, 0, 2, 0, 0, byte(seq), 0x80, 1, 0, byte(len(pkt))}
+	f = append(f, pkt...)
+	return append(f, make(common.RawBytes, util.CalcPadding(len(f), 8))...)
+}
+
+func TestFEC(t *testing.T) {
+	addr := &snet.UDPAddr{
+		IA: xtest.MustParseIA("1-ff00:0:300"),
+		Host: &net.UDPAddr{
+			IP:   net.IP{192, 168, 1, 1},
+			Port: 80,
+		},
+	}
+	mt := &MockTun{}
+	w := NewWorker(addr, 1, mt)
+	enc := fec.NewEncoder(fec.Params{DataFrames: 4, ParityFrames: 1})
+
+	// A lost frame is recovered, and the frames after it are held back until
+	// then.
+	var frames []common.RawBytes
+	for i := 0; i < 4; i++ {
+		frames = append(frames, protectedFrame(i, byte(10+i), byte(20+i), byte(30+i)))
+		enc.Add(frames[i])
+	}
+	for _, i := range []int{0, 2, 3} {
+		SendFrame(t, w, frames[i])
+	}
+	mt.AssertPacket(t, []byte{10, 20, 30})
+	mt.AssertDone(t)
+	SendFrame(t, w, enc.Parity()[0])
+	for i := 1; i < 4; i++ {
+		mt.AssertPacket(t, []byte{byte(10 + i), byte(20 + i), byte(30 + i)})
+	}
+	mt.AssertDone(t)
+
+	// More lost frames than parity frames cannot be recovered. The held back
+	// frames are passed on once the parity has arrived.
+	frames = frames[:0]
+	for i := 4; i < 8; i++ {
+		frames = append(frames, protectedFrame(i, byte(10+i)))
+		enc.Add(frames[i-4])
+	}
+	SendFrame(t, w, frames[0])
+	SendFrame(t, w, frames[3])
+	mt.AssertPacket(t, []byte{14})
+	mt.AssertDone(t)
+	SendFrame(t, w, enc.Parity()[0])
+	mt.AssertPacket(t, []byte{17})
+	mt.AssertDone(t)
+}
@@ -0,0 +1,224 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memtun_test
+
+import (
+	"encoding/binary"
+	"io"
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/ctrl/sig_mgmt"
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/ringbuf"
+	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/lib/xtest"
+	"github.com/scionproto/scion/go/sig/egress/iface"
+	"github.com/scionproto/scion/go/sig/egress/reader"
+	"github.com/scionproto/scion/go/sig/egress/router"
+	"github.com/scionproto/scion/go/sig/egress/worker"
+	"github.com/scionproto/scion/go/sig/internal/ingress"
+	"github.com/scionproto/scion/go/sig/internal/memtun"
+)
+
+func TestMain(m *testing.M) {
+	log.Discard()
+	os.Exit(m.Run())
+}
+
+// TestLoopback sends IPv4 and IPv6 packets between the hosts behind two SIGs.
+// The egress of each SIG is connected to the ingress of the other one over a
+// loopback network, instead of SCION.
+func TestLoopback(t *testing.T) {
+	iface.Init()
+	devA, devB := memtun.New(memtun.DefaultQueueLen), memtun.New(memtun.DefaultQueueLen)
+	defer devA.Close()
+	defer devB.Close()
+	iaA, iaB := xtest.MustParseIA("1-ff00:0:110"), xtest.MustParseIA("1-ff00:0:111")
+	netsA := []*net.IPNet{mustParseCIDR(t, "10.1.0.0/16"),
+		mustParseCIDR(t, "2001:db8:1::/48")}
+	netsB := []*net.IPNet{mustParseCIDR(t, "10.2.0.0/16"),
+		mustParseCIDR(t, "2001:db8:2::/48")}
+	defer connect(t, iaA, iaB, devB, netsB)()
+	defer connect(t, iaB, iaA, devA, netsA)()
+	for _, dev := range []*memtun.Device{devA, devB} {
+		go reader.NewReader(dev.SIG).Run()
+	}
+
+	tests := map[string]struct {
+		From, To *memtun.Device
+		Pkt      common.RawBytes
+	}{
+		"IPv4 A to B": {
+			From: devA,
+			To:   devB,
+			Pkt:  ipv4Pkt(net.ParseIP("10.1.0.1"), net.ParseIP("10.2.0.1"), 100),
+		},
+		"IPv4 B to A": {
+			From: devB,
+			To:   devA,
+			Pkt:  ipv4Pkt(net.ParseIP("10.2.0.1"), net.ParseIP("10.1.0.1"), 100),
+		},
+		"IPv6 A to B": {
+			From: devA,
+			To:   devB,
+			Pkt:  ipv6Pkt(net.ParseIP("2001:db8:1::1"), net.ParseIP("2001:db8:2::1"), 100),
+		},
+		"large IPv4 A to B": {
+			From: devA,
+			To:   devB,
+			Pkt:  ipv4Pkt(net.ParseIP("10.1.0.1"), net.ParseIP("10.2.0.1"), 3000),
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := test.From.Host.Write(test.Pkt)
+			require.NoError(t, err)
+			assert.Equal(t, test.Pkt, readPkt(t, test.To.Host))
+		})
+	}
+}
+
+// connect sets up the egress from the SIG in srcIA towards the nets of dstIA,
+// and delivers the frames to the ingress of the SIG of the dst device. It
+// returns a function that tears down the egress.
+func connect(t *testing.T, srcIA, dstIA addr.IA, dst *memtun.Device,
+	nets []*net.IPNet) func() {
+
+	ring := ringbuf.New(64, nil, "egress_"+dstIA.String())
+	for _, n := range nets {
+		require.NoError(t, router.NetMap.Add(n, dstIA, ring))
+	}
+	conn := &loopback{
+		src: &snet.UDPAddr{
+			IA:   srcIA,
+			Host: &net.UDPAddr{IP: net.IP{127, 0, 0, 1}, Port: 30056},
+		},
+		frames: make(chan common.RawBytes, 64),
+		closed: make(chan struct{}),
+	}
+	go worker.NewWorker(&session{ia: dstIA, ring: ring}, conn, true, log.New()).Run()
+	go ingress.NewDispatcher(dst.SIG, conn).Run()
+	return func() {
+		for _, n := range nets {
+			router.NetMap.Delete(n)
+		}
+		ring.Close()
+		conn.Close()
+	}
+}
+
+func readPkt(t *testing.T, end *memtun.End) common.RawBytes {
+	pkts := make(chan common.RawBytes, 1)
+	go func() {
+		buf := make(common.RawBytes, 9000)
+		n, err := end.Read(buf)
+		if err == nil {
+			pkts <- buf[:n]
+		}
+	}()
+	select {
+	case pkt := <-pkts:
+		return pkt
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for packet")
+		return nil
+	}
+}
+
+func mustParseCIDR(t *testing.T, s string) *net.IPNet {
+	_, n, err := net.ParseCIDR(s)
+	require.NoError(t, err)
+	return n
+}
+
+func ipv4Pkt(src, dst net.IP, payloadLen int) common.RawBytes {
+	pkt := make(common.RawBytes, 20+payloadLen)
+	pkt[0] = 0x45
+	binary.BigEndian.PutUint16(pkt[2:], uint16(len(pkt)))
+	copy(pkt[12:16], src.To4())
+	copy(pkt[16:20], dst.To4())
+	for i := 20; i < len(pkt); i++ {
+		pkt[i] = byte(i)
+	}
+	return pkt
+}
+
+func ipv6Pkt(src, dst net.IP, payloadLen int) common.RawBytes {
+	pkt := make(common.RawBytes, 40+payloadLen)
+	pkt[0] = 0x60
+	binary.BigEndian.PutUint16(pkt[4:], uint16(payloadLen))
+	copy(pkt[8:24], src.To16())
+	copy(pkt[24:40], dst.To16())
+	for i := 40; i < len(pkt); i++ {
+		pkt[i] = byte(i)
+	}
+	return pkt
+}
+
+// loopback delivers the frames written by an egress worker to an ingress
+// dispatcher.
+type loopback struct {
+	src    *snet.UDPAddr
+	frames chan common.RawBytes
+	closed chan struct{}
+}
+
+func (l *loopback) WriteTo(b []byte, _ net.Addr) (int, error) {
+	select {
+	case l.frames <- append(common.RawBytes(nil), b...):
+		return len(b), nil
+	case <-l.closed:
+		return 0, io.EOF
+	}
+}
+
+func (l *loopback) ReadFrom(b []byte) (int, net.Addr, error) {
+	select {
+	case frame := <-l.frames:
+		return copy(b, frame), l.src, nil
+	case <-l.closed:
+		return 0, nil, io.EOF
+	}
+}
+
+// Close unblocks pending reads and writes.
+func (l *loopback) Close() error {
+	close(l.closed)
+	return nil
+}
+
+type session struct {
+	ia   addr.IA
+	ring *ringbuf.Ring
+}
+
+func (s *session) Logger() log.Logger        { return log.New() }
+func (s *session) IA() addr.IA               { return s.ia }
+func (s *session) ID() sig_mgmt.SessionType  { return 0 }
+func (s *session) Conn() *snet.Conn          { return nil }
+func (s *session) Ring() *ringbuf.Ring       { return s.ring }
+func (s *session) Remote() *iface.RemoteInfo { return nil }
+func (s *session) Cleanup() error            { return nil }
+func (s *session) Healthy() bool             { return true }
+func (s *session) PathPool() iface.PathPool  { return nil }
+func (s *session) AnnounceWorkerStopped()    {}
@@ -0,0 +1,114 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package memtun implements an in-process replacement for the TUN device of
+// the SIG.
+//
+// A device has two ends. The SIG end is passed to the egress reader and the
+// ingress writer in place of the TUN device. The host end is used by the
+// local application, e.g., a test, to exchange IP packets with the SIG. As for
+// a TUN device, each Read returns a single packet and each Write takes a
+// single packet. Creating a device requires no privileges.
+package memtun
+
+import (
+	"io"
+	"sync"
+
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/serrors"
+)
+
+// DefaultQueueLen is the default number of packets queued in each direction.
+const DefaultQueueLen = 1000
+
+// ErrClosed is returned when writing to a closed device.
+var ErrClosed = serrors.New("device closed")
+
+// Device is an in-process TUN device.
+type Device struct {
+	// SIG is the end used by the SIG.
+	SIG *End
+	// Host is the end used by the local application.
+	Host *End
+}
+
+// New creates a device with queueLen packets queued in each direction.
+func New(queueLen int) *Device {
+	d := &device{
+		toHost:   make(chan common.RawBytes, queueLen),
+		fromHost: make(chan common.RawBytes, queueLen),
+		closed:   make(chan struct{}),
+	}
+	return &Device{
+		SIG:  &End{dev: d, in: d.fromHost, out: d.toHost},
+		Host: &End{dev: d, in: d.toHost, out: d.fromHost},
+	}
+}
+
+// Close closes both ends of the device.
+func (d *Device) Close() error {
+	return d.SIG.Close()
+}
+
+type device struct {
+	toHost    chan common.RawBytes
+	fromHost  chan common.RawBytes
+	closeOnce sync.Once
+	closed    chan struct{}
+}
+
+var _ io.ReadWriteCloser = (*End)(nil)
+
+// End is one end of a device. It is safe for concurrent use.
+type End struct {
+	dev *device
+	in  chan common.RawBytes
+	out chan common.RawBytes
+}
+
+// Read reads the next packet. If b is too small, the packet is truncated. It
+// blocks until a packet is available, and returns io.EOF once the device is
+// closed.
+func (e *End) Read(b []byte) (int, error) {
+	select {
+	case pkt := <-e.in:
+		return copy(b, pkt), nil
+	case <-e.dev.closed:
+		return 0, io.EOF
+	}
+}
+
+// Write writes a single packet. It blocks while the queue towards the other
+// end is full.
+func (e *End) Write(b []byte) (int, error) {
+	pkt := append(common.RawBytes(nil), b...)
+	select {
+	case <-e.dev.closed:
+		return 0, ErrClosed
+	default:
+	}
+	select {
+	case e.out <- pkt:
+		return len(b), nil
+	case <-e.dev.closed:
+		return 0, ErrClosed
+	}
+}
+
+// Close closes the device, i.e., both ends.
+func (e *End) Close() error {
+	e.dev.closeOnce.Do(func() { close(e.dev.closed) })
+	return nil
+}
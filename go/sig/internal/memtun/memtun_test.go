@@ -0,0 +1,78 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memtun_test
+
+import (
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/sig/internal/memtun"
+)
+
+func TestDevice(t *testing.T) {
+	dev := memtun.New(2)
+	buf := make([]byte, 16)
+
+	t.Run("packets are delivered to the other end", func(t *testing.T) {
+		_, err := dev.Host.Write([]byte{1, 2, 3})
+		require.NoError(t, err)
+		_, err = dev.Host.Write([]byte{4, 5})
+		require.NoError(t, err)
+		n, err := dev.SIG.Read(buf)
+		require.NoError(t, err)
+		assert.Equal(t, []byte{1, 2, 3}, buf[:n])
+		n, err = dev.SIG.Read(buf)
+		require.NoError(t, err)
+		assert.Equal(t, []byte{4, 5}, buf[:n])
+
+		_, err = dev.SIG.Write([]byte{6})
+		require.NoError(t, err)
+		n, err = dev.Host.Read(buf)
+		require.NoError(t, err)
+		assert.Equal(t, []byte{6}, buf[:n])
+	})
+
+	t.Run("written packets are copied", func(t *testing.T) {
+		pkt := []byte{1, 2, 3}
+		_, err := dev.SIG.Write(pkt)
+		require.NoError(t, err)
+		pkt[0] = 9
+		n, err := dev.Host.Read(buf)
+		require.NoError(t, err)
+		assert.Equal(t, []byte{1, 2, 3}, buf[:n])
+	})
+
+	t.Run("packets are truncated to the buffer", func(t *testing.T) {
+		_, err := dev.SIG.Write([]byte{1, 2, 3})
+		require.NoError(t, err)
+		n, err := dev.Host.Read(buf[:2])
+		require.NoError(t, err)
+		assert.Equal(t, []byte{1, 2}, buf[:n])
+	})
+
+	t.Run("close affects both ends", func(t *testing.T) {
+		require.NoError(t, dev.Close())
+		_, err := dev.Host.Read(buf)
+		assert.Equal(t, io.EOF, err)
+		_, err = dev.SIG.Read(buf)
+		assert.Equal(t, io.EOF, err)
+		_, err = dev.Host.Write([]byte{1})
+		assert.Equal(t, memtun.ErrClosed, err)
+		assert.NoError(t, dev.SIG.Close())
+	})
+}
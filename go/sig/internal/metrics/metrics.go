@@ -57,6 +57,22 @@ var (
 	SessionMTU            *prometheus.GaugeVec
 	SessionHealth         *prometheus.GaugeVec
 	SessionRemoteSwitched *prometheus.CounterVec
+	// Metrics of the paths of multipath sessions.
+	PathFramesSent     *prometheus.CounterVec
+	PathFrameBytesSent *prometheus.CounterVec
+	PathRTT            *prometheus.GaugeVec
+	PathLoss           *prometheus.GaugeVec
+	PathHealth         *prometheus.GaugeVec
+	// Metrics of the forward error correction of frames.
+	FECParityFramesSent *prometheus.CounterVec
+	FECParityBytesSent  *prometheus.CounterVec
+	FECParityFramesRecv *prometheus.CounterVec
+	FECFramesRecovered  *prometheus.CounterVec
+	FECUnrecoverable    *prometheus.CounterVec
+	// Metrics of the prefix announcements.
+	PrefixAnnsSent *prometheus.CounterVec
+	PrefixAnnsRecv *prometheus.CounterVec
+	AnnouncedNets  *prometheus.GaugeVec
 
 	EgressRxQueueFull *prometheus.CounterVec
 )
@@ -66,6 +82,7 @@ var ConfigVersion uint64
 
 func init() {
 	iaLabels := []string{"dst_isd_as", "sessId"}
+	pathLabels := []string{"dst_isd_as", "sessId", "path"}
 
 	// Some closures to reduce boiler-plate.
 	newC := func(name, help string) prometheus.Counter {
@@ -113,6 +130,32 @@ func init() {
 		iaLabels)
 	SessionRemoteSwitched = newCVec("session_switch_remote",
 		"Number of times the remote has changed.", iaLabels)
+	PathFramesSent = newCVec("path_frames_sent_total",
+		"Number of frames sent on a path of a multipath session.", pathLabels)
+	PathFrameBytesSent = newCVec("path_frame_bytes_sent_total",
+		"Number of frame bytes sent on a path of a multipath session.", pathLabels)
+	PathRTT = newGVec("path_rtt_seconds",
+		"Smoothed probe roundtrip time of a path of a multipath session.", pathLabels)
+	PathLoss = newGVec("path_loss_ratio",
+		"Smoothed ratio of lost probes of a path of a multipath session.", pathLabels)
+	PathHealth = newGVec("path_health",
+		"Path health (1: in rotation or 0: out of rotation).", pathLabels)
+	FECParityFramesSent = newCVec("fec_parity_frames_sent_total",
+		"Number of FEC parity frames sent.", iaLabels)
+	FECParityBytesSent = newCVec("fec_parity_bytes_sent_total",
+		"Number of FEC parity frame bytes sent.", iaLabels)
+	FECParityFramesRecv = newCVec("fec_parity_frames_recv_total",
+		"Number of FEC parity frames received.", iaLabels)
+	FECFramesRecovered = newCVec("fec_frames_recovered_total",
+		"Number of lost frames recovered with FEC.", iaLabels)
+	FECUnrecoverable = newCVec("fec_unrecoverable_total",
+		"Number of frame losses that could not be recovered with FEC.", iaLabels)
+	PrefixAnnsSent = newCVec("prefix_announcements_sent_total",
+		"Number of prefix announcements sent.", []string{"dst_isd_as"})
+	PrefixAnnsRecv = newCVec("prefix_announcements_recv_total",
+		"Number of prefix announcements received.", []string{"dst_isd_as", "result"})
+	AnnouncedNets = newGVec("announced_nets",
+		"Number of accepted networks announced by a remote AS.", []string{"dst_isd_as"})
 
 	EgressRxQueueFull = newCVec("egress_recv_queue_full_total",
 		"Egress packets dropped due to full queues.", []string{"dst_isd_as"})
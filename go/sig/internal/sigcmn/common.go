@@ -31,6 +31,7 @@ import (
 	"github.com/scionproto/scion/go/lib/serrors"
 	"github.com/scionproto/scion/go/lib/snet"
 	"github.com/scionproto/scion/go/lib/sock/reliable"
+	"github.com/scionproto/scion/go/lib/topology/overlay"
 	"github.com/scionproto/scion/go/sig/internal/sigconfig"
 	"github.com/scionproto/scion/go/sig/internal/snetmigrate"
 )
@@ -137,7 +138,7 @@ func newDispatcher(cfg sigconfig.SigConf) (reliable.Dispatcher, error) {
 	}
 	// Initialize dispatcher bypass.
 	log.Info("Bypassing SCION dispatcher", "addr", cfg.DispatcherBypass)
-	dispServer, err := dispatcher.NewServer(cfg.DispatcherBypass)
+	dispServer, err := dispatcher.NewServer(cfg.DispatcherBypass, overlay.PortRange{})
 	if err != nil {
 		return nil, serrors.WrapStr("unable to initialize bypass dispatcher", err)
 	}
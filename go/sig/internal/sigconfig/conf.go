@@ -33,6 +33,14 @@ const (
 	DefaultTunRTableId = 11
 )
 
+const (
+	// TunTypeKernel is the kernel TUN device.
+	TunTypeKernel = "kernel"
+	// TunTypeMemory is an in-memory device that is not connected to the host.
+	// It requires no privileges, and is used for testing.
+	TunTypeMemory = "memory"
+)
+
 type Config struct {
 	Features env.Features
 	Logging  log.Config       `toml:"log,omitempty"`
@@ -93,6 +101,9 @@ type SigConf struct {
 	EncapPort uint16 `toml:"encap_port,omitempty"`
 	// Name of TUN device to create. (default DefaultTunName)
 	Tun string `toml:"tun,omitempty"`
+	// TunType is the type of the TUN device, either TunTypeKernel or
+	// TunTypeMemory. (default TunTypeKernel)
+	TunType string `toml:"tun_type,omitempty"`
 	// TunRTableId the id of the routing table used in the SIG. (default DefaultTunRTableId)
 	TunRTableId int `toml:"tun_routing_table_id,omitempty"`
 	// IPv4 source address hint to put into routing table.
@@ -103,6 +114,15 @@ type SigConf struct {
 	// dispatcher. If the field is empty bypass is not done and SCION dispatcher is used
 	// instead.
 	DispatcherBypass string `toml:"disaptcher_bypass,omitempty"`
+	// CryptoDir is the directory containing the crypto material used to sign
+	// and verify prefix announcements. It must have a certs sub-directory with
+	// the TRCs and the certificate chain of the local AS, and a keys
+	// sub-directory with the signing key. If empty, announcements are neither
+	// signed nor verified.
+	CryptoDir string `toml:"crypto_dir,omitempty"`
+	// InsecureAnnouncements allows to send and accept prefix announcements
+	// that are not signed. (default false)
+	InsecureAnnouncements bool `toml:"insecure_announcements,omitempty"`
 }
 
 // InitDefaults sets the default values to unset values.
@@ -135,6 +155,13 @@ func (cfg *SigConf) Validate() error {
 	if cfg.Tun == "" {
 		cfg.Tun = DefaultTunName
 	}
+	switch cfg.TunType {
+	case "":
+		cfg.TunType = TunTypeKernel
+	case TunTypeKernel, TunTypeMemory:
+	default:
+		return serrors.New("Invalid tun_type", "tun_type", cfg.TunType)
+	}
 	if cfg.TunRTableId == 0 {
 		cfg.TunRTableId = DefaultTunRTableId
 	}
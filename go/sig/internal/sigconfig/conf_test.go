@@ -63,5 +63,8 @@ func CheckTestSigConf(t *testing.T, cfg *SigConf, id string) {
 	assert.Equal(t, DefaultCtrlPort, int(cfg.CtrlPort))
 	assert.Equal(t, DefaultEncapPort, int(cfg.EncapPort))
 	assert.Equal(t, DefaultTunName, cfg.Tun)
+	assert.Equal(t, TunTypeKernel, cfg.TunType)
 	assert.Equal(t, DefaultTunRTableId, cfg.TunRTableId)
+	assert.Equal(t, "/etc/scion/sig", cfg.CryptoDir)
+	assert.False(t, cfg.InsecureAnnouncements)
 }
@@ -38,6 +38,21 @@ encap_port = 30056
 # Name of TUN device to create. (default DefaultTunName)
 tun = "sig"
 
+# Type of the TUN device, either "kernel" or "memory". The in-memory device is
+# not connected to the host and requires no privileges, it is used for
+# testing. (default "kernel")
+tun_type = "kernel"
+
 # Id of the routing table. (default 11)
 tun_routing_table_id = 11
+
+# Directory with the crypto material used to sign and verify prefix
+# announcements. It contains the TRCs and the AS certificate chain in the certs
+# sub-directory, and the AS signing key in the keys sub-directory. If empty,
+# announcements are neither signed nor verified. (default "")
+crypto_dir = "/etc/scion/sig"
+
+# Send and accept prefix announcements that are not signed. Any host on the
+# path can then announce prefixes on behalf of a remote AS. (default false)
+insecure_announcements = false
 `
@@ -19,6 +19,7 @@ import (
 	"time"
 
 	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/ctrl/seg"
 	"github.com/scionproto/scion/go/lib/snet"
 	"github.com/scionproto/scion/go/lib/spath"
 )
@@ -56,6 +57,14 @@ func (p *emptyPath) Expiry() time.Time {
 	return time.Time{}
 }
 
+func (p *emptyPath) Load() []seg.InterfaceLoad {
+	return nil
+}
+
+func (p *emptyPath) StaticInfo() []seg.InterfaceStaticInfo {
+	return nil
+}
+
 func (p *emptyPath) Copy() snet.Path {
 	if p == nil {
 		return nil
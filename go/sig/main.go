@@ -17,6 +17,7 @@ package main
 
 import (
 	"bytes"
+	"context"
 	"flag"
 	"fmt"
 	"io"
@@ -38,8 +39,10 @@ import (
 	"github.com/scionproto/scion/go/lib/sigdisp"
 	"github.com/scionproto/scion/go/lib/sigjson"
 	"github.com/scionproto/scion/go/sig/egress"
+	"github.com/scionproto/scion/go/sig/internal/announce"
 	"github.com/scionproto/scion/go/sig/internal/base"
 	"github.com/scionproto/scion/go/sig/internal/ingress"
+	"github.com/scionproto/scion/go/sig/internal/memtun"
 	"github.com/scionproto/scion/go/sig/internal/metrics"
 	"github.com/scionproto/scion/go/sig/internal/sigcmn"
 	"github.com/scionproto/scion/go/sig/internal/sigconfig"
@@ -48,6 +51,8 @@ import (
 
 var (
 	cfg sigconfig.Config
+	// memTun is the in-memory TUN device, if configured.
+	memTun *memtun.Device
 )
 
 func init() {
@@ -82,9 +87,13 @@ func realMain() int {
 		log.Crit("Unable to create & configure TUN device", "err", err)
 		return 1
 	}
-	if err := sigcmn.Init(cfg.Sig, cfg.Sciond); err != nil {
-		log.Crit("Error during initialization", "err", err)
-		return 1
+	if memTun != nil {
+		// Nothing is attached to the host end of the in-memory device, the
+		// packets received from remote SIGs are dropped.
+		go func() {
+			defer log.HandlePanic()
+			discard(memTun.Host)
+		}()
 	}
 	env.SetupEnv(
 		func() {
@@ -93,19 +102,10 @@ func realMain() int {
 			log.Info("reloadOnSIGHUP: reload done", "success", success)
 		},
 	)
-	sigdisp.Init(sigcmn.CtrlConn, false)
-	// Parse sig config
-	if loadConfig(cfg.Sig.SIGConfig) != true {
-		log.Crit("Unable to load sig config on startup")
+	if err := start(tunIO); err != nil {
+		log.Crit("Error during initialization", "err", err)
 		return 1
 	}
-	// Reply to probes from other SIGs.
-	go func() {
-		defer log.HandlePanic()
-		base.PollReqHdlr()
-	}()
-	egress.Init(tunIO)
-	ingress.Init(tunIO)
 	http.HandleFunc("/config", configHandler)
 	http.HandleFunc("/info", env.InfoHandler)
 	cfg.Metrics.StartPrometheus()
@@ -117,6 +117,40 @@ func realMain() int {
 	}
 }
 
+// start initializes the network, loads the SIG config and starts the egress
+// and ingress of the SIG.
+func start(tunIO io.ReadWriteCloser) error {
+	if err := sigcmn.Init(cfg.Sig, cfg.Sciond); err != nil {
+		return err
+	}
+	sigdisp.Init(sigcmn.CtrlConn, false)
+	if cfg.Sig.CryptoDir != "" {
+		err := announce.LoadCrypto(context.Background(), cfg.Sig.IA, cfg.Sig.CryptoDir)
+		if err != nil {
+			return common.NewBasicError("Unable to load crypto material for announcements",
+				err)
+		}
+	}
+	announce.Insecure = cfg.Sig.InsecureAnnouncements
+	if announce.Insecure {
+		log.Warn("Unsigned prefix announcements are sent and accepted")
+	} else if !announce.Enabled() {
+		log.Info("Prefix announcements are disabled, no crypto_dir is configured")
+	}
+	// Parse sig config
+	if loadConfig(cfg.Sig.SIGConfig) != true {
+		return serrors.New("Unable to load sig config on startup")
+	}
+	// Reply to probes from other SIGs.
+	go func() {
+		defer log.HandlePanic()
+		base.PollReqHdlr()
+	}()
+	egress.Init(tunIO)
+	ingress.Init(tunIO)
+	return nil
+}
+
 // setupBasic loads the config from file and initializes logging.
 func setupBasic() error {
 	// Load and initialize config.
@@ -149,6 +183,11 @@ func setupTun() (io.ReadWriteCloser, error) {
 	if err := checkPerms(); err != nil {
 		return nil, serrors.WrapStr("Permissions checks failed", err)
 	}
+	if cfg.Sig.TunType == sigconfig.TunTypeMemory {
+		log.Info("Using in-memory TUN device, the SIG is not connected to the host")
+		memTun = memtun.New(memtun.DefaultQueueLen)
+		return memTun.SIG, nil
+	}
 	tunLink, tunIO, err := xnet.ConnectTun(cfg.Sig.Tun)
 	if err != nil {
 		return nil, err
@@ -218,3 +257,13 @@ func configHandler(w http.ResponseWriter, r *http.Request) {
 	toml.NewEncoder(&buf).Encode(cfg)
 	fmt.Fprint(w, buf.String())
 }
+
+// discard reads and drops packets until r is closed.
+func discard(r io.Reader) {
+	buf := make([]byte, common.MaxMTU)
+	for {
+		if _, err := r.Read(buf); err != nil {
+			return
+		}
+	}
+}
@@ -0,0 +1,123 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/env"
+	"github.com/scionproto/scion/go/lib/fatal"
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/xtest"
+	"github.com/scionproto/scion/go/sig/internal/sigconfig"
+)
+
+// TestMemTun runs the SIG with the in-memory TUN device, the bypass
+// dispatcher and a fake SCIOND. The SIG has a session to its own AS, such
+// that the packets written to the device go through the egress session, the
+// path, snet and the ingress, and come back out of the device. The SIG keeps
+// process-wide state, hence it can only be started once per test binary.
+func TestMemTun(t *testing.T) {
+	fatal.Init()
+	log.Discard()
+	dir, cleanF := xtest.MustTempDir("", "sig")
+	defer cleanF()
+	ia := xtest.MustParseIA("1-ff00:0:110")
+	port := freeUDPPort(t)
+
+	sdFile := filepath.Join(dir, "sd.json")
+	sd := fmt.Sprintf(`{"entries": [{"reply_start_timestamp": 0, "paths": [
+		{"fingerprint": "loop", "next_hop": "127.0.0.1:%d", "ia": "%s",
+		"expiration_timestamp": 7200}
+	]}]}`, port, ia)
+	require.NoError(t, ioutil.WriteFile(sdFile, []byte(sd), 0644))
+	sigFile := filepath.Join(dir, "sig.json")
+	sigJSON := fmt.Sprintf(`{"ConfigVersion": 1, "ASes": {"%s": {"Nets": ["10.3.0.0/16"]}}}`,
+		ia)
+	require.NoError(t, ioutil.WriteFile(sigFile, []byte(sigJSON), 0644))
+
+	cfg.Features.AllowRunAsRoot = true
+	cfg.Sciond = env.SCIONDClient{FakeData: sdFile, PathCount: 1}
+	cfg.Sig = sigconfig.SigConf{
+		ID:               "sig1",
+		SIGConfig:        sigFile,
+		IA:               ia,
+		IP:               net.IP{127, 0, 0, 1},
+		TunType:          sigconfig.TunTypeMemory,
+		DispatcherBypass: fmt.Sprintf(":%d", port),
+	}
+	require.NoError(t, cfg.Sig.Validate())
+	tunIO, err := setupTun()
+	require.NoError(t, err)
+	require.NotNil(t, memTun)
+	defer memTun.Close()
+	require.NoError(t, start(tunIO))
+
+	pkts := make(chan common.RawBytes, 16)
+	go func() {
+		for {
+			buf := make(common.RawBytes, common.MaxMTU)
+			n, err := memTun.Host.Read(buf)
+			if err != nil {
+				return
+			}
+			pkts <- buf[:n]
+		}
+	}()
+	pkt := ipv4Pkt(net.IP{10, 3, 0, 1}, net.IP{10, 3, 0, 2}, 100)
+	// Packets are dropped until the session found the remote SIG, retry.
+	timeout := time.After(10 * time.Second)
+	for {
+		_, err := memTun.Host.Write(pkt)
+		require.NoError(t, err)
+		select {
+		case got := <-pkts:
+			assert.Equal(t, pkt, got)
+			return
+		case <-time.After(100 * time.Millisecond):
+		case <-timeout:
+			t.Fatal("Timed out waiting for packet")
+		}
+	}
+}
+
+func freeUDPPort(t *testing.T) int {
+	conn, err := net.ListenUDP("udp", &net.UDPAddr{})
+	require.NoError(t, err)
+	defer conn.Close()
+	return conn.LocalAddr().(*net.UDPAddr).Port
+}
+
+func ipv4Pkt(src, dst net.IP, payloadLen int) common.RawBytes {
+	pkt := make(common.RawBytes, 20+payloadLen)
+	pkt[0] = 0x45
+	binary.BigEndian.PutUint16(pkt[2:], uint16(len(pkt)))
+	copy(pkt[12:16], src.To4())
+	copy(pkt[16:20], dst.To4())
+	for i := 20; i < len(pkt); i++ {
+		pkt[i] = byte(i)
+	}
+	return pkt
+}
@@ -0,0 +1,178 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/scionproto/scion/go/lib/log/logparse"
+)
+
+type eventKind int
+
+const (
+	// evQueued is logged by the border router when a packet is classified.
+	evQueued eventKind = iota
+	// evForwarded is logged by the border router schedulers.
+	evForwarded
+	// evDropped is logged by the border router when a packet is dropped.
+	evDropped
+	// evNotified is logged by the border router for every congestion warning
+	// it creates for a packet.
+	evNotified
+	// evQueueLengths is logged by the border router together with every drop.
+	evQueueLengths
+	// evWarningReceived is logged by end hosts that receive a congestion
+	// warning.
+	evWarningReceived
+	// evFlowReport is logged by applications at the end of a flow.
+	evFlowReport
+)
+
+// event is a single log entry relevant for the analysis.
+type event struct {
+	kind eventKind
+	// id is the packet ID assigned by the border router.
+	id string
+	// queue is the queue number, or empty if unknown.
+	queue string
+	// action is the action taken by the queue, or empty if unknown.
+	action string
+	// flow identifies the flow by source and destination address, or is empty
+	// if unknown.
+	flow string
+	// lengths are the queue lengths of evQueueLengths.
+	lengths []int
+	// name, sent, received and warnings are the values of evFlowReport.
+	name     string
+	sent     uint64
+	received uint64
+	warnings uint64
+}
+
+var (
+	forwardedRegex = regexp.MustCompile(`^[\w ]+ forwarded$`)
+	warningRegex   = regexp.MustCompile(`^CW packet received`)
+	lengthsRegex   = regexp.MustCompile(`\d+`)
+)
+
+// actions maps the numeric police actions to names, see border/qos/conf.
+var actions = map[string]string{"0": "PASS", "1": "NOTIFY", "2": "DROP", "3": "DROPNOTIFY"}
+
+// parseEvent extracts the event from a log entry. It returns false, if the
+// entry is not relevant.
+func parseEvent(e logparse.LogEntry) (event, bool) {
+	line := joinLines(e.Lines)
+	msg := message(line)
+	switch {
+	case msg == "Packet queued":
+		ev := event{kind: evQueued, id: field(line, "id"), queue: field(line, "queue"),
+			flow: flowOf(line)}
+		ev.action = actions[field(line, "action")]
+		return ev, ev.id != ""
+	case msg == "Dropping packet":
+		ev := event{kind: evDropped, id: field(line, "id"), queue: field(line, "queue")}
+		return ev, true
+	case msg == "DROPSTAT":
+		ev := event{kind: evQueueLengths}
+		for _, l := range lengthsRegex.FindAllString(field(line, "queueLengths"), -1) {
+			n, _ := strconv.Atoi(l)
+			ev.lengths = append(ev.lengths, n)
+		}
+		return ev, true
+	case msg == "New SCMP Notification":
+		ev := event{kind: evNotified, id: field(line, "Pkt ID")}
+		return ev, ev.id != ""
+	case msg == "New queueing packet":
+		// Logged right before the notification is created. It is the only
+		// place that connects the queue to notified packets, if the packet was
+		// not logged when it was queued.
+		ev := event{kind: evQueued, id: field(line, "Pkt ID"), queue: field(line, "QNo"),
+			flow: flowOf(line)}
+		return ev, ev.id != ""
+	case forwardedRegex.MatchString(msg):
+		ev := event{kind: evForwarded, id: field(line, "id")}
+		return ev, ev.id != ""
+	case warningRegex.MatchString(msg):
+		return event{kind: evWarningReceived, flow: flowOf(line)}, true
+	case msg == "Flow finished":
+		ev := event{kind: evFlowReport, name: field(line, "flow"), flow: flowOf(line)}
+		ev.sent, _ = strconv.ParseUint(field(line, "sent"), 10, 64)
+		ev.received, _ = strconv.ParseUint(field(line, "received"), 10, 64)
+		ev.warnings, _ = strconv.ParseUint(field(line, "warnings"), 10, 64)
+		return ev, true
+	}
+	return event{}, false
+}
+
+// joinLines joins the lines of a multi-line log entry into a single line,
+// without the continuation markers.
+func joinLines(lines []string) string {
+	trimmed := make([]string, 0, len(lines))
+	for _, l := range lines {
+		trimmed = append(trimmed, strings.TrimPrefix(l, "> "))
+	}
+	return strings.Join(trimmed, " ")
+}
+
+// message returns the message of a log line, i.e., everything up to the first
+// key=value pair. The keys of the relevant entries never contain spaces.
+func message(line string) string {
+	i := strings.IndexByte(line, '=')
+	if i < 0 {
+		return strings.TrimSpace(line)
+	}
+	j := strings.LastIndexByte(line[:i], ' ')
+	if j < 0 {
+		return ""
+	}
+	return strings.TrimSpace(line[:j])
+}
+
+// fieldRegexes caches the compiled regular expressions of field.
+var fieldRegexes = make(map[string]*regexp.Regexp)
+
+// field returns the value of the key in the log line, or the empty string if
+// the key is not present.
+func field(line, key string) string {
+	re, ok := fieldRegexes[key]
+	if !ok {
+		re = regexp.MustCompile(
+			`(?:^|\s)` + regexp.QuoteMeta(key) + `=("(?:[^"\\]|\\.)*"|\S*)`)
+		fieldRegexes[key] = re
+	}
+	m := re.FindStringSubmatch(line)
+	if m == nil {
+		return ""
+	}
+	if v, err := strconv.Unquote(m[1]); err == nil {
+		return v
+	}
+	return m[1]
+}
+
+// flowOf returns the flow identifier from the SrcIA, SrcHost, DstIA and
+// DstHost fields of a log line, or the empty string if any of them is missing.
+func flowOf(line string) string {
+	srcIA, srcHost := field(line, "SrcIA"), field(line, "SrcHost")
+	dstIA, dstHost := field(line, "DstIA"), field(line, "DstHost")
+	if srcIA == "" || srcHost == "" || dstIA == "" || dstHost == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s,[%s] -> %s,[%s]", srcIA, srcHost, dstIA, dstHost)
+}
@@ -0,0 +1,118 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Log analysis for QoS experiments.
+//
+// The tool reads the border router, SCIOND, dispatcher and application logs
+// of an experiment run, as produced by log15/fmt15. It correlates the queued,
+// forwarded and dropped packets and the created congestion warnings by the
+// packet ID the border router assigns to every packet, and reports the
+// statistics per queue, per flow or per receiving host as CSV or JSON.
+//
+// Every file is treated as the log of a single element, named after the file
+// without its extension (e.g. br1-ff00_0_110-1). The packet level events are
+// only logged by border routers at debug level, and the queued packets only if
+// LogQueuedPackets is set in the QoS config of the router. Otherwise, only the
+// queues of notified packets are known. Drops and queue lengths are
+// also available at info level, but cannot be attributed to flows then.
+//
+// Applications report the outcome of a flow with a "Flow finished" entry with
+// the keys flow, SrcIA, SrcHost, DstIA, DstHost, sent, received and warnings,
+// as done by the trafficgen tool.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/scionproto/scion/go/lib/env"
+	"github.com/scionproto/scion/go/lib/log/logparse"
+)
+
+var (
+	by      = flag.String("by", ByQueue, "Group the statistics by queue|flow|host")
+	format  = flag.String("format", FormatCSV, "Output format: csv|json")
+	outFile = flag.String("o", "-", "Output file, - for stdout")
+	version = flag.Bool("version", false, "Output version information and exit.")
+)
+
+func main() {
+	os.Exit(realMain())
+}
+
+func realMain() int {
+	flag.Usage = printUsage
+	flag.Parse()
+	if *version {
+		fmt.Print(env.VersionInfo())
+		return 0
+	}
+	if flag.NArg() == 0 {
+		printUsage()
+		return 1
+	}
+	a := newAnalysis()
+	for _, fn := range flag.Args() {
+		if err := analyzeFile(a, fn); err != nil {
+			fmt.Fprintf(os.Stderr, "ERROR: %s\n", err)
+			return 1
+		}
+	}
+	var w io.Writer = os.Stdout
+	if *outFile != "-" {
+		f, err := os.Create(*outFile)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "ERROR: %s\n", err)
+			return 1
+		}
+		defer f.Close()
+		w = f
+	}
+	if err := write(w, a, *by, *format); err != nil {
+		fmt.Fprintf(os.Stderr, "ERROR: %s\n", err)
+		return 1
+	}
+	return 0
+}
+
+// analyzeFile adds the events of the log file to the analysis.
+func analyzeFile(a *analysis, fn string) error {
+	f, err := os.Open(fn)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	element := fnToEName(fn)
+	logparse.ParseFrom(f, fn, element, func(e logparse.LogEntry) {
+		if ev, ok := parseEvent(e); ok {
+			a.add(e.Element, ev)
+		}
+	})
+	return nil
+}
+
+// fnToEName turns a path like "logs/br1-ff00_0_311-1.log" into
+// "br1-ff00_0_311-1".
+func fnToEName(fn string) string {
+	return strings.TrimSuffix(filepath.Base(fn), filepath.Ext(fn))
+}
+
+func printUsage() {
+	fmt.Fprintf(os.Stderr, "Usage: %s [flags] <logfile> [logfile ...]\n", os.Args[0])
+	flag.PrintDefaults()
+}
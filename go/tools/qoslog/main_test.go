@@ -0,0 +1,98 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/log/logparse"
+)
+
+const flow1 = "1-ff00:0:110,[127.0.0.1] -> 1-ff00:0:111,[127.0.0.2]"
+
+func analyzeTestdata(t *testing.T) *analysis {
+	a := newAnalysis()
+	for _, fn := range []string{
+		"testdata/br1-ff00_0_110-1.log",
+		"testdata/client.log",
+		"testdata/dispatcher.log",
+	} {
+		require.NoError(t, analyzeFile(a, fn))
+	}
+	return a
+}
+
+func TestQueues(t *testing.T) {
+	a := analyzeTestdata(t)
+	expected := []*QueueStats{
+		{Router: "br1-ff00_0_110-1", Queue: "0", Packets: 1, Forwarded: 1},
+		{Router: "br1-ff00_0_110-1", Queue: "1", Packets: 2, Forwarded: 1, Dropped: 1,
+			Notified: 1, Notifications: 1, DropRatio: 0.5, MaxLength: 12},
+		{Router: "br1-ff00_0_110-1", Queue: "2", MaxLength: 3},
+	}
+	assert.Equal(t, expected, a.Queues())
+}
+
+func TestFlows(t *testing.T) {
+	a := analyzeTestdata(t)
+	expected := []*FlowStats{
+		{Flow: flow1, Name: "bulk", Packets: 2, Forwarded: 1, Dropped: 1, Notifications: 1,
+			DropRatio: 0.5, Sent: 10, Received: 8, Loss: 0.19999999999999996,
+			WarningsReceived: 1},
+		{Flow: "1-ff00:0:110,[127.0.0.3] -> 1-ff00:0:111,[127.0.0.2]", Packets: 1,
+			Forwarded: 1},
+	}
+	assert.Equal(t, expected, a.Flows())
+	assert.Equal(t, []*HostStats{{Element: "dispatcher", WarningsReceived: 1}}, a.Hosts())
+}
+
+func TestWriteCSV(t *testing.T) {
+	a := analyzeTestdata(t)
+	var buf bytes.Buffer
+	require.NoError(t, write(&buf, a, ByQueue, FormatCSV))
+	expected := "router,queue,packets,forwarded,dropped,notified,notifications," +
+		"drop_ratio,max_length\n" +
+		"br1-ff00_0_110-1,0,1,1,0,0,0,0.0000,0\n" +
+		"br1-ff00_0_110-1,1,2,1,1,1,1,0.5000,12\n" +
+		"br1-ff00_0_110-1,2,0,0,0,0,0,0.0000,3\n"
+	assert.Equal(t, expected, buf.String())
+	assert.Error(t, write(&buf, a, "bogus", FormatCSV))
+	assert.Error(t, write(&buf, a, ByFlow, "bogus"))
+}
+
+func TestParseMultilineEvent(t *testing.T) {
+	// The notification path logs its entry over several lines.
+	e := logparse.LogEntry{Lines: []string{
+		"New queueing packet",
+		">  SrcIA=1-ff00:0:110 SrcHost=127.0.0.1 DstIA=1-ff00:0:111 DstHost=127.0.0.2 ",
+		"> QNo=1 Pkt ID=a2 Current IF=1 NextIF=2",
+	}}
+	ev, ok := parseEvent(e)
+	require.True(t, ok)
+	assert.Equal(t, event{kind: evQueued, id: "a2", queue: "1", flow: flow1}, ev)
+}
+
+func TestField(t *testing.T) {
+	line := `New SCMP Notification SrcIA=1-ff00:0:110 Pkt ID=a2 q="a b" l4 hdr type=SCMP`
+	assert.Equal(t, "New SCMP Notification", message(line))
+	assert.Equal(t, "a2", field(line, "Pkt ID"))
+	assert.Equal(t, "a b", field(line, "q"))
+	assert.Equal(t, "SCMP", field(line, "l4 hdr type"))
+	assert.Equal(t, "", field(line, "DstIA"))
+}
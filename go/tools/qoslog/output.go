@@ -0,0 +1,103 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+
+	"github.com/scionproto/scion/go/lib/serrors"
+)
+
+const (
+	FormatCSV  = "csv"
+	FormatJSON = "json"
+
+	ByQueue = "queue"
+	ByFlow  = "flow"
+	ByHost  = "host"
+)
+
+// write writes the statistics grouped by the given key in the given format.
+func write(w io.Writer, a *analysis, by, format string) error {
+	var header []string
+	var rows [][]string
+	var v interface{}
+	switch by {
+	case ByQueue:
+		stats := a.Queues()
+		header = []string{"router", "queue", "packets", "forwarded", "dropped", "notified",
+			"notifications", "drop_ratio", "max_length"}
+		for _, s := range stats {
+			rows = append(rows, []string{s.Router, orDash(s.Queue), itoa(s.Packets),
+				itoa(s.Forwarded), itoa(s.Dropped), itoa(s.Notified), itoa(s.Notifications),
+				ftoa(s.DropRatio), itoa(s.MaxLength)})
+		}
+		v = stats
+	case ByFlow:
+		stats := a.Flows()
+		header = []string{"flow", "name", "packets", "forwarded", "dropped", "notifications",
+			"drop_ratio", "sent", "received", "loss", "warnings_received"}
+		for _, s := range stats {
+			rows = append(rows, []string{orDash(s.Flow), s.Name, itoa(s.Packets),
+				itoa(s.Forwarded), itoa(s.Dropped), itoa(s.Notifications), ftoa(s.DropRatio),
+				utoa(s.Sent), utoa(s.Received), ftoa(s.Loss), utoa(s.WarningsReceived)})
+		}
+		v = stats
+	case ByHost:
+		stats := a.Hosts()
+		header = []string{"element", "warnings_received"}
+		for _, s := range stats {
+			rows = append(rows, []string{s.Element, utoa(s.WarningsReceived)})
+		}
+		v = stats
+	default:
+		return serrors.New("unknown grouping", "by", by)
+	}
+	switch format {
+	case FormatCSV:
+		cw := csv.NewWriter(w)
+		cw.Write(header)
+		cw.WriteAll(rows)
+		return cw.Error()
+	case FormatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "    ")
+		return enc.Encode(v)
+	default:
+		return serrors.New("unknown format", "format", format)
+	}
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+func itoa(i int) string {
+	return strconv.Itoa(i)
+}
+
+func utoa(u uint64) string {
+	return strconv.FormatUint(u, 10)
+}
+
+func ftoa(f float64) string {
+	return strconv.FormatFloat(f, 'f', 4, 64)
+}
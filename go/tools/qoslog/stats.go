@@ -0,0 +1,280 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+)
+
+// packet is the correlated state of a single packet in a border router.
+type packet struct {
+	router        string
+	queue         string
+	flow          string
+	action        string
+	forwarded     bool
+	dropped       bool
+	notifications int
+}
+
+type queueKey struct {
+	router string
+	queue  string
+}
+
+// analysis correlates the events of all log files of an experiment run.
+type analysis struct {
+	packets map[string]*packet
+	// anonymous counts the packets that were logged without a packet ID.
+	anonymous int
+	// maxLengths contains the maximum observed length per queue.
+	maxLengths map[queueKey]int
+	// warnings counts the congestion warnings received per element and flow.
+	warnings map[string]map[string]uint64
+	// reports contains the application flow reports by flow.
+	reports map[string]event
+}
+
+func newAnalysis() *analysis {
+	return &analysis{
+		packets:    make(map[string]*packet),
+		maxLengths: make(map[queueKey]int),
+		warnings:   make(map[string]map[string]uint64),
+		reports:    make(map[string]event),
+	}
+}
+
+// add adds an event logged by the given element.
+func (a *analysis) add(element string, ev event) {
+	switch ev.kind {
+	case evQueued:
+		p := a.packet(element, ev.id)
+		if ev.queue != "" {
+			p.queue = ev.queue
+		}
+		if ev.flow != "" {
+			p.flow = ev.flow
+		}
+		if ev.action != "" {
+			p.action = ev.action
+		}
+	case evForwarded:
+		a.packet(element, ev.id).forwarded = true
+	case evDropped:
+		p := a.packet(element, ev.id)
+		p.dropped = true
+		if ev.queue != "" {
+			p.queue = ev.queue
+		}
+	case evNotified:
+		a.packet(element, ev.id).notifications++
+	case evQueueLengths:
+		for i, l := range ev.lengths {
+			k := queueKey{router: element, queue: strconv.Itoa(i)}
+			if l > a.maxLengths[k] {
+				a.maxLengths[k] = l
+			}
+		}
+	case evWarningReceived:
+		if a.warnings[element] == nil {
+			a.warnings[element] = make(map[string]uint64)
+		}
+		a.warnings[element][ev.flow]++
+	case evFlowReport:
+		key := ev.flow
+		if key == "" {
+			key = ev.name
+		}
+		a.reports[key] = ev
+	}
+}
+
+// packet returns the packet with the given ID in the router. Packets without
+// ID cannot be correlated and are tracked individually.
+func (a *analysis) packet(router, id string) *packet {
+	if id == "" {
+		a.anonymous++
+		id = fmt.Sprintf("#%d", a.anonymous)
+	}
+	key := router + "/" + id
+	p, ok := a.packets[key]
+	if !ok {
+		p = &packet{router: router}
+		a.packets[key] = p
+	}
+	return p
+}
+
+// QueueStats are the statistics of a single queue in a border router.
+type QueueStats struct {
+	Router        string
+	Queue         string
+	Packets       int
+	Forwarded     int
+	Dropped       int
+	Notified      int
+	Notifications int
+	DropRatio     float64
+	MaxLength     int
+}
+
+// FlowStats are the statistics of a single flow. The packet counts are
+// aggregated over all border routers, the sent and received counts and the
+// received warnings are reported by the applications.
+type FlowStats struct {
+	Flow             string
+	Name             string
+	Packets          int
+	Forwarded        int
+	Dropped          int
+	Notifications    int
+	DropRatio        float64
+	Sent             uint64
+	Received         uint64
+	Loss             float64
+	WarningsReceived uint64
+}
+
+// HostStats are the congestion warnings received by a single element.
+type HostStats struct {
+	Element          string
+	WarningsReceived uint64
+}
+
+// Queues returns the per-queue statistics sorted by router and queue.
+func (a *analysis) Queues() []*QueueStats {
+	queues := make(map[queueKey]*QueueStats)
+	get := func(k queueKey) *QueueStats {
+		s, ok := queues[k]
+		if !ok {
+			s = &QueueStats{Router: k.router, Queue: k.queue}
+			queues[k] = s
+		}
+		return s
+	}
+	for _, p := range a.packets {
+		s := get(queueKey{router: p.router, queue: p.queue})
+		s.Packets++
+		s.Dropped += boolToInt(p.dropped)
+		s.Forwarded += boolToInt(p.forwarded)
+		s.Notifications += p.notifications
+		s.Notified += boolToInt(p.notifications > 0)
+	}
+	for k, l := range a.maxLengths {
+		get(k).MaxLength = l
+	}
+	res := make([]*QueueStats, 0, len(queues))
+	for _, s := range queues {
+		s.DropRatio = ratio(s.Dropped, s.Packets)
+		res = append(res, s)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].Router != res[j].Router {
+			return res[i].Router < res[j].Router
+		}
+		return lessNumeric(res[i].Queue, res[j].Queue)
+	})
+	return res
+}
+
+// Flows returns the per-flow statistics sorted by flow. Packets that cannot
+// be attributed to a flow are reported under the empty flow.
+func (a *analysis) Flows() []*FlowStats {
+	flows := make(map[string]*FlowStats)
+	get := func(flow string) *FlowStats {
+		s, ok := flows[flow]
+		if !ok {
+			s = &FlowStats{Flow: flow}
+			flows[flow] = s
+		}
+		return s
+	}
+	for _, p := range a.packets {
+		s := get(p.flow)
+		s.Packets++
+		s.Dropped += boolToInt(p.dropped)
+		s.Forwarded += boolToInt(p.forwarded)
+		s.Notifications += p.notifications
+	}
+	for key, r := range a.reports {
+		s := get(key)
+		s.Name = r.name
+		s.Sent = r.sent
+		s.Received = r.received
+		s.WarningsReceived = r.warnings
+		if r.sent > 0 && r.received <= r.sent {
+			s.Loss = 1 - float64(r.received)/float64(r.sent)
+		}
+	}
+	for _, byFlow := range a.warnings {
+		for flow, n := range byFlow {
+			if flow == "" {
+				continue
+			}
+			if _, ok := a.reports[flow]; !ok {
+				get(flow).WarningsReceived += n
+			}
+		}
+	}
+	res := make([]*FlowStats, 0, len(flows))
+	for _, s := range flows {
+		s.DropRatio = ratio(s.Dropped, s.Packets)
+		res = append(res, s)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Flow < res[j].Flow })
+	return res
+}
+
+// Hosts returns the received congestion warnings per element sorted by
+// element.
+func (a *analysis) Hosts() []*HostStats {
+	res := make([]*HostStats, 0, len(a.warnings))
+	for element, byFlow := range a.warnings {
+		s := &HostStats{Element: element}
+		for _, n := range byFlow {
+			s.WarningsReceived += n
+		}
+		res = append(res, s)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Element < res[j].Element })
+	return res
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func ratio(a, b int) float64 {
+	if b == 0 {
+		return 0
+	}
+	return float64(a) / float64(b)
+}
+
+// lessNumeric compares queue numbers numerically. Unknown queues (empty
+// strings) sort first.
+func lessNumeric(a, b string) bool {
+	x, errA := strconv.Atoi(a)
+	y, errB := strconv.Atoi(b)
+	if errA != nil || errB != nil {
+		return a < b
+	}
+	return x < y
+}
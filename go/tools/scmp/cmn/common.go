@@ -39,9 +39,11 @@ import (
 )
 
 const (
-	DefaultInterval = 1 * time.Second
-	DefaultTimeout  = 2 * time.Second
-	MaxEchoes       = 1 << 16
+	DefaultInterval    = 1 * time.Second
+	DefaultTimeout     = 2 * time.Second
+	DefaultRate        = 100
+	DefaultPayloadSize = 512
+	MaxEchoes          = 1 << 16
 )
 
 type ScmpStats struct {
@@ -57,6 +59,8 @@ var (
 	Interactive bool
 	Interval    time.Duration
 	Timeout     time.Duration
+	Rate        uint
+	PayloadSize uint
 	Remote      snet.UDPAddr
 	localIP     string
 )
@@ -64,6 +68,7 @@ var (
 var (
 	LocalIA   addr.IA
 	LocalIP   net.IP
+	LocalPort uint16
 	Conn      net.PacketConn
 	Mtu       uint16
 	PathEntry snet.Path
@@ -74,9 +79,15 @@ var (
 func init() {
 	// Set up flag vars
 	flag.BoolVar(&Interactive, "i", false, "Interactive mode")
-	flag.DurationVar(&Interval, "interval", DefaultInterval, "time between packets (echo only)")
+	flag.DurationVar(&Interval, "interval", DefaultInterval,
+		"time between packets (echo and telemetry only)")
 	flag.DurationVar(&Timeout, "timeout", DefaultTimeout, "timeout per packet")
-	flag.UintVar(&Count, "c", 0, "Total number of packet to send (echo only). Maximum value 65535")
+	flag.UintVar(&Count, "c", 0,
+		"Total number of packet to send (echo, congestion and telemetry only). "+
+			"Maximum value 65535")
+	flag.UintVar(&Rate, "rate", DefaultRate, "probe packets per second (congestion only)")
+	flag.UintVar(&PayloadSize, "size", DefaultPayloadSize,
+		"probe payload size in bytes (congestion only)")
 	flag.StringVar(&localIP, "local", "", "(Optional) IP address to listen on")
 	flag.Var(&Remote, "remote", "(Mandatory for clients) address to connect to")
 	flag.Usage = scmpUsage
@@ -92,6 +103,8 @@ command:
    echo
    tr | traceroute
    rp | recordpath
+   cw | congestion
+   int | telemetry
 
 flags:
 `)
@@ -142,6 +155,9 @@ func ValidateFlags() {
 	if Count > uint(zero-1) {
 		Fatal("Maximum count value is %d", zero-1)
 	}
+	if Rate == 0 {
+		Fatal("Rate must be positive")
+	}
 }
 
 func NewSCMPPkt(t scmp.Type, info scmp.Info, ext common.Extension) *spkt.ScnPkt {
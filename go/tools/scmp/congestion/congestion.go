@@ -0,0 +1,224 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package congestion implements the congestion mode of the scmp tool. It sends
+// a stream of UDP probes at a fixed rate towards the remote and prints every
+// congestion warning that the border routers on the path send back.
+package congestion
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/hpkt"
+	"github.com/scionproto/scion/go/lib/l4"
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/scmp"
+	"github.com/scionproto/scion/go/lib/spkt"
+	"github.com/scionproto/scion/go/tools/scmp/cmn"
+)
+
+// warning is the information carried by both the basic and the stochastic
+// congestion warning.
+type warning struct {
+	CurrBW        uint64
+	QueueLength   uint64
+	QueueFullness uint64
+	ConsIngress   common.IFIDType
+	Violation     uint64
+}
+
+var (
+	wg       sync.WaitGroup
+	mtx      sync.Mutex
+	sendDone bool
+	sendEnd  time.Time
+	// warnings counts the received congestion warnings per sending router
+	// and interface.
+	warnings = make(map[string]uint)
+	basic    uint
+	stoch    uint
+)
+
+func Run() {
+	cmn.SetupSignals(summary)
+	wg.Add(1)
+	go func() {
+		defer log.HandlePanic()
+		sendPkts()
+	}()
+	recvPkts()
+	wg.Wait()
+	summary()
+}
+
+func sendPkts() {
+	defer wg.Done()
+	defer func() {
+		mtx.Lock()
+		defer mtx.Unlock()
+		sendDone = true
+		sendEnd = time.Now()
+	}()
+	pkt := &spkt.ScnPkt{
+		DstIA:   cmn.Remote.IA,
+		SrcIA:   cmn.LocalIA,
+		DstHost: addr.HostFromIP(cmn.Remote.Host.IP),
+		SrcHost: addr.HostFromIP(cmn.LocalIP),
+		Path:    cmn.Remote.Path,
+		L4: &l4.UDP{
+			SrcPort: cmn.LocalPort,
+			DstPort: uint16(cmn.Remote.Host.Port),
+		},
+		Pld: make(common.RawBytes, cmn.PayloadSize),
+	}
+	b := make(common.RawBytes, cmn.Mtu)
+	nhAddr := cmn.NextHopAddr()
+
+	ticker := time.NewTicker(time.Second / time.Duration(cmn.Rate))
+	defer ticker.Stop()
+	for ; true; <-ticker.C {
+		pktLen, err := hpkt.WriteScnPkt(pkt, b)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "ERROR: Unable to serialize SCION packet %v\n", err)
+			break
+		}
+		written, err := cmn.Conn.WriteTo(b[:pktLen], nhAddr)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "ERROR: Unable to write %v\n", err)
+			break
+		} else if written != pktLen {
+			fmt.Fprintf(os.Stderr, "ERROR: Wrote incomplete message. written=%d, expected=%d\n",
+				written, pktLen)
+			break
+		}
+		mtx.Lock()
+		cmn.Stats.Sent += 1
+		sent := cmn.Stats.Sent
+		mtx.Unlock()
+		// More packets?
+		if cmn.Count != 0 && sent == cmn.Count {
+			break
+		}
+	}
+}
+
+func recvPkts() {
+	pkt := &spkt.ScnPkt{}
+	b := make(common.RawBytes, cmn.Mtu)
+
+	for {
+		cmn.Conn.SetReadDeadline(time.Now().Add(cmn.Timeout))
+		pktLen, _, err := cmn.Conn.ReadFrom(b)
+		if err != nil {
+			if !common.IsTimeoutErr(err) {
+				fmt.Fprintf(os.Stderr, "ERROR: Unable to read: %v\n", err)
+				return
+			}
+			mtx.Lock()
+			done := sendDone && time.Since(sendEnd) >= cmn.Timeout
+			mtx.Unlock()
+			if done {
+				return
+			}
+			continue
+		}
+		if err := hpkt.ParseScnPkt(pkt, b[:pktLen]); err != nil {
+			fmt.Fprintf(os.Stderr, "ERROR: SCION packet parse: %v\n", err)
+			continue
+		}
+		scmpHdr, w, err := validate(pkt)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "ERROR: SCMP validation: %v\n", err)
+			continue
+		}
+		key := fmt.Sprintf("%s,[%s] ingress=%d", pkt.SrcIA, pkt.SrcHost, w.ConsIngress)
+		mtx.Lock()
+		cmn.Stats.Recv += 1
+		warnings[key] += 1
+		if scmpHdr.Type == scmp.T_G_BasicCongWarn {
+			basic += 1
+		} else {
+			stoch += 1
+		}
+		mtx.Unlock()
+		prettyPrint(pkt, scmpHdr, w)
+	}
+}
+
+func validate(pkt *spkt.ScnPkt) (*scmp.Hdr, *warning, error) {
+	scmpHdr, scmpPld, err := cmn.Validate(pkt)
+	if err != nil {
+		return nil, nil, err
+	}
+	switch info := scmpPld.Info.(type) {
+	case *scmp.InfoBscCW:
+		return scmpHdr, &warning{CurrBW: info.CurrBW, QueueLength: info.QueueLength,
+			QueueFullness: info.QueueFullness, ConsIngress: info.ConsIngress,
+			Violation: info.Violation}, nil
+	case *scmp.InfoStochCW:
+		return scmpHdr, &warning{CurrBW: info.CurrBW, QueueLength: info.QueueLength,
+			QueueFullness: info.QueueFullness, ConsIngress: info.ConsIngress,
+			Violation: info.Violation}, nil
+	default:
+		return nil, nil, common.NewBasicError("Not a congestion warning", nil,
+			"class", scmpHdr.Class, "type", scmpHdr.Type.Name(scmpHdr.Class))
+	}
+}
+
+func prettyPrint(pkt *spkt.ScnPkt, scmpHdr *scmp.Hdr, w *warning) {
+	fmt.Printf("%s from %s,[%s] ingress=%d CurrBW=%d QueueLength=%d QueueFullness=%d "+
+		"Violation=%d\n", scmpHdr.Type.Name(scmpHdr.Class), pkt.SrcIA, pkt.SrcHost,
+		w.ConsIngress, w.CurrBW, w.QueueLength, w.QueueFullness, w.Violation)
+}
+
+func summary() {
+	mtx.Lock()
+	defer mtx.Unlock()
+	elapsed := time.Since(cmn.Start)
+	secs := elapsed.Seconds()
+	var sendRate, warnRate, warnRatio float64
+	if secs > 0 {
+		sendRate = float64(cmn.Stats.Sent) / secs
+		warnRate = float64(cmn.Stats.Recv) / secs
+	}
+	if cmn.Stats.Sent != 0 {
+		warnRatio = float64(cmn.Stats.Recv) * 100 / float64(cmn.Stats.Sent)
+	}
+	// The SCION header size depends on the path, only count L4 and payload.
+	probeBits := float64(cmn.PayloadSize+l4.UDPLen) * 8
+	fmt.Printf("\n--- %s,[%s] congestion statistics ---\n", cmn.Remote.IA, cmn.Remote.Host)
+	fmt.Printf("%d probes sent (%.1f pkt/s, %.0f bit/s), time %v\n",
+		cmn.Stats.Sent, sendRate, sendRate*probeBits, elapsed.Round(time.Microsecond))
+	fmt.Printf("%d congestion warnings received (%d basic, %d stochastic), "+
+		"%.2f warnings/s, %.2f%% of probes\n",
+		cmn.Stats.Recv, basic, stoch, warnRate, warnRatio)
+	keys := make([]string, 0, len(warnings))
+	for k := range warnings {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		var rate float64
+		if secs > 0 {
+			rate = float64(warnings[k]) / secs
+		}
+		fmt.Printf("  %s: %d warnings (%.2f/s)\n", k, warnings[k], rate)
+	}
+}
@@ -31,8 +31,10 @@ import (
 	"github.com/scionproto/scion/go/lib/snet/addrutil"
 	"github.com/scionproto/scion/go/lib/sock/reliable"
 	"github.com/scionproto/scion/go/tools/scmp/cmn"
+	"github.com/scionproto/scion/go/tools/scmp/congestion"
 	"github.com/scionproto/scion/go/tools/scmp/echo"
 	"github.com/scionproto/scion/go/tools/scmp/recordpath"
+	"github.com/scionproto/scion/go/tools/scmp/telemetry"
 	"github.com/scionproto/scion/go/tools/scmp/traceroute"
 )
 
@@ -78,7 +80,7 @@ func main() {
 
 	// Connect to the dispatcher
 	dispatcherService := reliable.NewDispatcher(*dispatcher)
-	cmn.Conn, _, err = dispatcherService.Register(context.Background(), cmn.LocalIA,
+	cmn.Conn, cmn.LocalPort, err = dispatcherService.Register(context.Background(), cmn.LocalIA,
 		&net.UDPAddr{IP: cmn.LocalIP}, addr.SvcNone)
 	if err != nil {
 		cmn.Fatal("Unable to register with the dispatcher addr=%s\nerr=%v", cmn.LocalIP, err)
@@ -97,6 +99,13 @@ func doCommand(cmd string) int {
 		traceroute.Run()
 	case "rp", "recordpath":
 		recordpath.Run()
+	case "int", "telemetry":
+		telemetry.Run()
+	case "cw", "congestion":
+		congestion.Run()
+		// Congestion warnings are not replies to the probes, the sent and
+		// received counters are not expected to match.
+		return 0
 	default:
 		fmt.Fprintf(os.Stderr, "ERROR: Invalid command %s\n", cmd)
 		flag.Usage()
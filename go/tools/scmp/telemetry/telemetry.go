@@ -0,0 +1,178 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package telemetry sends SCMP echo requests that carry the in-band network
+// telemetry extension. The border routers on the forward and the reverse path
+// fill in their records, and the tool prints the latency between consecutive
+// records and the queue occupancy at every hop.
+package telemetry
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/hpkt"
+	"github.com/scionproto/scion/go/lib/layers"
+	"github.com/scionproto/scion/go/lib/scmp"
+	"github.com/scionproto/scion/go/lib/spkt"
+	"github.com/scionproto/scion/go/tools/scmp/cmn"
+)
+
+var (
+	id    uint64
+	stats []hopStats
+)
+
+// hopStats aggregates the records of one position on the path over all
+// probes.
+type hopStats struct {
+	record   layers.INTRecord
+	samples  int
+	latency  time.Duration
+	queueLen int
+	maxQueue uint16
+}
+
+func Run() {
+	cmn.SetupSignals(summary)
+	// Every interface on the path is a border router, on the way there and
+	// back.
+	capacity := 1
+	if cmn.PathEntry != nil {
+		capacity = 2 * len(cmn.PathEntry.Interfaces())
+	}
+	if capacity > layers.MaxINTRecords {
+		capacity = layers.MaxINTRecords
+	}
+	extn, err := layers.NewExtnINT(capacity)
+	if err != nil {
+		cmn.Fatal("Unable to create INT extension: %v", err)
+	}
+	id = cmn.Rand()
+	info := &scmp.InfoEcho{Id: id}
+	pkt := cmn.NewSCMPPkt(scmp.T_G_EchoRequest, info, extn)
+	b := make(common.RawBytes, cmn.Mtu)
+	for seq := uint16(0); cmn.Count == 0 || uint(seq) < cmn.Count; seq++ {
+		if seq != 0 {
+			time.Sleep(cmn.Interval)
+		}
+		info.Seq = seq
+		info.Write(pkt.Pld.(common.RawBytes)[scmp.MetaLen:])
+		probe(pkt, b)
+	}
+	summary()
+}
+
+func probe(pkt *spkt.ScnPkt, b common.RawBytes) {
+	ts := time.Now()
+	cmn.UpdatePktTS(pkt, ts)
+	pktLen, err := hpkt.WriteScnPkt(pkt, b)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "ERROR: Unable to serialize SCION packet %v\n", err)
+		return
+	}
+	written, err := cmn.Conn.WriteTo(b[:pktLen], cmn.NextHopAddr())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "ERROR: Unable to write %v\n", err)
+		return
+	} else if written != pktLen {
+		fmt.Fprintf(os.Stderr, "ERROR: Wrote incomplete message. written=%d, expected=%d\n",
+			pktLen, written)
+		return
+	}
+	cmn.Stats.Sent += 1
+	cmn.Conn.SetReadDeadline(ts.Add(cmn.Timeout))
+	for {
+		pktLen, _, err = cmn.Conn.ReadFrom(b)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "ERROR: %v\n", err)
+			return
+		}
+		now := time.Now()
+		pktRecv := &spkt.ScnPkt{}
+		if err := hpkt.ParseScnPkt(pktRecv, b[:pktLen]); err != nil {
+			fmt.Fprintf(os.Stderr, "ERROR: SCION packet parse error: %v\n", err)
+			continue
+		}
+		info, extn, err := validate(pktRecv)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "ERROR: %v\n", err)
+			continue
+		}
+		cmn.Stats.Recv += 1
+		rtt := now.Sub(ts).Round(time.Microsecond)
+		fmt.Printf("%d bytes from %s,[%s] scmp_seq=%d time=%s Hops=%d/%d\n",
+			pktLen, pktRecv.SrcIA, pktRecv.SrcHost, info.Seq, rtt, len(extn.Records),
+			extn.Capacity)
+		prev := ts
+		for i, r := range extn.Records {
+			latency := r.Timestamp.Sub(prev)
+			prev = r.Timestamp
+			fmt.Printf(" %2d. %s %d>%d +%s queue %d: %d pkts (%d%%)\n", i+1, r.IA,
+				r.Ingress, r.Egress, latency.Round(time.Microsecond), r.QueueNo,
+				r.QueueLength, r.QueueFill)
+			add(i, r, latency)
+		}
+		return
+	}
+}
+
+func add(i int, r layers.INTRecord, latency time.Duration) {
+	for len(stats) <= i {
+		stats = append(stats, hopStats{})
+	}
+	s := &stats[i]
+	s.record = r
+	s.samples++
+	s.latency += latency
+	s.queueLen += int(r.QueueLength)
+	if r.QueueLength > s.maxQueue {
+		s.maxQueue = r.QueueLength
+	}
+}
+
+func summary() {
+	fmt.Printf("\n--- %s,[%s] telemetry statistics ---\n", cmn.Remote.IA, cmn.Remote.Host)
+	fmt.Printf("%d packets transmitted, %d received\n", cmn.Stats.Sent, cmn.Stats.Recv)
+	for i, s := range stats {
+		fmt.Printf(" %2d. %s %d>%d avg +%s queue avg %.1f max %d pkts (%d samples)\n",
+			i+1, s.record.IA, s.record.Ingress, s.record.Egress,
+			(s.latency / time.Duration(s.samples)).Round(time.Microsecond),
+			float64(s.queueLen)/float64(s.samples), s.maxQueue, s.samples)
+	}
+}
+
+func validate(pkt *spkt.ScnPkt) (*scmp.InfoEcho, *layers.ExtnINT, error) {
+	_, scmpPld, err := cmn.Validate(pkt)
+	if err != nil {
+		return nil, nil, err
+	}
+	info, ok := scmpPld.Info.(*scmp.InfoEcho)
+	if !ok {
+		return nil, nil,
+			common.NewBasicError("Not an Info Echo", nil, "type", common.TypeOf(scmpPld.Info))
+	}
+	if info.Id != id {
+		return nil, nil,
+			common.NewBasicError("Wrong SCMP ID", nil, "expected", id, "actual", info.Id)
+	}
+	for _, e := range pkt.HBHExt {
+		if extn, ok := e.(*layers.ExtnINT); ok {
+			return info, extn, nil
+		}
+	}
+	return nil, nil, common.NewBasicError("Reply without INT extension", nil)
+}
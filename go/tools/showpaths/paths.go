@@ -41,6 +41,7 @@ var (
 	expiration = flag.Bool("expiration", false, "Show path expiration timestamps")
 	refresh    = flag.Bool("refresh", false, "Set refresh flag for SCIOND path request")
 	status     = flag.Bool("p", false, "Probe the paths and print out the statuses")
+	quality    = flag.Bool("quality", false, "Show the path quality measured by SCIOND")
 	localIPStr = flag.String("local", "", "(Optional) local IP address to use for health checks")
 	version    = flag.Bool("version", false, "Output version information and exit.")
 )
@@ -109,6 +110,9 @@ func main() {
 		if *status {
 			fmt.Printf(" Status: %s", pathStatuses[pathprobe.PathKey(path)])
 		}
+		if *quality {
+			fmt.Printf(" Quality: %s", fmtQuality(path))
+		}
 		fmt.Printf("\n")
 	}
 }
@@ -192,3 +196,12 @@ func LogFatal(msg string, a ...interface{}) {
 	log.Crit(msg, a...)
 	os.Exit(1)
 }
+
+// fmtQuality formats the quality of the path, as measured by SCIOND.
+func fmtQuality(path snet.Path) string {
+	p, ok := path.(interface{ Quality() *sciond.PathQuality })
+	if !ok || p.Quality() == nil {
+		return "Unknown"
+	}
+	return p.Quality().String()
+}
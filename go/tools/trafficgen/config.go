@@ -0,0 +1,137 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"io/ioutil"
+
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/pathpol"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/lib/util"
+)
+
+const (
+	// L4UDP flows send UDP datagrams to a trafficgen server.
+	L4UDP = "udp"
+	// L4SCMP flows send SCMP echo requests that are answered by the remote
+	// dispatcher.
+	L4SCMP = "scmp"
+
+	// MinUDPPacketSize is the smallest payload that fits the probe header.
+	MinUDPPacketSize = hdrLen
+)
+
+// Config is the experiment description for the client mode.
+type Config struct {
+	// Duration is how long every flow sends traffic.
+	Duration util.DurWrap
+	// Flows are the flows that are run in parallel.
+	Flows []FlowConfig
+}
+
+// FlowConfig describes a single flow.
+type FlowConfig struct {
+	// Name identifies the flow in the results.
+	Name string
+	// Remote is the destination of the flow, e.g. 1-ff00:0:110,[127.0.0.1]:40002.
+	// For SCMP flows the port is ignored.
+	Remote string
+	// Rate is the sending rate of the flow, e.g. "10Mbps".
+	Rate util.Bandwidth
+	// PacketSize is the UDP payload size in bytes. It is ignored for SCMP
+	// flows, whose size is fixed by the SCMP echo format.
+	PacketSize int
+	// L4 is the L4 protocol of the flow, either "udp" or "scmp".
+	L4 string
+	// Sequence optionally restricts the paths the flow can use. The first
+	// path returned by SCIOND that matches the sequence is used.
+	Sequence *pathpol.Sequence `json:",omitempty"`
+	// PathIndex selects the n-th of the matching paths.
+	PathIndex int
+
+	remote *snet.UDPAddr
+}
+
+// LoadConfig loads and validates the experiment description from a JSON file.
+func LoadConfig(file string) (*Config, error) {
+	raw, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, common.NewBasicError("Unable to read config", err, "file", file)
+	}
+	cfg := &Config{}
+	if err := json.Unmarshal(raw, cfg); err != nil {
+		return nil, common.NewBasicError("Unable to parse config", err, "file", file)
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// Validate checks that the config is complete and fills in defaults.
+func (cfg *Config) Validate() error {
+	if cfg.Duration.Duration <= 0 {
+		return serrors.New("duration must be positive", "duration", cfg.Duration)
+	}
+	if len(cfg.Flows) == 0 {
+		return serrors.New("no flows configured")
+	}
+	names := make(map[string]struct{}, len(cfg.Flows))
+	for i := range cfg.Flows {
+		f := &cfg.Flows[i]
+		if f.Name == "" {
+			return serrors.New("flow without name", "index", i)
+		}
+		if _, ok := names[f.Name]; ok {
+			return serrors.New("duplicate flow name", "name", f.Name)
+		}
+		names[f.Name] = struct{}{}
+		if err := f.validate(); err != nil {
+			return serrors.WrapStr("invalid flow", err, "name", f.Name)
+		}
+	}
+	return nil
+}
+
+func (f *FlowConfig) validate() error {
+	var err error
+	if f.remote, err = snet.ParseUDPAddr(f.Remote); err != nil {
+		return serrors.WrapStr("unable to parse remote", err, "remote", f.Remote)
+	}
+	if f.Rate == 0 {
+		return serrors.New("rate must be positive")
+	}
+	if f.PathIndex < 0 {
+		return serrors.New("path index must not be negative", "index", f.PathIndex)
+	}
+	switch f.L4 {
+	case "", L4UDP:
+		f.L4 = L4UDP
+		if f.remote.Host.Port == 0 {
+			return serrors.New("UDP flows need a remote port", "remote", f.Remote)
+		}
+		if f.PacketSize < MinUDPPacketSize || f.PacketSize > common.MaxMTU {
+			return serrors.New("invalid packet size", "min", MinUDPPacketSize,
+				"max", common.MaxMTU, "actual", f.PacketSize)
+		}
+	case L4SCMP:
+	default:
+		return serrors.New("unsupported L4 type", "l4", f.L4)
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/util"
+	"github.com/scionproto/scion/go/lib/xtest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	cfg, err := LoadConfig("testdata/flows.json")
+	require.NoError(t, err)
+	assert.Equal(t, 10*time.Second, cfg.Duration.Duration)
+	require.Len(t, cfg.Flows, 3)
+
+	assert.Equal(t, util.Bandwidth(20000000), cfg.Flows[0].Rate)
+	assert.Equal(t, L4UDP, cfg.Flows[0].L4)
+	assert.NotNil(t, cfg.Flows[0].Sequence)
+	assert.Equal(t, xtest.MustParseIA("1-ff00:0:112"), cfg.Flows[0].remote.IA)
+	assert.Equal(t, 40002, cfg.Flows[0].remote.Host.Port)
+
+	assert.Equal(t, L4UDP, cfg.Flows[1].L4, "udp is the default")
+	assert.Equal(t, 1, cfg.Flows[1].PathIndex)
+	assert.Nil(t, cfg.Flows[1].Sequence)
+
+	assert.Equal(t, L4SCMP, cfg.Flows[2].L4)
+}
+
+func TestConfigValidate(t *testing.T) {
+	valid := func() FlowConfig {
+		return FlowConfig{
+			Name:       "flow",
+			Remote:     "1-ff00:0:110,[127.0.0.1]:40000",
+			Rate:       1000,
+			PacketSize: 100,
+		}
+	}
+	tests := map[string]struct {
+		Modify    func(cfg *Config)
+		Assertion assert.ErrorAssertionFunc
+	}{
+		"valid": {
+			Modify:    func(*Config) {},
+			Assertion: assert.NoError,
+		},
+		"no duration": {
+			Modify:    func(cfg *Config) { cfg.Duration = util.DurWrap{} },
+			Assertion: assert.Error,
+		},
+		"no flows": {
+			Modify:    func(cfg *Config) { cfg.Flows = nil },
+			Assertion: assert.Error,
+		},
+		"duplicate name": {
+			Modify:    func(cfg *Config) { cfg.Flows = append(cfg.Flows, valid()) },
+			Assertion: assert.Error,
+		},
+		"invalid remote": {
+			Modify:    func(cfg *Config) { cfg.Flows[0].Remote = "127.0.0.1:40000" },
+			Assertion: assert.Error,
+		},
+		"udp without port": {
+			Modify:    func(cfg *Config) { cfg.Flows[0].Remote = "1-ff00:0:110,[127.0.0.1]" },
+			Assertion: assert.Error,
+		},
+		"scmp without port": {
+			Modify: func(cfg *Config) {
+				cfg.Flows[0].Remote = "1-ff00:0:110,[127.0.0.1]"
+				cfg.Flows[0].L4 = L4SCMP
+			},
+			Assertion: assert.NoError,
+		},
+		"zero rate": {
+			Modify:    func(cfg *Config) { cfg.Flows[0].Rate = 0 },
+			Assertion: assert.Error,
+		},
+		"packet too small": {
+			Modify:    func(cfg *Config) { cfg.Flows[0].PacketSize = hdrLen - 1 },
+			Assertion: assert.Error,
+		},
+		"packet too large": {
+			Modify:    func(cfg *Config) { cfg.Flows[0].PacketSize = common.MaxMTU + 1 },
+			Assertion: assert.Error,
+		},
+		"unknown l4": {
+			Modify:    func(cfg *Config) { cfg.Flows[0].L4 = "tcp" },
+			Assertion: assert.Error,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			cfg := &Config{
+				Duration: util.DurWrap{Duration: time.Second},
+				Flows:    []FlowConfig{valid()},
+			}
+			test.Modify(cfg)
+			test.Assertion(t, cfg.Validate())
+		})
+	}
+}
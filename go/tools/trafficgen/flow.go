@@ -0,0 +1,341 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/l4"
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/pathpol"
+	"github.com/scionproto/scion/go/lib/sciond"
+	"github.com/scionproto/scion/go/lib/scmp"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/lib/sock/reliable"
+	"github.com/scionproto/scion/go/lib/topology"
+)
+
+const (
+	reportAttempts = 3
+	reportTimeout  = time.Second
+)
+
+var _ snet.SCMPHandler = (*flow)(nil)
+
+// flow sends the traffic of a single configured flow and collects the
+// measurements. It is the SCMP handler of its own connection, so that
+// congestion warnings and echo replies are attributed to the flow.
+type flow struct {
+	cfg    FlowConfig
+	id     uint32
+	local  snet.SCIONAddress
+	remote snet.SCIONAddress
+	path   snet.Path
+	nh     *net.UDPAddr
+	conn   snet.PacketConn
+	port   uint16
+
+	mtx     sync.Mutex
+	res     FlowResult
+	rttSum  time.Duration
+	rttMax  time.Duration
+	reports chan *report
+	done    chan struct{}
+}
+
+func newFlow(ctx context.Context, cfg FlowConfig, id uint32, ds reliable.Dispatcher,
+	sdConn sciond.Connector) (*flow, error) {
+
+	f := &flow{
+		cfg:     cfg,
+		id:      id,
+		local:   snet.SCIONAddress{IA: local.IA, Host: addr.HostFromIP(local.Host.IP)},
+		remote:  snet.SCIONAddress{IA: cfg.remote.IA, Host: addr.HostFromIP(cfg.remote.Host.IP)},
+		reports: make(chan *report, 1),
+		done:    make(chan struct{}),
+	}
+	f.res = FlowResult{
+		Name:             cfg.Name,
+		L4:               cfg.L4,
+		Remote:           cfg.Remote,
+		Rate:             cfg.Rate.String(),
+		PacketSize:       cfg.PacketSize,
+		WarningsByRouter: make(map[string]uint64),
+	}
+	if err := f.choosePath(ctx, sdConn); err != nil {
+		return nil, err
+	}
+	svc := &snet.DefaultPacketDispatcherService{Dispatcher: ds, SCMPHandler: f}
+	var err error
+	f.conn, f.port, err = svc.Register(ctx, local.IA, &net.UDPAddr{IP: local.Host.IP},
+		addr.SvcNone)
+	if err != nil {
+		return nil, common.NewBasicError("Unable to register flow", err, "flow", cfg.Name)
+	}
+	return f, nil
+}
+
+func (f *flow) choosePath(ctx context.Context, sdConn sciond.Connector) error {
+	if f.remote.IA.Equal(local.IA) {
+		f.nh = &net.UDPAddr{IP: f.cfg.remote.Host.IP, Port: topology.EndhostPort}
+		return nil
+	}
+	paths, err := sdConn.Paths(ctx, f.remote.IA, local.IA, sciond.PathReqFlags{})
+	if err != nil {
+		return common.NewBasicError("Unable to fetch paths", err, "flow", f.cfg.Name)
+	}
+	if f.cfg.Sequence != nil {
+		ps := make(pathpol.PathSet, len(paths))
+		for _, p := range paths {
+			ps[p.Fingerprint()] = p
+		}
+		ps = f.cfg.Sequence.Eval(ps)
+		matching := paths[:0]
+		for _, p := range paths {
+			if _, ok := ps[p.Fingerprint()]; ok {
+				matching = append(matching, p)
+			}
+		}
+		paths = matching
+	}
+	if f.cfg.PathIndex >= len(paths) {
+		return serrors.New("no matching path", "flow", f.cfg.Name,
+			"available", len(paths), "index", f.cfg.PathIndex)
+	}
+	f.path = paths[f.cfg.PathIndex]
+	f.nh = f.path.OverlayNextHop()
+	f.res.Path = fmt.Sprintf("%s", f.path)
+	return nil
+}
+
+// run sends traffic for the given duration and collects the results.
+func (f *flow) run(duration, drain time.Duration) *FlowResult {
+	defer func() {
+		close(f.done)
+		f.conn.Close()
+	}()
+	go func() {
+		defer log.HandlePanic()
+		f.recv()
+	}()
+	sendTime := f.send(duration)
+	time.Sleep(drain)
+	var rep *report
+	if f.cfg.L4 == L4UDP {
+		rep = f.requestReport()
+	}
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	if f.cfg.L4 == L4SCMP {
+		rep = &report{Packets: f.res.RecvPackets, Bytes: f.res.RecvBytes,
+			LatencySum: int64(f.rttSum), LatencyMax: int64(f.rttMax)}
+	} else if rep == nil {
+		f.res.ReportMissing = true
+	}
+	f.res.finish(rep, sendTime)
+	log.Info("Flow finished", "flow", f.cfg.Name, "SrcIA", f.local.IA, "SrcHost", f.local.Host,
+		"DstIA", f.remote.IA, "DstHost", f.remote.Host, "sent", f.res.SentPackets,
+		"received", f.res.RecvPackets, "warnings", f.res.CongestionWarnings)
+	res := f.res
+	return &res
+}
+
+// send paces the packets such that the configured rate is met on average.
+// It returns the time spent sending.
+func (f *flow) send(duration time.Duration) time.Duration {
+	pkt := f.newPacket()
+	start := time.Now()
+	size, pldSize := f.packetLen()
+	interval := time.Duration(float64(size*8) / float64(f.cfg.Rate) * float64(time.Second))
+	var seq uint64
+	for {
+		now := time.Now()
+		if now.Sub(start) >= duration {
+			return now.Sub(start)
+		}
+		if err := f.write(pkt, seq, now); err != nil {
+			log.Error("Unable to send packet", "flow", f.cfg.Name, "err", err)
+		} else {
+			f.mtx.Lock()
+			f.res.SentPackets++
+			f.res.SentBytes += uint64(pldSize)
+			f.mtx.Unlock()
+		}
+		seq++
+		time.Sleep(time.Until(start.Add(time.Duration(seq) * interval)))
+	}
+}
+
+func (f *flow) newPacket() *snet.Packet {
+	pkt := &snet.Packet{
+		PacketInfo: snet.PacketInfo{
+			Destination: f.remote,
+			Source:      f.local,
+		},
+	}
+	if f.path != nil {
+		pkt.Path = f.path.Path()
+	}
+	return pkt
+}
+
+// packetLen returns the L4 length and the payload length of the packets of
+// the flow. SCMP echo messages are accounted as payload in their entirety.
+func (f *flow) packetLen() (int, int) {
+	if f.cfg.L4 == L4SCMP {
+		info := &scmp.InfoEcho{}
+		l := scmp.HdrLen + scmp.MetaLen + info.Len()
+		return l, l
+	}
+	return l4.UDPLen + f.cfg.PacketSize, f.cfg.PacketSize
+}
+
+func (f *flow) write(pkt *snet.Packet, seq uint64, now time.Time) error {
+	if f.cfg.L4 == L4SCMP {
+		info := &scmp.InfoEcho{Id: uint64(f.id), Seq: uint16(seq)}
+		meta := scmp.Meta{InfoLen: uint8(info.Len() / common.LineLen)}
+		pld := make(common.RawBytes, scmp.MetaLen+info.Len())
+		meta.Write(pld)
+		info.Write(pld[scmp.MetaLen:])
+		scmpHdr := scmp.NewHdr(
+			scmp.ClassType{Class: scmp.C_General, Type: scmp.T_G_EchoRequest}, len(pld))
+		scmpHdr.SetTime(now)
+		pkt.L4Header = scmpHdr
+		pkt.Payload = pld
+	} else {
+		pld := make(common.RawBytes, f.cfg.PacketSize)
+		h := &hdr{Type: msgData, Flow: f.id, Seq: seq, Timestamp: now.UnixNano()}
+		h.Write(pld)
+		pkt.L4Header = &l4.UDP{SrcPort: f.port, DstPort: uint16(f.cfg.remote.Host.Port)}
+		pkt.Payload = pld
+	}
+	return f.conn.WriteTo(pkt, f.nh)
+}
+
+// recv reads from the connection until it is closed. SCMP messages are
+// handled in Handle, UDP messages are expected to be reports.
+func (f *flow) recv() {
+	for {
+		var pkt snet.Packet
+		var ov net.UDPAddr
+		if err := f.conn.ReadFrom(&pkt, &ov); err != nil {
+			select {
+			case <-f.done:
+				return
+			default:
+				log.Debug("Unable to read", "flow", f.cfg.Name, "err", err)
+				continue
+			}
+		}
+		pld, ok := pkt.Payload.(common.RawBytes)
+		if !ok {
+			continue
+		}
+		h, err := hdrFromRaw(pld)
+		if err != nil || h.Type != msgReport || h.Flow != f.id {
+			log.Debug("Ignoring unexpected message", "flow", f.cfg.Name, "err", err)
+			continue
+		}
+		rep, err := reportFromRaw(pld[hdrLen:])
+		if err != nil {
+			log.Error("Invalid report", "flow", f.cfg.Name, "err", err)
+			continue
+		}
+		select {
+		case f.reports <- rep:
+		default:
+		}
+	}
+}
+
+func (f *flow) requestReport() *report {
+	pkt := f.newPacket()
+	for i := 0; i < reportAttempts; i++ {
+		pld := make(common.RawBytes, hdrLen)
+		h := &hdr{Type: msgReportReq, Flow: f.id, Timestamp: time.Now().UnixNano()}
+		h.Write(pld)
+		pkt.L4Header = &l4.UDP{SrcPort: f.port, DstPort: uint16(f.cfg.remote.Host.Port)}
+		pkt.Payload = pld
+		if err := f.conn.WriteTo(pkt, f.nh); err != nil {
+			log.Error("Unable to request report", "flow", f.cfg.Name, "err", err)
+			continue
+		}
+		select {
+		case rep := <-f.reports:
+			return rep
+		case <-time.After(reportTimeout):
+			log.Info("Report request timed out", "flow", f.cfg.Name, "attempt", i)
+		}
+	}
+	return nil
+}
+
+// Handle implements snet.SCMPHandler. It accounts congestion warnings and
+// echo replies, all other SCMP messages are ignored.
+func (f *flow) Handle(pkt *snet.Packet) error {
+	scmpHdr, ok := pkt.L4Header.(*scmp.Hdr)
+	if !ok {
+		return nil
+	}
+	pld, ok := pkt.Payload.(*scmp.Payload)
+	if !ok {
+		return nil
+	}
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	switch info := pld.Info.(type) {
+	case *scmp.InfoEcho:
+		if scmpHdr.Type != scmp.T_G_EchoReply || info.Id != uint64(f.id) {
+			return nil
+		}
+		rtt := time.Since(scmpHdr.Time())
+		f.res.RecvPackets++
+		f.res.RecvBytes += uint64(scmpHdr.TotalLen)
+		f.rttSum += rtt
+		if rtt > f.rttMax {
+			f.rttMax = rtt
+		}
+	case *scmp.InfoBscCW:
+		f.addWarning(&Warning{Type: "basic", Router: routerName(pkt),
+			CurrBW: info.CurrBW, QueueLength: info.QueueLength,
+			QueueFullness: info.QueueFullness, ConsIngress: info.ConsIngress,
+			Violation: info.Violation})
+	case *scmp.InfoStochCW:
+		f.addWarning(&Warning{Type: "stochastic", Router: routerName(pkt),
+			CurrBW: info.CurrBW, QueueLength: info.QueueLength,
+			QueueFullness: info.QueueFullness, ConsIngress: info.ConsIngress,
+			Violation: info.Violation})
+	default:
+		log.Debug("Ignoring SCMP message", "flow", f.cfg.Name, "hdr", scmpHdr)
+	}
+	return nil
+}
+
+func (f *flow) addWarning(w *Warning) {
+	f.res.CongestionWarnings++
+	f.res.WarningsByRouter[fmt.Sprintf("%s ingress=%d", w.Router, w.ConsIngress)]++
+	f.res.LastWarning = w
+}
+
+func routerName(pkt *snet.Packet) string {
+	return fmt.Sprintf("%s,[%s]", pkt.Source.IA, pkt.Source.Host)
+}
@@ -0,0 +1,146 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Traffic generator for QoS experiments.
+//
+// In client mode, the tool runs the flows described in a JSON config file in
+// parallel and writes per-flow goodput, loss, latency and the received
+// congestion warnings to a JSON results file. UDP flows need a trafficgen
+// server at the remote address, which reports the receive side statistics at
+// the end of the experiment. SCMP flows send echo requests that are answered
+// by the remote dispatcher.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"math/rand"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/env"
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/sciond"
+	_ "github.com/scionproto/scion/go/lib/scrypto" // Make sure math/rand is seeded
+	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/lib/sock/reliable"
+	"github.com/scionproto/scion/go/lib/util"
+)
+
+const (
+	ModeServer = "server"
+	ModeClient = "client"
+)
+
+var (
+	local snet.UDPAddr
+
+	cfgFile    = flag.String("config", "", "Experiment config file (client only)")
+	dispatcher = flag.String("dispatcher", reliable.DefaultDispPath, "Path to dispatcher socket")
+	drain      = &util.DurWrap{Duration: time.Second}
+	mode       = flag.String("mode", ModeClient, "Run in "+ModeClient+" or "+ModeServer+" mode")
+	resFile    = flag.String("results", "-",
+		"File the results are written to, - for stdout (client only)")
+	sciondAddr = flag.String("sciond", sciond.DefaultSCIONDAddress, "SCIOND address")
+	version    = flag.Bool("version", false, "Output version information and exit.")
+	logConsole string
+)
+
+func init() {
+	flag.Var(&local, "local", "(Mandatory) address to listen on")
+	flag.Var(drain, "drain", "Time to wait for in-flight packets before requesting reports")
+	flag.StringVar(&logConsole, "log.console", "info",
+		"Console logging level: trace|debug|info|warn|error|crit")
+}
+
+func main() {
+	os.Exit(realMain())
+}
+
+func realMain() int {
+	flag.Parse()
+	if *version {
+		fmt.Print(env.VersionInfo())
+		return 0
+	}
+	if err := log.Setup(log.Config{Console: log.ConsoleConfig{Level: logConsole}}); err != nil {
+		fmt.Fprintf(os.Stderr, "ERROR: %s\n", err)
+		flag.Usage()
+		return 1
+	}
+	defer log.Flush()
+	defer log.HandlePanic()
+	if local.Host == nil {
+		log.Crit("Missing local address")
+		return 1
+	}
+	ds := reliable.NewDispatcher(*dispatcher)
+	switch *mode {
+	case ModeServer:
+		if err := runServer(ds); err != nil {
+			log.Crit("Server failed", "err", err)
+			return 1
+		}
+		return 0
+	case ModeClient:
+		if err := runClient(ds); err != nil {
+			log.Crit("Client failed", "err", err)
+			return 1
+		}
+		return 0
+	default:
+		log.Crit("Unknown mode, must be either '" + ModeClient + "' or '" + ModeServer + "'")
+		return 1
+	}
+}
+
+func runClient(ds reliable.Dispatcher) error {
+	cfg, err := LoadConfig(*cfgFile)
+	if err != nil {
+		return err
+	}
+	ctx, cancelF := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancelF()
+	sdConn, err := sciond.NewService(*sciondAddr).Connect(ctx)
+	if err != nil {
+		return err
+	}
+	flows := make([]*flow, 0, len(cfg.Flows))
+	for _, fc := range cfg.Flows {
+		f, err := newFlow(ctx, fc, rand.Uint32(), ds, sdConn)
+		if err != nil {
+			return err
+		}
+		flows = append(flows, f)
+	}
+	res := &Results{
+		Start:    time.Now(),
+		Duration: cfg.Duration.String(),
+		Flows:    make([]*FlowResult, len(flows)),
+	}
+	log.Info("Starting experiment", "flows", len(flows), "duration", cfg.Duration)
+	var wg sync.WaitGroup
+	for i, f := range flows {
+		wg.Add(1)
+		go func(i int, f *flow) {
+			defer log.HandlePanic()
+			defer wg.Done()
+			res.Flows[i] = f.run(cfg.Duration.Duration, drain.Duration)
+		}(i, f)
+	}
+	wg.Wait()
+	return res.Write(*resFile)
+}
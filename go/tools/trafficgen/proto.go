@@ -0,0 +1,134 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/serrors"
+)
+
+// The UDP probes carry a fixed header followed by padding:
+//
+//  0               2               4               6               8
+//  +-------+-------+-------+-------+-------+-------+-------+-------+
+//  |             Magic             | Type  |       Reserved        |
+//  +-------+-------+-------+-------+-------+-------+-------+-------+
+//  |            Flow ID            |           (unused)            |
+//  +-------+-------+-------+-------+-------+-------+-------+-------+
+//  |                           Sequence                            |
+//  +-------+-------+-------+-------+-------+-------+-------+-------+
+//  |                      Timestamp (unix ns)                      |
+//  +-------+-------+-------+-------+-------+-------+-------+-------+
+//
+// Report messages append the receiver statistics of the flow to the header.
+const (
+	magic     uint32 = 0x5347454e // "SGEN"
+	hdrLen           = 32
+	reportLen        = 6 * 8
+)
+
+type msgType uint8
+
+const (
+	msgData msgType = iota
+	msgReportReq
+	msgReport
+)
+
+type hdr struct {
+	Type      msgType
+	Flow      uint32
+	Seq       uint64
+	Timestamp int64
+}
+
+func hdrFromRaw(b common.RawBytes) (*hdr, error) {
+	if len(b) < hdrLen {
+		return nil, serrors.New("message too short", "expected", hdrLen, "actual", len(b))
+	}
+	if m := common.Order.Uint32(b); m != magic {
+		return nil, serrors.New("invalid magic", "actual", m)
+	}
+	return &hdr{
+		Type:      msgType(b[4]),
+		Flow:      common.Order.Uint32(b[8:]),
+		Seq:       common.Order.Uint64(b[16:]),
+		Timestamp: int64(common.Order.Uint64(b[24:])),
+	}, nil
+}
+
+func (h *hdr) Write(b common.RawBytes) {
+	common.Order.PutUint32(b, magic)
+	b[4] = byte(h.Type)
+	b[5], b[6], b[7] = 0, 0, 0
+	common.Order.PutUint32(b[8:], h.Flow)
+	common.Order.PutUint32(b[12:], 0)
+	common.Order.PutUint64(b[16:], h.Seq)
+	common.Order.PutUint64(b[24:], uint64(h.Timestamp))
+}
+
+// report contains the receiver side statistics of a flow.
+type report struct {
+	Packets uint64
+	Bytes   uint64
+	// FirstRecv and LastRecv are the unix ns timestamps of the first and the
+	// last packet received.
+	FirstRecv int64
+	LastRecv  int64
+	// LatencySum and LatencyMax are the sum and the maximum of the one-way
+	// latencies in ns. They are only meaningful if the clocks of sender and
+	// receiver are synchronized.
+	LatencySum int64
+	LatencyMax int64
+}
+
+func reportFromRaw(b common.RawBytes) (*report, error) {
+	if len(b) < reportLen {
+		return nil, serrors.New("report too short", "expected", reportLen, "actual", len(b))
+	}
+	return &report{
+		Packets:    common.Order.Uint64(b),
+		Bytes:      common.Order.Uint64(b[8:]),
+		FirstRecv:  int64(common.Order.Uint64(b[16:])),
+		LastRecv:   int64(common.Order.Uint64(b[24:])),
+		LatencySum: int64(common.Order.Uint64(b[32:])),
+		LatencyMax: int64(common.Order.Uint64(b[40:])),
+	}, nil
+}
+
+func (r *report) Write(b common.RawBytes) {
+	common.Order.PutUint64(b, r.Packets)
+	common.Order.PutUint64(b[8:], r.Bytes)
+	common.Order.PutUint64(b[16:], uint64(r.FirstRecv))
+	common.Order.PutUint64(b[24:], uint64(r.LastRecv))
+	common.Order.PutUint64(b[32:], uint64(r.LatencySum))
+	common.Order.PutUint64(b[40:], uint64(r.LatencyMax))
+}
+
+// add accounts a data packet of size n that was sent at sent and received at
+// recv, both in unix ns.
+func (r *report) add(n int, sent, recv int64) {
+	if r.Packets == 0 {
+		r.FirstRecv = recv
+	}
+	r.Packets++
+	r.Bytes += uint64(n)
+	r.LastRecv = recv
+	lat := recv - sent
+	r.LatencySum += lat
+	if lat > r.LatencyMax {
+		r.LatencyMax = lat
+	}
+}
@@ -0,0 +1,69 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/common"
+)
+
+func TestHdrRoundTrip(t *testing.T) {
+	h := &hdr{Type: msgReportReq, Flow: 0xdeadbeef, Seq: 42, Timestamp: 1234567890}
+	b := make(common.RawBytes, hdrLen)
+	h.Write(b)
+	parsed, err := hdrFromRaw(b)
+	require.NoError(t, err)
+	assert.Equal(t, h, parsed)
+
+	_, err = hdrFromRaw(b[:hdrLen-1])
+	assert.Error(t, err)
+	b[0] ^= 0xff
+	_, err = hdrFromRaw(b)
+	assert.Error(t, err, "bad magic")
+}
+
+func TestReport(t *testing.T) {
+	r := &report{}
+	r.add(100, 0, 10)
+	r.add(200, 15, 40)
+	expected := &report{Packets: 2, Bytes: 300, FirstRecv: 10, LastRecv: 40,
+		LatencySum: 35, LatencyMax: 25}
+	assert.Equal(t, expected, r)
+
+	b := make(common.RawBytes, reportLen)
+	r.Write(b)
+	parsed, err := reportFromRaw(b)
+	require.NoError(t, err)
+	assert.Equal(t, r, parsed)
+}
+
+func TestFlowResultFinish(t *testing.T) {
+	r := &FlowResult{SentPackets: 10, SentBytes: 1000}
+	r.finish(&report{Packets: 8, Bytes: 800, LatencySum: int64(16 * time.Millisecond),
+		LatencyMax: int64(5 * time.Millisecond)}, 2*time.Second)
+	assert.InDelta(t, 0.2, r.Loss, 1e-9)
+	assert.InDelta(t, 3200, r.Goodput, 1e-9)
+	assert.InDelta(t, 2, r.LatencyAvg, 1e-9)
+	assert.InDelta(t, 5, r.LatencyMax, 1e-9)
+
+	r = &FlowResult{SentPackets: 10}
+	r.finish(nil, time.Second)
+	assert.Equal(t, float64(1), r.Loss, "missing report counts as full loss")
+}
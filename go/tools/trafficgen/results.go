@@ -0,0 +1,117 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/common"
+)
+
+// Results is the machine-readable outcome of an experiment.
+type Results struct {
+	Start    time.Time
+	Duration string
+	Flows    []*FlowResult
+}
+
+// FlowResult contains the measurements of a single flow. For UDP flows the
+// receive side values are reported by the trafficgen server and the latency
+// is one-way. For SCMP flows they are derived from the echo replies and the
+// latency is the round trip time.
+type FlowResult struct {
+	Name       string
+	L4         string
+	Remote     string
+	Path       string
+	Rate       string
+	PacketSize int
+
+	SentPackets uint64
+	SentBytes   uint64
+	RecvPackets uint64
+	RecvBytes   uint64
+	// Loss is the fraction of sent packets that were not received.
+	Loss float64
+	// Goodput is the received payload rate in bits per second.
+	Goodput    float64
+	LatencyAvg float64 `json:"LatencyAvgMs"`
+	LatencyMax float64 `json:"LatencyMaxMs"`
+	// ReportMissing is set if the server did not answer the report request.
+	ReportMissing bool `json:",omitempty"`
+
+	// CongestionWarnings is the total number of congestion warnings received.
+	CongestionWarnings uint64
+	// WarningsByRouter counts the congestion warnings per sending router and
+	// ingress interface.
+	WarningsByRouter map[string]uint64
+	// LastWarning is the content of the last congestion warning received.
+	LastWarning *Warning `json:",omitempty"`
+}
+
+// Warning is the content of a basic or stochastic congestion warning.
+type Warning struct {
+	Type          string
+	Router        string
+	CurrBW        uint64
+	QueueLength   uint64
+	QueueFullness uint64
+	ConsIngress   common.IFIDType
+	Violation     uint64
+}
+
+// finish computes the derived values of the result. sendTime is the time
+// spent sending.
+func (r *FlowResult) finish(rep *report, sendTime time.Duration) {
+	if rep != nil {
+		r.RecvPackets = rep.Packets
+		r.RecvBytes = rep.Bytes
+		if rep.Packets > 0 {
+			r.LatencyAvg = nsToMs(float64(rep.LatencySum) / float64(rep.Packets))
+			r.LatencyMax = nsToMs(float64(rep.LatencyMax))
+		}
+	}
+	if r.SentPackets > 0 && r.RecvPackets <= r.SentPackets {
+		r.Loss = 1 - float64(r.RecvPackets)/float64(r.SentPackets)
+	}
+	if sendTime > 0 {
+		r.Goodput = float64(r.RecvBytes*8) / sendTime.Seconds()
+	}
+}
+
+func nsToMs(ns float64) float64 {
+	return ns / float64(time.Millisecond)
+}
+
+// Write writes the results as JSON to file. If file is "-", the results are
+// written to stdout.
+func (res *Results) Write(file string) error {
+	raw, err := json.MarshalIndent(res, "", "    ")
+	if err != nil {
+		return common.NewBasicError("Unable to marshal results", err)
+	}
+	raw = append(raw, '\n')
+	if file == "-" {
+		_, err = os.Stdout.Write(raw)
+		return err
+	}
+	if err := ioutil.WriteFile(file, raw, 0644); err != nil {
+		return common.NewBasicError("Unable to write results", err, "file", file)
+	}
+	return nil
+}
@@ -0,0 +1,153 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/addr"
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/snet"
+	"github.com/scionproto/scion/go/lib/sock/reliable"
+)
+
+// flowIdleTimeout is the time after which the server forgets a flow it did
+// not receive any packet for.
+const flowIdleTimeout = time.Minute
+
+// flowKey identifies a flow on the server side.
+type flowKey struct {
+	src  string
+	flow uint32
+}
+
+// serverFlow is the receive side state of a flow.
+type serverFlow struct {
+	rep      report
+	lastSeen time.Time
+}
+
+// server receives the UDP flows and answers report requests with the
+// statistics it collected for the flow.
+type server struct {
+	conn      snet.PacketConn
+	flows     map[flowKey]*serverFlow
+	lastSweep time.Time
+}
+
+func runServer(ds reliable.Dispatcher) error {
+	svc := &snet.DefaultPacketDispatcherService{
+		Dispatcher:  ds,
+		SCMPHandler: snet.NewSCMPHandler(nil),
+	}
+	conn, port, err := svc.Register(context.Background(), local.IA, local.Host, addr.SvcNone)
+	if err != nil {
+		return common.NewBasicError("Unable to listen", err)
+	}
+	defer conn.Close()
+	log.Info("Listening", "local", fmt.Sprintf("%s,[%s]:%d", local.IA, local.Host.IP, port))
+	s := &server{conn: conn, flows: make(map[flowKey]*serverFlow), lastSweep: time.Now()}
+	return s.serve()
+}
+
+func (s *server) serve() error {
+	for {
+		var pkt snet.Packet
+		var ov net.UDPAddr
+		if err := s.conn.ReadFrom(&pkt, &ov); err != nil {
+			if isPermanent(err) {
+				return common.NewBasicError("Unable to read", err)
+			}
+			log.Error("Unable to read", "err", err)
+			continue
+		}
+		now := time.Now()
+		s.sweep(now)
+		recv := now.UnixNano()
+		pld, ok := pkt.Payload.(common.RawBytes)
+		if !ok {
+			continue
+		}
+		h, err := hdrFromRaw(pld)
+		if err != nil {
+			log.Debug("Ignoring invalid message", "src", pkt.Source, "err", err)
+			continue
+		}
+		key := flowKey{src: fmt.Sprintf("%s,%s", pkt.Source.IA, pkt.Source.Host), flow: h.Flow}
+		f, ok := s.flows[key]
+		if !ok {
+			f = &serverFlow{}
+			s.flows[key] = f
+		}
+		f.lastSeen = now
+		switch h.Type {
+		case msgData:
+			f.rep.add(len(pld), h.Timestamp, recv)
+		case msgReportReq:
+			if err := s.sendReport(&pkt, &ov, h, &f.rep); err != nil {
+				log.Error("Unable to send report", "dst", pkt.Source, "err", err)
+			}
+		default:
+			log.Debug("Ignoring unexpected message", "src", pkt.Source, "type", h.Type)
+		}
+	}
+}
+
+// sweep removes the flows that have been idle for longer than
+// flowIdleTimeout. To keep the per-packet cost low, the flows are only checked
+// once per timeout interval.
+func (s *server) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < flowIdleTimeout {
+		return
+	}
+	s.lastSweep = now
+	for key, f := range s.flows {
+		if now.Sub(f.lastSeen) >= flowIdleTimeout {
+			delete(s.flows, key)
+		}
+	}
+}
+
+// isPermanent returns whether err was caused by the connection to the
+// dispatcher, which does not recover once it is closed.
+func isPermanent(err error) bool {
+	if errors.Is(err, io.EOF) {
+		return true
+	}
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && !opErr.Temporary()
+}
+
+func (s *server) sendReport(pkt *snet.Packet, ov *net.UDPAddr, h *hdr, rep *report) error {
+	if pkt.Path != nil {
+		if err := pkt.Path.Reverse(); err != nil {
+			return common.NewBasicError("Unable to reverse path", err)
+		}
+	}
+	pkt.Destination, pkt.Source = pkt.Source, pkt.Destination
+	pkt.L4Header.Reverse()
+	pld := make(common.RawBytes, hdrLen+reportLen)
+	(&hdr{Type: msgReport, Flow: h.Flow, Seq: h.Seq, Timestamp: h.Timestamp}).Write(pld)
+	rep.Write(pld[hdrLen:])
+	pkt.Payload = pld
+	pkt.Extensions = nil
+	return s.conn.WriteTo(pkt, ov)
+}
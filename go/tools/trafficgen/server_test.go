@@ -0,0 +1,51 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/scionproto/scion/go/lib/common"
+)
+
+func TestServerSweep(t *testing.T) {
+	now := time.Now()
+	idle := flowKey{src: "1-ff00:0:110,127.0.0.1", flow: 1}
+	active := flowKey{src: "1-ff00:0:110,127.0.0.1", flow: 2}
+	s := &server{
+		flows: map[flowKey]*serverFlow{
+			idle:   {lastSeen: now.Add(-2 * flowIdleTimeout)},
+			active: {lastSeen: now},
+		},
+		lastSweep: now.Add(-flowIdleTimeout / 2),
+	}
+	s.sweep(now)
+	assert.Len(t, s.flows, 2, "flows are not checked before the timeout elapsed")
+	s.sweep(now.Add(flowIdleTimeout / 2))
+	assert.Contains(t, s.flows, active)
+	assert.NotContains(t, s.flows, idle)
+}
+
+func TestIsPermanent(t *testing.T) {
+	closed := &net.OpError{Op: "read", Net: "unix", Err: io.ErrClosedPipe}
+	assert.True(t, isPermanent(common.NewBasicError("Reliable socket read error", io.EOF)))
+	assert.True(t, isPermanent(common.NewBasicError("Reliable socket read error", closed)))
+	assert.False(t, isPermanent(common.NewBasicError("SCION packet parse error", nil)))
+}
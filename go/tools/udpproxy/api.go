@@ -0,0 +1,109 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/prom"
+)
+
+const namespace = "udpproxy"
+
+// ServeMux returns the HTTP API of the proxy. /profile returns the profiles of
+// both directions on GET and replaces them on PUT. /counters returns the packet
+// counters of both directions, /metrics exports them to prometheus.
+func (p *proxy) ServeMux() *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/profile", p.handleProfile)
+	mux.HandleFunc("/counters", p.handleCounters)
+	mux.Handle("/metrics", promhttp.Handler())
+	return mux
+}
+
+func (p *proxy) handleProfile(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, p.Profiles())
+	case http.MethodPut, http.MethodPost:
+		var profiles Profiles
+		if err := json.NewDecoder(r.Body).Decode(&profiles); err != nil {
+			http.Error(w, fmt.Sprintf("Unable to parse profiles: %s", err),
+				http.StatusBadRequest)
+			return
+		}
+		if err := profiles.Validate(); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		p.SetProfiles(profiles)
+		log.Info("Profiles updated", "xy", profiles.XY, "yx", profiles.YX)
+		writeJSON(w, profiles)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (p *proxy) handleCounters(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, map[string]Counters{
+		"XY": p.xy.Counters(),
+		"YX": p.yx.Counters(),
+	})
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "    ")
+	if err := enc.Encode(v); err != nil {
+		log.Error("Unable to write response", "err", err)
+	}
+}
+
+// registerMetrics exports the counters and the queue length of the link to
+// prometheus, labeled with the direction.
+func registerMetrics(l *link) {
+	labels := prometheus.Labels{"dir": l.name}
+	counter := func(name, help string, f func(c Counters) uint64) {
+		prom.SafeRegister(prometheus.NewCounterFunc(prometheus.CounterOpts{
+			Namespace:   namespace,
+			Name:        name,
+			Help:        help,
+			ConstLabels: labels,
+		}, func() float64 { return float64(f(l.Counters())) }))
+	}
+	counter("received_pkts_total", "Number of packets received.",
+		func(c Counters) uint64 { return c.Received })
+	counter("forwarded_pkts_total", "Number of packets forwarded.",
+		func(c Counters) uint64 { return c.Forwarded })
+	counter("lost_pkts_total", "Number of packets dropped by the loss model.",
+		func(c Counters) uint64 { return c.Lost })
+	counter("queue_full_pkts_total", "Number of packets dropped at the full queue.",
+		func(c Counters) uint64 { return c.QueueFull })
+	counter("reordered_pkts_total", "Number of packets that skipped the delay.",
+		func(c Counters) uint64 { return c.Reordered })
+	prom.SafeRegister(prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace:   namespace,
+		Name:        "queue_length_pkts",
+		Help:        "Number of packets in the bottleneck queue.",
+		ConstLabels: labels,
+	}, func() float64 { return float64(l.QueueLength()) }))
+}
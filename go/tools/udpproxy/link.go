@@ -0,0 +1,401 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"container/heap"
+	"math/rand"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/scionproto/scion/go/lib/log"
+	"github.com/scionproto/scion/go/lib/util"
+)
+
+// Counters are the packet counters of one direction of the link.
+type Counters struct {
+	// Received counts the packets read from the source network.
+	Received uint64
+	// Forwarded counts the packets written to the destination network.
+	Forwarded uint64
+	// Lost counts the packets dropped by the loss model.
+	Lost uint64
+	// QueueFull counts the packets dropped because the bottleneck queue was
+	// full.
+	QueueFull uint64
+	// Reordered counts the packets that skipped the delay.
+	Reordered uint64
+}
+
+// link emulates one direction of the link. Packets read from the source
+// connection pass the loss model and wait in the bottleneck queue until the
+// token bucket lets them through. They are then held back in the delay line
+// and finally written to the destination.
+type link struct {
+	name   string
+	from   net.PacketConn
+	to     net.PacketConn
+	toAddr net.Addr
+
+	mu      sync.Mutex
+	cond    *sync.Cond
+	closed  bool
+	profile Profile
+	rnd     *rand.Rand
+	loss    lossModel
+	bucket  tokenBucket
+	queue   [][]byte
+	// lastRelease is the release time of the last packet that was not
+	// reordered. Jitter alone does not reorder packets.
+	lastRelease time.Time
+
+	delayed  *delayLine
+	counters Counters
+}
+
+func newLink(name string, from, to net.PacketConn, toAddr net.Addr, p Profile) *link {
+	l := &link{
+		name:    name,
+		from:    from,
+		to:      to,
+		toAddr:  toAddr,
+		profile: p,
+		rnd:     rand.New(rand.NewSource(time.Now().UnixNano())),
+		delayed: newDelayLine(),
+	}
+	l.cond = sync.NewCond(&l.mu)
+	return l
+}
+
+// Run starts relaying packets. It returns immediately.
+func (l *link) Run() {
+	go func() {
+		defer log.HandlePanic()
+		l.receive()
+	}()
+	go func() {
+		defer log.HandlePanic()
+		l.shape()
+	}()
+	go func() {
+		defer log.HandlePanic()
+		l.delayed.run(l.send)
+	}()
+}
+
+// Close stops the link. Packets that are still queued are discarded. The
+// connections are not closed.
+func (l *link) Close() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.closed {
+		return
+	}
+	l.closed = true
+	l.cond.Broadcast()
+	l.delayed.close()
+}
+
+// Profile returns the current profile.
+func (l *link) Profile() Profile {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.profile
+}
+
+// SetProfile replaces the profile. It applies to all packets that leave the
+// bottleneck queue from now on. If the queue shrinks, the packets above the
+// new size are kept, but new packets are dropped until the queue drained.
+func (l *link) SetProfile(p Profile) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.profile = p
+}
+
+// Counters returns a snapshot of the counters.
+func (l *link) Counters() Counters {
+	return Counters{
+		Received:  atomic.LoadUint64(&l.counters.Received),
+		Forwarded: atomic.LoadUint64(&l.counters.Forwarded),
+		Lost:      atomic.LoadUint64(&l.counters.Lost),
+		QueueFull: atomic.LoadUint64(&l.counters.QueueFull),
+		Reordered: atomic.LoadUint64(&l.counters.Reordered),
+	}
+}
+
+// QueueLength returns the number of packets in the bottleneck queue.
+func (l *link) QueueLength() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return len(l.queue)
+}
+
+func (l *link) receive() {
+	b := make([]byte, 1<<16)
+	for {
+		n, _, err := l.from.ReadFrom(b)
+		if err != nil {
+			if l.isClosed() {
+				return
+			}
+			log.Error("Unable to read from listen conn", "link", l.name, "err", err)
+			continue
+		}
+		l.enqueue(append([]byte(nil), b[:n]...))
+	}
+}
+
+func (l *link) enqueue(pkt []byte) {
+	atomic.AddUint64(&l.counters.Received, 1)
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.loss.lost(&l.profile, l.rnd) {
+		atomic.AddUint64(&l.counters.Lost, 1)
+		return
+	}
+	if len(l.queue) >= l.profile.QueueSize {
+		atomic.AddUint64(&l.counters.QueueFull, 1)
+		return
+	}
+	l.queue = append(l.queue, pkt)
+	l.cond.Signal()
+}
+
+// shape takes the packets from the bottleneck queue at the rate of the token
+// bucket and hands them to the delay line.
+func (l *link) shape() {
+	for {
+		l.mu.Lock()
+		for len(l.queue) == 0 && !l.closed {
+			l.cond.Wait()
+		}
+		if l.closed {
+			l.mu.Unlock()
+			return
+		}
+		pkt := l.queue[0]
+		l.queue[0] = nil
+		l.queue = l.queue[1:]
+		now := time.Now()
+		departure := l.bucket.reserve(len(pkt), now, l.profile.Bandwidth, l.profile.Burst)
+		release := l.release(departure)
+		l.mu.Unlock()
+		// The packet occupies the link until it departed, the next packet has
+		// to wait in the queue.
+		if wait := departure.Sub(now); wait > 0 {
+			time.Sleep(wait)
+		}
+		l.delayed.push(pkt, release)
+	}
+}
+
+// release returns the time at which a packet that departs at the given time
+// leaves the link. It must be called with the lock held.
+func (l *link) release(departure time.Time) time.Time {
+	p := &l.profile
+	if p.Reorder > 0 && l.rnd.Float64() < p.Reorder {
+		atomic.AddUint64(&l.counters.Reordered, 1)
+		return departure
+	}
+	d := p.Delay.Duration
+	if j := p.Jitter.Duration; j > 0 {
+		d += time.Duration(l.rnd.Int63n(int64(2*j)+1)) - j
+	}
+	if d < 0 {
+		d = 0
+	}
+	r := departure.Add(d)
+	if r.Before(l.lastRelease) {
+		r = l.lastRelease
+	}
+	l.lastRelease = r
+	return r
+}
+
+func (l *link) send(pkt []byte) {
+	if _, err := l.to.WriteTo(pkt, l.toAddr); err != nil {
+		log.Error("Unable to write to destination", "link", l.name, "err", err)
+		return
+	}
+	atomic.AddUint64(&l.counters.Forwarded, 1)
+}
+
+func (l *link) isClosed() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.closed
+}
+
+// lossModel decides which packets are lost. With a loss burst of at most 1,
+// losses are independent. Otherwise, it is a Gilbert model that loses all
+// packets in the bad state. The transition probabilities are chosen such that
+// the mean loss rate is Loss and the mean time in the bad state is LossBurst
+// packets.
+type lossModel struct {
+	bad bool
+}
+
+func (m *lossModel) lost(p *Profile, rnd *rand.Rand) bool {
+	if p.Loss <= 0 {
+		m.bad = false
+		return false
+	}
+	if p.LossBurst <= 1 {
+		m.bad = false
+		return rnd.Float64() < p.Loss
+	}
+	toGood := 1 / p.LossBurst
+	toBad := p.Loss * toGood / (1 - p.Loss)
+	if m.bad {
+		m.bad = rnd.Float64() >= toGood
+	} else {
+		m.bad = rnd.Float64() < toBad
+	}
+	return m.bad
+}
+
+// tokenBucket implements the bandwidth cap. Packets larger than the available
+// tokens put the bucket into debt, which delays the following packets.
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// reserve takes the tokens for a packet of n bytes and returns the time at
+// which the packet departs.
+func (b *tokenBucket) reserve(n int, now time.Time, rate util.Bandwidth,
+	burst int) time.Time {
+
+	if rate == 0 {
+		b.last = time.Time{}
+		return now
+	}
+	bps := rate.BytesPerSecond()
+	if b.last.IsZero() {
+		b.tokens = float64(burst)
+	} else {
+		b.tokens += now.Sub(b.last).Seconds() * bps
+	}
+	if b.tokens > float64(burst) {
+		b.tokens = float64(burst)
+	}
+	b.last = now
+	b.tokens -= float64(n)
+	if b.tokens >= 0 {
+		return now
+	}
+	return now.Add(time.Duration(-b.tokens / bps * float64(time.Second)))
+}
+
+// delayLine holds packets until their release time.
+type delayLine struct {
+	mu      sync.Mutex
+	packets delayedPackets
+	seq     uint64
+	wake    chan struct{}
+	done    chan struct{}
+}
+
+func newDelayLine() *delayLine {
+	return &delayLine{
+		wake: make(chan struct{}, 1),
+		done: make(chan struct{}),
+	}
+}
+
+func (d *delayLine) push(pkt []byte, release time.Time) {
+	d.mu.Lock()
+	heap.Push(&d.packets, &delayedPacket{pkt: pkt, release: release, seq: d.seq})
+	d.seq++
+	d.mu.Unlock()
+	select {
+	case d.wake <- struct{}{}:
+	default:
+	}
+}
+
+// run calls send for every packet once it is due, until the delay line is
+// closed.
+func (d *delayLine) run(send func([]byte)) {
+	for {
+		d.mu.Lock()
+		if len(d.packets) == 0 {
+			d.mu.Unlock()
+			select {
+			case <-d.wake:
+				continue
+			case <-d.done:
+				return
+			}
+		}
+		wait := time.Until(d.packets[0].release)
+		if wait <= 0 {
+			p := heap.Pop(&d.packets).(*delayedPacket)
+			d.mu.Unlock()
+			send(p.pkt)
+			continue
+		}
+		d.mu.Unlock()
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-d.wake:
+			timer.Stop()
+		case <-d.done:
+			timer.Stop()
+			return
+		}
+	}
+}
+
+func (d *delayLine) close() {
+	close(d.done)
+}
+
+type delayedPacket struct {
+	pkt     []byte
+	release time.Time
+	// seq keeps packets with the same release time in order.
+	seq uint64
+}
+
+// delayedPackets is a min-heap of packets ordered by release time.
+type delayedPackets []*delayedPacket
+
+func (h delayedPackets) Len() int { return len(h) }
+
+func (h delayedPackets) Less(i, j int) bool {
+	if h[i].release.Equal(h[j].release) {
+		return h[i].seq < h[j].seq
+	}
+	return h[i].release.Before(h[j].release)
+}
+
+func (h delayedPackets) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+
+func (h *delayedPackets) Push(x interface{}) {
+	*h = append(*h, x.(*delayedPacket))
+}
+
+func (h *delayedPackets) Pop() interface{} {
+	old := *h
+	n := len(old)
+	p := old[n-1]
+	old[n-1] = nil
+	*h = old[:n-1]
+	return p
+}
@@ -0,0 +1,145 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"math/rand"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/util"
+)
+
+func TestTokenBucket(t *testing.T) {
+	now := time.Now()
+	// 8kbps is 1000 bytes per second.
+	rate := util.Bandwidth(8000)
+	b := tokenBucket{}
+	// The full burst passes immediately.
+	assert.Equal(t, now, b.reserve(1000, now, rate, 2000))
+	assert.Equal(t, now, b.reserve(1000, now, rate, 2000))
+	// The next packet has to wait until the tokens are refilled.
+	assert.Equal(t, now.Add(500*time.Millisecond), b.reserve(500, now, rate, 2000))
+	assert.Equal(t, now.Add(time.Second), b.reserve(500, now, rate, 2000))
+	// After a long pause, the bucket is full again, but not above the burst.
+	later := now.Add(time.Hour)
+	assert.Equal(t, later, b.reserve(2000, later, rate, 2000))
+	assert.Equal(t, later.Add(100*time.Millisecond), b.reserve(100, later, rate, 2000))
+	// Without rate, nothing is delayed.
+	assert.Equal(t, later, b.reserve(1<<20, later, 0, 0))
+}
+
+func TestLossModel(t *testing.T) {
+	tests := map[string]Profile{
+		"independent": {Loss: 0.2},
+		"bursty":      {Loss: 0.2, LossBurst: 5},
+	}
+	for name, p := range tests {
+		t.Run(name, func(t *testing.T) {
+			rnd := rand.New(rand.NewSource(1))
+			m := lossModel{}
+			const n = 100000
+			var lost, bursts int
+			prev := false
+			for i := 0; i < n; i++ {
+				l := m.lost(&p, rnd)
+				if l {
+					lost++
+					if !prev {
+						bursts++
+					}
+				}
+				prev = l
+			}
+			assert.InDelta(t, p.Loss, float64(lost)/n, 0.01)
+			meanBurst := p.LossBurst
+			if meanBurst < 1 {
+				// Independent losses have a mean burst length of 1/(1-loss).
+				meanBurst = 1 / (1 - p.Loss)
+			}
+			assert.InDelta(t, meanBurst, float64(lost)/float64(bursts), 0.2)
+		})
+	}
+}
+
+func TestLinkRelease(t *testing.T) {
+	l := newLink("test", nil, nil, nil, Profile{
+		Delay:  util.DurWrap{Duration: 10 * time.Millisecond},
+		Jitter: util.DurWrap{Duration: 5 * time.Millisecond},
+	})
+	now := time.Now()
+	prev := now
+	for i := 0; i < 1000; i++ {
+		r := l.release(now)
+		assert.False(t, r.Before(prev), "jitter must not reorder")
+		assert.False(t, r.Before(now.Add(5*time.Millisecond)))
+		assert.False(t, r.After(now.Add(15*time.Millisecond)))
+		prev = r
+	}
+	l.SetProfile(Profile{Delay: util.DurWrap{Duration: time.Second}, Reorder: 1})
+	assert.Equal(t, now, l.release(now))
+	assert.Equal(t, uint64(1), l.Counters().Reordered)
+}
+
+func TestLink(t *testing.T) {
+	listen := func() *net.UDPConn {
+		c, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+		require.NoError(t, err)
+		return c
+	}
+	src, in, out, dst := listen(), listen(), listen(), listen()
+	defer src.Close()
+	defer in.Close()
+	defer out.Close()
+	defer dst.Close()
+
+	l := newLink("test", in, out, dst.LocalAddr(), Profile{
+		QueueSize: 10,
+		Delay:     util.DurWrap{Duration: 10 * time.Millisecond},
+	})
+	l.Run()
+	defer l.Close()
+
+	send := func(n int) {
+		for i := 0; i < n; i++ {
+			_, err := src.WriteTo([]byte{byte(i)}, in.LocalAddr())
+			require.NoError(t, err)
+		}
+	}
+	send(5)
+	start := time.Now()
+	b := make([]byte, 16)
+	require.NoError(t, dst.SetReadDeadline(time.Now().Add(time.Second)))
+	for i := 0; i < 5; i++ {
+		n, _, err := dst.ReadFrom(b)
+		require.NoError(t, err)
+		assert.Equal(t, []byte{byte(i)}, b[:n])
+	}
+	assert.True(t, time.Since(start) >= 5*time.Millisecond)
+	assert.Equal(t, Counters{Received: 5, Forwarded: 5}, l.Counters())
+
+	// With total loss nothing passes.
+	l.SetProfile(Profile{QueueSize: 10, Loss: 0.999999, LossBurst: 1})
+	send(5)
+	require.NoError(t, dst.SetReadDeadline(time.Now().Add(100*time.Millisecond)))
+	_, _, err := dst.ReadFrom(b)
+	assert.Error(t, err)
+	assert.Equal(t, uint64(10), l.Counters().Received)
+	assert.Equal(t, uint64(5), l.Counters().Lost)
+}
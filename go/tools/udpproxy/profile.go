@@ -0,0 +1,133 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"io/ioutil"
+
+	"github.com/scionproto/scion/go/lib/common"
+	"github.com/scionproto/scion/go/lib/serrors"
+	"github.com/scionproto/scion/go/lib/util"
+)
+
+const (
+	// DefaultQueueSize is the size of the bottleneck queue in packets if the
+	// profile does not set it.
+	DefaultQueueSize = 1000
+	// DefaultBurst is the token bucket size in bytes if the profile sets a
+	// bandwidth but no burst.
+	DefaultBurst = 10 * 1500
+)
+
+// Profile describes the emulated link in one direction. The zero value is a
+// perfect link that relays all packets immediately.
+type Profile struct {
+	// Bandwidth caps the rate of the link. Zero means unlimited.
+	Bandwidth util.Bandwidth
+	// Burst is the size of the token bucket in bytes, i.e., how many bytes can
+	// be sent back to back after the link was idle.
+	Burst int
+	// QueueSize is the number of packets the bottleneck queue in front of the
+	// bandwidth cap can hold. Packets that arrive at a full queue are dropped.
+	QueueSize int
+	// Delay is added to every packet after it left the bottleneck queue.
+	Delay util.DurWrap
+	// Jitter is the maximum deviation from Delay. The actual delay is chosen
+	// uniformly from [Delay-Jitter, Delay+Jitter].
+	Jitter util.DurWrap
+	// Loss is the probability that a packet is lost, between 0 and 1.
+	Loss float64
+	// LossBurst is the mean number of consecutive lost packets. Values larger
+	// than 1 switch from independent losses to a Gilbert model with the same
+	// mean loss rate.
+	LossBurst float64
+	// Reorder is the probability that a packet skips the delay and overtakes
+	// the packets before it, between 0 and 1.
+	Reorder float64
+}
+
+// Validate checks the profile and fills in defaults.
+func (p *Profile) Validate() error {
+	if p.Burst < 0 {
+		return serrors.New("burst must not be negative", "burst", p.Burst)
+	}
+	if p.Bandwidth != 0 && p.Burst == 0 {
+		p.Burst = DefaultBurst
+	}
+	if p.QueueSize < 0 {
+		return serrors.New("queue size must not be negative", "size", p.QueueSize)
+	}
+	if p.QueueSize == 0 {
+		p.QueueSize = DefaultQueueSize
+	}
+	if p.Delay.Duration < 0 || p.Jitter.Duration < 0 {
+		return serrors.New("delay and jitter must not be negative",
+			"delay", p.Delay, "jitter", p.Jitter)
+	}
+	if p.Loss < 0 || p.Loss >= 1 {
+		return serrors.New("loss must be in [0, 1)", "loss", p.Loss)
+	}
+	if p.LossBurst < 0 {
+		return serrors.New("loss burst must not be negative", "burst", p.LossBurst)
+	}
+	if p.LossBurst > 1 && p.Loss/(1-p.Loss) > p.LossBurst {
+		// The Gilbert model cannot reach the loss rate with such short bursts.
+		return serrors.New("loss burst too short for loss rate",
+			"loss", p.Loss, "burst", p.LossBurst)
+	}
+	if p.Reorder < 0 || p.Reorder > 1 {
+		return serrors.New("reorder must be in [0, 1]", "reorder", p.Reorder)
+	}
+	return nil
+}
+
+// Profiles are the profiles of both directions of the link.
+type Profiles struct {
+	// XY applies to the packets received on network x and sent to y.
+	XY Profile
+	// YX applies to the packets received on network y and sent to x.
+	YX Profile
+}
+
+// Validate validates both profiles.
+func (p *Profiles) Validate() error {
+	if err := p.XY.Validate(); err != nil {
+		return serrors.WrapStr("invalid x to y profile", err)
+	}
+	if err := p.YX.Validate(); err != nil {
+		return serrors.WrapStr("invalid y to x profile", err)
+	}
+	return nil
+}
+
+// LoadProfiles loads and validates the profiles from a JSON file. An empty
+// file name results in perfect links in both directions.
+func LoadProfiles(file string) (*Profiles, error) {
+	p := &Profiles{}
+	if file != "" {
+		raw, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, common.NewBasicError("Unable to read profile", err, "file", file)
+		}
+		if err := json.Unmarshal(raw, p); err != nil {
+			return nil, common.NewBasicError("Unable to parse profile", err, "file", file)
+		}
+	}
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
@@ -0,0 +1,80 @@
+// Copyright 2020 ETH Zurich
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scionproto/scion/go/lib/util"
+)
+
+func TestLoadProfiles(t *testing.T) {
+	p, err := LoadProfiles("testdata/profiles.json")
+	require.NoError(t, err)
+	expected := &Profiles{
+		XY: Profile{
+			Bandwidth: 10 * 1000 * 1000,
+			Burst:     DefaultBurst,
+			QueueSize: 100,
+			Delay:     util.DurWrap{Duration: 20 * time.Millisecond},
+			Jitter:    util.DurWrap{Duration: 2 * time.Millisecond},
+			Loss:      0.01,
+			LossBurst: 3,
+		},
+		YX: Profile{
+			QueueSize: DefaultQueueSize,
+			Delay:     util.DurWrap{Duration: 20 * time.Millisecond},
+			Reorder:   0.001,
+		},
+	}
+	assert.Equal(t, expected, p)
+
+	p, err = LoadProfiles("")
+	require.NoError(t, err)
+	assert.Equal(t, DefaultQueueSize, p.XY.QueueSize)
+	assert.Zero(t, p.XY.Bandwidth)
+}
+
+func TestProfileValidate(t *testing.T) {
+	tests := map[string]struct {
+		Profile Profile
+		Valid   bool
+	}{
+		"zero":            {Profile: Profile{}, Valid: true},
+		"negative burst":  {Profile: Profile{Burst: -1}},
+		"negative queue":  {Profile: Profile{QueueSize: -1}},
+		"negative delay":  {Profile: Profile{Delay: util.DurWrap{Duration: -1}}},
+		"negative jitter": {Profile: Profile{Jitter: util.DurWrap{Duration: -1}}},
+		"negative loss":   {Profile: Profile{Loss: -0.1}},
+		"total loss":      {Profile: Profile{Loss: 1}},
+		"bursty loss":     {Profile: Profile{Loss: 0.5, LossBurst: 2}, Valid: true},
+		"short bursts":    {Profile: Profile{Loss: 0.8, LossBurst: 2}},
+		"reorder":         {Profile: Profile{Reorder: 1.1}},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := test.Profile.Validate()
+			if test.Valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
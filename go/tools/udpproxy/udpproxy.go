@@ -1,11 +1,18 @@
 // Copyright 2019 Anapaya Systems
 
+// UDP proxy that relays datagrams between two UDP address pairs.
+//
+// The proxy can emulate a constrained link in each direction, with a bandwidth
+// cap, a bottleneck queue, delay, jitter, loss and reordering, as described by
+// the profiles file. The profiles can be changed at runtime over the HTTP API,
+// which also exports the packet counters.
 package main
 
 import (
 	"flag"
 	"fmt"
 	"net"
+	"net/http"
 	"os"
 
 	"github.com/scionproto/scion/go/lib/log"
@@ -21,18 +28,27 @@ var (
 		"local UDP address on network y, in IP:port format (required)")
 	remoteY = flag.String("remote_y", "",
 		"remote UDP address on network y, in IP:port format (required)")
+	profileFile = flag.String("profile", "",
+		"JSON file with the link profiles, no emulation if empty (optional)")
+	httpAddr = flag.String("http", "",
+		"address of the HTTP API for profiles and counters, in IP:port format (optional)")
 )
 
 func main() {
 	flag.Parse()
 
-	if err := Proxy(*localX, *remoteX, *localY, *remoteY); err != nil {
+	profiles, err := LoadProfiles(*profileFile)
+	if err != nil {
+		log.Crit("Unable to load profiles", "err", err)
+		os.Exit(1)
+	}
+	if err := Proxy(*localX, *remoteX, *localY, *remoteY, profiles); err != nil {
 		log.Crit("Fatal proxy error", "err", err)
 		os.Exit(1)
 	}
 }
 
-func Proxy(localX, remoteX, localY, remoteY string) error {
+func Proxy(localX, remoteX, localY, remoteY string, profiles *Profiles) error {
 	lxAddr, err := net.ResolveUDPAddr("udp", localX)
 	if err != nil {
 		return serrors.New("unable to parse local x address", "err", err)
@@ -74,22 +90,48 @@ func Proxy(localX, remoteX, localY, remoteY string) error {
 		),
 	)
 
-	go redirect(xConn, yConn, ryAddr)
-	go redirect(yConn, xConn, rxAddr)
+	p := newProxy(xConn, yConn, rxAddr, ryAddr, profiles)
+	registerMetrics(p.xy)
+	registerMetrics(p.yx)
+	p.Run()
+	if httpAddr := *httpAddr; httpAddr != "" {
+		log.Info("Serving HTTP API", "addr", httpAddr)
+		return http.ListenAndServe(httpAddr, p.ServeMux())
+	}
 	select {}
 }
 
-func redirect(from, to net.PacketConn, toAddr *net.UDPAddr) {
-	b := make([]byte, 1<<16)
-	for {
-		n, _, err := from.ReadFrom(b)
-		if err != nil {
-			log.Error("Unable to read from listen conn", "err", err)
-		}
-
-		_, err = to.WriteTo(b[:n], toAddr)
-		if err != nil {
-			log.Error("unable to write to destination", "err", err)
-		}
+// proxy relays packets in both directions over emulated links.
+type proxy struct {
+	xy *link
+	yx *link
+}
+
+func newProxy(xConn, yConn net.PacketConn, rxAddr, ryAddr net.Addr,
+	profiles *Profiles) *proxy {
+
+	return &proxy{
+		xy: newLink("xy", xConn, yConn, ryAddr, profiles.XY),
+		yx: newLink("yx", yConn, xConn, rxAddr, profiles.YX),
 	}
 }
+
+func (p *proxy) Run() {
+	p.xy.Run()
+	p.yx.Run()
+}
+
+func (p *proxy) Close() {
+	p.xy.Close()
+	p.yx.Close()
+}
+
+func (p *proxy) Profiles() Profiles {
+	return Profiles{XY: p.xy.Profile(), YX: p.yx.Profile()}
+}
+
+// SetProfiles replaces the profiles, they must be validated.
+func (p *proxy) SetProfiles(profiles Profiles) {
+	p.xy.SetProfile(profiles.XY)
+	p.yx.SetProfile(profiles.YX)
+}
@@ -67,6 +67,16 @@ class CertGenerator(object):
             cp('-r', as_dir / 'certs', elem_dir / 'certs')
             cp('-r', as_dir / 'keys', elem_dir / 'keys')
             cp(as_dir.dirname.dirname // '*/trcs/*.trc', elem_dir / 'certs')
+        # Copy the certs and key dir for the SIGs, which sign their prefix
+        # announcements.
+        for topo_id in topo_dicts:
+            as_dir = local.path(topo_id.base_dir(self.args.output_dir))
+            sig_dir = as_dir / ('sig%s' % topo_id.file_fmt())
+            if not sig_dir.exists():
+                continue
+            cp('-r', as_dir / 'certs', sig_dir / 'certs')
+            cp('-r', as_dir / 'keys', sig_dir / 'keys')
+            cp(as_dir.dirname.dirname // '*/trcs/*.trc', sig_dir / 'certs')
         # Copy the customers dir for all certificate servers.
         for topo_id, as_topo in topo_dicts.items():
             as_dir = local.path(topo_id.base_dir(self.args.output_dir))
@@ -56,6 +56,9 @@ def add_arguments(parser):
                         to be built manually e.g. when running acceptance tests)')
     parser.add_argument('-qos', '--colibri', action='store_true',
                         help='Generate COLIBRI service')
+    parser.add_argument('--direct-ports',
+                        help='Port range of the applications that bypass the dispatcher\
+                        (E.g. "31000-32767")')
     return parser
 
 
@@ -95,6 +95,8 @@ class GoGenerator(object):
                 'prometheus': prom_addr_br(name, v, DEFAULT_BR_PROM_PORT),
             },
         }
+        if self.args.direct_ports:
+            raw_entry['br'] = {'direct_ports': self.args.direct_ports}
         return raw_entry
 
     def _copy_qos_conf(self, path, base, name):
@@ -289,7 +291,7 @@ class GoGenerator(object):
     def _build_disp_conf(self, name, topo_id=None):
         prometheus_addr = prom_addr_dispatcher(self.args.docker, topo_id,
                                                self.args.networks, DISP_PROM_PORT, name)
-        return {
+        entry = {
             'dispatcher': {
                 'id': name,
             },
@@ -298,6 +300,9 @@ class GoGenerator(object):
                 'prometheus': prometheus_addr,
             },
         }
+        if self.args.direct_ports:
+            entry['dispatcher']['direct_ports'] = self.args.direct_ports
+        return entry
 
     def _tracing_entry(self):
         docker_ip = docker_host(self.args.in_docker, self.args.docker)
@@ -159,8 +159,16 @@ class AddressProxy(yaml.YAMLObject):
 
 
 class PortGenerator(object):
-    def __init__(self):
-        self.iter = iter(range(31000, 35000))
+    def __init__(self, direct_ports=None):
+        """
+        :param str direct_ports: Port range of the applications that bypass the
+            dispatcher, of the form min-max. The generated ports are not in it.
+        """
+        skip = range(0)
+        if direct_ports:
+            low, high = direct_ports.split('-')
+            skip = range(int(low), int(high) + 1)
+        self.iter = (p for p in range(31000, 35000 + len(skip)) if p not in skip)
         self._ports = defaultdict(lambda: next(self.iter))
 
     def register(self, id_):
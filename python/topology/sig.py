@@ -152,6 +152,7 @@ class SIGGenerator(object):
                 'sig_config': 'conf/cfg.json',
                 'isd_as': str(topo_id),
                 'ip': str(net[ipv]),
+                'crypto_dir': 'conf',
             },
             'sciond_connection': {
                 'address': socket_address_str(sciond_ip, SD_API_PORT),
@@ -77,7 +77,7 @@ class TopoGenArgs(ArgsBase):
             ADDR_TYPE_6: subnet_gen6,
         }
         self.default_mtu = default_mtu
-        self.port_gen = PortGenerator()
+        self.port_gen = PortGenerator(args.direct_ports)
 
 
 class TopoGenerator(object):